@@ -0,0 +1,32 @@
+package defs
+
+// CoinSelectionStrategy represents the strategy of choosing user's UTXOs to fund a transaction.
+type CoinSelectionStrategy string
+
+// Supported coin selection strategies.
+const (
+	// CoinSelectionLargestFirst allocates the biggest UTXOs first, so the transaction has the smallest number of inputs.
+	CoinSelectionLargestFirst CoinSelectionStrategy = "largest-first"
+	// CoinSelectionSmallestFirst allocates the smallest UTXOs first, which consolidates the dust in the wallet.
+	CoinSelectionSmallestFirst CoinSelectionStrategy = "smallest-first"
+	// CoinSelectionBranchAndBound looks for a set of UTXOs which funds the transaction exactly, so no change output is needed.
+	// When such a set cannot be found, it falls back to CoinSelectionLargestFirst.
+	CoinSelectionBranchAndBound CoinSelectionStrategy = "branch-and-bound"
+	// CoinSelectionRandom allocates UTXOs in random order, which makes the wallet's UTXOs linking harder.
+	CoinSelectionRandom CoinSelectionStrategy = "random"
+)
+
+// ParseCoinSelectionStrategy parses a string into a CoinSelectionStrategy (case-insensitive).
+func ParseCoinSelectionStrategy(str string) (CoinSelectionStrategy, error) {
+	return parseEnumCaseInsensitive(str,
+		CoinSelectionLargestFirst,
+		CoinSelectionSmallestFirst,
+		CoinSelectionBranchAndBound,
+		CoinSelectionRandom,
+	)
+}
+
+// DefaultCoinSelectionStrategy returns the coin selection strategy used when none is configured.
+func DefaultCoinSelectionStrategy() CoinSelectionStrategy {
+	return CoinSelectionLargestFirst
+}
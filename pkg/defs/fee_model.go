@@ -5,34 +5,74 @@ import "fmt"
 // FeeModelType represents different fee models which can be configured.
 type FeeModelType string
 
-// SatPerKB - Currently only "sat/kb" is supported.
+// Supported fee model types.
 const (
+	// SatPerKB - the fee value is the number of satoshis per (started) kilobyte of the transaction.
 	SatPerKB FeeModelType = "sat/kb"
+	// SatPerByte - the fee value is the number of satoshis per byte of the transaction.
+	SatPerByte FeeModelType = "sat/byte"
+	// SatPerBytes - the fee value is the number of satoshis per FeeModel.Bytes bytes of the transaction,
+	// which allows to express fractional rates exactly. The fee is proportional to the transaction size and rounded up once.
+	SatPerBytes FeeModelType = "sat/bytes"
 )
 
 // ParseFeeModelType parses a string into a FeeModelType (case-insensitive).
 func ParseFeeModelType(str string) (FeeModelType, error) {
-	return parseEnumCaseInsensitive(str, SatPerKB)
+	return parseEnumCaseInsensitive(str, SatPerKB, SatPerByte, SatPerBytes)
 }
 
 // FeeModel represents a fee model with its value.
 type FeeModel struct {
 	Type  FeeModelType `mapstructure:"type"`
 	Value int64        `mapstructure:"value"`
+	// Bytes is the number of bytes the Value (and DataValue) is paid for, required only by SatPerBytes type.
+	Bytes uint64 `mapstructure:"bytes"`
+	// DataValue is the rate (in units of Type) applied to bytes of data (OP_RETURN) outputs,
+	// which allows to make the data cheaper than standard bytes. Zero means that Value is used also for data bytes.
+	DataValue int64 `mapstructure:"data_value"`
+	// MinFee is the minimum fee (in satoshis) paid for any transaction.
+	MinFee int64 `mapstructure:"min_fee"`
+	// DustLimit is the minimum value (in satoshis) of a change output, smaller change is given to the miner as a fee.
+	DustLimit uint64 `mapstructure:"dust_limit"`
 }
 
-// Validate double checks if under the Type is a valid enum, and checks if the value is valid.
+// Validate double checks if under the Type is a valid enum, and checks if the values are valid.
 func (f *FeeModel) Validate() error {
 	var err error
 	if f.Type, err = ParseFeeModelType(string(f.Type)); err != nil {
 		return fmt.Errorf("invalid fee model: %s", f.Type)
 	}
-	if f.Type == SatPerKB && f.Value <= 0 {
+	if f.Value <= 0 {
 		return fmt.Errorf("invalid fee value: %d", f.Value)
 	}
+	if f.Type == SatPerBytes && f.Bytes == 0 {
+		return fmt.Errorf("invalid fee model: %s requires the number of bytes", f.Type)
+	}
+	if f.DataValue < 0 {
+		return fmt.Errorf("invalid data fee value: %d", f.DataValue)
+	}
+	if f.MinFee < 0 {
+		return fmt.Errorf("invalid minimum fee: %d", f.MinFee)
+	}
 	return nil
 }
 
+// DataRate returns the rate applied to bytes of data outputs.
+func (f *FeeModel) DataRate() int64 {
+	if f.DataValue == 0 {
+		return f.Value
+	}
+	return f.DataValue
+}
+
+// Unit returns the unit of the fee rate, e.g. "sat/kb" or "sat/1000 bytes" for SatPerBytes type.
+func (f *FeeModel) Unit() string {
+	if f.Type == SatPerBytes {
+		return fmt.Sprintf("sat/%d bytes", f.Bytes)
+	}
+	return string(f.Type)
+}
+
 // DefaultFeeModel returns minimal fee model.
 func DefaultFeeModel() FeeModel {
 	return FeeModel{
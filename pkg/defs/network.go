@@ -1,15 +1,28 @@
 package defs
 
-// BSVNetwork represents the Bitcoin SV network type (mainnet or testnet)
+// BSVNetwork represents the Bitcoin SV network type (mainnet, testnet or regtest)
 type BSVNetwork string
 
 // BSVNetwork constants for the different Bitcoin SV network types
 const (
 	NetworkMainnet BSVNetwork = "main"
 	NetworkTestnet BSVNetwork = "test"
+	// NetworkRegtest is a local or custom network (e.g. a node or teranode started in regtest mode),
+	// there are no public services for it, so the URLs of the services must be configured explicitly.
+	NetworkRegtest BSVNetwork = "regtest"
 )
 
 // ParseBSVNetworkStr will parse the given string and return the corresponding BSVNetwork type or an error
 func ParseBSVNetworkStr(network string) (BSVNetwork, error) {
-	return parseEnumCaseInsensitive(network, NetworkMainnet, NetworkTestnet)
+	return parseEnumCaseInsensitive(network, NetworkMainnet, NetworkTestnet, NetworkRegtest)
+}
+
+// IsMainnet returns true for the main network, the other networks use the testnet prefixes of addresses and keys
+func (n BSVNetwork) IsMainnet() bool {
+	return n == NetworkMainnet
+}
+
+// HasPublicServices returns true if the public services (e.g. WhatsOnChain, Bitails) are available for the network
+func (n BSVNetwork) HasPublicServices() bool {
+	return n == NetworkMainnet || n == NetworkTestnet
 }
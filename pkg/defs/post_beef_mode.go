@@ -0,0 +1,22 @@
+package defs
+
+// PostBeefMode represents the way the transactions are broadcasted to the configured broadcasters.
+type PostBeefMode string
+
+// Supported post beef modes.
+const (
+	// PostBeefModeOneByOne broadcasts to the broadcasters one by one until the first one accepts all the transactions.
+	PostBeefModeOneByOne PostBeefMode = "one-by-one"
+	// PostBeefModeAll broadcasts to all the broadcasters in parallel.
+	PostBeefModeAll PostBeefMode = "all"
+)
+
+// ParsePostBeefMode parses a string into a PostBeefMode (case-insensitive).
+func ParsePostBeefMode(str string) (PostBeefMode, error) {
+	return parseEnumCaseInsensitive(str, PostBeefModeOneByOne, PostBeefModeAll)
+}
+
+// DefaultPostBeefMode returns the post beef mode used when none is configured.
+func DefaultPostBeefMode() PostBeefMode {
+	return PostBeefModeOneByOne
+}
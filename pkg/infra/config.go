@@ -2,6 +2,8 @@ package infra
 
 import (
 	"fmt"
+	"strings"
+	"time"
 
 	"github.com/4chain-ag/go-wallet-toolbox/pkg/defs"
 	"github.com/4chain-ag/go-wallet-toolbox/pkg/internal/config"
@@ -12,14 +14,18 @@ import (
 // Config is the configuration for the "remote storage server" service (aka "infra")
 type Config struct {
 	// Name is the human-readable name of this storage server
-	Name             string          `mapstructure:"name"`
-	ServerPrivateKey string          `mapstructure:"server_private_key"`
-	BSVNetwork       defs.BSVNetwork `mapstructure:"bsv_network"`
-	FeeModel         defs.FeeModel   `mapstructure:"fee_model"`
-	DBConfig         defs.Database   `mapstructure:"db"`
-	HTTPConfig       HTTPConfig      `mapstructure:"http"`
-	Logging          LogConfig       `mapstructure:"logging"`
-	Commission       defs.Commission `mapstructure:"commission"`
+	Name             string                     `mapstructure:"name"`
+	ServerPrivateKey string                     `mapstructure:"server_private_key"`
+	BSVNetwork       defs.BSVNetwork            `mapstructure:"bsv_network"`
+	FeeModel         defs.FeeModel              `mapstructure:"fee_model"`
+	DynamicFeeModel  DynamicFeeModelConfig      `mapstructure:"dynamic_fee_model"`
+	CoinSelection    defs.CoinSelectionStrategy `mapstructure:"coin_selection"`
+	DBConfig         defs.Database              `mapstructure:"db"`
+	HTTPConfig       HTTPConfig                 `mapstructure:"http"`
+	Logging          LogConfig                  `mapstructure:"logging"`
+	Commission       defs.Commission            `mapstructure:"commission"`
+	Reservation      ReservationConfig          `mapstructure:"reservation"`
+	ArcCallback      ArcCallbackConfig          `mapstructure:"arc_callback"`
 }
 
 // DBConfig is the configuration for the database
@@ -32,6 +38,36 @@ type HTTPConfig struct {
 	Port uint `mapstructure:"port"`
 }
 
+// ReservationConfig is the configuration for the reservation of UTXOs allocated by createAction
+type ReservationConfig struct {
+	// Timeout is the time after which UTXOs reserved by not processed (unsigned) transactions are released, zero disables the expiration
+	Timeout time.Duration `mapstructure:"timeout"`
+	// SweepInterval is the interval of aborting unsigned transactions with expired reservations
+	SweepInterval time.Duration `mapstructure:"sweep_interval"`
+}
+
+// DynamicFeeModelConfig is the configuration for fetching the fee model from the ARC policy.
+// The FeeModel from the configuration is used when it is disabled or the policy cannot be fetched.
+type DynamicFeeModelConfig struct {
+	Enabled bool `mapstructure:"enabled"`
+	// ArcURL is the URL of ARC which policy is fetched
+	ArcURL string `mapstructure:"arc_url"`
+	// ArcToken is the authorization token for ARC
+	ArcToken string `mapstructure:"arc_token"`
+	// RefreshInterval is the interval of fetching the ARC policy
+	RefreshInterval time.Duration `mapstructure:"refresh_interval"`
+}
+
+// ArcCallbackConfig is the configuration for receiving the transaction status updates from ARC.
+// The ARC callback URL should point to the Path of this server and the ARC callback token should be equal to Token.
+type ArcCallbackConfig struct {
+	Enabled bool `mapstructure:"enabled"`
+	// Path is the path of the endpoint receiving the callbacks
+	Path string `mapstructure:"path"`
+	// Token is the token expected in the Authorization header of the callbacks
+	Token string `mapstructure:"token"`
+}
+
 // LogConfig is the configuration for the logging
 type LogConfig struct {
 	Enabled bool            `mapstructure:"enabled"`
@@ -51,12 +87,28 @@ func Defaults() Config {
 			Port: 8100,
 		},
 		FeeModel: defs.DefaultFeeModel(),
+		DynamicFeeModel: DynamicFeeModelConfig{
+			Enabled:         false,
+			ArcURL:          "https://arc.taal.com",
+			ArcToken:        "",
+			RefreshInterval: 10 * time.Minute,
+		},
+		CoinSelection: defs.DefaultCoinSelectionStrategy(),
 		Logging: LogConfig{
 			Enabled: true,
 			Level:   defs.LogLevelInfo,
 			Handler: defs.JSONHandler,
 		},
 		Commission: defs.DefaultCommission(),
+		Reservation: ReservationConfig{
+			Timeout:       5 * time.Minute,
+			SweepInterval: time.Minute,
+		},
+		ArcCallback: ArcCallbackConfig{
+			Enabled: false,
+			Path:    "/arc/callback",
+			Token:   "",
+		},
 	}
 }
 
@@ -73,6 +125,14 @@ func (c *Config) Validate() (err error) {
 		return fmt.Errorf("invalid fee model: %w", err)
 	}
 
+	if err = c.DynamicFeeModel.Validate(); err != nil {
+		return fmt.Errorf("invalid dynamic fee model config: %w", err)
+	}
+
+	if c.CoinSelection, err = defs.ParseCoinSelectionStrategy(string(c.CoinSelection)); err != nil {
+		return fmt.Errorf("invalid coin selection strategy: %w", err)
+	}
+
 	if err = c.DBConfig.Validate(); err != nil {
 		return fmt.Errorf("invalid DB config: %w", err)
 	}
@@ -85,6 +145,14 @@ func (c *Config) Validate() (err error) {
 		return fmt.Errorf("invalid commission config: %w", err)
 	}
 
+	if err = c.Reservation.Validate(); err != nil {
+		return fmt.Errorf("invalid reservation config: %w", err)
+	}
+
+	if err = c.ArcCallback.Validate(); err != nil {
+		return fmt.Errorf("invalid arc callback config: %w", err)
+	}
+
 	return nil
 }
 
@@ -97,6 +165,48 @@ func (c *DBConfig) Validate() (err error) {
 	return nil
 }
 
+// Validate validates the reservation configuration
+func (c *ReservationConfig) Validate() error {
+	if c.Timeout < 0 {
+		return fmt.Errorf("timeout must not be negative")
+	}
+	if c.Timeout > 0 && c.SweepInterval <= 0 {
+		return fmt.Errorf("sweep interval must be positive when timeout is set")
+	}
+
+	return nil
+}
+
+// Validate validates the dynamic fee model configuration
+func (c *DynamicFeeModelConfig) Validate() error {
+	if !c.Enabled {
+		return nil
+	}
+	if c.ArcURL == "" {
+		return fmt.Errorf("arc url is required")
+	}
+	if c.RefreshInterval <= 0 {
+		return fmt.Errorf("refresh interval must be positive")
+	}
+
+	return nil
+}
+
+// Validate validates the ARC callback configuration
+func (c *ArcCallbackConfig) Validate() error {
+	if !c.Enabled {
+		return nil
+	}
+	if !strings.HasPrefix(c.Path, "/") {
+		return fmt.Errorf("path must start with /")
+	}
+	if c.Token == "" {
+		return fmt.Errorf("token is required")
+	}
+
+	return nil
+}
+
 // Validate validates the HTTP configuration
 func (c *LogConfig) Validate() (err error) {
 	if c.Level, err = defs.ParseLogLevelStr(string(c.Level)); err != nil {
@@ -39,6 +39,123 @@ func TestFeeZero(t *testing.T) {
 	require.Error(t, err)
 }
 
+func TestFeeModelConfig(t *testing.T) {
+	// given:
+	t.Setenv("TEST_SERVER_PRIVATE_KEY", fixtures.StorageServerPrivKey)
+	t.Setenv("TEST_FEE_MODEL_TYPE", "SAT/BYTE")
+	t.Setenv("TEST_FEE_MODEL_VALUE", "2")
+	t.Setenv("TEST_FEE_MODEL_DATA_VALUE", "1")
+	t.Setenv("TEST_FEE_MODEL_MIN_FEE", "10")
+	t.Setenv("TEST_FEE_MODEL_DUST_LIMIT", "135")
+
+	// when:
+	infraSrv, err := infra.NewServer(infra.WithEnvPrefix("TEST"))
+
+	// then:
+	require.NoError(t, err)
+	require.Equal(t, defs.FeeModel{
+		Type:      defs.SatPerByte,
+		Value:     2,
+		DataValue: 1,
+		MinFee:    10,
+		DustLimit: 135,
+	}, infraSrv.Config.FeeModel)
+}
+
+func TestInvalidFeeModelValues(t *testing.T) {
+	tests := map[string]struct {
+		envKey string
+	}{
+		"negative data value": {
+			envKey: "TEST_FEE_MODEL_DATA_VALUE",
+		},
+		"negative minimum fee": {
+			envKey: "TEST_FEE_MODEL_MIN_FEE",
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			// given:
+			t.Setenv("TEST_SERVER_PRIVATE_KEY", fixtures.StorageServerPrivKey)
+			t.Setenv(test.envKey, "-1")
+
+			// when:
+			_, err := infra.NewServer(infra.WithEnvPrefix("TEST"))
+
+			// then:
+			require.Error(t, err)
+		})
+	}
+}
+
+func TestInvalidDynamicFeeModelConfig(t *testing.T) {
+	tests := map[string]struct {
+		envKey   string
+		envValue string
+	}{
+		"negative refresh interval": {
+			envKey:   "TEST_DYNAMIC_FEE_MODEL_REFRESH_INTERVAL",
+			envValue: "-1m",
+		},
+		"zero refresh interval": {
+			envKey:   "TEST_DYNAMIC_FEE_MODEL_REFRESH_INTERVAL",
+			envValue: "0s",
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			// given:
+			t.Setenv("TEST_SERVER_PRIVATE_KEY", fixtures.StorageServerPrivKey)
+			t.Setenv("TEST_DYNAMIC_FEE_MODEL_ENABLED", "true")
+			t.Setenv(test.envKey, test.envValue)
+
+			// when:
+			_, err := infra.NewServer(infra.WithEnvPrefix("TEST"))
+
+			// then:
+			require.Error(t, err)
+		})
+	}
+}
+
+func TestNegativeReservationTimeout(t *testing.T) {
+	// given:
+	t.Setenv("TEST_SERVER_PRIVATE_KEY", fixtures.StorageServerPrivKey)
+	t.Setenv("TEST_RESERVATION_TIMEOUT", "-1m")
+
+	// when:
+	_, err := infra.NewServer(infra.WithEnvPrefix("TEST"))
+
+	// then:
+	require.Error(t, err)
+}
+
+func TestInvalidArcCallbackPath(t *testing.T) {
+	// given:
+	t.Setenv("TEST_SERVER_PRIVATE_KEY", fixtures.StorageServerPrivKey)
+	t.Setenv("TEST_ARC_CALLBACK_ENABLED", "true")
+	t.Setenv("TEST_ARC_CALLBACK_PATH", "arc/callback")
+	t.Setenv("TEST_ARC_CALLBACK_TOKEN", "token")
+
+	// when:
+	_, err := infra.NewServer(infra.WithEnvPrefix("TEST"))
+
+	// then:
+	require.Error(t, err)
+}
+
+func TestMissingArcCallbackToken(t *testing.T) {
+	// given:
+	t.Setenv("TEST_SERVER_PRIVATE_KEY", fixtures.StorageServerPrivKey)
+	t.Setenv("TEST_ARC_CALLBACK_ENABLED", "true")
+
+	// when:
+	_, err := infra.NewServer(infra.WithEnvPrefix("TEST"))
+
+	// then:
+	require.Error(t, err)
+}
+
 func TestEnums(t *testing.T) {
 	tests := map[string]struct {
 		envKey string
@@ -61,6 +178,12 @@ func TestEnums(t *testing.T) {
 		"Fee model": {
 			envKey: "TEST_FEE_MODEL_TYPE",
 		},
+		"Coin selection": {
+			envKey: "TEST_COIN_SELECTION",
+		},
+		"Reservation timeout": {
+			envKey: "TEST_RESERVATION_TIMEOUT",
+		},
 	}
 	for name, test := range tests {
 		t.Run(name, func(t *testing.T) {
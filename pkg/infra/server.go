@@ -4,12 +4,17 @@ import (
 	"context"
 	"fmt"
 	"log/slog"
+	"net/http"
 	"os"
+	"time"
 
 	"github.com/4chain-ag/go-wallet-toolbox/pkg/internal/config"
 	"github.com/4chain-ag/go-wallet-toolbox/pkg/internal/logging"
+	"github.com/4chain-ag/go-wallet-toolbox/pkg/services"
+	"github.com/4chain-ag/go-wallet-toolbox/pkg/services/configuration"
 	"github.com/4chain-ag/go-wallet-toolbox/pkg/storage"
-	"github.com/4chain-ag/go-wallet-toolbox/pkg/wdk"
+	primitives "github.com/bsv-blockchain/go-sdk/primitives/ec"
+	"github.com/go-resty/resty/v2"
 )
 
 // Server is a struct that holds the "infra" server configuration
@@ -46,17 +51,46 @@ func NewServer(opts ...InitOption) (*Server, error) {
 
 	logger := logging.Child(makeLogger(&cfg, &options), "infra")
 
-	storageIdentityKey, err := wdk.IdentityKey(cfg.ServerPrivateKey)
+	serverPrivateKey, err := primitives.PrivateKeyFromHex(cfg.ServerPrivateKey)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create storage identity key: %w", err)
+		return nil, fmt.Errorf("failed to parse server private key: %w", err)
+	}
+	storageIdentityKey := serverPrivateKey.PubKey().ToDERHex()
+
+	var providerOpts []storage.ProviderOption
+	if cfg.DynamicFeeModel.Enabled || cfg.ArcCallback.Enabled {
+		servicesConfig := configuration.WalletServices{
+			Chain: cfg.BSVNetwork,
+		}
+		if cfg.DynamicFeeModel.Enabled {
+			servicesConfig.ArcURL = cfg.DynamicFeeModel.ArcURL
+			servicesConfig.ArcConfig = configuration.ARC{
+				Token: cfg.DynamicFeeModel.ArcToken,
+			}
+		}
+
+		walletServices, err := services.New(resty.New(), logger, servicesConfig)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create services: %w", err)
+		}
+
+		if cfg.DynamicFeeModel.Enabled {
+			providerOpts = append(providerOpts, storage.WithFeeModelFetcher(walletServices))
+		}
+		// the merkle paths of the mined transactions reported by the ARC callbacks are verified with the chain tracker
+		if cfg.ArcCallback.Enabled {
+			providerOpts = append(providerOpts, storage.WithChainTracker(walletServices.ChainTracker()))
+		}
 	}
 
 	activeStorage, err := storage.NewGORMProvider(logger, storage.GORMProviderConfig{
-		DB:         cfg.DBConfig,
-		Chain:      cfg.BSVNetwork,
-		FeeModel:   cfg.FeeModel,
-		Commission: cfg.Commission,
-	})
+		DB:                 cfg.DBConfig,
+		Chain:              cfg.BSVNetwork,
+		FeeModel:           cfg.FeeModel,
+		CoinSelection:      cfg.CoinSelection,
+		Commission:         cfg.Commission,
+		ReservationTimeout: cfg.Reservation.Timeout,
+	}, providerOpts...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create storage provider: %w", err)
 	}
@@ -66,17 +100,35 @@ func NewServer(opts ...InitOption) (*Server, error) {
 		return nil, fmt.Errorf("failed to migrate storage: %w", err)
 	}
 
+	serverOptions := storage.ServerOptions{Port: cfg.HTTPConfig.Port, PrivateKey: serverPrivateKey}
+	if cfg.ArcCallback.Enabled {
+		serverOptions.Handlers = map[string]http.Handler{
+			cfg.ArcCallback.Path: storage.NewArcCallbackHandler(logger, activeStorage, cfg.ArcCallback.Token),
+		}
+	}
+
 	return &Server{
 		Config: cfg,
 
 		logger:        logger,
 		storage:       activeStorage,
-		storageServer: storage.NewServer(logger, activeStorage, storage.ServerOptions{Port: cfg.HTTPConfig.Port}),
+		storageServer: storage.NewServer(logger, activeStorage, serverOptions),
 	}, nil
 }
 
 // ListenAndServe starts the JSON-RPC server
 func (s *Server) ListenAndServe() error {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	if s.Config.Reservation.Timeout > 0 {
+		go s.sweepExpiredReservations(ctx)
+	}
+
+	if s.Config.DynamicFeeModel.Enabled {
+		go s.refreshFeeModel(ctx)
+	}
+
 	err := s.storageServer.Start()
 	if err != nil {
 		return fmt.Errorf("failed to start storage server: %w", err)
@@ -84,6 +136,45 @@ func (s *Server) ListenAndServe() error {
 	return nil
 }
 
+func (s *Server) sweepExpiredReservations(ctx context.Context) {
+	ticker := time.NewTicker(s.Config.Reservation.SweepInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			aborted, err := s.storage.SweepExpiredReservations(ctx)
+			if err != nil {
+				s.logger.Error("failed to sweep expired reservations", logging.Error(err))
+				continue
+			}
+			if aborted > 0 {
+				s.logger.Info("aborted transactions with expired reservations", slog.Int64("count", aborted))
+			}
+		}
+	}
+}
+
+func (s *Server) refreshFeeModel(ctx context.Context) {
+	ticker := time.NewTicker(s.Config.DynamicFeeModel.RefreshInterval)
+	defer ticker.Stop()
+
+	for {
+		err := s.storage.RefreshFeeModel(ctx)
+		if err != nil {
+			s.logger.Warn("failed to refresh fee model", logging.Error(err))
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
 func makeLogger(cfg *Config, options *Options) *slog.Logger {
 	if options.Logger != nil {
 		return options.Logger
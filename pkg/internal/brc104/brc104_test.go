@@ -0,0 +1,357 @@
+package brc104_test
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/4chain-ag/go-wallet-toolbox/pkg/internal/brc104"
+	"github.com/4chain-ag/go-wallet-toolbox/pkg/internal/logging"
+	primitives "github.com/bsv-blockchain/go-sdk/primitives/ec"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	serverPrivKey = "8143f5ed6c5b41c3d084d39d49e161d8dde4b50b0685a4e4ac23959d3b8a319b"
+	clientPrivKey = "143ab18a84d3b25e1a13cefa90038411e5d2014590a2a4a57263d1593c8dee1c"
+)
+
+type roundTripFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+func TestAuthentication(t *testing.T) {
+	givenKey := func(t *testing.T, privKey string) *primitives.PrivateKey {
+		key, err := primitives.PrivateKeyFromHex(privKey)
+		require.NoError(t, err)
+		return key
+	}
+
+	// echoHandler responds with the identity key of the authenticated peer and the request body
+	echoHandler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		identityKey, _ := brc104.IdentityKey(r.Context())
+		body, _ := io.ReadAll(r.Body)
+		w.Header().Set("X-Bsv-Echo", "echo")
+		_, _ = w.Write([]byte(identityKey + ":" + string(body)))
+	})
+
+	givenServer := func(t *testing.T, middleware *brc104.Middleware) *httptest.Server {
+		mux := http.NewServeMux()
+		mux.Handle("POST /{$}", middleware.Handler(echoHandler))
+		mux.HandleFunc("POST "+brc104.HandshakePath, middleware.HandleHandshake)
+
+		server := httptest.NewServer(mux)
+		t.Cleanup(server.Close)
+		return server
+	}
+
+	givenMiddleware := func(t *testing.T) *brc104.Middleware {
+		return brc104.NewMiddleware(logging.NewTestLogger(t), givenKey(t, serverPrivKey))
+	}
+
+	post := func(t *testing.T, client *http.Client, url, body string) (*http.Response, error) {
+		req, err := http.NewRequest(http.MethodPost, url+"/?query=1", strings.NewReader(body))
+		require.NoError(t, err)
+		req.Header.Set("Content-Type", "text/plain; charset=utf-8")
+		req.Header.Set("X-Bsv-Custom", "custom")
+		return client.Do(req)
+	}
+
+	readBody := func(t *testing.T, res *http.Response) string {
+		defer func() {
+			_ = res.Body.Close()
+		}()
+		body, err := io.ReadAll(res.Body)
+		require.NoError(t, err)
+		return string(body)
+	}
+
+	t.Run("authenticates the client and binds its identity key to the request", func(t *testing.T) {
+		// given:
+		server := givenServer(t, givenMiddleware(t))
+		clientKey := givenKey(t, clientPrivKey)
+		client := &http.Client{Transport: brc104.NewTransport(clientKey, server.Client().Transport)}
+
+		// when:
+		res, err := post(t, client, server.URL, "hello")
+
+		// then:
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusOK, res.StatusCode)
+		assert.Equal(t, clientKey.PubKey().ToDERHex()+":hello", readBody(t, res))
+
+		// when:
+		res, err = post(t, client, server.URL, "again")
+
+		// then:
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusOK, res.StatusCode)
+		assert.Equal(t, clientKey.PubKey().ToDERHex()+":again", readBody(t, res))
+	})
+
+	t.Run("rejects not authenticated request", func(t *testing.T) {
+		// given:
+		server := givenServer(t, givenMiddleware(t))
+
+		// when:
+		res, err := post(t, server.Client(), server.URL, "hello")
+
+		// then:
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusUnauthorized, res.StatusCode)
+		assert.NotContains(t, readBody(t, res), "hello")
+	})
+
+	t.Run("rejects request modified after signing", func(t *testing.T) {
+		// given:
+		server := givenServer(t, givenMiddleware(t))
+		base := server.Client().Transport
+
+		// and:
+		tampering := roundTripFunc(func(req *http.Request) (*http.Response, error) {
+			if req.URL.Path != brc104.HandshakePath {
+				req.Body = io.NopCloser(bytes.NewReader([]byte("tampered")))
+				req.ContentLength = int64(len("tampered"))
+			}
+			return base.RoundTrip(req)
+		})
+		client := &http.Client{Transport: brc104.NewTransport(givenKey(t, clientPrivKey), tampering)}
+
+		// when:
+		res, err := post(t, client, server.URL, "hello")
+
+		// then:
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusUnauthorized, res.StatusCode)
+	})
+
+	t.Run("rejects response modified after signing", func(t *testing.T) {
+		// given:
+		server := givenServer(t, givenMiddleware(t))
+		base := server.Client().Transport
+
+		// and:
+		tampering := roundTripFunc(func(req *http.Request) (*http.Response, error) {
+			res, err := base.RoundTrip(req)
+			if err == nil && req.URL.Path != brc104.HandshakePath {
+				res.Header.Set("X-Bsv-Echo", "tampered")
+			}
+			return res, err
+		})
+		client := &http.Client{Transport: brc104.NewTransport(givenKey(t, clientPrivKey), tampering)}
+
+		// when:
+		_, err := post(t, client, server.URL, "hello")
+
+		// then:
+		require.Error(t, err)
+	})
+
+	t.Run("rejects replayed request", func(t *testing.T) {
+		// given:
+		server := givenServer(t, givenMiddleware(t))
+		base := server.Client().Transport
+
+		// and:
+		var replayed *http.Response
+		replaying := roundTripFunc(func(req *http.Request) (*http.Response, error) {
+			res, err := base.RoundTrip(req)
+			if err != nil || req.URL.Path == brc104.HandshakePath {
+				return res, err
+			}
+
+			replay := req.Clone(req.Context())
+			replay.Body, err = req.GetBody()
+			require.NoError(t, err)
+			replayed, err = base.RoundTrip(replay)
+			require.NoError(t, err)
+			_ = replayed.Body.Close()
+			return res, nil
+		})
+		client := &http.Client{Transport: brc104.NewTransport(givenKey(t, clientPrivKey), replaying)}
+
+		// when:
+		res, err := post(t, client, server.URL, "hello")
+
+		// then:
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusOK, res.StatusCode)
+		assert.Contains(t, readBody(t, res), ":hello")
+
+		// and:
+		require.NotNil(t, replayed)
+		assert.Equal(t, http.StatusUnauthorized, replayed.StatusCode)
+	})
+
+	// handshake sends the initial request of the handshake without using the session afterward
+	handshake := func(t *testing.T, server *httptest.Server) int {
+		body, err := json.Marshal(brc104.AuthMessage{
+			Version:      brc104.Version,
+			MessageType:  brc104.MessageTypeInitialRequest,
+			IdentityKey:  givenKey(t, clientPrivKey).PubKey().ToDERHex(),
+			InitialNonce: base64.StdEncoding.EncodeToString([]byte("initial nonce of the handshake..")),
+		})
+		require.NoError(t, err)
+
+		res, err := server.Client().Post(server.URL+brc104.HandshakePath, "application/json", bytes.NewReader(body))
+		require.NoError(t, err)
+		_ = readBody(t, res)
+		return res.StatusCode
+	}
+
+	// givenCountingClient returns the authenticating client counting its handshakes
+	givenCountingClient := func(t *testing.T, server *httptest.Server, handshakes *int) *http.Client {
+		base := server.Client().Transport
+		counting := roundTripFunc(func(req *http.Request) (*http.Response, error) {
+			if req.URL.Path == brc104.HandshakePath {
+				*handshakes++
+			}
+			return base.RoundTrip(req)
+		})
+		return &http.Client{Transport: brc104.NewTransport(givenKey(t, clientPrivKey), counting)}
+	}
+
+	t.Run("drops the sessions without authenticated requests when the limit is reached", func(t *testing.T) {
+		// given:
+		server := givenServer(t, brc104.NewMiddleware(logging.NewTestLogger(t), givenKey(t, serverPrivKey), brc104.WithMaxSessions(2)))
+
+		// and:
+		var handshakes int
+		client := givenCountingClient(t, server, &handshakes)
+		res, err := post(t, client, server.URL, "hello")
+		require.NoError(t, err)
+		require.Equal(t, http.StatusOK, res.StatusCode)
+		_ = readBody(t, res)
+
+		// and:
+		for range 5 {
+			require.Equal(t, http.StatusOK, handshake(t, server))
+		}
+
+		// when:
+		res, err = post(t, client, server.URL, "again")
+
+		// then:
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusOK, res.StatusCode)
+		assert.Contains(t, readBody(t, res), ":again")
+		assert.Equal(t, 1, handshakes)
+	})
+
+	t.Run("rejects the handshake when all the sessions have authenticated requests", func(t *testing.T) {
+		// given:
+		server := givenServer(t, brc104.NewMiddleware(logging.NewTestLogger(t), givenKey(t, serverPrivKey), brc104.WithMaxSessions(1)))
+
+		// and:
+		var handshakes int
+		res, err := post(t, givenCountingClient(t, server, &handshakes), server.URL, "hello")
+		require.NoError(t, err)
+		require.Equal(t, http.StatusOK, res.StatusCode)
+		_ = readBody(t, res)
+
+		// when:
+		status := handshake(t, server)
+
+		// then:
+		assert.Equal(t, http.StatusServiceUnavailable, status)
+	})
+
+	t.Run("limits the rate of the handshakes", func(t *testing.T) {
+		// given:
+		server := givenServer(t, brc104.NewMiddleware(logging.NewTestLogger(t), givenKey(t, serverPrivKey), brc104.WithHandshakesPerMinute(2)))
+
+		// and:
+		require.Equal(t, http.StatusOK, handshake(t, server))
+		require.Equal(t, http.StatusOK, handshake(t, server))
+
+		// when:
+		status := handshake(t, server)
+
+		// then:
+		assert.Equal(t, http.StatusTooManyRequests, status)
+	})
+
+	t.Run("repeats the handshake when the session reached the limit of requests", func(t *testing.T) {
+		// given:
+		server := givenServer(t, givenMiddleware(t))
+
+		// and:
+		var handshakes int
+		client := givenCountingClient(t, server, &handshakes)
+
+		// when:
+		for range 1_001 {
+			res, err := post(t, client, server.URL, "hello")
+			require.NoError(t, err)
+			require.Equal(t, http.StatusOK, res.StatusCode)
+			_ = readBody(t, res)
+		}
+
+		// then:
+		assert.Equal(t, 2, handshakes)
+	})
+
+	t.Run("rejects response of not authenticating server", func(t *testing.T) {
+		// given:
+		mux := http.NewServeMux()
+		mux.HandleFunc("POST "+brc104.HandshakePath, givenMiddleware(t).HandleHandshake)
+		mux.Handle("POST /{$}", echoHandler)
+		server := httptest.NewServer(mux)
+		defer server.Close()
+
+		// and:
+		client := &http.Client{Transport: brc104.NewTransport(givenKey(t, clientPrivKey), server.Client().Transport)}
+
+		// when:
+		_, err := post(t, client, server.URL, "hello")
+
+		// then:
+		require.Error(t, err)
+	})
+
+	t.Run("repeats the handshake when the server lost the session", func(t *testing.T) {
+		// given:
+		var handshakes atomic.Int32
+		var middleware atomic.Pointer[brc104.Middleware]
+		middleware.Store(givenMiddleware(t))
+
+		// and:
+		mux := http.NewServeMux()
+		mux.HandleFunc("POST "+brc104.HandshakePath, func(w http.ResponseWriter, r *http.Request) {
+			handshakes.Add(1)
+			middleware.Load().HandleHandshake(w, r)
+		})
+		mux.HandleFunc("POST /{$}", func(w http.ResponseWriter, r *http.Request) {
+			middleware.Load().Handler(echoHandler).ServeHTTP(w, r)
+		})
+		server := httptest.NewServer(mux)
+		defer server.Close()
+
+		// and:
+		client := &http.Client{Transport: brc104.NewTransport(givenKey(t, clientPrivKey), server.Client().Transport)}
+		res, err := post(t, client, server.URL, "hello")
+		require.NoError(t, err)
+		_ = readBody(t, res)
+
+		// and the server restarted:
+		middleware.Store(givenMiddleware(t))
+
+		// when:
+		res, err = post(t, client, server.URL, "again")
+
+		// then:
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusOK, res.StatusCode)
+		assert.Contains(t, readBody(t, res), ":again")
+		assert.Equal(t, int32(2), handshakes.Load())
+	})
+}
@@ -0,0 +1,75 @@
+// Package brc104 implements the BRC-103 mutual authentication of peers over the BRC-104 HTTP transport
+package brc104
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+const (
+	// Version is the version of the BRC-103 protocol
+	Version = "0.1"
+	// HandshakePath is the path of the endpoint exchanging the BRC-103 handshake messages
+	HandshakePath = "/.well-known/auth"
+)
+
+const (
+	headerVersion     = "x-bsv-auth-version"
+	headerMessageType = "x-bsv-auth-message-type"
+	headerIdentityKey = "x-bsv-auth-identity-key"
+	headerNonce       = "x-bsv-auth-nonce"
+	headerYourNonce   = "x-bsv-auth-your-nonce"
+	headerSignature   = "x-bsv-auth-signature"
+	headerRequestID   = "x-bsv-auth-request-id"
+)
+
+// MessageType is the type of the BRC-103 message
+type MessageType string
+
+// MessageType constants of the handshake and general messages
+const (
+	MessageTypeInitialRequest  MessageType = "initialRequest"
+	MessageTypeInitialResponse MessageType = "initialResponse"
+	MessageTypeGeneral         MessageType = "general"
+)
+
+// AuthMessage is the BRC-103 message exchanged by the peers during the handshake
+type AuthMessage struct {
+	Version      string      `json:"version"`
+	MessageType  MessageType `json:"messageType"`
+	IdentityKey  string      `json:"identityKey"`
+	Nonce        string      `json:"nonce,omitempty"`
+	InitialNonce string      `json:"initialNonce,omitempty"`
+	YourNonce    string      `json:"yourNonce,omitempty"`
+	Signature    Bytes       `json:"signature,omitempty"`
+}
+
+// Bytes is a byte slice serialized to JSON as an array of numbers, the same way as by the TypeScript peers
+type Bytes []byte
+
+// MarshalJSON implements json.Marshaler
+func (b Bytes) MarshalJSON() ([]byte, error) {
+	numbers := make([]uint16, len(b))
+	for i, v := range b {
+		numbers[i] = uint16(v)
+	}
+	return json.Marshal(numbers)
+}
+
+// UnmarshalJSON implements json.Unmarshaler
+func (b *Bytes) UnmarshalJSON(data []byte) error {
+	var numbers []uint16
+	if err := json.Unmarshal(data, &numbers); err != nil {
+		return fmt.Errorf("expected array of bytes: %w", err)
+	}
+
+	bytes := make(Bytes, len(numbers))
+	for i, v := range numbers {
+		if v > 0xff {
+			return fmt.Errorf("value %d at index %d is not a byte", v, i)
+		}
+		bytes[i] = byte(v) //nolint:gosec // the range is checked above
+	}
+	*b = bytes
+	return nil
+}
@@ -0,0 +1,493 @@
+package brc104
+
+import (
+	"bytes"
+	"container/list"
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/4chain-ag/go-wallet-toolbox/pkg/internal/logging"
+	primitives "github.com/bsv-blockchain/go-sdk/primitives/ec"
+)
+
+const (
+	// sessionTTL is the time after which the peer must repeat the handshake
+	sessionTTL = 24 * time.Hour
+	// maxMessageSize limits the size of the handshake messages and the bodies of the authenticated requests
+	maxMessageSize = 10 << 20
+	// DefaultMaxSessions is the default limit of the sessions kept by the Middleware
+	DefaultMaxSessions = 10_000
+	// DefaultHandshakesPerMinute is the default limit of the handshakes per client IP address and per identity key
+	DefaultHandshakesPerMinute = 60
+	// maxRequestsPerSession limits the number of the nonces and request ids remembered to reject the replayed requests,
+	// the session is ended when it's reached, so the peer must repeat the handshake
+	maxRequestsPerSession = 1_000
+	// cleanupInterval is the minimal time between the removals of the expired sessions and the unused rate limits
+	cleanupInterval = time.Minute
+)
+
+var errTooManySessions = errors.New("too many sessions")
+
+type identityKeyCtxKey struct{}
+
+// IdentityKey returns the identity key of the peer authenticated for the request by the Middleware
+func IdentityKey(ctx context.Context) (string, bool) {
+	identityKey, ok := ctx.Value(identityKeyCtxKey{}).(string)
+	return identityKey, ok && identityKey != ""
+}
+
+type session struct {
+	peerKey         *primitives.PublicKey
+	peerIdentityKey string
+	peerNonce       string
+	createdAt       time.Time
+
+	// pending is the element of Middleware.pending until the first request of the session is authenticated
+	pending *list.Element
+
+	// usedNonces and usedRequestIDs of the authenticated requests, the requests reusing them are rejected as replayed
+	usedNonces     map[string]struct{}
+	usedRequestIDs map[string]struct{}
+}
+
+// MiddlewareOption is function for additional setup of Middleware
+type MiddlewareOption func(*Middleware)
+
+// WithMaxSessions limits the number of the sessions kept by the Middleware.
+// When the limit is reached, the oldest session without any authenticated request is dropped,
+// the sessions with authenticated requests are never dropped for the new ones, so the new handshakes are rejected until they expire.
+func WithMaxSessions(maxSessions int) MiddlewareOption {
+	return func(m *Middleware) {
+		m.maxSessions = max(maxSessions, 1)
+	}
+}
+
+// WithHandshakesPerMinute limits the rate of the handshakes per client IP address and per identity key.
+func WithHandshakesPerMinute(handshakesPerMinute int) MiddlewareOption {
+	return func(m *Middleware) {
+		m.handshakes.perMinute = float64(max(handshakesPerMinute, 1))
+	}
+}
+
+// Middleware authenticates the peers with BRC-103 handshake and their requests with BRC-104 general messages,
+// the responses are signed with the server's key so the peers can authenticate the server as well.
+type Middleware struct {
+	logger      *slog.Logger
+	key         *primitives.PrivateKey
+	identityKey string
+
+	mu          sync.Mutex
+	sessions    map[string]*session // by the server's session nonce
+	pending     *list.List          // nonces of the sessions without authenticated requests, the oldest first
+	maxSessions int
+	cleanedAt   time.Time
+	handshakes  *handshakeLimiter
+}
+
+// NewMiddleware creates the authentication middleware of the server with given private key
+func NewMiddleware(logger *slog.Logger, privateKey *primitives.PrivateKey, opts ...MiddlewareOption) *Middleware {
+	m := &Middleware{
+		logger:      logging.Child(logging.DefaultIfNil(logger), "brc104"),
+		key:         privateKey,
+		identityKey: identityKey(privateKey),
+		sessions:    make(map[string]*session),
+		pending:     list.New(),
+		maxSessions: DefaultMaxSessions,
+		handshakes:  newHandshakeLimiter(DefaultHandshakesPerMinute),
+	}
+	for _, opt := range opts {
+		opt(m)
+	}
+	return m
+}
+
+// HandleHandshake handles the BRC-103 initial request of the peer, it should be mounted on HandshakePath
+func (m *Middleware) HandleHandshake(w http.ResponseWriter, r *http.Request) {
+	var request AuthMessage
+	err := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxMessageSize)).Decode(&request)
+	if err != nil {
+		m.logger.Debug("invalid handshake message", logging.Error(err))
+		writeError(w, http.StatusBadRequest, "ERR_INVALID_AUTH_MESSAGE", "Invalid auth message")
+		return
+	}
+
+	if !m.allowHandshake(clientIP(r), request.IdentityKey) {
+		m.logger.Debug("too many handshakes", slog.String("identityKey", request.IdentityKey), slog.String("remoteAddr", r.RemoteAddr))
+		writeError(w, http.StatusTooManyRequests, "ERR_TOO_MANY_HANDSHAKES", "Too many handshakes")
+		return
+	}
+
+	response, err := m.handshake(&request)
+	if errors.Is(err, errTooManySessions) {
+		m.logger.Warn("handshake rejected, the limit of sessions is reached", slog.String("identityKey", request.IdentityKey))
+		writeError(w, http.StatusServiceUnavailable, "ERR_TOO_MANY_SESSIONS", "Too many sessions")
+		return
+	}
+	if err != nil {
+		m.logger.Debug("handshake failed", slog.String("identityKey", request.IdentityKey), logging.Error(err))
+		writeError(w, http.StatusUnauthorized, "ERR_UNAUTHORIZED", "Mutual-authentication failed!")
+		return
+	}
+
+	w.Header().Set(headerVersion, response.Version)
+	w.Header().Set(headerMessageType, string(response.MessageType))
+	w.Header().Set(headerIdentityKey, response.IdentityKey)
+	w.Header().Set(headerNonce, response.InitialNonce)
+	w.Header().Set(headerYourNonce, response.YourNonce)
+	w.Header().Set(headerSignature, hex.EncodeToString(response.Signature))
+	writeJSON(w, http.StatusOK, response)
+}
+
+// Handler returns the handler accepting only the requests authenticated by BRC-104 headers,
+// the identity key of the peer is bound to the request context and can be read by IdentityKey.
+func (m *Middleware) Handler(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		peer, requestID, body, err := m.authenticate(r)
+		if err != nil {
+			m.logger.Debug("request is not authenticated", slog.String("path", r.URL.Path), logging.Error(err))
+			writeError(w, http.StatusUnauthorized, "ERR_UNAUTHORIZED", "Mutual-authentication failed!")
+			return
+		}
+
+		r = r.WithContext(context.WithValue(r.Context(), identityKeyCtxKey{}, peer.peerIdentityKey))
+		r.Body = io.NopCloser(bytes.NewReader(body))
+
+		response := &bufferedResponse{header: make(http.Header), status: http.StatusOK}
+		next.ServeHTTP(response, r)
+
+		err = m.signResponse(response, peer, requestID)
+		if err != nil {
+			m.logger.Error("failed to sign response", logging.Error(err))
+			writeError(w, http.StatusInternalServerError, "ERR_INTERNAL", "Failed to sign the response")
+			return
+		}
+		response.writeTo(w)
+	})
+}
+
+func (m *Middleware) handshake(request *AuthMessage) (*AuthMessage, error) {
+	if request.Version != Version {
+		return nil, fmt.Errorf("unsupported version %q", request.Version)
+	}
+	if request.MessageType != MessageTypeInitialRequest {
+		return nil, fmt.Errorf("unsupported message type %q", request.MessageType)
+	}
+
+	peerKey, err := primitives.PublicKeyFromString(request.IdentityKey)
+	if err != nil {
+		return nil, fmt.Errorf("invalid identity key: %w", err)
+	}
+
+	sessionNonce, err := newNonce()
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := handshakeData(request.InitialNonce, sessionNonce)
+	if err != nil {
+		return nil, err
+	}
+
+	signature, err := sign(m.key, peerKey, signatureKeyID(request.InitialNonce, sessionNonce), data)
+	if err != nil {
+		return nil, err
+	}
+
+	err = m.addSession(sessionNonce, &session{
+		peerKey:         peerKey,
+		peerIdentityKey: request.IdentityKey,
+		peerNonce:       request.InitialNonce,
+		createdAt:       time.Now(),
+		usedNonces:      make(map[string]struct{}),
+		usedRequestIDs:  make(map[string]struct{}),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &AuthMessage{
+		Version:      Version,
+		MessageType:  MessageTypeInitialResponse,
+		IdentityKey:  m.identityKey,
+		InitialNonce: sessionNonce,
+		YourNonce:    request.InitialNonce,
+		Signature:    signature,
+	}, nil
+}
+
+func (m *Middleware) authenticate(r *http.Request) (*session, string, []byte, error) {
+	if r.Header.Get(headerVersion) != Version {
+		return nil, "", nil, fmt.Errorf("unsupported version %q", r.Header.Get(headerVersion))
+	}
+
+	sessionNonce := r.Header.Get(headerYourNonce)
+	peer := m.session(sessionNonce)
+	if peer == nil {
+		return nil, "", nil, errors.New("unknown session")
+	}
+	if peer.peerIdentityKey != r.Header.Get(headerIdentityKey) {
+		return nil, "", nil, errors.New("identity key doesn't match the session")
+	}
+
+	signature, err := hex.DecodeString(r.Header.Get(headerSignature))
+	if err != nil {
+		return nil, "", nil, fmt.Errorf("invalid signature: %w", err)
+	}
+
+	body, err := io.ReadAll(http.MaxBytesReader(nil, r.Body, maxMessageSize))
+	if err != nil {
+		return nil, "", nil, fmt.Errorf("failed to read body: %w", err)
+	}
+
+	requestID := r.Header.Get(headerRequestID)
+	payload, err := requestPayload(requestID, r.Method, r.URL, r.Header, body)
+	if err != nil {
+		return nil, "", nil, err
+	}
+
+	nonce := r.Header.Get(headerNonce)
+	err = verify(m.key, peer.peerKey, signatureKeyID(nonce, sessionNonce), payload, signature)
+	if err != nil {
+		return nil, "", nil, err
+	}
+
+	err = m.markUsed(sessionNonce, peer, nonce, requestID)
+	if err != nil {
+		return nil, "", nil, err
+	}
+
+	return peer, requestID, body, nil
+}
+
+func (m *Middleware) signResponse(response *bufferedResponse, peer *session, requestID string) error {
+	nonce, err := newNonce()
+	if err != nil {
+		return err
+	}
+
+	payload, err := responsePayload(requestID, response.status, response.header, response.body.Bytes())
+	if err != nil {
+		return err
+	}
+
+	signature, err := sign(m.key, peer.peerKey, signatureKeyID(nonce, peer.peerNonce), payload)
+	if err != nil {
+		return err
+	}
+
+	response.header.Set(headerVersion, Version)
+	response.header.Set(headerIdentityKey, m.identityKey)
+	response.header.Set(headerNonce, nonce)
+	response.header.Set(headerYourNonce, peer.peerNonce)
+	response.header.Set(headerRequestID, requestID)
+	response.header.Set(headerSignature, hex.EncodeToString(signature))
+	return nil
+}
+
+// addSession stores the new session, when the limit of the sessions is reached,
+// the oldest session without authenticated requests is dropped or errTooManySessions is returned if there is none.
+func (m *Middleware) addSession(nonce string, s *session) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	now := time.Now()
+	if now.Sub(m.cleanedAt) >= cleanupInterval {
+		m.removeExpiredSessions(now)
+		m.handshakes.removeUnused(now)
+		m.cleanedAt = now
+	}
+
+	if len(m.sessions) >= m.maxSessions {
+		oldest := m.pending.Front()
+		if oldest == nil {
+			return errTooManySessions
+		}
+		m.removeSession(oldest.Value.(string)) //nolint:forcetypeassert // only nonces are stored in pending
+	}
+
+	s.pending = m.pending.PushBack(nonce)
+	m.sessions[nonce] = s
+	return nil
+}
+
+func (m *Middleware) removeExpiredSessions(now time.Time) {
+	for nonce, s := range m.sessions {
+		if now.Sub(s.createdAt) > sessionTTL {
+			m.removeSession(nonce)
+		}
+	}
+}
+
+func (m *Middleware) removeSession(nonce string) {
+	s, ok := m.sessions[nonce]
+	if !ok {
+		return
+	}
+	if s.pending != nil {
+		m.pending.Remove(s.pending)
+	}
+	delete(m.sessions, nonce)
+}
+
+// markUsed records the nonce and the request id of the authenticated request,
+// it fails when any of them has been already used in the session.
+// The session which reached maxRequestsPerSession is ended, so the peer must repeat the handshake.
+func (m *Middleware) markUsed(sessionNonce string, s *session, nonce, requestID string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if len(s.usedNonces) >= maxRequestsPerSession {
+		m.removeSession(sessionNonce)
+		return errors.New("session reached the limit of requests")
+	}
+
+	_, nonceUsed := s.usedNonces[nonce]
+	_, requestIDUsed := s.usedRequestIDs[requestID]
+	if nonceUsed || requestIDUsed {
+		return errors.New("nonce or request id has been already used in the session")
+	}
+
+	s.usedNonces[nonce] = struct{}{}
+	s.usedRequestIDs[requestID] = struct{}{}
+	if s.pending != nil {
+		m.pending.Remove(s.pending)
+		s.pending = nil
+	}
+	return nil
+}
+
+func (m *Middleware) session(nonce string) *session {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	s, ok := m.sessions[nonce]
+	if !ok || time.Since(s.createdAt) > sessionTTL {
+		return nil
+	}
+	return s
+}
+
+func (m *Middleware) allowHandshake(ip, identityKey string) bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return m.handshakes.allow(time.Now(), "ip:"+ip, "identityKey:"+identityKey)
+}
+
+// handshakeLimiter limits the rate of the handshakes per key (e.g. client IP address or identity key) with token buckets.
+// It's not safe for concurrent use.
+type handshakeLimiter struct {
+	perMinute float64
+	buckets   map[string]*handshakeBucket
+}
+
+type handshakeBucket struct {
+	tokens     float64
+	refilledAt time.Time
+}
+
+func newHandshakeLimiter(perMinute int) *handshakeLimiter {
+	return &handshakeLimiter{
+		perMinute: float64(perMinute),
+		buckets:   make(map[string]*handshakeBucket),
+	}
+}
+
+// allow takes a token from the buckets of all the keys, it returns false without taking any token if any of the buckets is empty.
+func (l *handshakeLimiter) allow(now time.Time, keys ...string) bool {
+	buckets := make([]*handshakeBucket, 0, len(keys))
+	for _, key := range keys {
+		bucket, ok := l.buckets[key]
+		if !ok {
+			bucket = &handshakeBucket{tokens: l.perMinute, refilledAt: now}
+			l.buckets[key] = bucket
+		}
+		l.refill(bucket, now)
+		if bucket.tokens < 1 {
+			return false
+		}
+		buckets = append(buckets, bucket)
+	}
+
+	for _, bucket := range buckets {
+		bucket.tokens--
+	}
+	return true
+}
+
+// removeUnused removes the full buckets, they are the same as the new ones
+func (l *handshakeLimiter) removeUnused(now time.Time) {
+	for key, bucket := range l.buckets {
+		l.refill(bucket, now)
+		if bucket.tokens >= l.perMinute {
+			delete(l.buckets, key)
+		}
+	}
+}
+
+func (l *handshakeLimiter) refill(bucket *handshakeBucket, now time.Time) {
+	bucket.tokens = min(l.perMinute, bucket.tokens+now.Sub(bucket.refilledAt).Minutes()*l.perMinute)
+	bucket.refilledAt = now
+}
+
+func clientIP(r *http.Request) string {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
+
+// bufferedResponse keeps the response of the next handler until it's signed
+type bufferedResponse struct {
+	header http.Header
+	status int
+	body   bytes.Buffer
+}
+
+func (b *bufferedResponse) Header() http.Header {
+	return b.header
+}
+
+func (b *bufferedResponse) WriteHeader(status int) {
+	b.status = status
+}
+
+func (b *bufferedResponse) Write(data []byte) (int, error) {
+	n, err := b.body.Write(data)
+	if err != nil {
+		return n, fmt.Errorf("failed to buffer response: %w", err)
+	}
+	return n, nil
+}
+
+func (b *bufferedResponse) writeTo(w http.ResponseWriter) {
+	for key, values := range b.header {
+		w.Header()[key] = values
+	}
+	w.WriteHeader(b.status)
+	_, _ = w.Write(b.body.Bytes())
+}
+
+func writeError(w http.ResponseWriter, status int, code, description string) {
+	writeJSON(w, status, map[string]string{
+		"status":      "error",
+		"code":        code,
+		"description": description,
+	})
+}
+
+func writeJSON(w http.ResponseWriter, status int, value any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(value)
+}
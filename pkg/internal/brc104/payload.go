@@ -0,0 +1,114 @@
+package brc104
+
+import (
+	"bytes"
+	"cmp"
+	"encoding/base64"
+	"fmt"
+	"math"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+
+	"github.com/bsv-blockchain/go-sdk/transaction"
+	"github.com/go-softwarelab/common/pkg/to"
+)
+
+// requestPayload serializes the request the way it's signed by the BRC-104 general message
+func requestPayload(requestID, method string, u *url.URL, header http.Header, body []byte) ([]byte, error) {
+	id, err := base64.StdEncoding.DecodeString(requestID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid request id: %w", err)
+	}
+
+	var buf bytes.Buffer
+	buf.Write(id)
+	writeBytes(&buf, []byte(method))
+	path := u.EscapedPath()
+	if path == "" {
+		// the empty path is sent as "/", the same as the pathname of URL in JavaScript
+		path = "/"
+	}
+	writeBytes(&buf, []byte(path))
+	if u.RawQuery != "" {
+		writeBytes(&buf, []byte("?"+u.RawQuery))
+	} else {
+		writeOptionalBytes(&buf, nil)
+	}
+	writeHeaders(&buf, signedHeaders(header, true))
+	writeOptionalBytes(&buf, body)
+
+	return buf.Bytes(), nil
+}
+
+// responsePayload serializes the response the way it's signed by the BRC-104 general message
+func responsePayload(requestID string, status int, header http.Header, body []byte) ([]byte, error) {
+	id, err := base64.StdEncoding.DecodeString(requestID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid request id: %w", err)
+	}
+
+	statusCode, err := to.UInt64(status)
+	if err != nil {
+		return nil, fmt.Errorf("invalid status code: %w", err)
+	}
+
+	var buf bytes.Buffer
+	buf.Write(id)
+	buf.Write(transaction.VarInt(statusCode).Bytes())
+	writeHeaders(&buf, signedHeaders(header, false))
+	writeOptionalBytes(&buf, body)
+
+	return buf.Bytes(), nil
+}
+
+// signedHeaders returns the sorted headers covered by the signature:
+// x-bsv-* (except x-bsv-auth-*), authorization and, for the requests only, content-type without its parameters
+func signedHeaders(header http.Header, withContentType bool) [][2]string {
+	var headers [][2]string
+	for key, values := range header {
+		key = strings.ToLower(key)
+		value := strings.Join(values, ", ")
+		switch {
+		case strings.HasPrefix(key, "x-bsv-auth"):
+			continue
+		case strings.HasPrefix(key, "x-bsv-"), key == "authorization":
+			headers = append(headers, [2]string{key, value})
+		case withContentType && key == "content-type":
+			mediaType, _, _ := strings.Cut(value, ";")
+			headers = append(headers, [2]string{key, strings.TrimSpace(mediaType)})
+		}
+	}
+
+	slices.SortFunc(headers, func(a, b [2]string) int {
+		return cmp.Compare(a[0], b[0])
+	})
+	return headers
+}
+
+func writeHeaders(buf *bytes.Buffer, headers [][2]string) {
+	writeLength(buf, len(headers))
+	for _, header := range headers {
+		writeBytes(buf, []byte(header[0]))
+		writeBytes(buf, []byte(header[1]))
+	}
+}
+
+func writeBytes(buf *bytes.Buffer, data []byte) {
+	writeLength(buf, len(data))
+	buf.Write(data)
+}
+
+func writeLength(buf *bytes.Buffer, length int) {
+	buf.Write(transaction.VarInt(length).Bytes()) //nolint:gosec // length is never negative
+}
+
+// writeOptionalBytes writes the empty data as the varint of -1, which marks the absent value
+func writeOptionalBytes(buf *bytes.Buffer, data []byte) {
+	if len(data) == 0 {
+		buf.Write(transaction.VarInt(math.MaxUint64).Bytes())
+		return
+	}
+	writeBytes(buf, data)
+}
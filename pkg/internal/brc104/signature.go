@@ -0,0 +1,95 @@
+package brc104
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+
+	primitives "github.com/bsv-blockchain/go-sdk/primitives/ec"
+)
+
+// signatureInvoicePrefix is the BRC-43 invoice number prefix of the protocol [2, "auth message signature"]
+const signatureInvoicePrefix = "2-auth message signature-"
+
+// nonceSize is a multiple of 3, so the base64 nonces have no padding
+// and the concatenation of the nonces, which is decoded at once by the TypeScript peers, is a valid base64
+const nonceSize = 48
+
+var errInvalidSignature = errors.New("invalid signature")
+
+// sign creates the signature of the data with the key derived by BRC-42 for the counterparty
+func sign(key *primitives.PrivateKey, counterparty *primitives.PublicKey, keyID string, data []byte) ([]byte, error) {
+	derived, err := key.DeriveChild(counterparty, signatureInvoicePrefix+keyID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to derive signing key: %w", err)
+	}
+
+	hash := sha256.Sum256(data)
+	signature, err := derived.Sign(hash[:])
+	if err != nil {
+		return nil, fmt.Errorf("failed to sign: %w", err)
+	}
+
+	der, err := signature.ToDER()
+	if err != nil {
+		return nil, fmt.Errorf("failed to serialize signature: %w", err)
+	}
+	return der, nil
+}
+
+// verify checks the signature of the data created by the counterparty with the key derived by BRC-42 for us
+func verify(key *primitives.PrivateKey, counterparty *primitives.PublicKey, keyID string, data, signature []byte) error {
+	derived, err := counterparty.DeriveChild(key, signatureInvoicePrefix+keyID)
+	if err != nil {
+		return fmt.Errorf("failed to derive verification key: %w", err)
+	}
+
+	parsed, err := primitives.ParseDERSignature(signature)
+	if err != nil {
+		return fmt.Errorf("%w: %w", errInvalidSignature, err)
+	}
+
+	if !derived.Verify(data, parsed) {
+		return errInvalidSignature
+	}
+	return nil
+}
+
+func signatureKeyID(nonce, yourNonce string) string {
+	return nonce + " " + yourNonce
+}
+
+// handshakeData is the data signed in the initial response, the concatenation of the initial nonces of both peers
+func handshakeData(requesterNonce, responderNonce string) ([]byte, error) {
+	requester, err := base64.StdEncoding.DecodeString(requesterNonce)
+	if err != nil {
+		return nil, fmt.Errorf("invalid nonce: %w", err)
+	}
+	responder, err := base64.StdEncoding.DecodeString(responderNonce)
+	if err != nil {
+		return nil, fmt.Errorf("invalid nonce: %w", err)
+	}
+	return append(requester, responder...), nil
+}
+
+func newNonce() (string, error) {
+	return randomBase64(nonceSize)
+}
+
+func newRequestID() (string, error) {
+	return randomBase64(32)
+}
+
+func randomBase64(size int) (string, error) {
+	data := make([]byte, size)
+	if _, err := rand.Read(data); err != nil {
+		return "", fmt.Errorf("failed to generate random bytes: %w", err)
+	}
+	return base64.StdEncoding.EncodeToString(data), nil
+}
+
+func identityKey(key *primitives.PrivateKey) string {
+	return key.PubKey().ToDERHex()
+}
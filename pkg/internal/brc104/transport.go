@@ -0,0 +1,262 @@
+package brc104
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+
+	primitives "github.com/bsv-blockchain/go-sdk/primitives/ec"
+)
+
+type serverSession struct {
+	serverKey         *primitives.PublicKey
+	serverIdentityKey string
+	serverNonce       string
+	nonce             string
+}
+
+// Transport is the http.RoundTripper authenticating the client to the servers with BRC-103 over BRC-104,
+// it makes the handshake with every server on the first request and verifies the signatures of the responses.
+type Transport struct {
+	key         *primitives.PrivateKey
+	identityKey string
+	base        http.RoundTripper
+
+	mu       sync.Mutex
+	sessions map[string]*serverSession // by the origin of the server
+}
+
+// NewTransport creates the authenticating transport with given private key of the client,
+// the requests are sent by the base transport or http.DefaultTransport when it's nil.
+func NewTransport(privateKey *primitives.PrivateKey, base http.RoundTripper) *Transport {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	return &Transport{
+		key:         privateKey,
+		identityKey: identityKey(privateKey),
+		base:        base,
+		sessions:    make(map[string]*serverSession),
+	}
+}
+
+// RoundTrip implements http.RoundTripper
+func (t *Transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	var body []byte
+	if req.Body != nil {
+		var err error
+		body, err = io.ReadAll(req.Body)
+		_ = req.Body.Close()
+		if err != nil {
+			return nil, fmt.Errorf("failed to read request body: %w", err)
+		}
+	}
+
+	origin := req.URL.Scheme + "://" + req.URL.Host
+
+	session, err := t.session(req.Context(), origin)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := t.send(req, session, body)
+	if err != nil || res.StatusCode != http.StatusUnauthorized || res.Header.Get(headerIdentityKey) != "" {
+		return res, err
+	}
+
+	// the server doesn't know the session (e.g. it was restarted or the session has expired), so repeat the handshake
+	_ = res.Body.Close()
+	t.dropSession(origin, session)
+
+	session, err = t.session(req.Context(), origin)
+	if err != nil {
+		return nil, err
+	}
+	return t.send(req, session, body)
+}
+
+func (t *Transport) send(req *http.Request, session *serverSession, body []byte) (*http.Response, error) {
+	requestID, err := newRequestID()
+	if err != nil {
+		return nil, err
+	}
+	nonce, err := newNonce()
+	if err != nil {
+		return nil, err
+	}
+
+	signed := req.Clone(req.Context())
+	signed.Body = io.NopCloser(bytes.NewReader(body))
+	signed.ContentLength = int64(len(body))
+	signed.GetBody = func() (io.ReadCloser, error) {
+		return io.NopCloser(bytes.NewReader(body)), nil
+	}
+
+	payload, err := requestPayload(requestID, signed.Method, signed.URL, signed.Header, body)
+	if err != nil {
+		return nil, err
+	}
+	signature, err := sign(t.key, session.serverKey, signatureKeyID(nonce, session.serverNonce), payload)
+	if err != nil {
+		return nil, err
+	}
+
+	signed.Header.Set(headerVersion, Version)
+	signed.Header.Set(headerIdentityKey, t.identityKey)
+	signed.Header.Set(headerNonce, nonce)
+	signed.Header.Set(headerYourNonce, session.serverNonce)
+	signed.Header.Set(headerRequestID, requestID)
+	signed.Header.Set(headerSignature, hex.EncodeToString(signature))
+
+	res, err := t.base.RoundTrip(signed)
+	if err != nil {
+		return nil, fmt.Errorf("failed to send request: %w", err)
+	}
+
+	if res.StatusCode == http.StatusUnauthorized && res.Header.Get(headerIdentityKey) == "" {
+		return res, nil
+	}
+
+	err = t.verifyResponse(res, session, requestID)
+	if err != nil {
+		_ = res.Body.Close()
+		return nil, err
+	}
+	return res, nil
+}
+
+func (t *Transport) verifyResponse(res *http.Response, session *serverSession, requestID string) error {
+	body, err := io.ReadAll(res.Body)
+	_ = res.Body.Close()
+	if err != nil {
+		return fmt.Errorf("failed to read response body: %w", err)
+	}
+	res.Body = io.NopCloser(bytes.NewReader(body))
+
+	if res.Header.Get(headerIdentityKey) != session.serverIdentityKey {
+		return errors.New("response is not signed by the authenticated server")
+	}
+	if res.Header.Get(headerYourNonce) != session.nonce {
+		return errors.New("response is signed for other session")
+	}
+	if res.Header.Get(headerRequestID) != requestID {
+		return errors.New("response is signed for other request")
+	}
+
+	signature, err := hex.DecodeString(res.Header.Get(headerSignature))
+	if err != nil {
+		return fmt.Errorf("invalid response signature: %w", err)
+	}
+
+	payload, err := responsePayload(requestID, res.StatusCode, res.Header, body)
+	if err != nil {
+		return err
+	}
+
+	err = verify(t.key, session.serverKey, signatureKeyID(res.Header.Get(headerNonce), session.nonce), payload, signature)
+	if err != nil {
+		return fmt.Errorf("failed to verify response: %w", err)
+	}
+	return nil
+}
+
+func (t *Transport) session(ctx context.Context, origin string) (*serverSession, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if session, ok := t.sessions[origin]; ok {
+		return session, nil
+	}
+
+	session, err := t.handshake(ctx, origin)
+	if err != nil {
+		return nil, fmt.Errorf("handshake with %s failed: %w", origin, err)
+	}
+	t.sessions[origin] = session
+	return session, nil
+}
+
+func (t *Transport) dropSession(origin string, session *serverSession) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.sessions[origin] == session {
+		delete(t.sessions, origin)
+	}
+}
+
+func (t *Transport) handshake(ctx context.Context, origin string) (*serverSession, error) {
+	nonce, err := newNonce()
+	if err != nil {
+		return nil, err
+	}
+
+	message, err := json.Marshal(AuthMessage{
+		Version:      Version,
+		MessageType:  MessageTypeInitialRequest,
+		IdentityKey:  t.identityKey,
+		InitialNonce: nonce,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal initial request: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, origin+HandshakePath, bytes.NewReader(message))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create initial request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	res, err := t.base.RoundTrip(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to send initial request: %w", err)
+	}
+	defer func() {
+		_ = res.Body.Close()
+	}()
+
+	if res.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status code %d", res.StatusCode)
+	}
+
+	var response AuthMessage
+	err = json.NewDecoder(io.LimitReader(res.Body, maxMessageSize)).Decode(&response)
+	if err != nil {
+		return nil, fmt.Errorf("invalid initial response: %w", err)
+	}
+
+	if response.MessageType != MessageTypeInitialResponse {
+		return nil, fmt.Errorf("unexpected message type %q", response.MessageType)
+	}
+	if response.YourNonce != nonce {
+		return nil, errors.New("initial response is signed for other request")
+	}
+
+	serverKey, err := primitives.PublicKeyFromString(response.IdentityKey)
+	if err != nil {
+		return nil, fmt.Errorf("invalid server identity key: %w", err)
+	}
+
+	data, err := handshakeData(nonce, response.InitialNonce)
+	if err != nil {
+		return nil, err
+	}
+
+	err = verify(t.key, serverKey, signatureKeyID(nonce, response.InitialNonce), data, response.Signature)
+	if err != nil {
+		return nil, fmt.Errorf("failed to verify initial response: %w", err)
+	}
+
+	return &serverSession{
+		serverKey:         serverKey,
+		serverIdentityKey: response.IdentityKey,
+		serverNonce:       response.InitialNonce,
+		nonce:             nonce,
+	}, nil
+}
@@ -0,0 +1,133 @@
+package fakenetwork
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+)
+
+// statusMalformed is the ARC status code of the transaction which can't be parsed
+const statusMalformed = 461
+
+type arcBroadcastRequest struct {
+	RawTx string `json:"rawTx"`
+}
+
+type arcTxInfo struct {
+	BlockHash    string    `json:"blockHash,omitempty"`
+	BlockHeight  uint32    `json:"blockHeight,omitempty"`
+	CompetingTxs []string  `json:"competingTxs,omitempty"`
+	ExtraInfo    string    `json:"extraInfo"`
+	MerklePath   string    `json:"merklePath,omitempty"`
+	Timestamp    time.Time `json:"timestamp"`
+	TxStatus     TxStatus  `json:"txStatus"`
+	TxID         string    `json:"txid"`
+}
+
+type arcError struct {
+	Type      string `json:"type"`
+	Title     string `json:"title"`
+	Status    int    `json:"status"`
+	Detail    string `json:"detail"`
+	Instance  string `json:"instance,omitempty"`
+	TxID      string `json:"txid,omitempty"`
+	ExtraInfo string `json:"extraInfo,omitempty"`
+}
+
+type arcPolicyResponse struct {
+	Policy    arcPolicy `json:"policy"`
+	Timestamp time.Time `json:"timestamp"`
+}
+
+type arcPolicy struct {
+	MaxScriptSizePolicy     uint64       `json:"maxscriptsizepolicy"`
+	MaxTxSigOpsCountsPolicy uint64       `json:"maxtxsigopscountspolicy"`
+	MaxTxSizePolicy         uint64       `json:"maxtxsizepolicy"`
+	MiningFee               arcMiningFee `json:"miningFee"`
+}
+
+type arcMiningFee struct {
+	Satoshis uint64 `json:"satoshis"`
+	Bytes    uint64 `json:"bytes"`
+}
+
+func (n *Network) registerARC(mux *http.ServeMux) {
+	mux.HandleFunc("POST /v1/tx", n.arcBroadcast)
+	mux.HandleFunc("GET /v1/tx/{txID}", n.arcQueryTransaction)
+	mux.HandleFunc("GET /v1/policy", n.arcPolicy)
+}
+
+func (n *Network) arcBroadcast(w http.ResponseWriter, r *http.Request) {
+	var body arcBroadcastRequest
+	err := json.NewDecoder(r.Body).Decode(&body)
+	if err != nil {
+		writeARCError(w, http.StatusBadRequest, "Bad request", "invalid request body", "")
+		return
+	}
+
+	var callback *callbackTarget
+	if callbackURL := r.Header.Get("X-CallbackUrl"); callbackURL != "" {
+		callback = &callbackTarget{url: callbackURL, token: r.Header.Get("X-CallbackToken")}
+	}
+
+	info, _, err := n.broadcast(body.RawTx, callback)
+	if err != nil {
+		writeARCError(w, statusMalformed, "Malformed transaction", err.Error(), "")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, toARCTxInfo(info))
+}
+
+func (n *Network) arcQueryTransaction(w http.ResponseWriter, r *http.Request) {
+	txID := r.PathValue("txID")
+
+	info := n.TxInfo(txID)
+	if info == nil {
+		writeARCError(w, http.StatusNotFound, "Not found", "transaction not found", txID)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, toARCTxInfo(info))
+}
+
+func (n *Network) arcPolicy(w http.ResponseWriter, _ *http.Request) {
+	writeJSON(w, http.StatusOK, arcPolicyResponse{
+		Policy: arcPolicy{
+			MaxScriptSizePolicy:     100_000_000,
+			MaxTxSigOpsCountsPolicy: 4_294_967_295,
+			MaxTxSizePolicy:         100_000_000,
+			MiningFee: arcMiningFee{
+				Satoshis: n.options.MiningFee.Satoshis,
+				Bytes:    n.options.MiningFee.Bytes,
+			},
+		},
+		Timestamp: time.Now(),
+	})
+}
+
+func toARCTxInfo(info *TxInfo) arcTxInfo {
+	result := arcTxInfo{
+		BlockHash:    info.BlockHash,
+		BlockHeight:  info.BlockHeight,
+		CompetingTxs: info.CompetingTxs,
+		ExtraInfo:    info.ExtraInfo,
+		Timestamp:    info.Timestamp,
+		TxStatus:     info.Status,
+		TxID:         info.TxID,
+	}
+	if info.MerklePath != nil {
+		result.MerklePath = info.MerklePath.Hex()
+	}
+	return result
+}
+
+func writeARCError(w http.ResponseWriter, status int, title, detail, txID string) {
+	writeJSON(w, status, arcError{
+		Type:   "https://bitcoin-sv.github.io/arc/#/errors",
+		Title:  title,
+		Status: status,
+		Detail: detail,
+		TxID:   txID,
+	})
+}
@@ -0,0 +1,195 @@
+package fakenetwork
+
+import (
+	"cmp"
+	"encoding/binary"
+	"fmt"
+	"math/big"
+	"slices"
+
+	"github.com/4chain-ag/go-wallet-toolbox/pkg/internal/pow"
+	"github.com/bsv-blockchain/go-sdk/chainhash"
+	"github.com/bsv-blockchain/go-sdk/transaction"
+	"github.com/go-softwarelab/common/pkg/to"
+)
+
+const (
+	blockVersion = 0x20000000
+	// regtestBits is the easiest proof of work target, so the nonce is found in a few attempts
+	regtestBits = 0x207fffff
+)
+
+// Block is the block of the simulated chain
+type Block struct {
+	Hash       chainhash.Hash
+	Height     uint32
+	Version    int32
+	PrevHash   chainhash.Hash
+	MerkleRoot chainhash.Hash
+	Time       uint32
+	Bits       uint32
+	Nonce      uint32
+	// TxIDs are the transactions mined in the block, without the coinbase
+	TxIDs []string
+
+	// tree contains the levels of the merkle tree, starting with the leaves (coinbase is the first one)
+	tree [][]chainhash.Hash
+}
+
+func newBlock(prev *Block, blockTime uint32, txIDs []string) (*Block, error) {
+	block := &Block{
+		Version: blockVersion,
+		Time:    blockTime,
+		Bits:    regtestBits,
+		TxIDs:   txIDs,
+	}
+
+	leaves := make([]chainhash.Hash, 0, len(txIDs)+1)
+	if prev != nil {
+		block.Height = prev.Height + 1
+		block.PrevHash = prev.Hash
+	}
+	leaves = append(leaves, coinbaseHash(block.Height))
+
+	for _, txID := range txIDs {
+		hash, err := chainhash.NewHashFromHex(txID)
+		if err != nil {
+			return nil, fmt.Errorf("invalid txid %s: %w", txID, err)
+		}
+		leaves = append(leaves, *hash)
+	}
+
+	block.tree = merkleTree(leaves)
+	block.MerkleRoot = block.tree[len(block.tree)-1][0]
+
+	err := block.mine()
+	if err != nil {
+		return nil, err
+	}
+	return block, nil
+}
+
+// MerklePath returns the merkle path of the transaction mined in the block.
+func (b *Block) MerklePath(txID string) (*transaction.MerklePath, error) {
+	index := slices.Index(b.TxIDs, txID)
+	if index < 0 {
+		return nil, fmt.Errorf("tx %s is not mined in block %s", txID, b.Hash)
+	}
+	// the coinbase is the first leaf
+	txOffset := uint64(index) + 1 //nolint:gosec // index is not negative
+
+	offset := txOffset
+	path := make([][]*transaction.PathElement, len(b.tree)-1)
+	for level := range path {
+		sibling := &transaction.PathElement{Offset: offset ^ 1}
+		if siblingHash, ok := b.nodeAt(level, sibling.Offset); ok {
+			sibling.Hash = &siblingHash
+		} else {
+			sibling.Duplicate = to.Ptr(true)
+		}
+		path[level] = []*transaction.PathElement{sibling}
+		offset >>= 1
+	}
+
+	txHash := b.tree[0][index+1]
+	txLeaf := &transaction.PathElement{
+		Offset: txOffset,
+		Hash:   &txHash,
+		Txid:   to.Ptr(true),
+	}
+	// the block has at least two leaves (coinbase and the transaction), so the path has at least one level
+	path[0] = append(path[0], txLeaf)
+	slices.SortFunc(path[0], func(a, b *transaction.PathElement) int {
+		return cmp.Compare(a.Offset, b.Offset)
+	})
+
+	return transaction.NewMerklePath(b.Height, path), nil
+}
+
+// TSCProof returns the merkle proof of the transaction mined in the block in the TSC format,
+// the nodes are the siblings of the calculated hashes, "*" marks a duplicate of the calculated hash.
+func (b *Block) TSCProof(txID string) (index uint64, nodes []string, err error) {
+	position := slices.Index(b.TxIDs, txID)
+	if position < 0 {
+		return 0, nil, fmt.Errorf("tx %s is not mined in block %s", txID, b.Hash)
+	}
+	index = uint64(position) + 1 //nolint:gosec // position is not negative
+
+	offset := index
+	nodes = make([]string, 0, len(b.tree)-1)
+	for level := range len(b.tree) - 1 {
+		if siblingHash, ok := b.nodeAt(level, offset^1); ok {
+			nodes = append(nodes, siblingHash.String())
+		} else {
+			nodes = append(nodes, "*")
+		}
+		offset >>= 1
+	}
+	return index, nodes, nil
+}
+
+// Bytes returns the 80 bytes serialization of the block header.
+func (b *Block) Bytes() []byte {
+	data := make([]byte, 80)
+	binary.LittleEndian.PutUint32(data[0:4], uint32(b.Version)) //nolint:gosec // version is serialized as signed int32
+	copy(data[4:36], b.PrevHash[:])
+	copy(data[36:68], b.MerkleRoot[:])
+	binary.LittleEndian.PutUint32(data[68:72], b.Time)
+	binary.LittleEndian.PutUint32(data[72:76], b.Bits)
+	binary.LittleEndian.PutUint32(data[76:80], b.Nonce)
+	return data
+}
+
+func (b *Block) nodeAt(level int, offset uint64) (chainhash.Hash, bool) {
+	if offset >= uint64(len(b.tree[level])) {
+		return chainhash.Hash{}, false
+	}
+	return b.tree[level][offset], true
+}
+
+// mine searches for the nonce satisfying the proof of work of the block bits.
+func (b *Block) mine() error {
+	target, err := pow.TargetFromBits(b.Bits)
+	if err != nil {
+		return fmt.Errorf("failed to mine block at height %d: %w", b.Height, err)
+	}
+	for nonce := uint32(0); nonce < ^uint32(0); nonce++ {
+		b.Nonce = nonce
+		b.Hash = chainhash.DoubleHashH(b.Bytes())
+
+		hashBE := slices.Clone(b.Hash[:])
+		slices.Reverse(hashBE)
+		if new(big.Int).SetBytes(hashBE).Cmp(target) <= 0 {
+			return nil
+		}
+	}
+	return fmt.Errorf("failed to find the nonce of block at height %d", b.Height)
+}
+
+// merkleTree calculates the levels of the merkle tree, the last level contains only the merkle root.
+// The last node of the level with odd number of nodes is hashed with itself.
+func merkleTree(leaves []chainhash.Hash) [][]chainhash.Hash {
+	tree := [][]chainhash.Hash{leaves}
+	for level := leaves; len(level) > 1; {
+		next := make([]chainhash.Hash, 0, (len(level)+1)/2)
+		for i := 0; i < len(level); i += 2 {
+			right := level[i]
+			if i+1 < len(level) {
+				right = level[i+1]
+			}
+			next = append(next, merkleParent(level[i], right))
+		}
+		tree = append(tree, next)
+		level = next
+	}
+	return tree
+}
+
+func merkleParent(left, right chainhash.Hash) chainhash.Hash {
+	return chainhash.DoubleHashH(append(slices.Clone(left[:]), right[:]...))
+}
+
+// coinbaseHash is the fake coinbase transaction hash, unique for every height
+func coinbaseHash(height uint32) chainhash.Hash {
+	return chainhash.DoubleHashH(binary.LittleEndian.AppendUint32([]byte("coinbase"), height))
+}
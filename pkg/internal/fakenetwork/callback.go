@@ -0,0 +1,86 @@
+package fakenetwork
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/4chain-ag/go-wallet-toolbox/pkg/internal/logging"
+)
+
+// arcCallback is the payload of the transaction status update sent by ARC to the X-CallbackUrl
+type arcCallback struct {
+	Timestamp    time.Time `json:"timestamp"`
+	TxID         string    `json:"txid"`
+	TxStatus     TxStatus  `json:"txStatus"`
+	ExtraInfo    string    `json:"extraInfo,omitempty"`
+	BlockHash    string    `json:"blockHash,omitempty"`
+	BlockHeight  uint32    `json:"blockHeight,omitempty"`
+	MerklePath   string    `json:"merklePath,omitempty"`
+	CompetingTxs []string  `json:"competingTxs,omitempty"`
+}
+
+type callbackNotification struct {
+	target callbackTarget
+	info   TxInfo
+}
+
+// notify sends the callbacks one by one, the failures are only logged as ARC would retry them later.
+func (n *Network) notify(notifications []callbackNotification) {
+	for _, notification := range notifications {
+		err := n.sendCallback(notification)
+		if err != nil {
+			n.logger.Warn("failed to send arc callback",
+				slog.String("txid", notification.info.TxID),
+				slog.String("url", notification.target.url),
+				logging.Error(err),
+			)
+		}
+	}
+}
+
+func (n *Network) sendCallback(notification callbackNotification) error {
+	info := notification.info
+	callback := arcCallback{
+		Timestamp:    info.Timestamp,
+		TxID:         info.TxID,
+		TxStatus:     info.Status,
+		ExtraInfo:    info.ExtraInfo,
+		BlockHash:    info.BlockHash,
+		BlockHeight:  info.BlockHeight,
+		CompetingTxs: info.CompetingTxs,
+	}
+	if info.MerklePath != nil {
+		callback.MerklePath = info.MerklePath.Hex()
+	}
+
+	body, err := json.Marshal(callback)
+	if err != nil {
+		return fmt.Errorf("failed to marshal callback: %w", err)
+	}
+
+	req, err := http.NewRequest(http.MethodPost, notification.target.url, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to create callback request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if notification.target.token != "" {
+		req.Header.Set("Authorization", "Bearer "+notification.target.token)
+	}
+
+	res, err := n.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to send callback: %w", err)
+	}
+	defer func() {
+		_ = res.Body.Close()
+	}()
+
+	if res.StatusCode != http.StatusOK {
+		return fmt.Errorf("callback receiver responded with status %d", res.StatusCode)
+	}
+	return nil
+}
@@ -0,0 +1,50 @@
+package main
+
+import (
+	"flag"
+	"log"
+	"time"
+
+	"github.com/4chain-ag/go-wallet-toolbox/pkg/defs"
+	"github.com/4chain-ag/go-wallet-toolbox/pkg/internal/fakenetwork"
+)
+
+func main() {
+	addr := flag.String("addr", ":8101", "Address to listen on")
+	network := flag.String("network", string(defs.NetworkTestnet), "Network name used in the WhatsOnChain paths (main, test or regtest)")
+	latency := flag.Duration("latency", 0, "Delay applied to every ARC and WhatsOnChain request")
+	blockInterval := flag.Duration("block-interval", 0, "Interval of mining the blocks automatically, zero mines only on /control/mine")
+
+	flag.Parse()
+
+	bsvNetwork, err := defs.ParseBSVNetworkStr(*network)
+	if err != nil {
+		log.Fatalf("Invalid network: %v\n", err)
+	}
+
+	fakeNetwork := fakenetwork.New(
+		fakenetwork.WithNetwork(bsvNetwork),
+		fakenetwork.WithLatency(*latency),
+	)
+
+	if *blockInterval > 0 {
+		go mineBlocks(fakeNetwork, *blockInterval)
+	}
+
+	err = fakeNetwork.ListenAndServe(*addr)
+	if err != nil {
+		log.Fatalf("Error serving fake network: %v\n", err)
+	}
+}
+
+func mineBlocks(fakeNetwork *fakenetwork.Network, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		_, err := fakeNetwork.MineBlock()
+		if err != nil {
+			log.Printf("Error mining block: %v\n", err)
+		}
+	}
+}
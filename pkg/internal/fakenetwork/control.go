@@ -0,0 +1,109 @@
+package fakenetwork
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+)
+
+type mineRequest struct {
+	Blocks int `json:"blocks"`
+}
+
+type minedBlock struct {
+	Hash   string   `json:"hash"`
+	Height uint32   `json:"height"`
+	TxIDs  []string `json:"txids"`
+}
+
+type rejectRequest struct {
+	TxID   string `json:"txid"`
+	Reason string `json:"reason"`
+}
+
+type doubleSpendRequest struct {
+	TxID         string   `json:"txid"`
+	CompetingTxs []string `json:"competingTxs"`
+}
+
+type latencyRequest struct {
+	// Latency is the duration in the format of time.ParseDuration, e.g. "250ms"
+	Latency string `json:"latency"`
+}
+
+func (n *Network) registerControl(mux *http.ServeMux) {
+	mux.HandleFunc("POST /mine", n.controlMine)
+	mux.HandleFunc("POST /reject", n.controlReject)
+	mux.HandleFunc("POST /double-spend", n.controlDoubleSpend)
+	mux.HandleFunc("POST /latency", n.controlLatency)
+}
+
+// controlMine mines the number of blocks given in the request body, one block when the body is empty
+func (n *Network) controlMine(w http.ResponseWriter, r *http.Request) {
+	request := mineRequest{Blocks: 1}
+	if r.ContentLength != 0 {
+		err := json.NewDecoder(r.Body).Decode(&request)
+		if err != nil || request.Blocks <= 0 {
+			http.Error(w, "invalid request body", http.StatusBadRequest)
+			return
+		}
+	}
+
+	blocks, err := n.MineBlocks(request.Blocks)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	mined := make([]minedBlock, 0, len(blocks))
+	for _, block := range blocks {
+		mined = append(mined, minedBlock{
+			Hash:   block.Hash.String(),
+			Height: block.Height,
+			TxIDs:  block.TxIDs,
+		})
+	}
+	writeJSON(w, http.StatusOK, mined)
+}
+
+func (n *Network) controlReject(w http.ResponseWriter, r *http.Request) {
+	var request rejectRequest
+	err := json.NewDecoder(r.Body).Decode(&request)
+	if err != nil || request.TxID == "" {
+		http.Error(w, "invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	n.RejectTx(request.TxID, request.Reason)
+	w.WriteHeader(http.StatusOK)
+}
+
+func (n *Network) controlDoubleSpend(w http.ResponseWriter, r *http.Request) {
+	var request doubleSpendRequest
+	err := json.NewDecoder(r.Body).Decode(&request)
+	if err != nil || request.TxID == "" || len(request.CompetingTxs) == 0 {
+		http.Error(w, "invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	n.DoubleSpend(request.TxID, request.CompetingTxs...)
+	w.WriteHeader(http.StatusOK)
+}
+
+func (n *Network) controlLatency(w http.ResponseWriter, r *http.Request) {
+	var request latencyRequest
+	err := json.NewDecoder(r.Body).Decode(&request)
+	if err != nil {
+		http.Error(w, "invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	latency, err := time.ParseDuration(request.Latency)
+	if err != nil || latency < 0 {
+		http.Error(w, "invalid latency", http.StatusBadRequest)
+		return
+	}
+
+	n.SetLatency(latency)
+	w.WriteHeader(http.StatusOK)
+}
@@ -0,0 +1,417 @@
+package fakenetwork
+
+import (
+	"fmt"
+	"log/slog"
+	"net/http"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/4chain-ag/go-wallet-toolbox/pkg/internal/logging"
+	"github.com/bsv-blockchain/go-sdk/transaction"
+	"github.com/go-softwarelab/common/pkg/to"
+)
+
+// TxStatus is the status of the transaction in the fake network, the values are the ones used by ARC
+type TxStatus string
+
+// TxStatus values reported by the fake network
+const (
+	StatusSeenOnNetwork        TxStatus = "SEEN_ON_NETWORK"
+	StatusMined                TxStatus = "MINED"
+	StatusRejected             TxStatus = "REJECTED"
+	StatusDoubleSpendAttempted TxStatus = "DOUBLE_SPEND_ATTEMPTED"
+)
+
+// TxInfo is the state of the transaction known to the fake network
+type TxInfo struct {
+	TxID         string
+	Status       TxStatus
+	ExtraInfo    string
+	CompetingTxs []string
+	Timestamp    time.Time
+	// BlockHash and BlockHeight are set for the transactions mined in the simulated chain
+	BlockHash   string
+	BlockHeight uint32
+	// MerklePath is set for the mined transactions, also for those proven by the broadcast BEEF
+	MerklePath *transaction.MerklePath
+}
+
+// Network is the in-process stand-in of the BSV network, as it is seen through ARC and WhatsOnChain.
+// It holds the simulated mempool and chain, the blocks are mined only on demand (see MineBlock).
+type Network struct {
+	options    Options
+	logger     *slog.Logger
+	httpClient *http.Client
+
+	mu      sync.RWMutex
+	txs     map[string]*txRecord
+	mempool []string
+	// spentBy maps the outpoints (txid.vout) to the transactions spending them
+	spentBy      map[string]string
+	blocks       []*Block
+	blocksByHash map[string]*Block
+	rejections   map[string]string
+	doubleSpends map[string][]string
+	latency      time.Duration
+}
+
+type txRecord struct {
+	info TxInfo
+	tx   *transaction.Transaction
+	// callback is the ARC callback registered with the broadcast of the transaction
+	callback *callbackTarget
+}
+
+type callbackTarget struct {
+	url   string
+	token string
+}
+
+// New creates the fake network with the chain containing only the genesis block.
+func New(opts ...InitOption) *Network {
+	options := defaultOptions()
+	for _, option := range opts {
+		option(&options)
+	}
+
+	network := &Network{
+		options:      options,
+		logger:       logging.Child(options.Logger, "fakenetwork"),
+		httpClient:   &http.Client{Timeout: 10 * time.Second},
+		txs:          make(map[string]*txRecord),
+		spentBy:      make(map[string]string),
+		blocksByHash: make(map[string]*Block),
+		rejections:   make(map[string]string),
+		doubleSpends: make(map[string][]string),
+		latency:      options.Latency,
+	}
+
+	genesis, err := newBlock(nil, network.blockTime(nil), nil)
+	if err != nil {
+		// the genesis block contains only the coinbase, so it can't fail
+		panic(fmt.Errorf("failed to create genesis block: %w", err))
+	}
+	network.appendBlock(genesis)
+
+	return network
+}
+
+// RejectTx makes the network reject the transaction with given reason when it's broadcast.
+func (n *Network) RejectTx(txID, reason string) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.rejections[txID] = reason
+}
+
+// DoubleSpend makes the network report the transaction as the double spend of the competing ones when it's broadcast.
+func (n *Network) DoubleSpend(txID string, competingTxIDs ...string) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.doubleSpends[txID] = competingTxIDs
+}
+
+// SetLatency sets the delay applied to every ARC and WhatsOnChain request.
+func (n *Network) SetLatency(latency time.Duration) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.latency = latency
+}
+
+// Tip returns the last block of the simulated chain.
+func (n *Network) Tip() *Block {
+	n.mu.RLock()
+	defer n.mu.RUnlock()
+	return n.tip()
+}
+
+// BlockAt returns the block at given height or nil if the chain is not that long.
+func (n *Network) BlockAt(height uint32) *Block {
+	n.mu.RLock()
+	defer n.mu.RUnlock()
+	return n.blockAt(height)
+}
+
+// TxInfo returns the state of the transaction or nil if it is not known to the network.
+func (n *Network) TxInfo(txID string) *TxInfo {
+	n.mu.RLock()
+	defer n.mu.RUnlock()
+
+	record, ok := n.txs[txID]
+	if !ok {
+		return nil
+	}
+	return to.Ptr(record.info)
+}
+
+// Mempool returns the txids of transactions waiting to be mined, in the order they were accepted.
+func (n *Network) Mempool() []string {
+	n.mu.RLock()
+	defer n.mu.RUnlock()
+	return slices.Clone(n.mempool)
+}
+
+// Broadcast submits the transaction to the network, the hex can be the raw transaction, extended format or BEEF.
+// The not mined ancestors included in BEEF are submitted as well.
+// Returns the state of the transaction and the flag if it was already known to the network.
+func (n *Network) Broadcast(txHex string) (*TxInfo, bool, error) {
+	return n.broadcast(txHex, nil)
+}
+
+// MineBlock mines all the transactions from the mempool into the new block,
+// the transactions broadcast with ARC callback are reported as mined.
+func (n *Network) MineBlock() (*Block, error) {
+	n.mu.Lock()
+
+	prev := n.tip()
+	block, err := newBlock(prev, n.blockTime(prev), n.mempool)
+	if err != nil {
+		n.mu.Unlock()
+		return nil, fmt.Errorf("failed to mine block: %w", err)
+	}
+
+	var notifications []callbackNotification
+	for _, txID := range block.TxIDs {
+		record := n.txs[txID]
+		record.info.MerklePath, err = block.MerklePath(txID)
+		if err != nil {
+			n.mu.Unlock()
+			return nil, fmt.Errorf("failed to create merkle path: %w", err)
+		}
+		record.info.Status = StatusMined
+		record.info.BlockHash = block.Hash.String()
+		record.info.BlockHeight = block.Height
+		record.info.Timestamp = time.Now()
+
+		if record.callback != nil {
+			notifications = append(notifications, callbackNotification{target: *record.callback, info: record.info})
+		}
+	}
+	n.mempool = nil
+	n.appendBlock(block)
+
+	n.mu.Unlock()
+
+	n.notify(notifications)
+	n.logger.Debug("mined block", slog.Uint64("height", uint64(block.Height)), slog.Int("txs", len(block.TxIDs)))
+
+	return block, nil
+}
+
+// MineBlocks mines count blocks, only the first one contains the transactions from the mempool.
+func (n *Network) MineBlocks(count int) ([]*Block, error) {
+	blocks := make([]*Block, 0, count)
+	for range count {
+		block, err := n.MineBlock()
+		if err != nil {
+			return nil, err
+		}
+		blocks = append(blocks, block)
+	}
+	return blocks, nil
+}
+
+func (n *Network) broadcast(txHex string, callback *callbackTarget) (*TxInfo, bool, error) {
+	tx, err := parseTx(txHex)
+	if err != nil {
+		return nil, false, err
+	}
+
+	n.mu.Lock()
+
+	for _, proven := range provenAncestors(tx) {
+		n.storeProven(proven)
+	}
+	for _, ancestor := range notMinedAncestors(tx) {
+		if _, known := n.txs[ancestor.TxID().String()]; !known {
+			n.submit(ancestor, nil)
+		}
+	}
+
+	record, alreadyKnown := n.submit(tx, callback)
+	info := record.info
+
+	n.mu.Unlock()
+
+	if !alreadyKnown && callback != nil {
+		n.notify([]callbackNotification{{target: *callback, info: info}})
+	}
+
+	return &info, alreadyKnown, nil
+}
+
+// submit accepts the transaction to the mempool unless it's rejected or double spends other transaction.
+// The rejected and double spend transactions can be resubmitted.
+func (n *Network) submit(tx *transaction.Transaction, callback *callbackTarget) (*txRecord, bool) {
+	txID := tx.TxID().String()
+
+	if record, ok := n.txs[txID]; ok && isAccepted(record.info.Status) {
+		return record, true
+	}
+
+	record := &txRecord{
+		tx:       tx,
+		callback: callback,
+		info: TxInfo{
+			TxID:      txID,
+			Timestamp: time.Now(),
+		},
+	}
+	n.txs[txID] = record
+
+	if reason, rejected := n.rejections[txID]; rejected {
+		record.info.Status = StatusRejected
+		record.info.ExtraInfo = reason
+		return record, false
+	}
+
+	competingTxs := slices.Clone(n.doubleSpends[txID])
+	for _, input := range tx.Inputs {
+		spendingTxID, spent := n.spentBy[outpoint(input.SourceTXID.String(), input.SourceTxOutIndex)]
+		if spent && spendingTxID != txID && !slices.Contains(competingTxs, spendingTxID) {
+			competingTxs = append(competingTxs, spendingTxID)
+		}
+	}
+	if len(competingTxs) > 0 {
+		record.info.Status = StatusDoubleSpendAttempted
+		record.info.ExtraInfo = "double spend attempted"
+		record.info.CompetingTxs = competingTxs
+		return record, false
+	}
+
+	record.info.Status = StatusSeenOnNetwork
+	for _, input := range tx.Inputs {
+		n.spentBy[outpoint(input.SourceTXID.String(), input.SourceTxOutIndex)] = txID
+	}
+	n.mempool = append(n.mempool, txID)
+
+	return record, false
+}
+
+// storeProven stores the transaction proven by merkle path from the broadcast BEEF,
+// it is treated as mined even though its block is not the part of the simulated chain.
+func (n *Network) storeProven(tx *transaction.Transaction) {
+	txID := tx.TxID().String()
+	if _, known := n.txs[txID]; known {
+		return
+	}
+
+	n.txs[txID] = &txRecord{
+		tx: tx,
+		info: TxInfo{
+			TxID:        txID,
+			Status:      StatusMined,
+			Timestamp:   time.Now(),
+			BlockHeight: tx.MerklePath.BlockHeight,
+			MerklePath:  tx.MerklePath,
+		},
+	}
+	for _, input := range tx.Inputs {
+		n.spentBy[outpoint(input.SourceTXID.String(), input.SourceTxOutIndex)] = txID
+	}
+}
+
+func (n *Network) tip() *Block {
+	return n.blocks[len(n.blocks)-1]
+}
+
+func (n *Network) blockAt(height uint32) *Block {
+	if int(height) >= len(n.blocks) {
+		return nil
+	}
+	return n.blocks[height]
+}
+
+func (n *Network) appendBlock(block *Block) {
+	n.blocks = append(n.blocks, block)
+	n.blocksByHash[block.Hash.String()] = block
+}
+
+// blockTime returns the current time, but always later than the time of the previous block.
+func (n *Network) blockTime(prev *Block) uint32 {
+	now, err := to.UInt32(time.Now().Unix())
+	if err != nil {
+		panic(fmt.Errorf("current time doesn't fit into block header: %w", err))
+	}
+	if prev != nil && now <= prev.Time {
+		return prev.Time + 1
+	}
+	return now
+}
+
+func (n *Network) currentLatency() time.Duration {
+	n.mu.RLock()
+	defer n.mu.RUnlock()
+	return n.latency
+}
+
+// parseTx parses the transaction from BEEF, extended format or raw transaction hex.
+func parseTx(txHex string) (*transaction.Transaction, error) {
+	tx, err := transaction.NewTransactionFromBEEFHex(txHex)
+	if err == nil {
+		return tx, nil
+	}
+
+	tx, err = transaction.NewTransactionFromHex(txHex)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse transaction: %w", err)
+	}
+	return tx, nil
+}
+
+// notMinedAncestors returns the ancestors without merkle path attached to the transaction inputs,
+// the parents go before their children.
+func notMinedAncestors(tx *transaction.Transaction) []*transaction.Transaction {
+	var ancestors []*transaction.Transaction
+	visited := make(map[string]bool)
+
+	var visit func(tx *transaction.Transaction)
+	visit = func(tx *transaction.Transaction) {
+		for _, input := range tx.Inputs {
+			source := input.SourceTransaction
+			if source == nil || source.MerklePath != nil || visited[source.TxID().String()] {
+				continue
+			}
+			visited[source.TxID().String()] = true
+			visit(source)
+			ancestors = append(ancestors, source)
+		}
+	}
+	visit(tx)
+
+	return ancestors
+}
+
+// provenAncestors returns the ancestors with merkle path attached to the transaction inputs.
+func provenAncestors(tx *transaction.Transaction) []*transaction.Transaction {
+	var proven []*transaction.Transaction
+	visited := make(map[string]bool)
+
+	var visit func(tx *transaction.Transaction)
+	visit = func(tx *transaction.Transaction) {
+		for _, input := range tx.Inputs {
+			source := input.SourceTransaction
+			if source == nil || visited[source.TxID().String()] {
+				continue
+			}
+			visited[source.TxID().String()] = true
+			if source.MerklePath != nil {
+				proven = append(proven, source)
+				continue
+			}
+			visit(source)
+		}
+	}
+	visit(tx)
+
+	return proven
+}
+
+func isAccepted(status TxStatus) bool {
+	return status == StatusSeenOnNetwork || status == StatusMined
+}
+
+func outpoint(txID string, vout uint32) string {
+	return fmt.Sprintf("%s.%d", txID, vout)
+}
@@ -0,0 +1,177 @@
+package fakenetwork_test
+
+import (
+	"testing"
+
+	"github.com/4chain-ag/go-wallet-toolbox/pkg/internal/fakenetwork"
+	"github.com/bsv-blockchain/go-sdk/chainhash"
+	txtestabilities "github.com/bsv-blockchain/universal-test-vectors/pkg/testabilities"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBroadcastAndMine(t *testing.T) {
+	t.Run("mines broadcast transactions with merkle paths proving them", func(t *testing.T) {
+		// given:
+		network := fakenetwork.New()
+		first := txtestabilities.GivenTX().WithInput(1001).WithP2PKHOutput(1000)
+		second := txtestabilities.GivenTX().WithInput(2001).WithP2PKHOutput(2000)
+		third := txtestabilities.GivenTX().WithInput(3001).WithP2PKHOutput(3000)
+
+		// and:
+		for _, spec := range []txtestabilities.TransactionSpec{first, second, third} {
+			info, alreadyKnown, err := network.Broadcast(spec.BEEF())
+			require.NoError(t, err)
+			require.False(t, alreadyKnown)
+			require.Equal(t, fakenetwork.StatusSeenOnNetwork, info.Status)
+		}
+
+		// when:
+		block, err := network.MineBlock()
+
+		// then:
+		require.NoError(t, err)
+		assert.Equal(t, uint32(1), block.Height)
+		assert.Equal(t, []string{first.ID(), second.ID(), third.ID()}, block.TxIDs)
+		assert.Empty(t, network.Mempool())
+
+		// and:
+		for _, spec := range []txtestabilities.TransactionSpec{first, second, third} {
+			info := network.TxInfo(spec.ID())
+			require.NotNil(t, info)
+			assert.Equal(t, fakenetwork.StatusMined, info.Status)
+			assert.Equal(t, block.Hash.String(), info.BlockHash)
+
+			txHash, err := chainhash.NewHashFromHex(spec.ID())
+			require.NoError(t, err)
+			root, err := info.MerklePath.ComputeRoot(txHash)
+			require.NoError(t, err)
+			assert.Equal(t, block.MerkleRoot.String(), root.String())
+		}
+	})
+
+	t.Run("chains mined blocks", func(t *testing.T) {
+		// given:
+		network := fakenetwork.New()
+		genesis := network.Tip()
+
+		// when:
+		blocks, err := network.MineBlocks(3)
+
+		// then:
+		require.NoError(t, err)
+		require.Len(t, blocks, 3)
+		assert.Equal(t, genesis.Hash, blocks[0].PrevHash)
+		assert.Equal(t, blocks[0].Hash, blocks[1].PrevHash)
+		assert.Equal(t, blocks[1].Hash, blocks[2].PrevHash)
+		assert.Equal(t, blocks[2], network.Tip())
+
+		// and:
+		for _, block := range blocks {
+			assert.Equal(t, chainhash.DoubleHashH(block.Bytes()), block.Hash)
+			assert.Greater(t, block.Time, genesis.Time)
+		}
+	})
+
+	t.Run("reports the already known transaction", func(t *testing.T) {
+		// given:
+		network := fakenetwork.New()
+		spec := txtestabilities.GivenTX().WithInput(1001).WithP2PKHOutput(1000)
+
+		// and:
+		_, _, err := network.Broadcast(spec.BEEF())
+		require.NoError(t, err)
+
+		// when:
+		info, alreadyKnown, err := network.Broadcast(spec.RawTX())
+
+		// then:
+		require.NoError(t, err)
+		assert.True(t, alreadyKnown)
+		assert.Equal(t, fakenetwork.StatusSeenOnNetwork, info.Status)
+	})
+
+	t.Run("accepts not mined ancestors from the beef", func(t *testing.T) {
+		// given:
+		network := fakenetwork.New()
+		parent := txtestabilities.GivenTX().WithInput(1001).WithP2PKHOutput(1000)
+		child := txtestabilities.GivenTX().WithInputFromUTXO(parent.TX(), 0).WithP2PKHOutput(999)
+
+		// when:
+		_, _, err := network.Broadcast(child.BEEF())
+
+		// then:
+		require.NoError(t, err)
+		assert.Equal(t, []string{parent.ID(), child.ID()}, network.Mempool())
+	})
+
+	t.Run("fails for not a transaction", func(t *testing.T) {
+		// given:
+		network := fakenetwork.New()
+
+		// when:
+		_, _, err := network.Broadcast("not a transaction")
+
+		// then:
+		require.Error(t, err)
+	})
+}
+
+func TestFailuresInjection(t *testing.T) {
+	t.Run("reports double spend of the mempool transaction", func(t *testing.T) {
+		// given:
+		network := fakenetwork.New()
+		parent := txtestabilities.GivenTX().WithInput(1001).WithP2PKHOutput(1000)
+		spend := txtestabilities.GivenTX().WithInputFromUTXO(parent.TX(), 0).WithP2PKHOutput(999)
+		doubleSpend := txtestabilities.GivenTX().WithInputFromUTXO(parent.TX(), 0).WithP2PKHOutput(998)
+
+		// and:
+		_, _, err := network.Broadcast(spend.BEEF())
+		require.NoError(t, err)
+
+		// when:
+		info, _, err := network.Broadcast(doubleSpend.BEEF())
+
+		// then:
+		require.NoError(t, err)
+		assert.Equal(t, fakenetwork.StatusDoubleSpendAttempted, info.Status)
+		assert.Equal(t, []string{spend.ID()}, info.CompetingTxs)
+		assert.NotContains(t, network.Mempool(), doubleSpend.ID())
+	})
+
+	t.Run("reports injected double spend", func(t *testing.T) {
+		// given:
+		network := fakenetwork.New()
+		spec := txtestabilities.GivenTX().WithInput(1001).WithP2PKHOutput(1000)
+		competingTxID := "0f0e0d0c0b0a09080706050403020100f0e0d0c0b0a090807060504030201000"
+
+		// and:
+		network.DoubleSpend(spec.ID(), competingTxID)
+
+		// when:
+		info, _, err := network.Broadcast(spec.BEEF())
+
+		// then:
+		require.NoError(t, err)
+		assert.Equal(t, fakenetwork.StatusDoubleSpendAttempted, info.Status)
+		assert.Equal(t, []string{competingTxID}, info.CompetingTxs)
+	})
+
+	t.Run("rejects transaction with injected reason", func(t *testing.T) {
+		// given:
+		network := fakenetwork.New()
+		spec := txtestabilities.GivenTX().WithInput(1001).WithP2PKHOutput(1000)
+
+		// and:
+		network.RejectTx(spec.ID(), "mandatory-script-verify-flag-failed")
+
+		// when:
+		info, _, err := network.Broadcast(spec.BEEF())
+
+		// then:
+		require.NoError(t, err)
+		assert.Equal(t, fakenetwork.StatusRejected, info.Status)
+		assert.Equal(t, "mandatory-script-verify-flag-failed", info.ExtraInfo)
+		assert.Empty(t, network.Mempool())
+	})
+}
@@ -0,0 +1,73 @@
+package fakenetwork
+
+import (
+	"log/slog"
+	"time"
+
+	"github.com/4chain-ag/go-wallet-toolbox/pkg/defs"
+)
+
+// Options is the parameters for initializing the fake network
+type Options struct {
+	Network      defs.BSVNetwork
+	Latency      time.Duration
+	ExchangeRate float64
+	MiningFee    MiningFee
+	Logger       *slog.Logger
+}
+
+// MiningFee is the fee rate announced by the fake ARC policy endpoint
+type MiningFee struct {
+	Satoshis uint64
+	Bytes    uint64
+}
+
+func defaultOptions() Options {
+	return Options{
+		Network:      defs.NetworkTestnet,
+		Latency:      0,
+		ExchangeRate: 50,
+		MiningFee: MiningFee{
+			Satoshis: 1,
+			Bytes:    1000,
+		},
+	}
+}
+
+// InitOption is a function that sets a parameter for initializing the fake network
+type InitOption func(*Options)
+
+// WithNetwork sets the network name used in the WhatsOnChain paths: /woc/v1/bsv/{network}
+func WithNetwork(network defs.BSVNetwork) InitOption {
+	return func(o *Options) {
+		o.Network = network
+	}
+}
+
+// WithLatency sets the delay applied to every ARC and WhatsOnChain request
+func WithLatency(latency time.Duration) InitOption {
+	return func(o *Options) {
+		o.Latency = latency
+	}
+}
+
+// WithExchangeRate sets the BSV/USD rate returned by the WhatsOnChain exchange rate endpoint
+func WithExchangeRate(rate float64) InitOption {
+	return func(o *Options) {
+		o.ExchangeRate = rate
+	}
+}
+
+// WithMiningFee sets the mining fee returned by the ARC policy endpoint
+func WithMiningFee(satoshis, bytes uint64) InitOption {
+	return func(o *Options) {
+		o.MiningFee = MiningFee{Satoshis: satoshis, Bytes: bytes}
+	}
+}
+
+// WithLogger sets the logger for the fake network
+func WithLogger(logger *slog.Logger) InitOption {
+	return func(o *Options) {
+		o.Logger = logger
+	}
+}
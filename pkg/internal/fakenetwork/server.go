@@ -0,0 +1,84 @@
+package fakenetwork
+
+import (
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"time"
+)
+
+const (
+	// ARCPath is the path prefix of the ARC endpoints, use it as the ARC URL: {server URL}/arc
+	ARCPath = "/arc"
+	// WhatsOnChainPath is the path prefix of the WhatsOnChain endpoints, followed by the network name
+	WhatsOnChainPath = "/woc/v1/bsv"
+	// ControlPath is the path prefix of the endpoints controlling the fake network (mining, failures injection)
+	ControlPath = "/control"
+)
+
+// Handler returns the HTTP handler serving the ARC, WhatsOnChain and control endpoints.
+func (n *Network) Handler() http.Handler {
+	mux := http.NewServeMux()
+
+	arc := http.NewServeMux()
+	n.registerARC(arc)
+	mux.Handle(ARCPath+"/", http.StripPrefix(ARCPath, n.withLatency(arc)))
+
+	woc := http.NewServeMux()
+	n.registerWhatsOnChain(woc)
+	wocPrefix := fmt.Sprintf("%s/%s", WhatsOnChainPath, n.options.Network)
+	mux.Handle(wocPrefix+"/", http.StripPrefix(wocPrefix, n.withLatency(woc)))
+
+	control := http.NewServeMux()
+	n.registerControl(control)
+	mux.Handle(ControlPath+"/", http.StripPrefix(ControlPath, control))
+
+	return mux
+}
+
+// ListenAndServe serves the fake network on given address, e.g. ":8101"
+func (n *Network) ListenAndServe(addr string) error {
+	server := &http.Server{
+		Addr:              addr,
+		Handler:           n.Handler(),
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	n.logger.Info("fake network is listening", slog.String("addr", addr), slog.String("network", string(n.options.Network)))
+	err := server.ListenAndServe()
+	if err != nil {
+		return fmt.Errorf("failed to serve fake network: %w", err)
+	}
+	return nil
+}
+
+// ARCURL returns the ARC URL of the fake network served at given server URL.
+func ARCURL(serverURL string) string {
+	return serverURL + ARCPath
+}
+
+// WhatsOnChainURL returns the WhatsOnChain URL (including the network) of the fake network served at given server URL.
+func (n *Network) WhatsOnChainURL(serverURL string) string {
+	return fmt.Sprintf("%s%s/%s", serverURL, WhatsOnChainPath, n.options.Network)
+}
+
+func (n *Network) withLatency(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		latency := n.currentLatency()
+		if latency > 0 {
+			select {
+			case <-time.After(latency):
+			case <-r.Context().Done():
+				return
+			}
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+func writeJSON(w http.ResponseWriter, status int, body any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(body)
+}
@@ -0,0 +1,459 @@
+package fakenetwork_test
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/4chain-ag/go-wallet-toolbox/pkg/internal/fakenetwork"
+	"github.com/bsv-blockchain/go-sdk/transaction"
+	txtestabilities "github.com/bsv-blockchain/universal-test-vectors/pkg/testabilities"
+	"github.com/go-resty/resty/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestARCEndpoints(t *testing.T) {
+	t.Run("broadcasts and queries the mined transaction", func(t *testing.T) {
+		// given:
+		network, client := givenServer(t)
+		spec := txtestabilities.GivenTX().WithInput(1001).WithP2PKHOutput(1000)
+
+		// when:
+		var broadcast map[string]any
+		res, err := client.R().
+			SetBody(map[string]string{"rawTx": spec.BEEF()}).
+			SetResult(&broadcast).
+			Post("/arc/v1/tx")
+
+		// then:
+		require.NoError(t, err)
+		require.Equal(t, http.StatusOK, res.StatusCode())
+		assert.Equal(t, spec.ID(), broadcast["txid"])
+		assert.Equal(t, "SEEN_ON_NETWORK", broadcast["txStatus"])
+
+		// when:
+		block, err := network.MineBlock()
+		require.NoError(t, err)
+
+		// and:
+		var queried map[string]any
+		res, err = client.R().SetResult(&queried).Get("/arc/v1/tx/" + spec.ID())
+
+		// then:
+		require.NoError(t, err)
+		require.Equal(t, http.StatusOK, res.StatusCode())
+		assert.Equal(t, "MINED", queried["txStatus"])
+		assert.Equal(t, block.Hash.String(), queried["blockHash"])
+		assert.NotEmpty(t, queried["merklePath"])
+	})
+
+	t.Run("responds with not found error for unknown transaction", func(t *testing.T) {
+		// given:
+		_, client := givenServer(t)
+
+		// when:
+		var arcErr map[string]any
+		res, err := client.R().SetError(&arcErr).Get("/arc/v1/tx/0f0e0d0c0b0a09080706050403020100f0e0d0c0b0a090807060504030201000")
+
+		// then:
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusNotFound, res.StatusCode())
+		assert.EqualValues(t, http.StatusNotFound, arcErr["status"])
+	})
+
+	t.Run("responds with policy", func(t *testing.T) {
+		// given:
+		network := fakenetwork.New(fakenetwork.WithMiningFee(5, 1000))
+		client := givenClient(t, network)
+
+		// when:
+		var policy struct {
+			Policy struct {
+				MiningFee struct {
+					Satoshis uint64 `json:"satoshis"`
+					Bytes    uint64 `json:"bytes"`
+				} `json:"miningFee"`
+			} `json:"policy"`
+		}
+		res, err := client.R().SetResult(&policy).Get("/arc/v1/policy")
+
+		// then:
+		require.NoError(t, err)
+		require.Equal(t, http.StatusOK, res.StatusCode())
+		assert.Equal(t, uint64(5), policy.Policy.MiningFee.Satoshis)
+		assert.Equal(t, uint64(1000), policy.Policy.MiningFee.Bytes)
+	})
+
+	t.Run("sends callbacks about seen and mined transaction", func(t *testing.T) {
+		// given:
+		network, client := givenServer(t)
+		spec := txtestabilities.GivenTX().WithInput(1001).WithP2PKHOutput(1000)
+
+		// and:
+		receiver := givenCallbackReceiver(t)
+
+		// when:
+		res, err := client.R().
+			SetHeader("X-CallbackUrl", receiver.url).
+			SetHeader("X-CallbackToken", "secret").
+			SetBody(map[string]string{"rawTx": spec.BEEF()}).
+			Post("/arc/v1/tx")
+		require.NoError(t, err)
+		require.Equal(t, http.StatusOK, res.StatusCode())
+
+		// and:
+		block, err := network.MineBlock()
+		require.NoError(t, err)
+
+		// then:
+		callbacks := receiver.received()
+		require.Len(t, callbacks, 2)
+		assert.Equal(t, "Bearer secret", callbacks[0].authorization)
+		assert.Equal(t, "SEEN_ON_NETWORK", callbacks[0].body["txStatus"])
+		assert.Equal(t, "MINED", callbacks[1].body["txStatus"])
+		assert.Equal(t, block.Hash.String(), callbacks[1].body["blockHash"])
+
+		// and:
+		merklePath, err := transaction.NewMerklePathFromHex(callbacks[1].body["merklePath"].(string))
+		require.NoError(t, err)
+		assert.Equal(t, block.Height, merklePath.BlockHeight)
+	})
+}
+
+func TestWhatsOnChainEndpoints(t *testing.T) {
+	t.Run("serves the tsc proof of mined transaction with its block header", func(t *testing.T) {
+		// given:
+		network, client := givenServer(t)
+		spec := txtestabilities.GivenTX().WithInput(1001).WithP2PKHOutput(1000)
+
+		// and:
+		_, _, err := network.Broadcast(spec.BEEF())
+		require.NoError(t, err)
+		block, err := network.MineBlock()
+		require.NoError(t, err)
+
+		// when:
+		var proofs []map[string]any
+		res, err := client.R().SetResult(&proofs).Get("/woc/v1/bsv/test/tx/" + spec.ID() + "/proof/tsc")
+
+		// then:
+		require.NoError(t, err)
+		require.Equal(t, http.StatusOK, res.StatusCode())
+		require.Len(t, proofs, 1)
+		assert.Equal(t, spec.ID(), proofs[0]["txOrId"])
+		assert.Equal(t, block.Hash.String(), proofs[0]["target"])
+
+		// when:
+		var header map[string]any
+		res, err = client.R().SetResult(&header).Get("/woc/v1/bsv/test/block/" + block.Hash.String() + "/header")
+
+		// then:
+		require.NoError(t, err)
+		require.Equal(t, http.StatusOK, res.StatusCode())
+		assert.EqualValues(t, block.Height, header["height"])
+		assert.Equal(t, block.MerkleRoot.String(), header["merkleroot"])
+		assert.Equal(t, "207fffff", header["bits"])
+		assert.Equal(t, block.PrevHash.String(), header["previousblockhash"])
+	})
+
+	t.Run("serves chain info and headers by height", func(t *testing.T) {
+		// given:
+		network, client := givenServer(t)
+		blocks, err := network.MineBlocks(2)
+		require.NoError(t, err)
+
+		// when:
+		var info map[string]any
+		res, err := client.R().SetResult(&info).Get("/woc/v1/bsv/test/chain/info")
+
+		// then:
+		require.NoError(t, err)
+		require.Equal(t, http.StatusOK, res.StatusCode())
+		assert.EqualValues(t, 2, info["blocks"])
+		assert.Equal(t, blocks[1].Hash.String(), info["bestblockhash"])
+
+		// when:
+		var header map[string]any
+		res, err = client.R().SetResult(&header).Get("/woc/v1/bsv/test/block/height/1")
+
+		// then:
+		require.NoError(t, err)
+		require.Equal(t, http.StatusOK, res.StatusCode())
+		assert.Equal(t, blocks[0].Hash.String(), header["hash"])
+
+		// when:
+		res, err = client.R().Get("/woc/v1/bsv/test/block/height/3")
+
+		// then:
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusNotFound, res.StatusCode())
+	})
+
+	t.Run("broadcasts raw transaction and reports mempool conflict", func(t *testing.T) {
+		// given:
+		_, client := givenServer(t)
+		parent := txtestabilities.GivenTX().WithInput(1001).WithP2PKHOutput(1000)
+		spend := txtestabilities.GivenTX().WithInputFromUTXO(parent.TX(), 0).WithP2PKHOutput(999)
+		doubleSpend := txtestabilities.GivenTX().WithInputFromUTXO(parent.TX(), 0).WithP2PKHOutput(998)
+
+		// when:
+		res, err := client.R().SetBody(map[string]string{"txhex": spend.EF()}).Post("/woc/v1/bsv/test/tx/raw")
+
+		// then:
+		require.NoError(t, err)
+		require.Equal(t, http.StatusOK, res.StatusCode())
+		assert.Equal(t, spend.ID(), strings.Trim(res.String(), "\" \n"))
+
+		// when:
+		res, err = client.R().SetBody(map[string]string{"txhex": doubleSpend.EF()}).Post("/woc/v1/bsv/test/tx/raw")
+
+		// then:
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusBadRequest, res.StatusCode())
+		assert.Contains(t, res.String(), "txn-mempool-conflict")
+
+		// when:
+		res, err = client.R().SetBody(map[string]string{"txhex": spend.EF()}).Post("/woc/v1/bsv/test/tx/raw")
+
+		// then:
+		require.NoError(t, err)
+		assert.Contains(t, res.String(), "txn-already-known")
+	})
+
+	t.Run("serves raw transaction and statuses", func(t *testing.T) {
+		// given:
+		network, client := givenServer(t)
+		mined := txtestabilities.GivenTX().WithInput(1001).WithP2PKHOutput(1000)
+		seen := txtestabilities.GivenTX().WithInput(2001).WithP2PKHOutput(2000)
+		unknownTxID := "0f0e0d0c0b0a09080706050403020100f0e0d0c0b0a090807060504030201000"
+
+		// and:
+		_, _, err := network.Broadcast(mined.BEEF())
+		require.NoError(t, err)
+		_, err = network.MineBlocks(2)
+		require.NoError(t, err)
+		_, _, err = network.Broadcast(seen.BEEF())
+		require.NoError(t, err)
+
+		// when:
+		res, err := client.R().Get("/woc/v1/bsv/test/tx/" + mined.ID() + "/hex")
+
+		// then:
+		require.NoError(t, err)
+		require.Equal(t, http.StatusOK, res.StatusCode())
+		assert.Equal(t, mined.RawTX(), res.String())
+
+		// when:
+		var statuses []map[string]any
+		res, err = client.R().
+			SetBody(map[string][]string{"txids": {mined.ID(), seen.ID(), unknownTxID}}).
+			SetResult(&statuses).
+			Post("/woc/v1/bsv/test/txs/status")
+
+		// then:
+		require.NoError(t, err)
+		require.Equal(t, http.StatusOK, res.StatusCode())
+		require.Len(t, statuses, 3)
+		assert.EqualValues(t, 2, statuses[0]["confirmations"])
+		assert.EqualValues(t, 1, statuses[0]["blockheight"])
+		assert.Nil(t, statuses[1]["blockhash"])
+		assert.Nil(t, statuses[1]["error"])
+		assert.Equal(t, "unknown", statuses[2]["error"])
+	})
+
+	t.Run("serves unspent outputs and history of script", func(t *testing.T) {
+		// given:
+		network, client := givenServer(t)
+		parent := txtestabilities.GivenTX().WithInput(1001).WithP2PKHOutput(1000).WithP2PKHOutput(500)
+		child := txtestabilities.GivenTX().WithInputFromUTXO(parent.TX(), 0).WithP2PKHOutput(999)
+		scriptHash := givenScriptHash(parent.TX().Outputs[0])
+
+		// and:
+		_, _, err := network.Broadcast(parent.BEEF())
+		require.NoError(t, err)
+		_, err = network.MineBlock()
+		require.NoError(t, err)
+		_, _, err = network.Broadcast(child.BEEF())
+		require.NoError(t, err)
+
+		// when:
+		var unspent struct {
+			Result []map[string]any `json:"result"`
+		}
+		res, err := client.R().SetResult(&unspent).Get("/woc/v1/bsv/test/script/" + scriptHash + "/unspent/all")
+
+		// then:
+		require.NoError(t, err)
+		require.Equal(t, http.StatusOK, res.StatusCode())
+		require.Len(t, unspent.Result, 2)
+		assert.Equal(t, parent.ID(), unspent.Result[0]["tx_hash"])
+		assert.EqualValues(t, 1, unspent.Result[0]["tx_pos"])
+		assert.EqualValues(t, 1, unspent.Result[0]["height"])
+		assert.Equal(t, child.ID(), unspent.Result[1]["tx_hash"])
+		assert.EqualValues(t, 0, unspent.Result[1]["height"])
+
+		// when:
+		var confirmed, unconfirmed struct {
+			Result []map[string]any `json:"result"`
+		}
+		_, err = client.R().SetResult(&confirmed).Get("/woc/v1/bsv/test/script/" + scriptHash + "/confirmed/history")
+		require.NoError(t, err)
+		_, err = client.R().SetResult(&unconfirmed).Get("/woc/v1/bsv/test/script/" + scriptHash + "/unconfirmed/history")
+		require.NoError(t, err)
+
+		// then:
+		require.Len(t, confirmed.Result, 1)
+		assert.Equal(t, parent.ID(), confirmed.Result[0]["tx_hash"])
+		require.Len(t, unconfirmed.Result, 1)
+		assert.Equal(t, child.ID(), unconfirmed.Result[0]["tx_hash"])
+
+		// when:
+		var bulk []struct {
+			Script string           `json:"script"`
+			Result []map[string]any `json:"result"`
+		}
+		_, err = client.R().
+			SetBody(map[string][]string{"scripts": {scriptHash}}).
+			SetResult(&bulk).
+			Post("/woc/v1/bsv/test/scripts/confirmed/history")
+		require.NoError(t, err)
+
+		// then:
+		require.Len(t, bulk, 1)
+		assert.Equal(t, scriptHash, bulk[0].Script)
+		require.Len(t, bulk[0].Result, 1)
+		assert.Equal(t, parent.ID(), bulk[0].Result[0]["tx_hash"])
+	})
+}
+
+func TestControlEndpoints(t *testing.T) {
+	t.Run("mines requested number of blocks", func(t *testing.T) {
+		// given:
+		network, client := givenServer(t)
+		spec := txtestabilities.GivenTX().WithInput(1001).WithP2PKHOutput(1000)
+
+		// and:
+		_, _, err := network.Broadcast(spec.BEEF())
+		require.NoError(t, err)
+
+		// when:
+		var mined []map[string]any
+		res, err := client.R().SetBody(map[string]int{"blocks": 2}).SetResult(&mined).Post("/control/mine")
+
+		// then:
+		require.NoError(t, err)
+		require.Equal(t, http.StatusOK, res.StatusCode())
+		require.Len(t, mined, 2)
+		assert.Equal(t, []any{spec.ID()}, mined[0]["txids"])
+		assert.Equal(t, uint32(2), network.Tip().Height)
+	})
+
+	t.Run("injects rejection and double spend", func(t *testing.T) {
+		// given:
+		network, client := givenServer(t)
+		rejected := txtestabilities.GivenTX().WithInput(1001).WithP2PKHOutput(1000)
+		doubleSpend := txtestabilities.GivenTX().WithInput(2001).WithP2PKHOutput(2000)
+		competingTxID := "0f0e0d0c0b0a09080706050403020100f0e0d0c0b0a090807060504030201000"
+
+		// when:
+		res, err := client.R().SetBody(map[string]string{"txid": rejected.ID(), "reason": "fee too low"}).Post("/control/reject")
+		require.NoError(t, err)
+		require.Equal(t, http.StatusOK, res.StatusCode())
+
+		// and:
+		res, err = client.R().SetBody(map[string]any{"txid": doubleSpend.ID(), "competingTxs": []string{competingTxID}}).Post("/control/double-spend")
+		require.NoError(t, err)
+		require.Equal(t, http.StatusOK, res.StatusCode())
+
+		// then:
+		info, _, err := network.Broadcast(rejected.BEEF())
+		require.NoError(t, err)
+		assert.Equal(t, fakenetwork.StatusRejected, info.Status)
+
+		// and:
+		info, _, err = network.Broadcast(doubleSpend.BEEF())
+		require.NoError(t, err)
+		assert.Equal(t, fakenetwork.StatusDoubleSpendAttempted, info.Status)
+	})
+
+	t.Run("delays responses by injected latency", func(t *testing.T) {
+		// given:
+		_, client := givenServer(t)
+
+		// when:
+		res, err := client.R().SetBody(map[string]string{"latency": "100ms"}).Post("/control/latency")
+		require.NoError(t, err)
+		require.Equal(t, http.StatusOK, res.StatusCode())
+
+		// and:
+		start := time.Now()
+		res, err = client.R().Get("/woc/v1/bsv/test/chain/info")
+
+		// then:
+		require.NoError(t, err)
+		require.Equal(t, http.StatusOK, res.StatusCode())
+		assert.GreaterOrEqual(t, time.Since(start), 100*time.Millisecond)
+	})
+}
+
+func givenServer(t *testing.T) (*fakenetwork.Network, *resty.Client) {
+	t.Helper()
+	network := fakenetwork.New()
+	return network, givenClient(t, network)
+}
+
+func givenClient(t *testing.T, network *fakenetwork.Network) *resty.Client {
+	t.Helper()
+	server := httptest.NewServer(network.Handler())
+	t.Cleanup(server.Close)
+	return resty.New().SetBaseURL(server.URL)
+}
+
+func givenScriptHash(output *transaction.TransactionOutput) string {
+	hash := sha256.Sum256(output.LockingScript.Bytes())
+	slices.Reverse(hash[:])
+	return hex.EncodeToString(hash[:])
+}
+
+type receivedCallback struct {
+	authorization string
+	body          map[string]any
+}
+
+type callbackReceiver struct {
+	url       string
+	mu        sync.Mutex
+	callbacks []receivedCallback
+}
+
+func givenCallbackReceiver(t *testing.T) *callbackReceiver {
+	t.Helper()
+	receiver := &callbackReceiver{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]any
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		receiver.mu.Lock()
+		defer receiver.mu.Unlock()
+		receiver.callbacks = append(receiver.callbacks, receivedCallback{authorization: r.Header.Get("Authorization"), body: body})
+	}))
+	t.Cleanup(server.Close)
+	receiver.url = server.URL
+	return receiver
+}
+
+func (r *callbackReceiver) received() []receivedCallback {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.callbacks
+}
@@ -0,0 +1,409 @@
+package fakenetwork
+
+import (
+	"cmp"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/bsv-blockchain/go-sdk/transaction"
+)
+
+// WhatsOnChain error messages recognized by the toolbox
+const (
+	wocTxAlreadyKnown    = "257: txn-already-known"
+	wocTxMempoolConflict = "258: txn-mempool-conflict"
+)
+
+type wocExchangeRate struct {
+	Time     int64   `json:"time"`
+	Rate     float64 `json:"rate"`
+	Currency string  `json:"currency"`
+}
+
+type wocTSCProof struct {
+	Index  uint64   `json:"index"`
+	TxOrID string   `json:"txOrId"`
+	Target string   `json:"target"`
+	Nodes  []string `json:"nodes"`
+}
+
+type wocBlockHeader struct {
+	Hash              string `json:"hash"`
+	Height            uint32 `json:"height"`
+	Version           int32  `json:"version"`
+	MerkleRoot        string `json:"merkleroot"`
+	Time              uint32 `json:"time"`
+	Nonce             uint32 `json:"nonce"`
+	Bits              string `json:"bits"`
+	PreviousBlockHash string `json:"previousblockhash,omitempty"`
+}
+
+type wocChainInfo struct {
+	Blocks        uint32 `json:"blocks"`
+	BestBlockHash string `json:"bestblockhash"`
+}
+
+type wocPostRawTxRequest struct {
+	TxHex string `json:"txhex"`
+}
+
+type wocScriptUnspent struct {
+	Height uint32 `json:"height"`
+	TxPos  uint32 `json:"tx_pos"`
+	TxHash string `json:"tx_hash"`
+	Value  uint64 `json:"value"`
+}
+
+type wocScriptUnspentResponse struct {
+	Script string             `json:"script"`
+	Result []wocScriptUnspent `json:"result"`
+	Error  string             `json:"error"`
+}
+
+type wocScriptHistoryItem struct {
+	TxHash string `json:"tx_hash"`
+	Height uint32 `json:"height"`
+}
+
+type wocScriptHistoryResponse struct {
+	Script        string                 `json:"script"`
+	Result        []wocScriptHistoryItem `json:"result"`
+	Error         string                 `json:"error"`
+	NextPageToken string                 `json:"nextPageToken,omitempty"`
+}
+
+type wocScriptsHistoryRequest struct {
+	Scripts []string `json:"scripts"`
+}
+
+type wocTxsStatusRequest struct {
+	TxIDs []string `json:"txids"`
+}
+
+type wocTxStatus struct {
+	TxID          string `json:"txid"`
+	BlockHash     string `json:"blockhash,omitempty"`
+	BlockHeight   uint32 `json:"blockheight,omitempty"`
+	Confirmations uint32 `json:"confirmations,omitempty"`
+	Error         string `json:"error,omitempty"`
+}
+
+func (n *Network) registerWhatsOnChain(mux *http.ServeMux) {
+	mux.HandleFunc("GET /exchangerate", n.wocExchangeRate)
+	mux.HandleFunc("GET /chain/info", n.wocChainInfo)
+	// the patterns /block/{hash}/header and /block/height/{height} conflict, so they are dispatched by wocBlock
+	mux.HandleFunc("GET /block/{first}/{second}", n.wocBlock)
+	mux.HandleFunc("GET /tx/{txID}/hex", n.wocRawTx)
+	mux.HandleFunc("GET /tx/{txID}/proof/tsc", n.wocTSCProof)
+	mux.HandleFunc("POST /tx/raw", n.wocPostRawTx)
+	mux.HandleFunc("POST /txs/status", n.wocTxsStatus)
+	mux.HandleFunc("GET /script/{scriptHash}/unspent/all", n.wocScriptUnspent)
+	mux.HandleFunc("GET /script/{scriptHash}/confirmed/history", n.wocScriptHistory(true))
+	mux.HandleFunc("GET /script/{scriptHash}/unconfirmed/history", n.wocScriptHistory(false))
+	mux.HandleFunc("POST /scripts/confirmed/history", n.wocScriptsHistory(true))
+	mux.HandleFunc("POST /scripts/unconfirmed/history", n.wocScriptsHistory(false))
+}
+
+func (n *Network) wocExchangeRate(w http.ResponseWriter, _ *http.Request) {
+	writeJSON(w, http.StatusOK, wocExchangeRate{
+		Time:     time.Now().Unix(),
+		Rate:     n.options.ExchangeRate,
+		Currency: "USD",
+	})
+}
+
+func (n *Network) wocChainInfo(w http.ResponseWriter, _ *http.Request) {
+	tip := n.Tip()
+	writeJSON(w, http.StatusOK, wocChainInfo{
+		Blocks:        tip.Height,
+		BestBlockHash: tip.Hash.String(),
+	})
+}
+
+func (n *Network) wocBlock(w http.ResponseWriter, r *http.Request) {
+	switch {
+	case r.PathValue("first") == "height":
+		n.wocBlockHeaderByHeight(w, r.PathValue("second"))
+	case r.PathValue("second") == "header":
+		n.wocBlockHeader(w, r.PathValue("first"))
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+func (n *Network) wocBlockHeader(w http.ResponseWriter, hash string) {
+	n.mu.RLock()
+	block, ok := n.blocksByHash[hash]
+	n.mu.RUnlock()
+
+	if !ok {
+		http.Error(w, "Not Found", http.StatusNotFound)
+		return
+	}
+	writeJSON(w, http.StatusOK, toWocBlockHeader(block))
+}
+
+func (n *Network) wocBlockHeaderByHeight(w http.ResponseWriter, heightParam string) {
+	height, err := strconv.ParseUint(heightParam, 10, 32)
+	if err != nil {
+		http.Error(w, "invalid height", http.StatusBadRequest)
+		return
+	}
+
+	block := n.BlockAt(uint32(height))
+	if block == nil {
+		http.Error(w, "Not Found", http.StatusNotFound)
+		return
+	}
+	writeJSON(w, http.StatusOK, toWocBlockHeader(block))
+}
+
+func (n *Network) wocRawTx(w http.ResponseWriter, r *http.Request) {
+	n.mu.RLock()
+	record, ok := n.txs[r.PathValue("txID")]
+	n.mu.RUnlock()
+
+	if !ok || !isAccepted(record.info.Status) {
+		http.Error(w, "Not Found", http.StatusNotFound)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/plain")
+	_, _ = w.Write([]byte(record.tx.Hex()))
+}
+
+func (n *Network) wocTSCProof(w http.ResponseWriter, r *http.Request) {
+	txID := r.PathValue("txID")
+
+	n.mu.RLock()
+	var block *Block
+	if record, ok := n.txs[txID]; ok {
+		block = n.blocksByHash[record.info.BlockHash]
+	}
+	n.mu.RUnlock()
+
+	if block == nil {
+		http.Error(w, "Not Found", http.StatusNotFound)
+		return
+	}
+
+	index, nodes, err := block.TSCProof(txID)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, []wocTSCProof{{
+		Index:  index,
+		TxOrID: txID,
+		Target: block.Hash.String(),
+		Nodes:  nodes,
+	}})
+}
+
+func (n *Network) wocPostRawTx(w http.ResponseWriter, r *http.Request) {
+	var body wocPostRawTxRequest
+	err := json.NewDecoder(r.Body).Decode(&body)
+	if err != nil {
+		http.Error(w, "invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	info, alreadyKnown, err := n.broadcast(body.TxHex, nil)
+	switch {
+	case err != nil:
+		http.Error(w, fmt.Sprintf("16: bad-txns: %s", err), http.StatusBadRequest)
+	case alreadyKnown:
+		http.Error(w, wocTxAlreadyKnown, http.StatusBadRequest)
+	case info.Status == StatusDoubleSpendAttempted:
+		http.Error(w, wocTxMempoolConflict, http.StatusBadRequest)
+	case info.Status == StatusRejected:
+		http.Error(w, info.ExtraInfo, http.StatusBadRequest)
+	default:
+		writeJSON(w, http.StatusOK, info.TxID)
+	}
+}
+
+func (n *Network) wocTxsStatus(w http.ResponseWriter, r *http.Request) {
+	var body wocTxsStatusRequest
+	err := json.NewDecoder(r.Body).Decode(&body)
+	if err != nil {
+		http.Error(w, "invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	n.mu.RLock()
+	defer n.mu.RUnlock()
+
+	tipHeight := n.tip().Height
+	statuses := make([]wocTxStatus, 0, len(body.TxIDs))
+	for _, txID := range body.TxIDs {
+		status := wocTxStatus{TxID: txID}
+		record, ok := n.txs[txID]
+		switch {
+		case !ok || !isAccepted(record.info.Status):
+			status.Error = "unknown"
+		case record.info.BlockHash != "":
+			status.BlockHash = record.info.BlockHash
+			status.BlockHeight = record.info.BlockHeight
+			status.Confirmations = tipHeight - record.info.BlockHeight + 1
+		}
+		statuses = append(statuses, status)
+	}
+
+	writeJSON(w, http.StatusOK, statuses)
+}
+
+func (n *Network) wocScriptUnspent(w http.ResponseWriter, r *http.Request) {
+	scriptHash := r.PathValue("scriptHash")
+
+	n.mu.RLock()
+	unspent := make([]wocScriptUnspent, 0)
+	for txID, record := range n.txs {
+		if !isAccepted(record.info.Status) {
+			continue
+		}
+		for vout, output := range record.tx.Outputs {
+			index := uint32(vout) //nolint:gosec // number of outputs fits into uint32
+			if scriptHashOf(output) != scriptHash {
+				continue
+			}
+			if _, spent := n.spentBy[outpoint(txID, index)]; spent {
+				continue
+			}
+			unspent = append(unspent, wocScriptUnspent{
+				Height: record.info.BlockHeight,
+				TxPos:  index,
+				TxHash: txID,
+				Value:  output.Satoshis,
+			})
+		}
+	}
+	n.mu.RUnlock()
+
+	slices.SortFunc(unspent, func(a, b wocScriptUnspent) int {
+		return cmp.Or(compareHeights(a.Height, b.Height), cmp.Compare(a.TxHash, b.TxHash), cmp.Compare(a.TxPos, b.TxPos))
+	})
+
+	writeJSON(w, http.StatusOK, wocScriptUnspentResponse{
+		Script: scriptHash,
+		Result: unspent,
+	})
+}
+
+// wocScriptHistory lists the transactions with outputs locked by the script or spending such outputs.
+func (n *Network) wocScriptHistory(confirmed bool) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		scriptHash := r.PathValue("scriptHash")
+
+		writeJSON(w, http.StatusOK, wocScriptHistoryResponse{
+			Script: scriptHash,
+			Result: n.scriptHistory(scriptHash, confirmed),
+		})
+	}
+}
+
+// wocScriptsHistory is the bulk version of wocScriptHistory.
+func (n *Network) wocScriptsHistory(confirmed bool) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var body wocScriptsHistoryRequest
+		err := json.NewDecoder(r.Body).Decode(&body)
+		if err != nil {
+			http.Error(w, "invalid request body", http.StatusBadRequest)
+			return
+		}
+
+		histories := make([]wocScriptHistoryResponse, 0, len(body.Scripts))
+		for _, scriptHash := range body.Scripts {
+			histories = append(histories, wocScriptHistoryResponse{
+				Script: scriptHash,
+				Result: n.scriptHistory(scriptHash, confirmed),
+			})
+		}
+		writeJSON(w, http.StatusOK, histories)
+	}
+}
+
+func (n *Network) scriptHistory(scriptHash string, confirmed bool) []wocScriptHistoryItem {
+	n.mu.RLock()
+	history := make([]wocScriptHistoryItem, 0)
+	for txID, record := range n.txs {
+		if !isAccepted(record.info.Status) || (record.info.MerklePath != nil) != confirmed {
+			continue
+		}
+		if n.touchesScript(record.tx, scriptHash) {
+			history = append(history, wocScriptHistoryItem{TxHash: txID, Height: record.info.BlockHeight})
+		}
+	}
+	n.mu.RUnlock()
+
+	slices.SortFunc(history, func(a, b wocScriptHistoryItem) int {
+		return cmp.Or(compareHeights(a.Height, b.Height), cmp.Compare(a.TxHash, b.TxHash))
+	})
+	return history
+}
+
+func (n *Network) touchesScript(tx *transaction.Transaction, scriptHash string) bool {
+	for _, output := range tx.Outputs {
+		if scriptHashOf(output) == scriptHash {
+			return true
+		}
+	}
+	for _, input := range tx.Inputs {
+		source, ok := n.txs[input.SourceTXID.String()]
+		if !ok || int(input.SourceTxOutIndex) >= len(source.tx.Outputs) {
+			continue
+		}
+		if scriptHashOf(source.tx.Outputs[input.SourceTxOutIndex]) == scriptHash {
+			return true
+		}
+	}
+	return false
+}
+
+// compareHeights orders the mined transactions by height, followed by the unconfirmed ones (zero height)
+func compareHeights(a, b uint32) int {
+	switch {
+	case a == b:
+		return 0
+	case a == 0:
+		return 1
+	case b == 0:
+		return -1
+	default:
+		return cmp.Compare(a, b)
+	}
+}
+
+func toWocBlockHeader(block *Block) wocBlockHeader {
+	header := wocBlockHeader{
+		Hash:       block.Hash.String(),
+		Height:     block.Height,
+		Version:    block.Version,
+		MerkleRoot: block.MerkleRoot.String(),
+		Time:       block.Time,
+		Nonce:      block.Nonce,
+		Bits:       fmt.Sprintf("%08x", block.Bits),
+	}
+	// the genesis block has no previous block
+	if block.Height > 0 {
+		header.PreviousBlockHash = block.PrevHash.String()
+	}
+	return header
+}
+
+// scriptHashOf returns the script hash of the output as used by WhatsOnChain: sha256 of the locking script in big endian hex
+func scriptHashOf(output *transaction.TransactionOutput) string {
+	if output.LockingScript == nil {
+		return ""
+	}
+	hash := sha256.Sum256(*output.LockingScript)
+	slices.Reverse(hash[:])
+	return hex.EncodeToString(hash[:])
+}
@@ -0,0 +1,20 @@
+package fixtures
+
+import (
+	"github.com/4chain-ag/go-wallet-toolbox/pkg/wdk"
+	"github.com/go-softwarelab/common/pkg/to"
+)
+
+func DefaultValidUpdateBasketConfigurationArgs() *wdk.UpdateBasketConfigurationArgs {
+	return &wdk.UpdateBasketConfigurationArgs{
+		Name:                    CustomBasket,
+		NumberOfDesiredUTXOs:    to.Ptr(int64(10)),
+		MinimumDesiredUTXOValue: to.Ptr(uint64(5000)),
+	}
+}
+
+func DefaultValidRemoveBasketArgs() *wdk.RemoveBasketArgs {
+	return &wdk.RemoveBasketArgs{
+		Name: CustomBasket,
+	}
+}
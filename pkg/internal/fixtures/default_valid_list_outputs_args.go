@@ -0,0 +1,28 @@
+package fixtures
+
+import (
+	"github.com/4chain-ag/go-wallet-toolbox/pkg/wdk"
+	"github.com/4chain-ag/go-wallet-toolbox/pkg/wdk/primitives"
+)
+
+const (
+	OutputTag      = "output-tag"
+	OutputOutpoint = "03895fb984362a4196bc9931629318fcbb2aeba7c6293638119ea653fa31d119.0" // outpoint of the output internalized with DefaultInternalizeActionArgs
+)
+
+func DefaultValidListOutputsArgs() *wdk.ListOutputsArgs {
+	return &wdk.ListOutputsArgs{
+		Basket:       CustomBasket,
+		Tags:         []primitives.StringUnder300{OutputTag},
+		TagQueryMode: wdk.QueryModeAny,
+		Limit:        primitives.PositiveIntegerDefault10Max10000(10),
+		Offset:       primitives.PositiveInteger(0),
+	}
+}
+
+func DefaultValidOutputTagsArgs() *wdk.OutputTagsArgs {
+	return &wdk.OutputTagsArgs{
+		Output: OutputOutpoint,
+		Tags:   []primitives.StringUnder300{OutputTag},
+	}
+}
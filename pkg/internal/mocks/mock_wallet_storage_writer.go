@@ -41,6 +41,20 @@ func (m *MockWalletStorageWriter) EXPECT() *MockWalletStorageWriterMockRecorder
 	return m.recorder
 }
 
+// AddOutputTags mocks base method.
+func (m *MockWalletStorageWriter) AddOutputTags(ctx context.Context, auth wdk.AuthID, args wdk.OutputTagsArgs) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddOutputTags", ctx, auth, args)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddOutputTags indicates an expected call of AddOutputTags.
+func (mr *MockWalletStorageWriterMockRecorder) AddOutputTags(ctx, auth, args any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddOutputTags", reflect.TypeOf((*MockWalletStorageWriter)(nil).AddOutputTags), ctx, auth, args)
+}
+
 // CreateAction mocks base method.
 func (m *MockWalletStorageWriter) CreateAction(ctx context.Context, auth wdk.AuthID, args wdk.ValidCreateActionArgs) (*wdk.StorageCreateActionResult, error) {
 	m.ctrl.T.Helper()
@@ -56,6 +70,21 @@ func (mr *MockWalletStorageWriterMockRecorder) CreateAction(ctx, auth, args any)
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateAction", reflect.TypeOf((*MockWalletStorageWriter)(nil).CreateAction), ctx, auth, args)
 }
 
+// EstimateCreateAction mocks base method.
+func (m *MockWalletStorageWriter) EstimateCreateAction(ctx context.Context, auth wdk.AuthID, args wdk.ValidCreateActionArgs) (*wdk.StorageEstimateCreateActionResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EstimateCreateAction", ctx, auth, args)
+	ret0, _ := ret[0].(*wdk.StorageEstimateCreateActionResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// EstimateCreateAction indicates an expected call of EstimateCreateAction.
+func (mr *MockWalletStorageWriterMockRecorder) EstimateCreateAction(ctx, auth, args any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EstimateCreateAction", reflect.TypeOf((*MockWalletStorageWriter)(nil).EstimateCreateAction), ctx, auth, args)
+}
+
 // FindOrInsertUser mocks base method.
 func (m *MockWalletStorageWriter) FindOrInsertUser(ctx context.Context, identityKey string) (*wdk.FindOrInsertUserResponse, error) {
 	m.ctrl.T.Helper()
@@ -86,6 +115,21 @@ func (mr *MockWalletStorageWriterMockRecorder) InsertCertificateAuth(ctx, auth,
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertCertificateAuth", reflect.TypeOf((*MockWalletStorageWriter)(nil).InsertCertificateAuth), ctx, auth, certificate)
 }
 
+// ListBaskets mocks base method.
+func (m *MockWalletStorageWriter) ListBaskets(ctx context.Context, auth wdk.AuthID) (*wdk.ListBasketsResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListBaskets", ctx, auth)
+	ret0, _ := ret[0].(*wdk.ListBasketsResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListBaskets indicates an expected call of ListBaskets.
+func (mr *MockWalletStorageWriterMockRecorder) ListBaskets(ctx, auth any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListBaskets", reflect.TypeOf((*MockWalletStorageWriter)(nil).ListBaskets), ctx, auth)
+}
+
 // ListCertificates mocks base method.
 func (m *MockWalletStorageWriter) ListCertificates(ctx context.Context, auth wdk.AuthID, args wdk.ListCertificatesArgs) (*wdk.ListCertificatesResult, error) {
 	m.ctrl.T.Helper()
@@ -101,6 +145,21 @@ func (mr *MockWalletStorageWriterMockRecorder) ListCertificates(ctx, auth, args
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListCertificates", reflect.TypeOf((*MockWalletStorageWriter)(nil).ListCertificates), ctx, auth, args)
 }
 
+// ListOutputs mocks base method.
+func (m *MockWalletStorageWriter) ListOutputs(ctx context.Context, auth wdk.AuthID, args wdk.ListOutputsArgs) (*wdk.ListOutputsResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListOutputs", ctx, auth, args)
+	ret0, _ := ret[0].(*wdk.ListOutputsResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListOutputs indicates an expected call of ListOutputs.
+func (mr *MockWalletStorageWriterMockRecorder) ListOutputs(ctx, auth, args any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListOutputs", reflect.TypeOf((*MockWalletStorageWriter)(nil).ListOutputs), ctx, auth, args)
+}
+
 // MakeAvailable mocks base method.
 func (m *MockWalletStorageWriter) MakeAvailable(ctx context.Context) (*wdk.TableSettings, error) {
 	m.ctrl.T.Helper()
@@ -144,3 +203,60 @@ func (mr *MockWalletStorageWriterMockRecorder) RelinquishCertificate(ctx, auth,
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RelinquishCertificate", reflect.TypeOf((*MockWalletStorageWriter)(nil).RelinquishCertificate), ctx, auth, args)
 }
+
+// RemoveBasket mocks base method.
+func (m *MockWalletStorageWriter) RemoveBasket(ctx context.Context, auth wdk.AuthID, args wdk.RemoveBasketArgs) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveBasket", ctx, auth, args)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemoveBasket indicates an expected call of RemoveBasket.
+func (mr *MockWalletStorageWriterMockRecorder) RemoveBasket(ctx, auth, args any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveBasket", reflect.TypeOf((*MockWalletStorageWriter)(nil).RemoveBasket), ctx, auth, args)
+}
+
+// RemoveOutputTags mocks base method.
+func (m *MockWalletStorageWriter) RemoveOutputTags(ctx context.Context, auth wdk.AuthID, args wdk.OutputTagsArgs) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveOutputTags", ctx, auth, args)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemoveOutputTags indicates an expected call of RemoveOutputTags.
+func (mr *MockWalletStorageWriterMockRecorder) RemoveOutputTags(ctx, auth, args any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveOutputTags", reflect.TypeOf((*MockWalletStorageWriter)(nil).RemoveOutputTags), ctx, auth, args)
+}
+
+// UpdateBasketConfiguration mocks base method.
+func (m *MockWalletStorageWriter) UpdateBasketConfiguration(ctx context.Context, auth wdk.AuthID, args wdk.UpdateBasketConfigurationArgs) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateBasketConfiguration", ctx, auth, args)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateBasketConfiguration indicates an expected call of UpdateBasketConfiguration.
+func (mr *MockWalletStorageWriterMockRecorder) UpdateBasketConfiguration(ctx, auth, args any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateBasketConfiguration", reflect.TypeOf((*MockWalletStorageWriter)(nil).UpdateBasketConfiguration), ctx, auth, args)
+}
+
+// WalletStats mocks base method.
+func (m *MockWalletStorageWriter) WalletStats(ctx context.Context, auth wdk.AuthID) (*wdk.WalletStatsResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WalletStats", ctx, auth)
+	ret0, _ := ret[0].(*wdk.WalletStatsResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// WalletStats indicates an expected call of WalletStats.
+func (mr *MockWalletStorageWriterMockRecorder) WalletStats(ctx, auth any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WalletStats", reflect.TypeOf((*MockWalletStorageWriter)(nil).WalletStats), ctx, auth)
+}
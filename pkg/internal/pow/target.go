@@ -0,0 +1,24 @@
+package pow
+
+import (
+	"fmt"
+	"math/big"
+)
+
+// TargetFromBits decodes the compact representation (bits) of the proof of work target.
+func TargetFromBits(bits uint32) (*big.Int, error) {
+	const signBit = 0x00800000
+
+	mantissa := bits & 0x007fffff
+	exponent := uint(bits >> 24)
+
+	if bits&signBit != 0 || mantissa == 0 {
+		return nil, fmt.Errorf("invalid bits %08x of block header", bits)
+	}
+
+	target := big.NewInt(int64(mantissa))
+	if exponent <= 3 {
+		return target.Rsh(target, 8*(3-exponent)), nil
+	}
+	return target.Lsh(target, 8*(exponent-3)), nil
+}
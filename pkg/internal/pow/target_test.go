@@ -0,0 +1,49 @@
+package pow_test
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/4chain-ag/go-wallet-toolbox/pkg/internal/pow"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTargetFromBits(t *testing.T) {
+	tests := map[string]struct {
+		bits     uint32
+		expected *big.Int
+	}{
+		"mainnet pow limit": {
+			bits:     0x1d00ffff,
+			expected: new(big.Int).Lsh(big.NewInt(0xffff), 8*(0x1d-3)),
+		},
+		"regtest pow limit": {
+			bits:     0x207fffff,
+			expected: new(big.Int).Lsh(big.NewInt(0x7fffff), 8*(0x20-3)),
+		},
+		"small exponent": {
+			bits:     0x01123456,
+			expected: big.NewInt(0x12),
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			// when:
+			target, err := pow.TargetFromBits(test.bits)
+
+			// then:
+			require.NoError(t, err)
+			require.Equal(t, 0, test.expected.Cmp(target))
+		})
+	}
+}
+
+func TestTargetFromInvalidBits(t *testing.T) {
+	for _, bits := range []uint32{0x1d800000, 0x1d000000} {
+		// when:
+		_, err := pow.TargetFromBits(bits)
+
+		// then:
+		require.Error(t, err)
+	}
+}
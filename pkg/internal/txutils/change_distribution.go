@@ -13,6 +13,7 @@ type Randomizer func(max uint64) uint64
 type ChangeDistribution struct {
 	initialValue satoshi.Value
 	randomizer   Randomizer
+	dustLimit    satoshi.Value
 }
 
 func NewChangeDistribution(initialValue satoshi.Value, randomizer Randomizer) *ChangeDistribution {
@@ -22,12 +23,20 @@ func NewChangeDistribution(initialValue satoshi.Value, randomizer Randomizer) *C
 	}
 }
 
-func (d *ChangeDistribution) Distribute(count uint64, amount satoshi.Value) iter.Seq[satoshi.Value] {
+// WithDustLimit sets the minimum value of each distributed output.
+func (d *ChangeDistribution) WithDustLimit(dustLimit satoshi.Value) *ChangeDistribution {
+	d.dustLimit = dustLimit
+	return d
+}
+
+// Distribute splits the amount among the count of change outputs,
+// it returns an error when the amount is too small to be distributed among the given count of outputs.
+func (d *ChangeDistribution) Distribute(count uint64, amount satoshi.Value) (iter.Seq[satoshi.Value], error) {
 	if count == 0 || amount == 0 {
-		return seq.Of[satoshi.Value]()
+		return seq.Of[satoshi.Value](), nil
 	}
 	if count == 1 {
-		return seq.Of(amount)
+		return seq.Of(amount), nil
 	}
 
 	// saturation: a moment when all the outputs are equal to initialValue
@@ -35,9 +44,9 @@ func (d *ChangeDistribution) Distribute(count uint64, amount satoshi.Value) iter
 
 	switch {
 	case amount == saturationThreshold:
-		return seq.Repeat(d.initialValue, count)
+		return seq.Repeat(d.initialValue, count), nil
 	case amount > saturationThreshold:
-		return d.saturatedRandomDistribution(count, amount)
+		return d.saturatedRandomDistribution(count, amount), nil
 	default:
 		return d.notSaturatedDistribution(count, amount)
 	}
@@ -91,18 +100,40 @@ func (d *ChangeDistribution) saturatedRandomDistribution(count uint64, amount sa
 // 4. number of outputs = count
 // e.g. For 3 outputs and 8 amount, we have:
 // [2, 3, 3]
-// WARNING: panics when amount is less than (1 + (count-1) * initialValue)
-func (d *ChangeDistribution) notSaturatedDistribution(count uint64, amount satoshi.Value) iter.Seq[satoshi.Value] {
+// Returns an error when amount is less than (1 + (count-1) * initialValue)
+func (d *ChangeDistribution) notSaturatedDistribution(count uint64, amount satoshi.Value) (iter.Seq[satoshi.Value], error) {
 	saturatedOutputs := count - 1
 	valueOfSatOuts := satoshi.MustMultiply(saturatedOutputs, d.initialValue)
+	if amount > valueOfSatOuts && amount-valueOfSatOuts < d.dustLimit {
+		return d.evenDistribution(count, amount)
+	}
 	if amount > valueOfSatOuts {
 		return seq.Concat(
 			seq.Of[satoshi.Value](amount-valueOfSatOuts),
 			seq.Repeat(satoshi.MustFrom(d.initialValue), saturatedOutputs),
-		)
+		), nil
+	}
+
+	return nil, fmt.Errorf("cannot distribute change outputs among given outputs (count: %d) for given amount (%d)", count, amount)
+}
+
+// evenDistribution - generate NOT-randomized outputs of (almost) equal values,
+// used when the first output of notSaturatedDistribution would be below the dust limit.
+// e.g. For 3 outputs and 8 amount, we have:
+// [4, 2, 2]
+// Returns an error when any of the outputs would be below the dust limit
+func (d *ChangeDistribution) evenDistribution(count uint64, amount satoshi.Value) (iter.Seq[satoshi.Value], error) {
+	amountUint64 := amount.MustUInt64()
+	base := satoshi.MustFrom(amountUint64 / count)
+	remainder := satoshi.MustFrom(amountUint64 % count)
+	if base < d.dustLimit {
+		return nil, fmt.Errorf("cannot distribute change outputs (count: %d) for given amount (%d) without creating dust", count, amount)
 	}
 
-	panic(fmt.Sprintf("Cannot distribute change outputs among given outputs (count: %d) for given amount (%d)", count, amount))
+	return seq.Concat(
+		seq.Of(base+remainder),
+		seq.Repeat(base, count-1),
+	), nil
 }
 
 // randomNoise randomizes values for each output in the distribution;
@@ -150,15 +150,16 @@ func TestChangeDistribution(t *testing.T) {
 			dist := NewChangeDistribution(test.initialValue, test.randomizer)
 
 			// when:
-			values := dist.Distribute(test.count, test.amount)
+			values, err := dist.Distribute(test.count, test.amount)
 
 			// then:
+			require.NoError(t, err)
 			require.EqualValues(t, test.expected, seq.Collect(values))
 		})
 	}
 }
 
-func TestChangeDistributionPanics(t *testing.T) {
+func TestChangeDistributionErrors(t *testing.T) {
 	tests := map[string]struct {
 		initialValue satoshi.Value
 		randomizer   func(uint64) uint64
@@ -189,17 +190,78 @@ func TestChangeDistributionPanics(t *testing.T) {
 			// given:
 			dist := NewChangeDistribution(test.initialValue, test.randomizer)
 
-			f := func() {
-				// when:
-				dist.Distribute(test.count, test.amount)
-			}
+			// when:
+			_, err := dist.Distribute(test.count, test.amount)
 
 			// then:
-			require.Panics(t, f)
+			require.Error(t, err)
 		})
 	}
 }
 
+func TestChangeDistributionWithDustLimit(t *testing.T) {
+	tests := map[string]struct {
+		initialValue satoshi.Value
+		dustLimit    satoshi.Value
+		count        uint64
+		amount       satoshi.Value
+		expected     []satoshi.Value
+	}{
+		"not saturated, first output above dust limit": {
+			initialValue: 1000,
+			dustLimit:    100,
+			count:        3,
+			amount:       2500,
+			expected:     []satoshi.Value{500, 1000, 1000},
+		},
+		"not saturated, first output equal to dust limit": {
+			initialValue: 1000,
+			dustLimit:    100,
+			count:        3,
+			amount:       2100,
+			expected:     []satoshi.Value{100, 1000, 1000},
+		},
+		"not saturated, first output below dust limit": {
+			initialValue: 1000,
+			dustLimit:    100,
+			count:        3,
+			amount:       2050,
+			expected:     []satoshi.Value{684, 683, 683},
+		},
+		"saturated is not affected by dust limit": {
+			initialValue: 1000,
+			dustLimit:    100,
+			count:        2,
+			amount:       3000,
+			expected:     []satoshi.Value{1500, 1500},
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			// given:
+			dist := NewChangeDistribution(test.initialValue, mockZeroRandomizer).WithDustLimit(test.dustLimit)
+
+			// when:
+			values, err := dist.Distribute(test.count, test.amount)
+
+			// then:
+			require.NoError(t, err)
+			require.EqualValues(t, test.expected, seq.Collect(values))
+		})
+	}
+}
+
+func TestChangeDistributionWithDustLimitErrors(t *testing.T) {
+	// given:
+	dist := NewChangeDistribution(1000, mockZeroRandomizer).WithDustLimit(1000)
+
+	// when:
+	_, err := dist.Distribute(3, 2500)
+
+	// then:
+	require.Error(t, err)
+}
+
 func TestChangeDistributionWithActualRandomizer(t *testing.T) {
 	// given:
 	initialValue := satoshi.Value(1000)
@@ -212,9 +274,10 @@ func TestChangeDistributionWithActualRandomizer(t *testing.T) {
 	dist := NewChangeDistribution(initialValue, random.Uint64)
 
 	// when:
-	values := dist.Distribute(count, satoshi.MustMultiply(2*count, initialValue))
+	values, err := dist.Distribute(count, satoshi.MustMultiply(2*count, initialValue))
 
 	// then:
+	require.NoError(t, err)
 	var i uint64
 	var equalsToInitial uint64
 	for v := range values {
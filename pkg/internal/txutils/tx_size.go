@@ -33,8 +33,20 @@ func TransactionOutputSize(scriptSize uint64) uint64 {
 // inputs is a sequence of input script sizes (and possibly error)
 // outputs is a sequence of output script sizes (and possibly error)
 func TransactionSize(inputSizes iter.Seq2[uint64, error], outputSizes iter.Seq2[uint64, error]) (uint64, error) {
+	return TransactionSizeWithEstimatedInputs(inputSizes, outputSizes, nil)
+}
+
+// TransactionSizeWithEstimatedInputs calculates the total size of a transaction in bytes like TransactionSize
+// estimatedInputSizes is a sequence of whole input sizes (e.g. the estimated input sizes of UTXOs) added to the inputs
+func TransactionSizeWithEstimatedInputs(inputSizes iter.Seq2[uint64, error], outputSizes iter.Seq2[uint64, error], estimatedInputSizes iter.Seq[uint64]) (uint64, error) {
 	var inputsCount uint64
 	var inputsSize uint64
+	if estimatedInputSizes != nil {
+		for inputSize := range estimatedInputSizes {
+			inputsCount++
+			inputsSize += inputSize
+		}
+	}
 	for scriptSize, err := range inputSizes {
 		if err != nil {
 			return 0, fmt.Errorf("failed to calculate unlocking script size: %w", err)
@@ -115,3 +115,23 @@ func TestTransactionSizeWithErrorOnOutputs(t *testing.T) {
 	// then:
 	require.Error(t, err)
 }
+
+func TestTransactionSizeWithEstimatedInputs(t *testing.T) {
+	// given:
+	inputSizes := seq.Of[uint64](100)
+	estimatedInputSizes := seq.Repeat[uint64](150, 252)
+	outputSizes := seq.Of[uint64](300)
+
+	// when:
+	size, err := TransactionSizeWithEstimatedInputs(seqerr.FromSeq(inputSizes), seqerr.FromSeq(outputSizes), estimatedInputSizes)
+
+	// then:
+	require.NoError(t, err)
+	require.Equal(t, uint64(8+ //tx envelope size
+		3+ // varint size of inputs count (253 inputs)
+		141+ // 40+100+1 // input [0] size
+		252*150+ // estimated inputs size
+		1+ // varint size of outputs count
+		311, // 8+300+3// output [0] size
+	), size)
+}
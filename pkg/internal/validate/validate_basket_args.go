@@ -0,0 +1,37 @@
+package validate
+
+import (
+	"fmt"
+
+	"github.com/4chain-ag/go-wallet-toolbox/pkg/wdk"
+)
+
+func UpdateBasketConfigurationArgs(args *wdk.UpdateBasketConfigurationArgs) error {
+	err := args.Name.Validate()
+	if err != nil {
+		return fmt.Errorf("invalid name argument: %w", err)
+	}
+
+	if args.NumberOfDesiredUTXOs == nil && args.MinimumDesiredUTXOValue == nil {
+		return fmt.Errorf("at least one of numberOfDesiredUTXOs or minimumDesiredUTXOValue must be provided")
+	}
+
+	if args.NumberOfDesiredUTXOs != nil && *args.NumberOfDesiredUTXOs < 0 {
+		return fmt.Errorf("invalid numberOfDesiredUTXOs argument: must be greater than or equal to 0")
+	}
+
+	return nil
+}
+
+func RemoveBasketArgs(args *wdk.RemoveBasketArgs) error {
+	err := args.Name.Validate()
+	if err != nil {
+		return fmt.Errorf("invalid name argument: %w", err)
+	}
+
+	if args.Name == wdk.BasketNameForChange {
+		return fmt.Errorf("basket for change (%s) cannot be removed", wdk.BasketNameForChange)
+	}
+
+	return nil
+}
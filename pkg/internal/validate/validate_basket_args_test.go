@@ -0,0 +1,104 @@
+package validate_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/4chain-ag/go-wallet-toolbox/pkg/internal/fixtures"
+	"github.com/4chain-ag/go-wallet-toolbox/pkg/internal/validate"
+	"github.com/4chain-ag/go-wallet-toolbox/pkg/wdk"
+	"github.com/4chain-ag/go-wallet-toolbox/pkg/wdk/primitives"
+	"github.com/go-softwarelab/common/pkg/to"
+	"github.com/stretchr/testify/require"
+)
+
+func TestForDefaultValidUpdateBasketConfigurationArgs(t *testing.T) {
+	// given:
+	args := fixtures.DefaultValidUpdateBasketConfigurationArgs()
+
+	// when:
+	err := validate.UpdateBasketConfigurationArgs(args)
+
+	// then:
+	require.NoError(t, err)
+}
+
+func TestWrongUpdateBasketConfigurationArgs(t *testing.T) {
+	tests := map[string]struct {
+		modifier func(args *wdk.UpdateBasketConfigurationArgs) *wdk.UpdateBasketConfigurationArgs
+	}{
+		"Empty name": {
+			modifier: func(args *wdk.UpdateBasketConfigurationArgs) *wdk.UpdateBasketConfigurationArgs {
+				args.Name = ""
+				return args
+			},
+		},
+		"Name too long": {
+			modifier: func(args *wdk.UpdateBasketConfigurationArgs) *wdk.UpdateBasketConfigurationArgs {
+				args.Name = primitives.StringUnder300(strings.Repeat("a", 301))
+				return args
+			},
+		},
+		"Nothing to update": {
+			modifier: func(args *wdk.UpdateBasketConfigurationArgs) *wdk.UpdateBasketConfigurationArgs {
+				args.NumberOfDesiredUTXOs = nil
+				args.MinimumDesiredUTXOValue = nil
+				return args
+			},
+		},
+		"Negative number of desired UTXOs": {
+			modifier: func(args *wdk.UpdateBasketConfigurationArgs) *wdk.UpdateBasketConfigurationArgs {
+				args.NumberOfDesiredUTXOs = to.Ptr(int64(-1))
+				return args
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			// given:
+			defaultArgs := fixtures.DefaultValidUpdateBasketConfigurationArgs()
+			modifiedArgs := test.modifier(defaultArgs)
+
+			// when:
+			err := validate.UpdateBasketConfigurationArgs(modifiedArgs)
+
+			// then:
+			require.Error(t, err)
+		})
+	}
+}
+
+func TestForDefaultValidRemoveBasketArgs(t *testing.T) {
+	// given:
+	args := fixtures.DefaultValidRemoveBasketArgs()
+
+	// when:
+	err := validate.RemoveBasketArgs(args)
+
+	// then:
+	require.NoError(t, err)
+}
+
+func TestWrongRemoveBasketArgs(t *testing.T) {
+	tests := map[string]struct {
+		args wdk.RemoveBasketArgs
+	}{
+		"Empty name": {
+			args: wdk.RemoveBasketArgs{Name: ""},
+		},
+		"Basket for change": {
+			args: wdk.RemoveBasketArgs{Name: wdk.BasketNameForChange},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			// when:
+			err := validate.RemoveBasketArgs(&test.args)
+
+			// then:
+			require.Error(t, err)
+		})
+	}
+}
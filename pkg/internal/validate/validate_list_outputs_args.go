@@ -0,0 +1,57 @@
+package validate
+
+import (
+	"fmt"
+
+	"github.com/4chain-ag/go-wallet-toolbox/pkg/wdk"
+	"github.com/4chain-ag/go-wallet-toolbox/pkg/wdk/primitives"
+)
+
+func ListOutputsArgs(args *wdk.ListOutputsArgs) error {
+	if args.Basket != "" {
+		err := args.Basket.Validate()
+		if err != nil {
+			return fmt.Errorf("invalid basket argument: %w", err)
+		}
+	}
+
+	err := validateTags(args.Tags)
+	if err != nil {
+		return err
+	}
+
+	switch args.TagQueryMode {
+	case "", wdk.QueryModeAny, wdk.QueryModeAll:
+	default:
+		return fmt.Errorf("invalid tagQueryMode argument: %s", args.TagQueryMode)
+	}
+
+	err = args.Limit.Validate()
+	if err != nil {
+		return fmt.Errorf("invalid limit argument: %w", err)
+	}
+
+	return nil
+}
+
+func OutputTagsArgs(args *wdk.OutputTagsArgs) error {
+	err := args.Output.Validate()
+	if err != nil {
+		return fmt.Errorf("invalid output argument: %w", err)
+	}
+
+	if len(args.Tags) == 0 {
+		return fmt.Errorf("at least one tag is required")
+	}
+
+	return validateTags(args.Tags)
+}
+
+func validateTags(tags []primitives.StringUnder300) error {
+	for i, tag := range tags {
+		if err := tag.Validate(); err != nil {
+			return fmt.Errorf("invalid tag [%d] argument: %w", i, err)
+		}
+	}
+	return nil
+}
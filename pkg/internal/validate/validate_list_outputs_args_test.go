@@ -0,0 +1,124 @@
+package validate_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/4chain-ag/go-wallet-toolbox/pkg/internal/fixtures"
+	"github.com/4chain-ag/go-wallet-toolbox/pkg/internal/validate"
+	"github.com/4chain-ag/go-wallet-toolbox/pkg/wdk"
+	"github.com/4chain-ag/go-wallet-toolbox/pkg/wdk/primitives"
+	"github.com/stretchr/testify/require"
+)
+
+func TestForDefaultValidListOutputsArgs(t *testing.T) {
+	// given:
+	args := fixtures.DefaultValidListOutputsArgs()
+
+	// when:
+	err := validate.ListOutputsArgs(args)
+
+	// then:
+	require.NoError(t, err)
+}
+
+func TestWrongListOutputsArgs(t *testing.T) {
+	tests := map[string]struct {
+		modifier func(args *wdk.ListOutputsArgs) *wdk.ListOutputsArgs
+	}{
+		"Basket name too long": {
+			modifier: func(args *wdk.ListOutputsArgs) *wdk.ListOutputsArgs {
+				args.Basket = primitives.StringUnder300(strings.Repeat("a", 301))
+				return args
+			},
+		},
+		"Empty tag": {
+			modifier: func(args *wdk.ListOutputsArgs) *wdk.ListOutputsArgs {
+				args.Tags = []primitives.StringUnder300{""}
+				return args
+			},
+		},
+		"Tag too long": {
+			modifier: func(args *wdk.ListOutputsArgs) *wdk.ListOutputsArgs {
+				args.Tags = []primitives.StringUnder300{primitives.StringUnder300(strings.Repeat("a", 301))}
+				return args
+			},
+		},
+		"Unknown tag query mode": {
+			modifier: func(args *wdk.ListOutputsArgs) *wdk.ListOutputsArgs {
+				args.TagQueryMode = "some"
+				return args
+			},
+		},
+		"Limit above maximum (10001)": {
+			modifier: func(args *wdk.ListOutputsArgs) *wdk.ListOutputsArgs {
+				args.Limit = 10001
+				return args
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			// given:
+			defaultArgs := fixtures.DefaultValidListOutputsArgs()
+			modifiedArgs := test.modifier(defaultArgs)
+
+			// when:
+			err := validate.ListOutputsArgs(modifiedArgs)
+
+			// then:
+			require.Error(t, err)
+		})
+	}
+}
+
+func TestForDefaultValidOutputTagsArgs(t *testing.T) {
+	// given:
+	args := fixtures.DefaultValidOutputTagsArgs()
+
+	// when:
+	err := validate.OutputTagsArgs(args)
+
+	// then:
+	require.NoError(t, err)
+}
+
+func TestWrongOutputTagsArgs(t *testing.T) {
+	tests := map[string]struct {
+		modifier func(args *wdk.OutputTagsArgs) *wdk.OutputTagsArgs
+	}{
+		"Outpoint without index": {
+			modifier: func(args *wdk.OutputTagsArgs) *wdk.OutputTagsArgs {
+				args.Output = "a3b2f0935c7b5bb7a841a09e535c13be86f4df0e7a91cebdc33812bfcc0eb9d7"
+				return args
+			},
+		},
+		"No tags": {
+			modifier: func(args *wdk.OutputTagsArgs) *wdk.OutputTagsArgs {
+				args.Tags = nil
+				return args
+			},
+		},
+		"Empty tag": {
+			modifier: func(args *wdk.OutputTagsArgs) *wdk.OutputTagsArgs {
+				args.Tags = []primitives.StringUnder300{""}
+				return args
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			// given:
+			defaultArgs := fixtures.DefaultValidOutputTagsArgs()
+			modifiedArgs := test.modifier(defaultArgs)
+
+			// when:
+			err := validate.OutputTagsArgs(modifiedArgs)
+
+			// then:
+			require.Error(t, err)
+		})
+	}
+}
@@ -0,0 +1,189 @@
+package services_test
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"slices"
+	"testing"
+
+	"github.com/4chain-ag/go-wallet-toolbox/pkg/services"
+	"github.com/4chain-ag/go-wallet-toolbox/pkg/services/configuration"
+	"github.com/4chain-ag/go-wallet-toolbox/pkg/services/internal/testabilities"
+	sdk "github.com/bsv-blockchain/go-sdk/transaction"
+	txtestabilities "github.com/bsv-blockchain/universal-test-vectors/pkg/testabilities"
+	"github.com/go-softwarelab/common/pkg/to"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func withBitails(config *configuration.WalletServices) {
+	config.BitailsAPIKey = to.Ptr(testabilities.BitailsAPIKey)
+}
+
+func TestBitailsRawTx(t *testing.T) {
+	t.Run("falls back to Bitails when WhatsOnChain doesn't know the transaction", func(t *testing.T) {
+		// given:
+		given := testabilities.Given(t)
+		txID := "3c64c621c0070ea56ca2ef13ef699483c3938f48e030b184f1d094678eda7ab8"
+		rawTxHex := "01000000010000000000000000000000000000000000000000000000000000000000000000ffffffff1703117b1900000000005f7c477c327c437c5f0006000000ffffffff016e2e5702000000001976a9147a112f6a373b80b4ebb2b02acef97f35aef7494488ac00000000"
+		given.WhatsOnChain().WillRespondWithRawTx(http.StatusNotFound, txID, "", nil)
+		given.Bitails().WillRespondWithRawTx(http.StatusOK, txID, rawTxHex)
+
+		// and:
+		walletServices := given.Services().WithConfig(withBitails)
+
+		// when:
+		result, err := walletServices.RawTx(txID)
+
+		// then:
+		require.NoError(t, err)
+		assert.Equal(t, "Bitails", result.Name)
+		assert.Equal(t, txID, result.TxID)
+		assert.Equal(t, rawTxHex, hex.EncodeToString(result.RawTx))
+	})
+}
+
+func TestBitailsMerklePath(t *testing.T) {
+	t.Run("falls back to Bitails when other services have no proof", func(t *testing.T) {
+		// given:
+		expectedPath := testMerklePath(t)
+		merkleRoot, err := expectedPath.ComputeRootHex(to.Ptr(merklePathTxID))
+		require.NoError(t, err)
+
+		// and:
+		given := testabilities.Given(t)
+		blockHash := givenActiveChainBlock(t, given, merkleRoot)
+		given.WhatsOnChain().WillRespondWithMerkleProof(http.StatusNotFound, merklePathTxID, "")
+		given.ARC().WillAlwaysReturnStatus(http.StatusNotFound)
+		given.Bitails().WillRespondWithMerkleProof(http.StatusOK, merklePathTxID, fmt.Sprintf(`{
+			"index": 1,
+			"txOrId": "%s",
+			"target": "%s",
+			"nodes": ["%s", "*"]
+		}`, merklePathTxID, blockHash, merklePathSibling))
+
+		// and:
+		walletServices := given.Services().WithConfig(withBitails, withChaintracks)
+
+		// when:
+		result, err := walletServices.MerklePath(context.Background(), merklePathTxID, false)
+
+		// then:
+		require.NoError(t, err)
+		require.NotNil(t, result.Name)
+		assert.Equal(t, "Bitails", *result.Name)
+		assert.Equal(t, expectedPath.Hex(), result.MerklePath.Hex())
+		assert.Equal(t, uint(merklePathHeight), result.Header.Height)
+	})
+}
+
+func TestBitailsPostBeef(t *testing.T) {
+	givenBeef := func(t *testing.T) (*sdk.Beef, string) {
+		tx := txtestabilities.GivenTX().WithInput(100).WithP2PKHOutput(99).TX()
+		beef, err := sdk.NewBeefFromTransaction(tx)
+		require.NoError(t, err)
+		return beef, tx.TxID().String()
+	}
+
+	tests := map[string]struct {
+		response       func(txID string) string
+		expectedStatus services.PostTxIDStatus
+		alreadyKnown   bool
+	}{
+		"accepted transaction": {
+			response: func(txID string) string {
+				return fmt.Sprintf(`[{"txid": "%s"}]`, txID)
+			},
+			expectedStatus: services.PostTxIDStatusSuccess,
+		},
+		"already known transaction": {
+			response: func(txID string) string {
+				return fmt.Sprintf(`[{"txid": "%s", "error": {"code": -27, "message": "Transaction already in the mempool"}}]`, txID)
+			},
+			expectedStatus: services.PostTxIDStatusSuccess,
+			alreadyKnown:   true,
+		},
+		"transaction with missing inputs": {
+			response: func(txID string) string {
+				return fmt.Sprintf(`[{"txid": "%s", "error": {"code": -25, "message": "Missing inputs"}}]`, txID)
+			},
+			expectedStatus: services.PostTxIDStatusInvalidTx,
+		},
+		"rejected transaction": {
+			response: func(txID string) string {
+				return fmt.Sprintf(`[{"txid": "%s", "error": {"code": -26, "message": "mandatory-script-verify-flag-failed"}}]`, txID)
+			},
+			expectedStatus: services.PostTxIDStatusInvalidTx,
+		},
+	}
+	for name, test := range tests {
+		t.Run("broadcasts to Bitails: "+name, func(t *testing.T) {
+			// given:
+			given := testabilities.Given(t)
+			given.ARC().WillAlwaysReturnStatus(http.StatusInternalServerError)
+			given.WhatsOnChain().WillRespondWithBroadcast(http.StatusInternalServerError, `"internal error"`)
+
+			// and:
+			beef, txID := givenBeef(t)
+			given.Bitails().WillRespondWithBroadcast(http.StatusOK, test.response(txID))
+
+			// and:
+			walletServices := given.Services().WithConfig(withBitails)
+
+			// when:
+			result, err := walletServices.PostBeef(context.Background(), beef, []string{txID})
+
+			// then:
+			require.NoError(t, err)
+			require.Len(t, result.Results, 3)
+			assert.Equal(t, "Bitails", result.Results[2].Name)
+
+			// and:
+			require.Len(t, result.TxIDResults, 1)
+			assert.Equal(t, test.expectedStatus, result.TxIDResults[0].Status)
+			assert.Equal(t, test.alreadyKnown, result.TxIDResults[0].AlreadyKnown)
+		})
+	}
+}
+
+func TestBitailsUtxoStatus(t *testing.T) {
+	t.Run("falls back to Bitails when WhatsOnChain fails", func(t *testing.T) {
+		// given:
+		scriptBytes, err := hex.DecodeString(utxoLockingScript)
+		require.NoError(t, err)
+
+		hash := sha256.Sum256(scriptBytes)
+		slices.Reverse(hash[:])
+		hashBE := hex.EncodeToString(hash[:])
+
+		// and:
+		given := testabilities.Given(t)
+		given.WhatsOnChain().WillRespondWithScriptUnspent(http.StatusInternalServerError, hashBE, "")
+		given.Bitails().WillRespondWithScriptUnspent(http.StatusOK, hashBE, fmt.Sprintf(`{
+			"scripthash": "%s",
+			"unspent": [{"txid": "%s", "vout": 1, "satoshis": 1000, "blockheight": 881234, "confirmations": 10}]
+		}`, hashBE, utxoTxID))
+
+		// and:
+		walletServices := given.Services().WithConfig(withBitails)
+
+		// when:
+		result, err := walletServices.UtxoStatus(context.Background(), utxoLockingScript, services.Script, false)
+
+		// then:
+		require.NoError(t, err)
+		assert.Equal(t, services.UtxoStatusResult{
+			Name:   "Bitails",
+			IsUtxo: to.Ptr(true),
+			Details: []services.UtxoStatusDetails{{
+				Height:   to.Ptr(int64(881234)),
+				Txid:     to.Ptr(utxoTxID),
+				Index:    to.Ptr(int64(1)),
+				Satoshis: to.Ptr(uint64(1000)),
+			}},
+		}, result)
+	})
+}
@@ -0,0 +1,254 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"math"
+	"strconv"
+
+	"github.com/4chain-ag/go-wallet-toolbox/pkg/defs"
+	"github.com/4chain-ag/go-wallet-toolbox/pkg/services/configuration"
+	"github.com/4chain-ag/go-wallet-toolbox/pkg/services/internal/chaintracks"
+	"github.com/4chain-ag/go-wallet-toolbox/pkg/services/internal/headers"
+	"github.com/4chain-ag/go-wallet-toolbox/pkg/services/internal/whatsonchain"
+	"github.com/bsv-blockchain/go-sdk/chainhash"
+	"github.com/bsv-blockchain/go-sdk/transaction/chaintracker"
+	"github.com/go-resty/resty/v2"
+	"github.com/go-softwarelab/common/pkg/to"
+)
+
+// headersProvider provides the block headers of the active chain for WalletServices.
+type headersProvider interface {
+	chaintracker.ChainTracker
+	Height(ctx context.Context) (uint32, error)
+	HeaderForHeight(ctx context.Context, height uint32) (*headers.Header, error)
+	HeaderByHash(ctx context.Context, hash chainhash.Hash) (uint32, *headers.Header, error)
+}
+
+func newHeadersProvider(logger *slog.Logger, httpClient *resty.Client, woc *whatsonchain.WhatsOnChain, config configuration.WalletServices) (headersProvider, error) {
+	if config.Chaintracks.URL != "" {
+		return &chaintracksHeaders{client: chaintracks.NewClient(logger, httpClient, config.Chaintracks.URL)}, nil
+	}
+
+	var source headers.Source = &wocHeadersSource{woc: woc}
+	if config.HeaderStore.SourceURL != "" {
+		source = &chaintracksSource{client: chaintracks.NewClient(logger, httpClient, config.HeaderStore.SourceURL)}
+	}
+	return newHeadersTracker(logger, source, config.Chain, config.HeaderStore)
+}
+
+func newHeadersTracker(logger *slog.Logger, source headers.Source, chain defs.BSVNetwork, config configuration.HeaderStore) (*headers.Tracker, error) {
+	store, err := headers.OpenStore(config.File)
+	if err != nil {
+		return nil, fmt.Errorf("cannot open headers store: %w", err)
+	}
+
+	var genesis *headers.Header
+	if config.Genesis != nil {
+		genesis, err = fromGenesisHeader(config.Genesis)
+		if err != nil {
+			return nil, fmt.Errorf("invalid genesis header: %w", err)
+		}
+	}
+
+	return headers.NewTracker(logger, source, store, headers.Options{
+		StartHeight:   config.StartHeight,
+		StartHash:     config.StartHash,
+		InitialDepth:  config.InitialDepth,
+		MaxReorgDepth: config.MaxReorgDepth,
+		SyncInterval:  config.SyncInterval,
+		Genesis:       genesis,
+		Params:        chainParams(chain),
+		Backfill:      config.SourceURL != "",
+	}), nil
+}
+
+func chainParams(chain defs.BSVNetwork) *headers.ChainParams {
+	switch chain {
+	case defs.NetworkMainnet:
+		return &headers.MainNetParams
+	case defs.NetworkTestnet:
+		return &headers.TestNetParams
+	default:
+		return &headers.RegTestParams
+	}
+}
+
+// chaintracksSource provides the serialized block headers for the headers store from the Chaintracks API.
+type chaintracksSource struct {
+	client *chaintracks.Client
+}
+
+func (s *chaintracksSource) ChainHeight(ctx context.Context) (uint32, error) {
+	return s.client.CurrentHeight(ctx)
+}
+
+func (s *chaintracksSource) Headers(ctx context.Context, height, count uint32) ([]byte, error) {
+	return s.client.Headers(ctx, height, count)
+}
+
+// wocHeadersSource provides the block headers for the headers store from WhatsOnChain,
+// it's used when no source of the serialized headers is configured,
+// because WhatsOnChain returns the headers as JSON one by one.
+type wocHeadersSource struct {
+	woc *whatsonchain.WhatsOnChain
+}
+
+func (s *wocHeadersSource) ChainHeight(ctx context.Context) (uint32, error) {
+	return s.woc.ChainHeight(ctx)
+}
+
+func (s *wocHeadersSource) Headers(ctx context.Context, height, count uint32) ([]byte, error) {
+	var data []byte
+	for current := height; current < height+count; current++ {
+		wocHeader, err := s.woc.BlockHeaderByHeight(ctx, current)
+		if err != nil {
+			return nil, err
+		}
+		if wocHeader == nil {
+			break
+		}
+
+		header, err := fromWocHeader(wocHeader)
+		if err != nil {
+			return nil, fmt.Errorf("invalid block header at height %d: %w", current, err)
+		}
+		if header.Hash().String() != wocHeader.Hash {
+			return nil, fmt.Errorf("computed hash %s of block header doesn't match %s", header.Hash(), wocHeader.Hash)
+		}
+		data = append(data, header.Bytes()...)
+	}
+	return data, nil
+}
+
+// chaintracksHeaders provides the block headers from the remote Chaintracks service.
+type chaintracksHeaders struct {
+	client *chaintracks.Client
+}
+
+func (c *chaintracksHeaders) IsValidRootForHeight(root *chainhash.Hash, height uint32) (bool, error) {
+	return c.client.IsValidRootForHeight(root, height)
+}
+
+func (c *chaintracksHeaders) Height(ctx context.Context) (uint32, error) {
+	return c.client.CurrentHeight(ctx)
+}
+
+func (c *chaintracksHeaders) HeaderForHeight(ctx context.Context, height uint32) (*headers.Header, error) {
+	found, err := c.client.HeaderForHeight(ctx, height)
+	if err != nil {
+		return nil, err
+	}
+	if found == nil {
+		return nil, fmt.Errorf("%w at height %d", headers.ErrHeaderNotFound, height)
+	}
+	return fromChaintracksHeader(found)
+}
+
+func (c *chaintracksHeaders) HeaderByHash(ctx context.Context, hash chainhash.Hash) (uint32, *headers.Header, error) {
+	found, err := c.client.HeaderForHash(ctx, hash.String())
+	if err != nil {
+		return 0, nil, err
+	}
+	if found == nil {
+		return 0, nil, fmt.Errorf("%w with hash %s", headers.ErrHeaderNotFound, hash)
+	}
+
+	header, err := fromChaintracksHeader(found)
+	if err != nil {
+		return 0, nil, err
+	}
+	return found.Height, header, nil
+}
+
+func fromChaintracksHeader(found *chaintracks.BlockHeader) (*headers.Header, error) {
+	header := &headers.Header{
+		Version: int32(found.Version), //nolint:gosec // version is serialized as signed int32
+		Time:    found.Time,
+		Bits:    found.Bits,
+		Nonce:   found.Nonce,
+	}
+	if err := chainhash.Decode(&header.PrevHash, found.PreviousHash); err != nil {
+		return nil, fmt.Errorf("invalid previous block hash: %w", err)
+	}
+	if err := chainhash.Decode(&header.MerkleRoot, found.MerkleRoot); err != nil {
+		return nil, fmt.Errorf("invalid merkle root: %w", err)
+	}
+	if header.Hash().String() != found.Hash {
+		return nil, fmt.Errorf("computed hash %s of block header doesn't match %s", header.Hash(), found.Hash)
+	}
+	return header, nil
+}
+
+func fromWocHeader(wocHeader *whatsonchain.BlockHeader) (*headers.Header, error) {
+	header := &headers.Header{}
+
+	if wocHeader.Version < math.MinInt32 || wocHeader.Version > math.MaxInt32 {
+		return nil, fmt.Errorf("invalid version %d", wocHeader.Version)
+	}
+	header.Version = int32(wocHeader.Version)
+
+	var err error
+	if header.Time, err = to.UInt32(wocHeader.Time); err != nil {
+		return nil, fmt.Errorf("invalid time: %w", err)
+	}
+	if header.Nonce, err = to.UInt32(wocHeader.Nonce); err != nil {
+		return nil, fmt.Errorf("invalid nonce: %w", err)
+	}
+
+	bits, err := strconv.ParseUint(wocHeader.Bits, 16, 32)
+	if err != nil {
+		return nil, fmt.Errorf("invalid bits %s: %w", wocHeader.Bits, err)
+	}
+	header.Bits = uint32(bits)
+
+	// the genesis block has no previous block
+	if wocHeader.PreviousBlockHash != "" {
+		if err = chainhash.Decode(&header.PrevHash, wocHeader.PreviousBlockHash); err != nil {
+			return nil, fmt.Errorf("invalid previous block hash: %w", err)
+		}
+	}
+	if err = chainhash.Decode(&header.MerkleRoot, wocHeader.MerkleRoot); err != nil {
+		return nil, fmt.Errorf("invalid merkle root: %w", err)
+	}
+
+	return header, nil
+}
+
+func fromGenesisHeader(genesis *configuration.GenesisHeader) (*headers.Header, error) {
+	header := &headers.Header{
+		Version: genesis.Version,
+		Time:    genesis.Time,
+		Nonce:   genesis.Nonce,
+	}
+
+	bits, err := strconv.ParseUint(genesis.Bits, 16, 32)
+	if err != nil {
+		return nil, fmt.Errorf("invalid bits %s: %w", genesis.Bits, err)
+	}
+	header.Bits = uint32(bits)
+
+	if err = chainhash.Decode(&header.MerkleRoot, genesis.MerkleRoot); err != nil {
+		return nil, fmt.Errorf("invalid merkle root: %w", err)
+	}
+	if genesis.Hash != "" && header.Hash().String() != genesis.Hash {
+		return nil, fmt.Errorf("computed hash %s of genesis header doesn't match %s", header.Hash(), genesis.Hash)
+	}
+	return header, nil
+}
+
+func fromStoredHeader(height uint32, header *headers.Header) *BlockHeader {
+	return &BlockHeader{
+		BaseBlockHeader: BaseBlockHeader{
+			Version:      int64(header.Version),
+			PreviousHash: header.PrevHash.String(),
+			MerkleRoot:   header.MerkleRoot.String(),
+			Time:         int64(header.Time),
+			Bits:         int64(header.Bits),
+			Nonce:        int64(header.Nonce),
+		},
+		Height: uint(height),
+		Hash:   header.Hash().String(),
+	}
+}
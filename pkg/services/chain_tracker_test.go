@@ -0,0 +1,278 @@
+package services_test
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/4chain-ag/go-wallet-toolbox/pkg/defs"
+	"github.com/4chain-ag/go-wallet-toolbox/pkg/internal/logging"
+	"github.com/4chain-ag/go-wallet-toolbox/pkg/services"
+	"github.com/4chain-ag/go-wallet-toolbox/pkg/services/configuration"
+	"github.com/4chain-ag/go-wallet-toolbox/pkg/services/internal/chaintracks"
+	"github.com/4chain-ag/go-wallet-toolbox/pkg/services/internal/testabilities"
+	"github.com/bsv-blockchain/go-sdk/chainhash"
+	"github.com/go-resty/resty/v2"
+	"github.com/go-softwarelab/common/pkg/to"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	genesisHash       = "000000000019d6689c085ae165831e934ff763ae46a2a6c172b3f1b60a8ce26f"
+	block1Hash        = "00000000839a8e6886ab5951d76f411475428afc90947ee320161bbf18eb6048"
+	block1MerkleRoot  = "0e3e2357e806b6cdb1f70b54c3a3a17b6714ee1f0e68bebb44a74b1efd512098"
+	block2Hash        = "000000006a625f06636b8bb6ac7b960a8d03705d1ace08b1a19da3fdcc99ddbd"
+	block2MerkleRoot  = "9b0fc92260312ce44e74ef369f5c66bbb85848f2eddd5a7a1cde251e54ccfdd5"
+	chainInfoResponse = `{"chain": "main", "blocks": 2, "bestblockhash": "` + block2Hash + `"}`
+
+	block0Raw = "0100000000000000000000000000000000000000000000000000000000000000000000003ba3edfd7a7b12b27ac72c3e67768f617fc81bc3888a51323a9fb8aa4b1e5e4a29ab5f49ffff001d1dac2b7c"
+	block1Raw = "010000006fe28c0ab6f1b372c1a6a246ae63f74f931e8365e15a089c68d6190000000000982051fd1e4ba744bbbe680e1fee14677ba1a3c3540bf7b1cdb606e857233e0e61bc6649ffff001d01e36299"
+	block2Raw = "010000004860eb18bf1b1620e37e9490fc8a427514416fd75159ab86688e9a8300000000d5fdcc541e25de1c7a5addedf24858b8bb665c9f36ef744ee42c316022c90f9bb0bc6649ffff001d08d2bd61"
+)
+
+var firstBlocks = map[uint32]string{
+	0: `{
+		"hash": "` + genesisHash + `",
+		"height": 0,
+		"version": 1,
+		"merkleroot": "4a5e1e4baab89f3a32518a88c31bc87f618f76673e2cc77ab2127b7afdeda33b",
+		"time": 1231006505,
+		"nonce": 2083236893,
+		"bits": "1d00ffff"
+	}`,
+	1: `{
+		"hash": "` + block1Hash + `",
+		"height": 1,
+		"version": 1,
+		"merkleroot": "` + block1MerkleRoot + `",
+		"time": 1231469665,
+		"nonce": 2573394689,
+		"bits": "1d00ffff",
+		"previousblockhash": "` + genesisHash + `"
+	}`,
+	2: `{
+		"hash": "` + block2Hash + `",
+		"height": 2,
+		"version": 1,
+		"merkleroot": "` + block2MerkleRoot + `",
+		"time": 1231469744,
+		"nonce": 1639830024,
+		"bits": "1d00ffff",
+		"previousblockhash": "` + block1Hash + `"
+	}`,
+}
+
+func TestChainTracker(t *testing.T) {
+	givenServices := func(t *testing.T) testabilities.ServicesFixture {
+		given := testabilities.Given(t)
+		given.WhatsOnChain().WillRespondWithChainInfo(http.StatusOK, chainInfoResponse)
+		for height, content := range firstBlocks {
+			given.WhatsOnChain().WillRespondWithBlockAtHeight(http.StatusOK, height, content)
+		}
+		return given
+	}
+
+	fromGenesis := func(config *configuration.WalletServices) {
+		config.HeaderStore.StartHeight = to.Ptr(uint32(0))
+	}
+
+	t.Run("returns height of the synced chain", func(t *testing.T) {
+		// given:
+		services := givenServices(t).Services().WithConfig(fromGenesis)
+
+		// when:
+		height, err := services.Height(context.Background())
+
+		// then:
+		require.NoError(t, err)
+		assert.Equal(t, uint32(2), height)
+	})
+
+	t.Run("returns serialized header for height", func(t *testing.T) {
+		// given:
+		services := givenServices(t).Services().WithConfig(fromGenesis)
+
+		// when:
+		header, err := services.HeaderForHeight(context.Background(), 1)
+
+		// then:
+		require.NoError(t, err)
+		require.Len(t, header, 80)
+		assert.Equal(t, block1Hash, chainhash.DoubleHashH(header).String())
+	})
+
+	t.Run("returns header for hash", func(t *testing.T) {
+		// given:
+		services := givenServices(t).Services().WithConfig(fromGenesis)
+
+		// when:
+		header, err := services.HashToHeader(context.Background(), block2Hash)
+
+		// then:
+		require.NoError(t, err)
+		assert.Equal(t, block2Hash, header.Hash)
+		assert.Equal(t, uint(2), header.Height)
+		assert.Equal(t, block1Hash, header.PreviousHash)
+		assert.Equal(t, block2MerkleRoot, header.MerkleRoot)
+		assert.Equal(t, int64(0x1d00ffff), header.Bits)
+	})
+
+	t.Run("validates merkle root for height", func(t *testing.T) {
+		// given:
+		services := givenServices(t).Services().WithConfig(fromGenesis)
+
+		// and:
+		root, err := chainhash.NewHashFromHex(block1MerkleRoot)
+		require.NoError(t, err)
+
+		// when:
+		valid, err := services.ChainTracker().IsValidRootForHeight(root, 1)
+
+		// then:
+		require.NoError(t, err)
+		assert.True(t, valid)
+
+		// when:
+		valid, err = services.ChainTracker().IsValidRootForHeight(root, 2)
+
+		// then:
+		require.NoError(t, err)
+		assert.False(t, valid)
+	})
+
+	t.Run("rejects header with hash not matching its content", func(t *testing.T) {
+		// given:
+		given := testabilities.Given(t)
+		given.WhatsOnChain().WillRespondWithChainInfo(http.StatusOK, chainInfoResponse)
+		given.WhatsOnChain().WillRespondWithBlockAtHeight(http.StatusOK, 0, strings.Replace(firstBlocks[0], genesisHash, block1Hash, 1))
+
+		// and:
+		services := given.Services().WithConfig(fromGenesis)
+
+		// when:
+		_, err := services.HeaderForHeight(context.Background(), 0)
+
+		// then:
+		require.Error(t, err)
+	})
+
+	t.Run("returns error for invalid block hash", func(t *testing.T) {
+		// given:
+		services := testabilities.Given(t).Services().WithDefaultConfig()
+
+		// when:
+		_, err := services.HashToHeader(context.Background(), "not a hash")
+
+		// then:
+		require.Error(t, err)
+	})
+
+	t.Run("uses Chaintracks service when configured", func(t *testing.T) {
+		// given:
+		given := testabilities.Given(t)
+		given.Chaintracks().IsUpAndRunning()
+		given.Chaintracks().WillRespondWithHeight(1)
+		given.Chaintracks().WillRespondWithHeader(chaintracks.BlockHeader{
+			Version:      1,
+			PreviousHash: genesisHash,
+			MerkleRoot:   block1MerkleRoot,
+			Time:         1231469665,
+			Bits:         0x1d00ffff,
+			Nonce:        2573394689,
+			Height:       1,
+			Hash:         block1Hash,
+		})
+
+		// and:
+		services := given.Services().WithConfig(func(config *configuration.WalletServices) {
+			config.Chaintracks.URL = testabilities.ChaintracksURL
+		})
+
+		// when:
+		height, err := services.Height(context.Background())
+
+		// then:
+		require.NoError(t, err)
+		assert.Equal(t, uint32(1), height)
+
+		// when:
+		header, err := services.HashToHeader(context.Background(), block1Hash)
+
+		// then:
+		require.NoError(t, err)
+		assert.Equal(t, uint(1), header.Height)
+		assert.Equal(t, block1MerkleRoot, header.MerkleRoot)
+
+		// and:
+		root, err := chainhash.NewHashFromHex(block1MerkleRoot)
+		require.NoError(t, err)
+
+		// when:
+		valid, err := services.ChainTracker().IsValidRootForHeight(root, 1)
+
+		// then:
+		require.NoError(t, err)
+		assert.True(t, valid)
+	})
+	t.Run("syncs headers from the configured source of serialized headers", func(t *testing.T) {
+		// given:
+		given := testabilities.Given(t)
+		given.Chaintracks().WillRespondWithHeight(2)
+		given.Chaintracks().WillServeHeaders(block0Raw, block1Raw, block2Raw)
+
+		// and:
+		services := given.Services().WithConfig(fromGenesis, func(config *configuration.WalletServices) {
+			config.HeaderStore.SourceURL = testabilities.ChaintracksURL
+		})
+
+		// when:
+		header, err := services.HashToHeader(context.Background(), block2Hash)
+
+		// then:
+		require.NoError(t, err)
+		assert.Equal(t, uint(2), header.Height)
+		assert.Equal(t, block2MerkleRoot, header.MerkleRoot)
+	})
+
+	t.Run("fetches headers below the first synced header", func(t *testing.T) {
+		// given:
+		given := testabilities.Given(t)
+		given.Chaintracks().WillRespondWithHeight(2)
+		given.Chaintracks().WillServeHeaders(block0Raw, block1Raw, block2Raw)
+
+		// and:
+		services := given.Services().WithConfig(func(config *configuration.WalletServices) {
+			config.HeaderStore.SourceURL = testabilities.ChaintracksURL
+			config.HeaderStore.StartHeight = to.Ptr(uint32(2))
+		})
+
+		// and:
+		root, err := chainhash.NewHashFromHex(block1MerkleRoot)
+		require.NoError(t, err)
+
+		// when:
+		valid, err := services.ChainTracker().IsValidRootForHeight(root, 1)
+
+		// then:
+		require.NoError(t, err)
+		assert.True(t, valid)
+	})
+}
+
+func TestChainTrackerConfigErrors(t *testing.T) {
+	t.Run("fails when the headers store cannot be opened", func(t *testing.T) {
+		// given:
+		config := configuration.WalletServices{
+			Chain:       defs.NetworkMainnet,
+			HeaderStore: configuration.HeaderStore{File: t.TempDir()},
+		}
+
+		// when:
+		_, err := services.New(resty.New(), logging.NewTestLogger(t), config)
+
+		// then:
+		require.Error(t, err)
+	})
+}
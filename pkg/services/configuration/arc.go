@@ -0,0 +1,21 @@
+package configuration
+
+// ARC is a struct that configures ARC service, the URL of ARC is configured by WalletServices.ArcURL
+type ARC struct {
+	Token         string `mapstructure:"token"`
+	DeploymentID  string `mapstructure:"deployment_id"`
+	WaitFor       string `mapstructure:"wait_for"`
+	CallbackURL   string `mapstructure:"callback_url"`
+	CallbackToken string `mapstructure:"callback_token"`
+	// Tokens are additional tokens used in turns with Token, when the quota of the current token is exhausted
+	Tokens    []string  `mapstructure:"tokens"`
+	RateLimit RateLimit `mapstructure:"rate_limit"`
+}
+
+// NamedARC is a struct that configures additional ARC service with its own URL
+type NamedARC struct {
+	// Name identifies the ARC service in results and logs, e.g. "GorillaPool"
+	Name string `mapstructure:"name"`
+	URL  string `mapstructure:"url"`
+	ARC  `mapstructure:",squash"`
+}
@@ -0,0 +1,8 @@
+package configuration
+
+// Chaintracks is a struct that configures the client of a remote Chaintracks headers service,
+// when the URL is provided, it is used as the ChainTracker instead of the local header store.
+type Chaintracks struct {
+	// URL is the base URL of the Chaintracks service, e.g. https://npm-registry.babbage.systems:8084
+	URL string `mapstructure:"url"`
+}
@@ -0,0 +1,40 @@
+package configuration
+
+import "time"
+
+// HeaderStore is a struct that configures the local block headers store used as the ChainTracker,
+// the headers are synced from the configured source of serialized headers or from WhatsOnChain.
+type HeaderStore struct {
+	// SourceURL is the URL of the service providing the 80 bytes serialized headers with the Chaintracks API (getHeaders),
+	// empty means the headers are fetched one by one from WhatsOnChain, and then the headers below the first synced one
+	// are not stored - only the requested header is fetched and validated for proof of work
+	SourceURL string `mapstructure:"source_url"`
+	// File is the path of the file persisting the synced headers, empty keeps the headers in memory only
+	File string `mapstructure:"file"`
+	// StartHeight is the height of the first synced header, nil means InitialDepth headers below the chain tip
+	StartHeight *uint32 `mapstructure:"start_height"`
+	// StartHash is the expected hash of the header at StartHeight
+	StartHash string `mapstructure:"start_hash"`
+	// InitialDepth is the number of headers below the chain tip synced when StartHeight is not provided
+	InitialDepth uint32 `mapstructure:"initial_depth"`
+	// MaxReorgDepth is the maximal number of headers which can be replaced by a reorg
+	MaxReorgDepth uint32 `mapstructure:"max_reorg_depth"`
+	// SyncInterval is the minimal time between the syncs triggered by the Height calls
+	SyncInterval time.Duration `mapstructure:"sync_interval"`
+	// Genesis is the header at height 0 of a regtest or custom network, when it's provided,
+	// the sync starting at height 0 uses it instead of trusting the genesis header returned by WhatsOnChain
+	Genesis *GenesisHeader `mapstructure:"genesis"`
+}
+
+// GenesisHeader is the genesis block header of a regtest or custom network,
+// the hashes are hex strings in the same (reversed) byte order as returned by WhatsOnChain
+type GenesisHeader struct {
+	Version    int32  `mapstructure:"version"`
+	MerkleRoot string `mapstructure:"merkle_root"`
+	Time       uint32 `mapstructure:"time"`
+	// Bits is the proof of work target in the compact form as hex string, e.g. "207fffff"
+	Bits  string `mapstructure:"bits"`
+	Nonce uint32 `mapstructure:"nonce"`
+	// Hash is the expected hash of the genesis header, it's checked to catch the mistakes in the other parameters
+	Hash string `mapstructure:"hash"`
+}
@@ -0,0 +1,9 @@
+package configuration
+
+// RateLimit is a struct that configures the token bucket limiting the rate of requests sent to the provider
+type RateLimit struct {
+	// RequestsPerSecond is the sustained rate of requests, zero disables the limit
+	RequestsPerSecond float64 `mapstructure:"requests_per_second"`
+	// Burst is the number of requests which can be sent at once, defaults to 1
+	Burst int `mapstructure:"burst"`
+}
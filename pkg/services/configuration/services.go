@@ -1,6 +1,7 @@
 package configuration
 
 import (
+	"fmt"
 	"time"
 
 	"github.com/4chain-ag/go-wallet-toolbox/pkg/defs"
@@ -17,9 +18,38 @@ type WalletServices struct {
 	DisableMapiCallback             bool                  `mapstructure:"disable_mapi_callback"`
 	ExchangeratesApiKey             string                `mapstructure:"exchangerates_api_key"`
 	ChaintracksFiatExchangeRatesUrl string                `mapstructure:"chaintracks_fiat_exchange_rates_url"`
-	Chaintracks                     any                   `mapstructure:"chaintracks"` // TODO: create *ChaintracksServiceClient
+	Chaintracks                     Chaintracks           `mapstructure:"chaintracks"`
 	ArcURL                          string                `mapstructure:"arc_url"`
-	ArcConfig                       any                   `mapstructure:"arc"` // TODO: create *ArcConfig
+	ArcConfig                       ARC                   `mapstructure:"arc"`
+	// AdditionalArcs are other ARC instances (e.g. GorillaPool) used to broadcast transactions together with the one configured by ArcURL
+	AdditionalArcs []NamedARC `mapstructure:"additional_arcs"`
+	// PostBeefMode selects if transactions are broadcasted one by one until the first success or to all broadcasters, empty value means the default mode.
+	PostBeefMode defs.PostBeefMode `mapstructure:"post_beef_mode"`
+	// BitailsURL is the base URL of Bitails API used when BitailsAPIKey is set, empty means the public API for Chain
+	BitailsURL string `mapstructure:"bitails_url"`
 
 	WhatsOnChain WhatsOnChain `mapstructure:"whats_on_chain"`
+	HeaderStore  HeaderStore  `mapstructure:"header_store"`
+}
+
+// Validate checks if the configuration is valid
+func (c *WalletServices) Validate() error {
+	if c.PostBeefMode != "" {
+		mode, err := defs.ParsePostBeefMode(string(c.PostBeefMode))
+		if err != nil {
+			return fmt.Errorf("invalid post beef mode: %s", c.PostBeefMode)
+		}
+		c.PostBeefMode = mode
+	}
+
+	if c.Chain.HasPublicServices() {
+		return nil
+	}
+	if c.WhatsOnChain.URL == "" {
+		return fmt.Errorf("there is no public WhatsOnChain API for the network %s, its URL must be configured", c.Chain)
+	}
+	if c.BitailsAPIKey != nil && c.BitailsURL == "" {
+		return fmt.Errorf("there is no public Bitails API for the network %s, its URL must be configured", c.Chain)
+	}
+	return nil
 }
@@ -8,7 +8,13 @@ import (
 
 // WhatsOnChain is a struct that configures WhatsOnChain service
 type WhatsOnChain struct {
+	// URL is the base URL of WhatsOnChain API including the network, e.g. http://localhost:8101/woc/v1/bsv/regtest,
+	// empty means the public API for WalletServices.Chain
+	URL               string              `mapstructure:"url"`
 	APIKey            string              `mapstructure:"api_key"`
 	BSVExchangeRate   wdk.BSVExchangeRate `mapstructure:"bsv_exchange_rate"`
 	BSVUpdateInterval *time.Duration      `mapstructure:"bsv_update_interval"`
+	// APIKeys are additional API keys used in turns with APIKey, when the quota of the current key is exhausted
+	APIKeys   []string  `mapstructure:"api_keys"`
+	RateLimit RateLimit `mapstructure:"rate_limit"`
 }
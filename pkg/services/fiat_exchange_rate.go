@@ -0,0 +1,85 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"maps"
+	"sync"
+	"time"
+
+	"github.com/4chain-ag/go-wallet-toolbox/pkg/internal/logging"
+	"github.com/4chain-ag/go-wallet-toolbox/pkg/services/internal/servicequeue"
+	"github.com/4chain-ag/go-wallet-toolbox/pkg/wdk"
+)
+
+// fiatRatesCache keeps the last known fiat exchange rates and refreshes them after the update interval.
+type fiatRatesCache struct {
+	logger         *slog.Logger
+	services       servicequeue.Queue[*wdk.FiatExchangeRates]
+	updateInterval time.Duration
+	// noProviders means the configured rates are always used, it's reported once when the services are created
+	noProviders bool
+
+	mu    sync.Mutex
+	rates wdk.FiatExchangeRates
+}
+
+// get returns the cached rates, refreshing them first if they are older than the update interval.
+// When the refresh fails, the stale rates are returned as long as there are any.
+func (c *fiatRatesCache) get(ctx context.Context) (wdk.FiatExchangeRates, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.noProviders {
+		if len(c.rates.Rates) == 0 {
+			return wdk.FiatExchangeRates{}, fmt.Errorf("no fiat exchange rates are configured and there are no providers to fetch them")
+		}
+		return c.rates, nil
+	}
+
+	if time.Since(c.rates.Timestamp) < c.updateInterval {
+		return c.rates, nil
+	}
+
+	rates, err := c.services.OneByOne(ctx)
+	if err != nil {
+		if len(c.rates.Rates) == 0 {
+			return wdk.FiatExchangeRates{}, fmt.Errorf("couldn't update fiat exchange rates: %w", err)
+		}
+		c.logger.Warn("couldn't update fiat exchange rates, using the stale ones", logging.Error(err), slog.Time("timestamp", c.rates.Timestamp))
+		return c.rates, nil
+	}
+
+	c.rates = wdk.FiatExchangeRates{
+		// the timestamp of the update is used, so the rates are not refreshed on every call when the provider returns old ones
+		Timestamp: time.Now(),
+		Base:      rates.Base,
+		Rates:     maps.Clone(rates.Rates),
+	}
+	return c.rates, nil
+}
+
+// fiatRate returns the amount of currency per one unit of base, converting through the base currency of the rates.
+func fiatRate(rates wdk.FiatExchangeRates, currency, base wdk.Currency) (float64, error) {
+	currencyPerRatesBase, err := ratePerRatesBase(rates, currency)
+	if err != nil {
+		return 0, err
+	}
+	basePerRatesBase, err := ratePerRatesBase(rates, base)
+	if err != nil {
+		return 0, err
+	}
+	return currencyPerRatesBase / basePerRatesBase, nil
+}
+
+func ratePerRatesBase(rates wdk.FiatExchangeRates, currency wdk.Currency) (float64, error) {
+	if currency == rates.Base {
+		return 1, nil
+	}
+	rate, ok := rates.Rates[currency]
+	if !ok || rate <= 0 {
+		return 0, fmt.Errorf("unknown exchange rate of currency %s", currency)
+	}
+	return rate, nil
+}
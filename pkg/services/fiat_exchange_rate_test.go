@@ -0,0 +1,220 @@
+package services_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/4chain-ag/go-wallet-toolbox/pkg/services/configuration"
+	"github.com/4chain-ag/go-wallet-toolbox/pkg/services/internal/testabilities"
+	"github.com/4chain-ag/go-wallet-toolbox/pkg/wdk"
+	"github.com/go-softwarelab/common/pkg/to"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const exchangeRatesAPIResponse = `{
+	"success": true,
+	"timestamp": 1700000000,
+	"base": "EUR",
+	"date": "2023-11-14",
+	"rates": {"USD": 1.25, "GBP": 0.9}
+}`
+
+func TestFiatExchangeRate(t *testing.T) {
+	chaintracksRates := wdk.FiatExchangeRates{
+		Timestamp: time.Now(),
+		Base:      wdk.USD,
+		Rates: map[wdk.Currency]float64{
+			wdk.USD: 1,
+			wdk.GBP: 0.75,
+			wdk.EUR: 0.9,
+		},
+	}
+
+	t.Run("converts cached rates through the base currency", func(t *testing.T) {
+		// given:
+		given := testabilities.Given(t)
+
+		// and:
+		services := given.Services().WithConfig(func(config *configuration.WalletServices) {
+			config.FiatExchangeRates.Timestamp = time.Now()
+		})
+
+		tests := map[string]struct {
+			currency wdk.Currency
+			base     *wdk.Currency
+			expected float64
+		}{
+			"GBP per USD by default": {
+				currency: wdk.GBP,
+				expected: 0.8,
+			},
+			"EUR per GBP": {
+				currency: wdk.EUR,
+				base:     to.Ptr(wdk.GBP),
+				expected: 0.93 / 0.8,
+			},
+			"USD per EUR": {
+				currency: wdk.USD,
+				base:     to.Ptr(wdk.EUR),
+				expected: 1 / 0.93,
+			},
+			"USD per USD": {
+				currency: wdk.USD,
+				base:     to.Ptr(wdk.USD),
+				expected: 1,
+			},
+		}
+		for name, test := range tests {
+			t.Run(name, func(t *testing.T) {
+				// when:
+				rate, err := services.FiatExchangeRate(context.Background(), test.currency, test.base)
+
+				// then:
+				require.NoError(t, err)
+				assert.InDelta(t, test.expected, rate, 1e-9)
+			})
+		}
+	})
+
+	t.Run("refreshes stale rates from Chaintracks", func(t *testing.T) {
+		// given:
+		given := testabilities.Given(t)
+		given.Chaintracks().WillRespondWithFiatRates(http.StatusOK, chaintracksRates)
+
+		// and:
+		services := given.Services().WithDefaultConfig()
+
+		// when:
+		rate, err := services.FiatExchangeRate(context.Background(), wdk.GBP, nil)
+
+		// then:
+		require.NoError(t, err)
+		assert.InDelta(t, 0.75, rate, 1e-9)
+	})
+
+	t.Run("falls back to exchangeratesapi when Chaintracks fails", func(t *testing.T) {
+		// given:
+		given := testabilities.Given(t)
+		given.Chaintracks().WillRespondWithFiatRates(http.StatusInternalServerError, wdk.FiatExchangeRates{})
+		given.ExchangeRatesAPI().WillRespondWithRates(http.StatusOK, exchangeRatesAPIResponse)
+
+		// and:
+		services := given.Services().WithDefaultConfig()
+
+		// when:
+		gbpRate, err := services.FiatExchangeRate(context.Background(), wdk.GBP, nil)
+
+		// then:
+		require.NoError(t, err)
+		assert.InDelta(t, 0.9/1.25, gbpRate, 1e-9)
+
+		// when:
+		eurRate, err := services.FiatExchangeRate(context.Background(), wdk.EUR, nil)
+
+		// then:
+		require.NoError(t, err)
+		assert.InDelta(t, 1/1.25, eurRate, 1e-9)
+	})
+
+	t.Run("returns stale rates when all providers fail", func(t *testing.T) {
+		// given:
+		given := testabilities.Given(t)
+		given.Chaintracks().WillRespondWithFiatRates(http.StatusInternalServerError, wdk.FiatExchangeRates{})
+		given.ExchangeRatesAPI().WillRespondWithRates(http.StatusUnauthorized, `{"success": false, "error": {"code": 101, "info": "invalid access key"}}`)
+
+		// and:
+		services := given.Services().WithDefaultConfig()
+
+		// when:
+		rate, err := services.FiatExchangeRate(context.Background(), wdk.GBP, nil)
+
+		// then:
+		require.NoError(t, err)
+		assert.InDelta(t, 0.8, rate, 1e-9)
+	})
+
+	t.Run("uses the configured rates when no providers are configured", func(t *testing.T) {
+		// given:
+		given := testabilities.Given(t)
+
+		// and:
+		services := given.Services().WithConfig(func(config *configuration.WalletServices) {
+			config.ChaintracksFiatExchangeRatesUrl = ""
+			config.ExchangeratesApiKey = ""
+		})
+
+		// when:
+		rate, err := services.FiatExchangeRate(context.Background(), wdk.GBP, nil)
+
+		// then:
+		require.NoError(t, err)
+		assert.InDelta(t, 0.8, rate, 1e-9)
+	})
+
+	t.Run("doesn't refresh rates again within update interval", func(t *testing.T) {
+		// given:
+		given := testabilities.Given(t)
+		given.Chaintracks().WillRespondWithFiatRates(http.StatusOK, chaintracksRates)
+
+		// and:
+		services := given.Services().WithDefaultConfig()
+		_, err := services.FiatExchangeRate(context.Background(), wdk.GBP, nil)
+		require.NoError(t, err)
+
+		// and:
+		given.Chaintracks().WillRespondWithFiatRates(http.StatusOK, wdk.FiatExchangeRates{
+			Timestamp: time.Now(),
+			Base:      wdk.USD,
+			Rates:     map[wdk.Currency]float64{wdk.GBP: 0.5},
+		})
+
+		// when:
+		rate, err := services.FiatExchangeRate(context.Background(), wdk.GBP, nil)
+
+		// then:
+		require.NoError(t, err)
+		assert.InDelta(t, 0.75, rate, 1e-9)
+	})
+
+	t.Run("returns error for unknown currency", func(t *testing.T) {
+		// given:
+		given := testabilities.Given(t)
+
+		// and:
+		services := given.Services().WithConfig(func(config *configuration.WalletServices) {
+			config.FiatExchangeRates.Timestamp = time.Now()
+		})
+
+		// when:
+		_, err := services.FiatExchangeRate(context.Background(), wdk.Currency("JPY"), nil)
+
+		// then:
+		require.Error(t, err)
+	})
+}
+
+func TestBsvExchangeRateUpdateInterval(t *testing.T) {
+	t.Run("doesn't query WhatsOnChain again within update interval", func(t *testing.T) {
+		// given:
+		given := testabilities.Given(t)
+		given.WhatsOnChain().WillRespondWithRates(http.StatusOK, `{"time": 123456, "rate": 50.5, "currency": "USD"}`, nil)
+
+		// and:
+		services := given.Services().WithDefaultConfig()
+		_, err := services.BsvExchangeRate()
+		require.NoError(t, err)
+
+		// and:
+		given.WhatsOnChain().WillRespondWithRates(http.StatusInternalServerError, "", nil)
+
+		// when:
+		rate, err := services.BsvExchangeRate()
+
+		// then:
+		require.NoError(t, err)
+		assert.InDelta(t, 50.5, rate, 1e-9)
+	})
+}
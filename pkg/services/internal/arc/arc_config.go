@@ -1,5 +1,7 @@
 package arc
 
+import "github.com/4chain-ag/go-wallet-toolbox/pkg/services/configuration"
+
 type Config struct {
 	URL           string
 	Token         string
@@ -7,4 +9,7 @@ type Config struct {
 	WaitFor       string
 	CallbackURL   string
 	CallbackToken string
+	// Tokens are additional tokens used in turns with Token, when the quota of the current token is exhausted
+	Tokens    []string
+	RateLimit configuration.RateLimit
 }
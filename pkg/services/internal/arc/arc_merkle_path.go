@@ -0,0 +1,37 @@
+package arc
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/4chain-ag/go-wallet-toolbox/pkg/services/results"
+	"github.com/bsv-blockchain/go-sdk/transaction"
+	"github.com/go-softwarelab/common/pkg/is"
+)
+
+// MerklePath queries ARC for the transaction and returns its merkle path.
+// Returns nil if the transaction is not known to ARC or is not mined yet.
+func (s *Service) MerklePath(ctx context.Context, txID string) (*results.MerklePath, error) {
+	txInfo, err := s.queryTransaction(ctx, txID)
+	if err != nil {
+		return nil, fmt.Errorf("arc query tx %s failed: %w", txID, err)
+	}
+
+	if !txInfo.Found() || is.BlankString(txInfo.MerklePath) {
+		return nil, nil
+	}
+
+	if txInfo.TxID != txID {
+		return nil, fmt.Errorf("got response for tx %s while querying for %s", txInfo.TxID, txID)
+	}
+
+	merklePath, err := transaction.NewMerklePathFromHex(txInfo.MerklePath)
+	if err != nil {
+		return nil, fmt.Errorf("invalid merkle path returned by arc: %w", err)
+	}
+
+	return &results.MerklePath{
+		MerklePath: merklePath,
+		BlockHash:  txInfo.BlockHash,
+	}, nil
+}
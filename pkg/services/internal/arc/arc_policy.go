@@ -0,0 +1,83 @@
+package arc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"time"
+
+	"github.com/4chain-ag/go-wallet-toolbox/pkg/defs"
+	"github.com/go-softwarelab/common/pkg/to"
+)
+
+// PolicyResponse is the response of ARC policy endpoint
+type PolicyResponse struct {
+	Policy    Policy    `json:"policy"`
+	Timestamp time.Time `json:"timestamp"`
+}
+
+// Policy represents the policy (limits and mining fee) of the ARC broadcaster
+type Policy struct {
+	MaxScriptSizePolicy     uint64    `json:"maxscriptsizepolicy"`
+	MaxTxSigOpsCountsPolicy uint64    `json:"maxtxsigopscountspolicy"`
+	MaxTxSizePolicy         uint64    `json:"maxtxsizepolicy"`
+	MiningFee               MiningFee `json:"miningFee"`
+}
+
+// MiningFee is the fee required by the miners: number of satoshis per number of bytes of transaction
+type MiningFee struct {
+	Satoshis uint64 `json:"satoshis"`
+	Bytes    uint64 `json:"bytes"`
+}
+
+// FeeModel returns the mining fee as the fee model with the exact rate, without rounding it to a whole unit.
+// The whole number of satoshis per byte is returned as sat/byte, any other rate as satoshis per the number of bytes from the policy.
+func (f MiningFee) FeeModel() (defs.FeeModel, error) {
+	if f.Bytes == 0 {
+		return defs.FeeModel{}, fmt.Errorf("invalid mining fee: zero bytes")
+	}
+
+	if f.Satoshis%f.Bytes == 0 {
+		value, err := to.Int64FromUnsigned(f.Satoshis / f.Bytes)
+		if err != nil {
+			return defs.FeeModel{}, fmt.Errorf("invalid mining fee: %w", err)
+		}
+		return defs.FeeModel{Type: defs.SatPerByte, Value: value}, nil
+	}
+
+	value, err := to.Int64FromUnsigned(f.Satoshis)
+	if err != nil {
+		return defs.FeeModel{}, fmt.Errorf("invalid mining fee: %w", err)
+	}
+	return defs.FeeModel{Type: defs.SatPerBytes, Value: value, Bytes: f.Bytes}, nil
+}
+
+// Policy fetches the current policy of ARC
+func (s *Service) Policy(ctx context.Context) (*Policy, error) {
+	result := &PolicyResponse{}
+	arcErr := &APIError{}
+	req := s.httpClient.R().
+		SetContext(ctx).
+		SetResult(result).
+		SetError(arcErr)
+
+	response, err := req.Get(s.policyURL)
+	if err != nil {
+		var netError net.Error
+		if errors.As(err, &netError) {
+			return nil, fmt.Errorf("arc is unreachable: %w", netError)
+		}
+		return nil, fmt.Errorf("failed to send request to arc: %w", err)
+	}
+
+	switch response.StatusCode() {
+	case http.StatusOK:
+		return &result.Policy, nil
+	case http.StatusUnauthorized, http.StatusForbidden:
+		return nil, fmt.Errorf("arc returned unauthorized: %w", arcErr)
+	default:
+		return nil, fmt.Errorf("arc returns unexpected http status [%d %s]: %w", response.StatusCode(), response.Status(), arcErr)
+	}
+}
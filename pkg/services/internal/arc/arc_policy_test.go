@@ -0,0 +1,105 @@
+package arc_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/4chain-ag/go-wallet-toolbox/pkg/defs"
+	"github.com/4chain-ag/go-wallet-toolbox/pkg/services/internal/arc"
+	"github.com/4chain-ag/go-wallet-toolbox/pkg/services/internal/testabilities"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPolicyWithARCService(t *testing.T) {
+	t.Run("fetch policy", func(t *testing.T) {
+		// given:
+		given := testabilities.Given(t)
+
+		// and:
+		given.ARC().WillReturnPolicyWithMiningFee(1, 1000)
+
+		// and:
+		service := given.Services().NewArcService()
+
+		// when:
+		policy, err := service.Policy(context.Background())
+
+		// then:
+		require.NoError(t, err)
+		assert.Equal(t, arc.MiningFee{Satoshis: 1, Bytes: 1000}, policy.MiningFee)
+		assert.Equal(t, uint64(100000000), policy.MaxTxSizePolicy)
+	})
+
+	errorTestCases := map[string]struct {
+		httpStatus int
+	}{
+		"unauthorized": {
+			httpStatus: http.StatusUnauthorized,
+		},
+		"internal server error": {
+			httpStatus: http.StatusInternalServerError,
+		},
+	}
+	for name, test := range errorTestCases {
+		t.Run("return error when arc responds with "+name, func(t *testing.T) {
+			// given:
+			given := testabilities.Given(t)
+
+			// and:
+			given.ARC().WillAlwaysReturnStatus(test.httpStatus)
+
+			// and:
+			service := given.Services().NewArcService()
+
+			// when:
+			_, err := service.Policy(context.Background())
+
+			// then:
+			require.Error(t, err)
+		})
+	}
+}
+
+func TestMiningFeeModel(t *testing.T) {
+	tests := map[string]struct {
+		miningFee arc.MiningFee
+		expected  defs.FeeModel
+	}{
+		"satoshis per kilobyte": {
+			miningFee: arc.MiningFee{Satoshis: 50, Bytes: 1000},
+			expected:  defs.FeeModel{Type: defs.SatPerBytes, Value: 50, Bytes: 1000},
+		},
+		"satoshis per byte": {
+			miningFee: arc.MiningFee{Satoshis: 1, Bytes: 1},
+			expected:  defs.FeeModel{Type: defs.SatPerByte, Value: 1},
+		},
+		"whole number of satoshis per byte": {
+			miningFee: arc.MiningFee{Satoshis: 2000, Bytes: 1000},
+			expected:  defs.FeeModel{Type: defs.SatPerByte, Value: 2},
+		},
+		"fractional rate is not rounded": {
+			miningFee: arc.MiningFee{Satoshis: 1, Bytes: 3000},
+			expected:  defs.FeeModel{Type: defs.SatPerBytes, Value: 1, Bytes: 3000},
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			// when:
+			feeModel, err := test.miningFee.FeeModel()
+
+			// then:
+			require.NoError(t, err)
+			assert.Equal(t, test.expected, feeModel)
+		})
+	}
+}
+
+func TestMiningFeeModelWithZeroBytes(t *testing.T) {
+	// when:
+	_, err := arc.MiningFee{Satoshis: 1}.FeeModel()
+
+	// then:
+	require.Error(t, err)
+}
@@ -20,6 +20,9 @@ import (
 	"github.com/go-softwarelab/common/pkg/types"
 )
 
+// ServiceName is the name of the ARC service
+const ServiceName = "ARC"
+
 // Custom ARC defined http status codes
 const (
 	StatusNotExtendedFormat             = 460
@@ -33,6 +36,7 @@ type Service struct {
 	config           Config
 	broadcastURL     string
 	queryTxURL       string
+	policyURL        string
 	broadcastHeaders httpx.Headers
 }
 
@@ -46,11 +50,10 @@ func NewARCService(logger *slog.Logger, httpClient *resty.Client, config Config)
 		AcceptJSON().
 		ContentTypeJSON().
 		UserAgent().Value("go-wallet-toolbox").
-		Authorization().IfNotEmpty(config.Token).
 		Set("XDeployment-ID").OrDefault(config.DeploymentID, "go-wallet-toolbox#"+time.Now().Format("20060102150405"))
 
-	httpClient = httpClient.Clone().
-		SetHeaders(headers)
+	limiter := httpx.NewLimiter(config.RateLimit.RequestsPerSecond, config.RateLimit.Burst, append([]string{config.Token}, config.Tokens...)...)
+	httpClient = limiter.Apply(httpClient).SetHeaders(headers)
 
 	service := &Service{
 		logger:     logging.Child(logger, "arc"),
@@ -64,6 +67,7 @@ func NewARCService(logger *slog.Logger, httpClient *resty.Client, config Config)
 			Set("X-WaitFor").IfNotEmpty(config.WaitFor),
 
 		queryTxURL: config.URL + "/v1/tx/{txID}",
+		policyURL:  config.URL + "/v1/policy",
 	}
 
 	return service
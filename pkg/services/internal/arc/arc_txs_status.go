@@ -0,0 +1,81 @@
+package arc
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/4chain-ag/go-wallet-toolbox/pkg/services/results"
+	"github.com/go-softwarelab/common/pkg/to"
+)
+
+// MaxConcurrentTxStatusQueries is the maximal number of transactions queried from ARC at once by TxsStatus
+const MaxConcurrentTxStatusQueries = 8
+
+// TxsStatus queries ARC for every transaction and returns their statuses, in the order of txIDs.
+// ARC doesn't provide a bulk status endpoint, so transactions are queried separately (up to MaxConcurrentTxStatusQueries at once).
+// The call fails when any of the queries failed, so the failure is not confused with the transaction unknown to ARC.
+// The depth of mined transactions is not known to ARC, only the block height is returned.
+func (s *Service) TxsStatus(ctx context.Context, txIDs []string) ([]results.TxIDStatus, error) {
+	ctx, cancel := context.WithCancelCause(ctx)
+	defer cancel(nil)
+
+	statuses := make([]results.TxIDStatus, len(txIDs))
+	limit := make(chan struct{}, MaxConcurrentTxStatusQueries)
+	var wg sync.WaitGroup
+	for i, txID := range txIDs {
+		select {
+		case limit <- struct{}{}:
+		case <-ctx.Done():
+		}
+		if ctx.Err() != nil {
+			break
+		}
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer func() { <-limit }()
+
+			status, err := s.txStatus(ctx, txID)
+			if err != nil {
+				cancel(err)
+				return
+			}
+			statuses[i] = status
+		}()
+	}
+	wg.Wait()
+
+	if err := context.Cause(ctx); err != nil {
+		return nil, err
+	}
+	return statuses, nil
+}
+
+func (s *Service) txStatus(ctx context.Context, txID string) (results.TxIDStatus, error) {
+	txInfo, err := s.queryTransaction(ctx, txID)
+	if err != nil {
+		return results.TxIDStatus{}, fmt.Errorf("arc query tx %s failed: %w", txID, err)
+	}
+	return toTxIDStatus(txID, txInfo)
+}
+
+func toTxIDStatus(txID string, txInfo *TXInfo) (results.TxIDStatus, error) {
+	switch {
+	case !txInfo.Found() || txInfo.TXStatus.IsProblematic():
+		return results.TxIDStatus{TxID: txID, Status: results.TxStatusUnknown}, nil
+	case txInfo.TXStatus.IsMined() && txInfo.BlockHeight > 0:
+		height, err := to.UInt32(txInfo.BlockHeight)
+		if err != nil {
+			return results.TxIDStatus{}, fmt.Errorf("invalid block height %d of tx %s: %w", txInfo.BlockHeight, txID, err)
+		}
+		return results.TxIDStatus{
+			TxID:        txID,
+			Status:      results.TxStatusMined,
+			BlockHeight: &height,
+		}, nil
+	default:
+		return results.TxIDStatus{TxID: txID, Status: results.TxStatusKnown}, nil
+	}
+}
@@ -0,0 +1,55 @@
+package bitails
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/4chain-ag/go-wallet-toolbox/pkg/services/internal/tsc"
+	"github.com/4chain-ag/go-wallet-toolbox/pkg/services/results"
+)
+
+// BlockHeightResolver returns the height of the block with given hash
+type BlockHeightResolver func(ctx context.Context, blockHash string) (uint32, error)
+
+// MerklePath fetches the TSC merkle proof of the transaction and converts it to the merkle path.
+// Bitails doesn't return the height of the block, so it is resolved by blockHeight.
+// Returns nil if the proof is not found (e.g. the transaction is not mined yet).
+func (b *Bitails) MerklePath(ctx context.Context, txID string, blockHeight BlockHeightResolver) (*results.MerklePath, error) {
+	var proof tsc.Proof
+	res, err := b.httpClient.
+		R().
+		SetContext(ctx).
+		SetResult(&proof).
+		Get(fmt.Sprintf("%s/tx/%s/proof/tsc", b.url, txID))
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch tsc proof: %w", err)
+	}
+	if res.StatusCode() == http.StatusNotFound {
+		return nil, nil
+	}
+	if res.StatusCode() != http.StatusOK {
+		return nil, fmt.Errorf("failed to retrieve successful response from Bitails. Actual status: %d", res.StatusCode())
+	}
+	if proof.TxOrID == "" {
+		return nil, nil
+	}
+	if proof.TxOrID != txID {
+		return nil, fmt.Errorf("got tsc proof for tx %s while querying for %s", proof.TxOrID, txID)
+	}
+
+	height, err := blockHeight(ctx, proof.Target)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get height of block %s of the tsc proof: %w", proof.Target, err)
+	}
+
+	merklePath, err := proof.ToMerklePath(height)
+	if err != nil {
+		return nil, err
+	}
+
+	return &results.MerklePath{
+		MerklePath: merklePath,
+		BlockHash:  proof.Target,
+	}, nil
+}
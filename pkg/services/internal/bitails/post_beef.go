@@ -0,0 +1,123 @@
+package bitails
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/4chain-ag/go-wallet-toolbox/pkg/services/results"
+	"github.com/bsv-blockchain/go-sdk/transaction"
+)
+
+// Error codes returned by Bitails (the node) when broadcasting a transaction
+const (
+	// errorCodeMissingInputs is returned when the inputs are already spent or unknown
+	errorCodeMissingInputs = -25
+	// errorCodeAlreadyInChain is returned when the transaction is already known
+	errorCodeAlreadyInChain = -27
+)
+
+type broadcastRequestBody struct {
+	Raws []string `json:"raws"`
+}
+
+type broadcastError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+type broadcastResult struct {
+	TxID  string          `json:"txid"`
+	Error *broadcastError `json:"error,omitempty"`
+}
+
+// PostBeef broadcasts the transactions with given txIDs from the beef as raw transactions in a single request.
+// The transactions should be ordered parents first.
+func (b *Bitails) PostBeef(ctx context.Context, beef *transaction.Beef, txIDs []string) (*results.PostBEEF, error) {
+	if beef == nil {
+		return nil, fmt.Errorf("cannot broadcast nil beef")
+	}
+	if len(txIDs) == 0 {
+		return nil, fmt.Errorf("txIDs to broadcast are required")
+	}
+
+	raws := make([]string, 0, len(txIDs))
+	for _, txID := range txIDs {
+		tx := beef.FindTransaction(txID)
+		if tx == nil {
+			return nil, fmt.Errorf("transaction %s not found in beef", txID)
+		}
+		raws = append(raws, tx.Hex())
+	}
+
+	return b.PostRaws(ctx, raws, txIDs)
+}
+
+// PostRaws broadcasts the raw transactions given in hex, txIDs must be in the same order as the raw transactions.
+func (b *Bitails) PostRaws(ctx context.Context, raws []string, txIDs []string) (*results.PostBEEF, error) {
+	if len(raws) != len(txIDs) {
+		return nil, fmt.Errorf("got %d raw transactions for %d txIDs", len(raws), len(txIDs))
+	}
+
+	var broadcasted []broadcastResult
+	res, err := b.httpClient.
+		R().
+		SetContext(ctx).
+		SetHeader("Content-Type", "application/json").
+		SetBody(broadcastRequestBody{Raws: raws}).
+		SetResult(&broadcasted).
+		Post(fmt.Sprintf("%s/tx/broadcast/multi", b.url))
+	if err != nil {
+		return nil, fmt.Errorf("failed to broadcast raw transactions: %w", err)
+	}
+	if res.StatusCode() != http.StatusOK && res.StatusCode() != http.StatusCreated {
+		return nil, fmt.Errorf("failed to broadcast raw transactions to Bitails. Actual status: %d, response: %s", res.StatusCode(), res.String())
+	}
+
+	byTxID := make(map[string]broadcastResult, len(broadcasted))
+	for _, result := range broadcasted {
+		byTxID[result.TxID] = result
+	}
+
+	txIDResults := make([]results.PostTxID, 0, len(txIDs))
+	for _, txID := range txIDs {
+		result, ok := byTxID[txID]
+		if !ok {
+			txIDResults = append(txIDResults, results.PostTxID{
+				Result: results.ResultStatusError,
+				TxID:   txID,
+				Error:  fmt.Errorf("missing broadcast result of transaction %s", txID),
+			})
+			continue
+		}
+		txIDResults = append(txIDResults, result.toPostTxID())
+	}
+
+	return &results.PostBEEF{
+		TxIDResults: txIDResults,
+	}, nil
+}
+
+func (r broadcastResult) toPostTxID() results.PostTxID {
+	result := results.PostTxID{
+		Result: results.ResultStatusSuccess,
+		TxID:   r.TxID,
+	}
+	if r.Error == nil {
+		return result
+	}
+
+	result.Data = r.Error.Message
+	switch r.Error.Code {
+	case errorCodeAlreadyInChain:
+		result.AlreadyKnown = true
+	case errorCodeMissingInputs:
+		// the node doesn't tell if the inputs are spent or just unknown, so it's not reported as a double spend
+		result.Result = results.ResultStatusError
+		result.Error = fmt.Errorf("transaction %s has missing inputs: %s", r.TxID, r.Error.Message)
+	default:
+		result.Result = results.ResultStatusError
+		result.Error = fmt.Errorf("bitails rejected transaction %s with code %d: %s", r.TxID, r.Error.Code, r.Error.Message)
+	}
+	return result
+}
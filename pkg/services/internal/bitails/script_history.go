@@ -0,0 +1,81 @@
+package bitails
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/4chain-ag/go-wallet-toolbox/pkg/services/results"
+)
+
+// scriptHistoryLimit is the maximal page size of the script history accepted by Bitails
+const scriptHistoryLimit = 5000
+
+// scriptHistoryItem is a transaction touching a script returned by Bitails
+type scriptHistoryItem struct {
+	TxID        string `json:"txid"`
+	BlockHeight int64  `json:"blockheight"`
+}
+
+type scriptHistoryResponse struct {
+	ScriptHash string              `json:"scripthash"`
+	History    []scriptHistoryItem `json:"history"`
+	PgKey      string              `json:"pgkey"`
+}
+
+// ScriptHistory returns confirmed and unconfirmed transactions touching the scripts with given script hashes,
+// in the order of scriptHashes. Unconfirmed transactions are returned with zero height.
+// The script hashes are sha256 of the locking scripts in big-endian (reversed) byte order,
+// Bitails has no bulk endpoint, so they are queried one by one.
+func (b *Bitails) ScriptHistory(ctx context.Context, scriptHashes []string) ([]results.ScriptHistory, error) {
+	histories := make([]results.ScriptHistory, 0, len(scriptHashes))
+	for _, scriptHash := range scriptHashes {
+		items, err := b.scriptHistory(ctx, scriptHash)
+		if err != nil {
+			return nil, err
+		}
+		histories = append(histories, results.ScriptHistory{ScriptHash: scriptHash, Items: items})
+	}
+	return histories, nil
+}
+
+// scriptHistory fetches all the pages of the history of the script
+func (b *Bitails) scriptHistory(ctx context.Context, scriptHash string) ([]results.ScriptHistoryItem, error) {
+	var history []results.ScriptHistoryItem
+	pgKey := ""
+	for {
+		var response scriptHistoryResponse
+
+		req := b.httpClient.
+			R().
+			SetContext(ctx).
+			SetResult(&response).
+			SetQueryParam("limit", strconv.Itoa(scriptHistoryLimit))
+		if pgKey != "" {
+			req.SetQueryParam("pgkey", pgKey)
+		}
+
+		res, err := req.Get(fmt.Sprintf("%s/scripthash/%s/history", b.url, scriptHash))
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch script history: %w", err)
+		}
+		if res.StatusCode() == http.StatusNotFound {
+			return history, nil
+		}
+		if res.StatusCode() != http.StatusOK {
+			return nil, fmt.Errorf("failed to retrieve successful response from Bitails. Actual status: %d", res.StatusCode())
+		}
+
+		for _, item := range response.History {
+			history = append(history, results.ScriptHistoryItem{
+				TxHash: item.TxID,
+				Height: item.BlockHeight,
+			})
+		}
+		if response.PgKey == "" || len(response.History) == 0 {
+			return history, nil
+		}
+		pgKey = response.PgKey
+	}
+}
@@ -0,0 +1,90 @@
+package bitails
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strings"
+
+	"github.com/4chain-ag/go-wallet-toolbox/pkg/defs"
+	"github.com/4chain-ag/go-wallet-toolbox/pkg/internal/logging"
+	"github.com/4chain-ag/go-wallet-toolbox/pkg/internal/txutils"
+	"github.com/4chain-ag/go-wallet-toolbox/pkg/services/internal/httpx"
+	"github.com/4chain-ag/go-wallet-toolbox/pkg/wdk"
+	"github.com/go-resty/resty/v2"
+)
+
+// ServiceName is the name of the Bitails service
+const ServiceName = "Bitails"
+
+// Base URLs of Bitails API
+const (
+	MainnetURL = "https://api.bitails.io"
+	TestnetURL = "https://test-api.bitails.io"
+)
+
+// Bitails is the client of Bitails API
+type Bitails struct {
+	httpClient *resty.Client
+	url        string
+	logger     *slog.Logger
+}
+
+// New creates a new Bitails client for given network, the API key is optional.
+// The empty url means the public API of the network.
+func New(httpClient *resty.Client, logger *slog.Logger, network defs.BSVNetwork, url, apiKey string) *Bitails {
+	if httpClient == nil {
+		panic("httpClient is required")
+	}
+
+	headers := httpx.NewHeaders().
+		AcceptJSON().
+		UserAgent().Value("go-wallet-toolbox")
+
+	switch {
+	case url != "":
+		url = strings.TrimSuffix(url, "/")
+	case network.IsMainnet():
+		url = MainnetURL
+	default:
+		url = TestnetURL
+	}
+
+	return &Bitails{
+		httpClient: httpx.NewLimiter(0, 0, apiKey).Apply(httpClient).SetHeaders(headers),
+		url:        url,
+		logger:     logging.Child(logger, "bitails").With(slog.String("network", string(network))),
+	}
+}
+
+// RawTx downloads the raw transaction with given txID.
+// Returns nil if the transaction is not found.
+func (b *Bitails) RawTx(ctx context.Context, txID string) (*wdk.RawTxResult, error) {
+	res, err := b.httpClient.
+		R().
+		SetContext(ctx).
+		SetHeader("Accept", "application/octet-stream").
+		Get(fmt.Sprintf("%s/download/tx/%s", b.url, txID))
+	if err != nil {
+		return nil, fmt.Errorf("failed to download raw tx: %w", err)
+	}
+	if res.StatusCode() == http.StatusNotFound {
+		return nil, nil
+	}
+	if res.StatusCode() != http.StatusOK {
+		return nil, fmt.Errorf("failed to retrieve successful response from Bitails. Actual status: %d", res.StatusCode())
+	}
+
+	rawTx := res.Body()
+	txIDFromRawTx := txutils.TransactionIDFromRawTx(rawTx)
+	if txID != txIDFromRawTx {
+		return nil, fmt.Errorf("computed txid %s doesn't match requested value %s", txIDFromRawTx, txID)
+	}
+
+	return &wdk.RawTxResult{
+		Name:  ServiceName,
+		TxID:  txID,
+		RawTx: rawTx,
+	}, nil
+}
@@ -0,0 +1,54 @@
+package bitails
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/4chain-ag/go-wallet-toolbox/pkg/services/results"
+)
+
+// scriptUnspent is an unspent output of a script returned by Bitails
+type scriptUnspent struct {
+	TxID        string `json:"txid"`
+	Vout        int64  `json:"vout"`
+	Satoshis    uint64 `json:"satoshis"`
+	BlockHeight int64  `json:"blockheight"`
+}
+
+type scriptUnspentResponse struct {
+	ScriptHash string          `json:"scripthash"`
+	Unspent    []scriptUnspent `json:"unspent"`
+}
+
+// ScriptUnspent returns unspent outputs of the script with given script hash.
+// The script hash is sha256 of the locking script in big-endian (reversed) byte order.
+func (b *Bitails) ScriptUnspent(ctx context.Context, scriptHash string) ([]results.ScriptUnspent, error) {
+	var response scriptUnspentResponse
+
+	res, err := b.httpClient.
+		R().
+		SetContext(ctx).
+		SetResult(&response).
+		Get(fmt.Sprintf("%s/scripthash/%s/unspent", b.url, scriptHash))
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch script unspent outputs: %w", err)
+	}
+	if res.StatusCode() == http.StatusNotFound {
+		return []results.ScriptUnspent{}, nil
+	}
+	if res.StatusCode() != http.StatusOK {
+		return nil, fmt.Errorf("failed to retrieve successful response from Bitails. Actual status: %d", res.StatusCode())
+	}
+
+	unspent := make([]results.ScriptUnspent, 0, len(response.Unspent))
+	for _, utxo := range response.Unspent {
+		unspent = append(unspent, results.ScriptUnspent{
+			Height: utxo.BlockHeight,
+			TxPos:  utxo.Vout,
+			TxHash: utxo.TxID,
+			Value:  utxo.Satoshis,
+		})
+	}
+	return unspent, nil
+}
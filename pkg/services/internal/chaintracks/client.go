@@ -0,0 +1,163 @@
+package chaintracks
+
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net"
+	"net/http"
+	"strconv"
+
+	"github.com/4chain-ag/go-wallet-toolbox/pkg/internal/logging"
+	"github.com/4chain-ag/go-wallet-toolbox/pkg/services/internal/httpx"
+	"github.com/bsv-blockchain/go-sdk/chainhash"
+	"github.com/go-resty/resty/v2"
+)
+
+// ServiceName is the name of the Chaintracks service
+const ServiceName = "Chaintracks"
+
+const statusSuccess = "success"
+
+// BlockHeader is the block header returned by Chaintracks
+type BlockHeader struct {
+	Version      uint32 `json:"version"`
+	PreviousHash string `json:"previousHash"`
+	MerkleRoot   string `json:"merkleRoot"`
+	Time         uint32 `json:"time"`
+	Bits         uint32 `json:"bits"`
+	Nonce        uint32 `json:"nonce"`
+	Height       uint32 `json:"height"`
+	Hash         string `json:"hash"`
+}
+
+// response is the envelope of every Chaintracks response
+type response[T any] struct {
+	Status      string `json:"status"`
+	Value       *T     `json:"value"`
+	Code        string `json:"code"`
+	Description string `json:"description"`
+}
+
+// Client is the client of the Chaintracks headers service.
+// It implements go-sdk chaintracker.ChainTracker.
+type Client struct {
+	logger     *slog.Logger
+	httpClient *resty.Client
+	url        string
+}
+
+// NewClient creates the client of the Chaintracks service with given base URL.
+func NewClient(logger *slog.Logger, httpClient *resty.Client, url string) *Client {
+	if httpClient == nil {
+		httpClient = resty.New()
+	}
+
+	headers := httpx.NewHeaders().
+		AcceptJSON().
+		UserAgent().Value("go-wallet-toolbox")
+
+	return &Client{
+		logger:     logging.Child(logger, "chaintracks"),
+		httpClient: httpClient.Clone().SetHeaders(headers),
+		url:        url,
+	}
+}
+
+// CurrentHeight returns the height of the tip of the active chain.
+func (c *Client) CurrentHeight(ctx context.Context) (uint32, error) {
+	height, err := get[uint32](ctx, c, "getPresentHeight", nil)
+	if err != nil {
+		return 0, err
+	}
+	if height == nil {
+		return 0, fmt.Errorf("chaintracks returned no height")
+	}
+	return *height, nil
+}
+
+// HeaderForHeight returns the header of the active chain at given height, or nil if not found.
+func (c *Client) HeaderForHeight(ctx context.Context, height uint32) (*BlockHeader, error) {
+	header, err := get[BlockHeader](ctx, c, "findHeaderHexForHeight", map[string]string{
+		"height": strconv.FormatUint(uint64(height), 10),
+	})
+	if err != nil {
+		return nil, err
+	}
+	if header != nil && header.Height != height {
+		return nil, fmt.Errorf("got header at height %d while querying for %d", header.Height, height)
+	}
+	return header, nil
+}
+
+// HeaderForHash returns the header with given hash, or nil if not found.
+func (c *Client) HeaderForHash(ctx context.Context, hash string) (*BlockHeader, error) {
+	header, err := get[BlockHeader](ctx, c, "findHeaderHexForBlockHash", map[string]string{
+		"hash": hash,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if header != nil && header.Hash != hash {
+		return nil, fmt.Errorf("got header %s while querying for %s", header.Hash, hash)
+	}
+	return header, nil
+}
+
+// Headers returns the concatenated 80 bytes serialized headers of the active chain starting at given height,
+// there are less than count headers when the tip of the chain is reached.
+func (c *Client) Headers(ctx context.Context, height, count uint32) ([]byte, error) {
+	data, err := get[string](ctx, c, "getHeaders", map[string]string{
+		"height": strconv.FormatUint(uint64(height), 10),
+		"count":  strconv.FormatUint(uint64(count), 10),
+	})
+	if err != nil {
+		return nil, err
+	}
+	if data == nil {
+		return nil, nil
+	}
+
+	headers, err := hex.DecodeString(*data)
+	if err != nil {
+		return nil, fmt.Errorf("chaintracks returned invalid headers hex: %w", err)
+	}
+	return headers, nil
+}
+
+// IsValidRootForHeight checks if the merkle root is the one of the header of the active chain at given height.
+func (c *Client) IsValidRootForHeight(root *chainhash.Hash, height uint32) (bool, error) {
+	header, err := c.HeaderForHeight(context.Background(), height)
+	if err != nil {
+		return false, err
+	}
+	if header == nil {
+		return false, nil
+	}
+	return header.MerkleRoot == root.String(), nil
+}
+
+func get[T any](ctx context.Context, c *Client, method string, query map[string]string) (*T, error) {
+	result := &response[T]{}
+	res, err := c.httpClient.
+		R().
+		SetContext(ctx).
+		SetQueryParams(query).
+		SetResult(result).
+		SetError(result).
+		Get(fmt.Sprintf("%s/%s", c.url, method))
+	if err != nil {
+		var netError net.Error
+		if errors.As(err, &netError) {
+			return nil, fmt.Errorf("chaintracks is unreachable: %w", netError)
+		}
+		return nil, fmt.Errorf("failed to send %s request to chaintracks: %w", method, err)
+	}
+
+	if res.StatusCode() != http.StatusOK || result.Status != statusSuccess {
+		return nil, fmt.Errorf("chaintracks %s failed with status %d: %s %s", method, res.StatusCode(), result.Code, result.Description)
+	}
+	return result.Value, nil
+}
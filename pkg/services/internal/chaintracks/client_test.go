@@ -0,0 +1,156 @@
+package chaintracks_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/4chain-ag/go-wallet-toolbox/pkg/services/internal/chaintracks"
+	"github.com/4chain-ag/go-wallet-toolbox/pkg/services/internal/testabilities"
+	"github.com/bsv-blockchain/go-sdk/chainhash"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var block1 = chaintracks.BlockHeader{
+	Version:      1,
+	PreviousHash: "000000000019d6689c085ae165831e934ff763ae46a2a6c172b3f1b60a8ce26f",
+	MerkleRoot:   "0e3e2357e806b6cdb1f70b54c3a3a17b6714ee1f0e68bebb44a74b1efd512098",
+	Time:         1231469665,
+	Bits:         0x1d00ffff,
+	Nonce:        2573394689,
+	Height:       1,
+	Hash:         "00000000839a8e6886ab5951d76f411475428afc90947ee320161bbf18eb6048",
+}
+
+const (
+	block0Raw = "0100000000000000000000000000000000000000000000000000000000000000000000003ba3edfd7a7b12b27ac72c3e67768f617fc81bc3888a51323a9fb8aa4b1e5e4a29ab5f49ffff001d1dac2b7c"
+	block1Raw = "010000006fe28c0ab6f1b372c1a6a246ae63f74f931e8365e15a089c68d6190000000000982051fd1e4ba744bbbe680e1fee14677ba1a3c3540bf7b1cdb606e857233e0e61bc6649ffff001d01e36299"
+)
+
+func TestChaintracksClient(t *testing.T) {
+	t.Run("returns current height", func(t *testing.T) {
+		// given:
+		given := testabilities.Given(t)
+		given.Chaintracks().WillRespondWithHeight(881_234)
+
+		// and:
+		client := given.Services().NewChaintracksClient()
+
+		// when:
+		height, err := client.CurrentHeight(context.Background())
+
+		// then:
+		require.NoError(t, err)
+		assert.Equal(t, uint32(881_234), height)
+	})
+
+	t.Run("returns header for height", func(t *testing.T) {
+		// given:
+		given := testabilities.Given(t)
+		given.Chaintracks().IsUpAndRunning()
+		given.Chaintracks().WillRespondWithHeader(block1)
+
+		// and:
+		client := given.Services().NewChaintracksClient()
+
+		// when:
+		header, err := client.HeaderForHeight(context.Background(), 1)
+
+		// then:
+		require.NoError(t, err)
+		assert.Equal(t, &block1, header)
+	})
+
+	t.Run("returns header for hash", func(t *testing.T) {
+		// given:
+		given := testabilities.Given(t)
+		given.Chaintracks().IsUpAndRunning()
+		given.Chaintracks().WillRespondWithHeader(block1)
+
+		// and:
+		client := given.Services().NewChaintracksClient()
+
+		// when:
+		header, err := client.HeaderForHash(context.Background(), block1.Hash)
+
+		// then:
+		require.NoError(t, err)
+		assert.Equal(t, &block1, header)
+	})
+
+	t.Run("returns serialized headers", func(t *testing.T) {
+		// given:
+		given := testabilities.Given(t)
+		given.Chaintracks().WillServeHeaders(block0Raw, block1Raw)
+
+		// and:
+		client := given.Services().NewChaintracksClient()
+
+		// when:
+		headers, err := client.Headers(context.Background(), 0, 3)
+
+		// then:
+		require.NoError(t, err)
+		require.Len(t, headers, 160)
+		assert.Equal(t, block1.Hash, chainhash.DoubleHashH(headers[80:]).String())
+	})
+
+	t.Run("returns nil for unknown header", func(t *testing.T) {
+		// given:
+		given := testabilities.Given(t)
+		given.Chaintracks().IsUpAndRunning()
+
+		// and:
+		client := given.Services().NewChaintracksClient()
+
+		// when:
+		header, err := client.HeaderForHeight(context.Background(), 2)
+
+		// then:
+		require.NoError(t, err)
+		assert.Nil(t, header)
+	})
+
+	t.Run("validates merkle root for height", func(t *testing.T) {
+		// given:
+		given := testabilities.Given(t)
+		given.Chaintracks().IsUpAndRunning()
+		given.Chaintracks().WillRespondWithHeader(block1)
+
+		// and:
+		client := given.Services().NewChaintracksClient()
+
+		// and:
+		root, err := chainhash.NewHashFromHex(block1.MerkleRoot)
+		require.NoError(t, err)
+
+		// when:
+		valid, err := client.IsValidRootForHeight(root, 1)
+
+		// then:
+		require.NoError(t, err)
+		assert.True(t, valid)
+
+		// when:
+		valid, err = client.IsValidRootForHeight(root, 2)
+
+		// then:
+		require.NoError(t, err)
+		assert.False(t, valid)
+	})
+
+	t.Run("returns error when service fails", func(t *testing.T) {
+		// given:
+		given := testabilities.Given(t)
+		given.Chaintracks().WillAlwaysFail()
+
+		// and:
+		client := given.Services().NewChaintracksClient()
+
+		// when:
+		_, err := client.CurrentHeight(context.Background())
+
+		// then:
+		require.Error(t, err)
+	})
+}
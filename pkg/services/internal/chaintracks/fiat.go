@@ -0,0 +1,53 @@
+package chaintracks
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"time"
+
+	"github.com/4chain-ag/go-wallet-toolbox/pkg/wdk"
+	"github.com/go-resty/resty/v2"
+)
+
+// FiatServiceName is the name of the Chaintracks fiat exchange rates service
+const FiatServiceName = "ChaintracksFiat"
+
+type fiatExchangeRates struct {
+	Timestamp time.Time                `json:"timestamp"`
+	Base      wdk.Currency             `json:"base"`
+	Rates     map[wdk.Currency]float64 `json:"rates"`
+}
+
+// FiatExchangeRates fetches the fiat exchange rates from the Chaintracks endpoint with given URL.
+func FiatExchangeRates(ctx context.Context, httpClient *resty.Client, url string) (*wdk.FiatExchangeRates, error) {
+	result := &response[fiatExchangeRates]{}
+	res, err := httpClient.
+		R().
+		SetContext(ctx).
+		SetResult(result).
+		SetError(result).
+		Get(url)
+	if err != nil {
+		var netError net.Error
+		if errors.As(err, &netError) {
+			return nil, fmt.Errorf("chaintracks is unreachable: %w", netError)
+		}
+		return nil, fmt.Errorf("failed to send fiat exchange rates request to chaintracks: %w", err)
+	}
+
+	if res.StatusCode() != http.StatusOK || result.Status != statusSuccess {
+		return nil, fmt.Errorf("chaintracks fiat exchange rates failed with status %d: %s %s", res.StatusCode(), result.Code, result.Description)
+	}
+	if result.Value == nil || len(result.Value.Rates) == 0 {
+		return nil, fmt.Errorf("chaintracks returned no fiat exchange rates")
+	}
+
+	return &wdk.FiatExchangeRates{
+		Timestamp: result.Value.Timestamp,
+		Base:      result.Value.Base,
+		Rates:     result.Value.Rates,
+	}, nil
+}
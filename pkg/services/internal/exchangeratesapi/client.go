@@ -0,0 +1,93 @@
+package exchangeratesapi
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/4chain-ag/go-wallet-toolbox/pkg/internal/logging"
+	"github.com/4chain-ag/go-wallet-toolbox/pkg/services/internal/httpx"
+	"github.com/4chain-ag/go-wallet-toolbox/pkg/wdk"
+	"github.com/go-resty/resty/v2"
+)
+
+// ServiceName is the name of the exchangeratesapi service
+const ServiceName = "exchangeratesapi"
+
+// URL is the endpoint of the latest rates of exchangeratesapi
+const URL = "https://api.exchangeratesapi.io/v1/latest"
+
+type latestRatesResponse struct {
+	Success   bool                     `json:"success"`
+	Timestamp int64                    `json:"timestamp"`
+	Base      wdk.Currency             `json:"base"`
+	Rates     map[wdk.Currency]float64 `json:"rates"`
+	Error     *struct {
+		Code int    `json:"code"`
+		Info string `json:"info"`
+	} `json:"error"`
+}
+
+// Client is the client of exchangeratesapi.io
+type Client struct {
+	logger     *slog.Logger
+	httpClient *resty.Client
+	apiKey     string
+}
+
+// NewClient creates the client of exchangeratesapi.io with given access key.
+func NewClient(logger *slog.Logger, httpClient *resty.Client, apiKey string) *Client {
+	if httpClient == nil {
+		httpClient = resty.New()
+	}
+
+	headers := httpx.NewHeaders().
+		AcceptJSON().
+		UserAgent().Value("go-wallet-toolbox")
+
+	return &Client{
+		logger:     logging.Child(logger, ServiceName),
+		httpClient: httpClient.Clone().SetHeaders(headers),
+		apiKey:     apiKey,
+	}
+}
+
+// FiatExchangeRates fetches the latest rates and converts them to be relative to USD.
+func (c *Client) FiatExchangeRates(ctx context.Context) (*wdk.FiatExchangeRates, error) {
+	var response latestRatesResponse
+	res, err := c.httpClient.
+		R().
+		SetContext(ctx).
+		SetQueryParam("access_key", c.apiKey).
+		SetResult(&response).
+		SetError(&response).
+		Get(URL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch exchange rates: %w", err)
+	}
+	if res.StatusCode() != http.StatusOK || !response.Success {
+		if response.Error != nil {
+			return nil, fmt.Errorf("exchangeratesapi returned error %d: %s", response.Error.Code, response.Error.Info)
+		}
+		return nil, fmt.Errorf("failed to retrieve successful response from exchangeratesapi. Actual status: %d", res.StatusCode())
+	}
+
+	usdPerBase, ok := response.Rates[wdk.USD]
+	if !ok || usdPerBase == 0 {
+		return nil, fmt.Errorf("exchangeratesapi returned no USD rate")
+	}
+
+	rates := make(map[wdk.Currency]float64, len(response.Rates)+1)
+	for currency, rate := range response.Rates {
+		rates[currency] = rate / usdPerBase
+	}
+	rates[response.Base] = 1 / usdPerBase
+
+	return &wdk.FiatExchangeRates{
+		Timestamp: time.Unix(response.Timestamp, 0),
+		Base:      wdk.USD,
+		Rates:     rates,
+	}, nil
+}
@@ -0,0 +1,80 @@
+package headers
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math/big"
+	"slices"
+
+	"github.com/4chain-ag/go-wallet-toolbox/pkg/internal/pow"
+	"github.com/bsv-blockchain/go-sdk/chainhash"
+)
+
+// Size is the length of the serialized block header
+const Size = 80
+
+// Header is the block header in the form of its 80 bytes serialization.
+type Header struct {
+	Version    int32
+	PrevHash   chainhash.Hash
+	MerkleRoot chainhash.Hash
+	Time       uint32
+	Bits       uint32
+	Nonce      uint32
+}
+
+// ParseHeader parses the 80 bytes serialized block header.
+func ParseHeader(data []byte) (*Header, error) {
+	if len(data) != Size {
+		return nil, fmt.Errorf("block header must be %d bytes long, got %d", Size, len(data))
+	}
+
+	header := &Header{
+		Version: int32(binary.LittleEndian.Uint32(data[0:4])), //nolint:gosec // version is serialized as signed int32
+		Time:    binary.LittleEndian.Uint32(data[68:72]),
+		Bits:    binary.LittleEndian.Uint32(data[72:76]),
+		Nonce:   binary.LittleEndian.Uint32(data[76:80]),
+	}
+	copy(header.PrevHash[:], data[4:36])
+	copy(header.MerkleRoot[:], data[36:68])
+
+	return header, nil
+}
+
+// Bytes returns the 80 bytes serialization of the header.
+func (h *Header) Bytes() []byte {
+	data := make([]byte, Size)
+	binary.LittleEndian.PutUint32(data[0:4], uint32(h.Version)) //nolint:gosec // version is serialized as signed int32
+	copy(data[4:36], h.PrevHash[:])
+	copy(data[36:68], h.MerkleRoot[:])
+	binary.LittleEndian.PutUint32(data[68:72], h.Time)
+	binary.LittleEndian.PutUint32(data[72:76], h.Bits)
+	binary.LittleEndian.PutUint32(data[76:80], h.Nonce)
+	return data
+}
+
+// Hash returns the double sha256 hash of the serialized header.
+func (h *Header) Hash() chainhash.Hash {
+	return chainhash.DoubleHashH(h.Bytes())
+}
+
+// ValidateProofOfWork checks if the target encoded in bits is not above the pow limit of the network
+// and the hash of the header is not above the target.
+func (h *Header) ValidateProofOfWork(params *ChainParams) error {
+	target, err := pow.TargetFromBits(h.Bits)
+	if err != nil {
+		return err
+	}
+	if target.Cmp(params.powLimit()) > 0 {
+		return fmt.Errorf("target of bits %08x of block header is above the pow limit %08x", h.Bits, params.PowLimitBits)
+	}
+
+	hash := h.Hash()
+	hashBE := slices.Clone(hash[:])
+	slices.Reverse(hashBE)
+
+	if new(big.Int).SetBytes(hashBE).Cmp(target) > 0 {
+		return fmt.Errorf("hash %s of block header is above the target of bits %08x", hash, h.Bits)
+	}
+	return nil
+}
@@ -0,0 +1,178 @@
+package headers
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/4chain-ag/go-wallet-toolbox/pkg/internal/pow"
+)
+
+// Constants of the difficulty adjustment algorithm (DAA) activated in November 2017
+const (
+	targetSpacing        = 600
+	daaWindow            = 144
+	minDifficultySpacing = 2 * targetSpacing
+)
+
+// ChainParams are the consensus rules of the network used to validate the proof of work of the headers.
+type ChainParams struct {
+	// PowLimitBits is the compact form of the highest proof of work target allowed by the network.
+	PowLimitBits uint32
+	// DAAHeight is the activation height of the difficulty adjustment algorithm,
+	// the bits of the headers whose previous header is below it are checked only against PowLimitBits.
+	DAAHeight uint32
+	// NoRetargeting means the difficulty never changes, every header has the bits of the previous one.
+	NoRetargeting bool
+	// AllowMinDifficultyBlocks allows the PowLimitBits target for the block mined 20 minutes after the previous one.
+	AllowMinDifficultyBlocks bool
+}
+
+// Chain parameters of the known networks
+var (
+	MainNetParams = ChainParams{
+		PowLimitBits: 0x1d00ffff,
+		DAAHeight:    504031,
+	}
+	TestNetParams = ChainParams{
+		PowLimitBits:             0x1d00ffff,
+		DAAHeight:                1188697,
+		AllowMinDifficultyBlocks: true,
+	}
+	RegTestParams = ChainParams{
+		PowLimitBits:             0x207fffff,
+		NoRetargeting:            true,
+		AllowMinDifficultyBlocks: true,
+	}
+)
+
+func (p *ChainParams) powLimit() *big.Int {
+	limit, err := pow.TargetFromBits(p.PowLimitBits)
+	if err != nil {
+		panic(fmt.Sprintf("invalid pow limit of chain params: %v", err))
+	}
+	return limit
+}
+
+// expectedBits returns the bits required for the header at given height by the difficulty adjustment rules.
+// The ancestor function returns the header at given height below the validated one.
+// It returns false when the difficulty cannot be computed, because the ancestors are not known
+// or the height is below the DAA activation.
+func (p *ChainParams) expectedBits(height uint32, header *Header, ancestor func(height uint32) (*Header, bool)) (uint32, bool) {
+	if height == 0 {
+		return 0, false
+	}
+	prevHeight := height - 1
+	prev, ok := ancestor(prevHeight)
+	if !ok {
+		return 0, false
+	}
+
+	if p.NoRetargeting {
+		return prev.Bits, true
+	}
+	if prevHeight < p.DAAHeight || prevHeight < daaWindow+2 {
+		return 0, false
+	}
+	if p.AllowMinDifficultyBlocks && int64(header.Time) > int64(prev.Time)+minDifficultySpacing {
+		return p.PowLimitBits, true
+	}
+
+	first, ok := suitableBlock(prevHeight-daaWindow, ancestor)
+	if !ok {
+		return 0, false
+	}
+	last, ok := suitableBlock(prevHeight, ancestor)
+	if !ok {
+		return 0, false
+	}
+
+	work := new(big.Int)
+	for h := first.height + 1; h <= last.height; h++ {
+		header, ok := ancestor(h)
+		if !ok {
+			return 0, false
+		}
+		blockWork, err := workFromBits(header.Bits)
+		if err != nil {
+			return 0, false
+		}
+		work.Add(work, blockWork)
+	}
+
+	timespan := int64(last.header.Time) - int64(first.header.Time)
+	timespan = max(min(timespan, 288*targetSpacing), 72*targetSpacing)
+
+	// the next target is (2^256 - W) / W, where W is the work done per target spacing in the window
+	work.Mul(work, big.NewInt(targetSpacing))
+	work.Quo(work, big.NewInt(timespan))
+	if work.Sign() == 0 {
+		return p.PowLimitBits, true
+	}
+	target := new(big.Int).Lsh(big.NewInt(1), 256)
+	target.Sub(target, work)
+	target.Quo(target, work)
+
+	if target.Cmp(p.powLimit()) > 0 {
+		return p.PowLimitBits, true
+	}
+	return bitsFromTarget(target), true
+}
+
+type indexedHeader struct {
+	height uint32
+	header *Header
+}
+
+// suitableBlock returns the block with the median time of the block at given height and its two predecessors.
+func suitableBlock(height uint32, ancestor func(height uint32) (*Header, bool)) (indexedHeader, bool) {
+	var blocks [3]indexedHeader
+	for i := range blocks {
+		h := height - 2 + uint32(i) //nolint:gosec // i is lower than 3
+		header, ok := ancestor(h)
+		if !ok {
+			return indexedHeader{}, false
+		}
+		blocks[i] = indexedHeader{height: h, header: header}
+	}
+
+	// the same sorting network as in the node, so the equal times resolve to the same block
+	if blocks[0].header.Time > blocks[2].header.Time {
+		blocks[0], blocks[2] = blocks[2], blocks[0]
+	}
+	if blocks[0].header.Time > blocks[1].header.Time {
+		blocks[0], blocks[1] = blocks[1], blocks[0]
+	}
+	if blocks[1].header.Time > blocks[2].header.Time {
+		blocks[1], blocks[2] = blocks[2], blocks[1]
+	}
+	return blocks[1], true
+}
+
+// workFromBits returns the expected number of hashes needed to mine a block with given bits: 2^256 / (target + 1).
+func workFromBits(bits uint32) (*big.Int, error) {
+	target, err := pow.TargetFromBits(bits)
+	if err != nil {
+		return nil, err
+	}
+	work := new(big.Int).Lsh(big.NewInt(1), 256)
+	return work.Quo(work, target.Add(target, big.NewInt(1))), nil
+}
+
+// bitsFromTarget encodes the proof of work target in the compact form, truncating the lower bits as the node does.
+func bitsFromTarget(target *big.Int) uint32 {
+	size := uint((target.BitLen() + 7) / 8) //nolint:gosec // bit length is never negative
+
+	var mantissa uint64
+	if size <= 3 {
+		mantissa = new(big.Int).Lsh(target, 8*(3-size)).Uint64()
+	} else {
+		mantissa = new(big.Int).Rsh(target, 8*(size-3)).Uint64()
+	}
+
+	// the mantissa is signed, so the value with the sign bit set is shifted to the next byte
+	if mantissa&0x00800000 != 0 {
+		mantissa >>= 8
+		size++
+	}
+	return uint32(mantissa) | uint32(size)<<24 //nolint:gosec // mantissa has 3 bytes and size fits in a byte
+}
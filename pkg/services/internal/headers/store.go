@@ -0,0 +1,277 @@
+package headers
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"sync"
+
+	"github.com/bsv-blockchain/go-sdk/chainhash"
+)
+
+// baseHeightSize is the length of the base height prefix of the headers file
+const baseHeightSize = 4
+
+// Store keeps a contiguous chain of block headers starting at the base height.
+// When the path is provided, the headers are persisted in the file:
+// the base height as 4 bytes little endian followed by the 80 bytes serialized headers.
+// Store is safe for concurrent use.
+type Store struct {
+	mu      sync.RWMutex
+	path    string
+	base    uint32
+	headers []*Header
+	hashes  []chainhash.Hash
+	heights map[chainhash.Hash]uint32
+}
+
+// OpenStore loads the headers from the file with given path, or creates an in-memory store if the path is empty.
+// The file is created on the first write if it doesn't exist.
+func OpenStore(path string) (*Store, error) {
+	s := &Store{
+		path:    path,
+		heights: make(map[chainhash.Hash]uint32),
+	}
+	if path == "" {
+		return s, nil
+	}
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return s, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read headers file: %w", err)
+	}
+
+	if err = s.load(data); err != nil {
+		return nil, fmt.Errorf("invalid headers file %s: %w", path, err)
+	}
+	return s, nil
+}
+
+func (s *Store) load(data []byte) error {
+	if len(data) == 0 {
+		return nil
+	}
+	if len(data) < baseHeightSize || (len(data)-baseHeightSize)%Size != 0 {
+		return fmt.Errorf("unexpected file length %d", len(data))
+	}
+
+	s.base = binary.LittleEndian.Uint32(data[:baseHeightSize])
+
+	reader := bytes.NewReader(data[baseHeightSize:])
+	chunk := make([]byte, Size)
+	for {
+		_, err := io.ReadFull(reader, chunk)
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		header, err := ParseHeader(chunk)
+		if err != nil {
+			return err
+		}
+		if !s.isEmpty() && header.PrevHash != s.hashes[len(s.hashes)-1] {
+			return fmt.Errorf("header at height %d doesn't link to the previous one", s.nextHeight())
+		}
+		s.add(header)
+	}
+}
+
+// IsEmpty returns true if there are no headers in the store.
+func (s *Store) IsEmpty() bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.isEmpty()
+}
+
+// Base returns the height of the first header in the store.
+func (s *Store) Base() uint32 {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.base
+}
+
+// Tip returns the height and the header of the last header in the store.
+func (s *Store) Tip() (uint32, *Header, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if s.isEmpty() {
+		return 0, nil, false
+	}
+	return s.nextHeight() - 1, s.headers[len(s.headers)-1], true
+}
+
+// At returns the header at given height.
+func (s *Store) At(height uint32) (*Header, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if height < s.base || height >= s.nextHeight() {
+		return nil, false
+	}
+	return s.headers[height-s.base], true
+}
+
+// HashAt returns the hash of the header at given height.
+func (s *Store) HashAt(height uint32) (chainhash.Hash, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if height < s.base || height >= s.nextHeight() {
+		return chainhash.Hash{}, false
+	}
+	return s.hashes[height-s.base], true
+}
+
+// HeightOf returns the height of the header with given hash.
+func (s *Store) HeightOf(hash chainhash.Hash) (uint32, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	height, ok := s.heights[hash]
+	return height, ok
+}
+
+// Reset replaces the content of the store with the single header at given height.
+func (s *Store) Reset(height uint32, header *Header) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if err := s.write(height, []*Header{header}); err != nil {
+		return err
+	}
+
+	s.base = height
+	s.headers = nil
+	s.hashes = nil
+	s.heights = make(map[chainhash.Hash]uint32)
+	s.add(header)
+	return nil
+}
+
+// Append adds the header on top of the tip, the header must link to the tip.
+func (s *Store) Append(header *Header) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.isEmpty() {
+		return fmt.Errorf("cannot append to empty store, reset it first")
+	}
+	if header.PrevHash != s.hashes[len(s.hashes)-1] {
+		return fmt.Errorf("header at height %d doesn't link to the previous one", s.nextHeight())
+	}
+
+	if s.path != "" {
+		file, err := os.OpenFile(s.path, os.O_APPEND|os.O_WRONLY, 0o600)
+		if err != nil {
+			return fmt.Errorf("failed to open headers file: %w", err)
+		}
+		defer file.Close() //nolint:errcheck // the write error is checked
+
+		if _, err = file.Write(header.Bytes()); err != nil {
+			return fmt.Errorf("failed to write header to file: %w", err)
+		}
+	}
+
+	s.add(header)
+	return nil
+}
+
+// Prepend adds the contiguous headers below the base, the last of them must be the parent of the base header.
+func (s *Store) Prepend(headers []*Header) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.isEmpty() {
+		return fmt.Errorf("cannot prepend to empty store, reset it first")
+	}
+	if len(headers) == 0 {
+		return nil
+	}
+	if uint64(len(headers)) > uint64(s.base) {
+		return fmt.Errorf("cannot prepend %d headers below the base height %d", len(headers), s.base)
+	}
+
+	base := s.base - uint32(len(headers)) //nolint:gosec // checked above that it's not above the base
+	prepended := append(slices.Clone(headers), s.headers...)
+	for i := 1; i < len(prepended); i++ {
+		if prepended[i].PrevHash != prepended[i-1].Hash() {
+			return fmt.Errorf("header at height %d doesn't link to the previous one", base+uint32(i)) //nolint:gosec // number of headers fits in uint32
+		}
+	}
+
+	if err := s.write(base, prepended); err != nil {
+		return err
+	}
+
+	s.base = base
+	s.headers = nil
+	s.hashes = nil
+	s.heights = make(map[chainhash.Hash]uint32, len(prepended))
+	for _, header := range prepended {
+		s.add(header)
+	}
+	return nil
+}
+
+// TruncateAbove removes all the headers above given height.
+func (s *Store) TruncateAbove(height uint32) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if height < s.base {
+		return fmt.Errorf("cannot truncate below the base height %d", s.base)
+	}
+	if height >= s.nextHeight() {
+		return nil
+	}
+
+	keep := height - s.base + 1
+	if s.path != "" {
+		if err := os.Truncate(s.path, int64(baseHeightSize+Size*keep)); err != nil {
+			return fmt.Errorf("failed to truncate headers file: %w", err)
+		}
+	}
+
+	for _, hash := range s.hashes[keep:] {
+		delete(s.heights, hash)
+	}
+	s.headers = s.headers[:keep]
+	s.hashes = s.hashes[:keep]
+	return nil
+}
+
+func (s *Store) isEmpty() bool {
+	return len(s.headers) == 0
+}
+
+func (s *Store) add(header *Header) {
+	hash := header.Hash()
+	s.heights[hash] = s.nextHeight()
+	s.headers = append(s.headers, header)
+	s.hashes = append(s.hashes, hash)
+}
+
+func (s *Store) nextHeight() uint32 {
+	return s.base + uint32(len(s.headers)) //nolint:gosec // number of headers fits in uint32
+}
+
+// write replaces the content of the file with the headers starting at the base height.
+func (s *Store) write(base uint32, headers []*Header) error {
+	if s.path == "" {
+		return nil
+	}
+
+	data := make([]byte, baseHeightSize, baseHeightSize+Size*len(headers))
+	binary.LittleEndian.PutUint32(data, base)
+	for _, header := range headers {
+		data = append(data, header.Bytes()...)
+	}
+
+	if err := os.WriteFile(s.path, data, 0o600); err != nil {
+		return fmt.Errorf("failed to write headers file: %w", err)
+	}
+	return nil
+}
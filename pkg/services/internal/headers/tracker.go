@@ -0,0 +1,401 @@
+package headers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/4chain-ag/go-wallet-toolbox/pkg/internal/logging"
+	"github.com/bsv-blockchain/go-sdk/chainhash"
+)
+
+// ErrHeaderNotFound is returned when the header is not known to the tracker.
+var ErrHeaderNotFound = errors.New("block header not found")
+
+// Default values of Options
+const (
+	DefaultInitialDepth  = 144
+	DefaultMaxReorgDepth = 100
+	DefaultSyncInterval  = time.Minute
+	DefaultBatchSize     = 2000
+)
+
+// Source provides the block headers of the active chain.
+type Source interface {
+	// ChainHeight returns the height of the tip of the active chain.
+	ChainHeight(ctx context.Context) (uint32, error)
+	// Headers returns the concatenated 80 bytes serialized headers of the active chain starting at given height,
+	// there are less than count headers when the tip of the chain is reached.
+	Headers(ctx context.Context, height, count uint32) ([]byte, error)
+}
+
+// Options configures the Tracker.
+type Options struct {
+	// StartHeight is the height of the first synced header, nil means InitialDepth headers below the chain tip.
+	StartHeight *uint32
+	// StartHash is the expected hash of the header at StartHeight, empty means the header from the source is trusted.
+	StartHash string
+	// InitialDepth is the number of headers below the chain tip synced when StartHeight is not provided.
+	InitialDepth uint32
+	// MaxReorgDepth is the maximal number of headers which can be replaced by a reorg.
+	MaxReorgDepth uint32
+	// SyncInterval is the minimal time between the syncs triggered by the Height calls.
+	SyncInterval time.Duration
+	// Genesis is the trusted header at height 0, it's used instead of the one from the source when the sync starts at 0.
+	Genesis *Header
+	// Params are the consensus rules of the network used to validate the proof of work, nil means MainNetParams.
+	Params *ChainParams
+	// BatchSize is the maximal number of headers fetched from the source at once.
+	BatchSize uint32
+	// Backfill enables fetching and storing all the headers between the requested height below the first synced header and it,
+	// so they are trusted as linked to the synced chain. It should be enabled only for a source serving the headers in batches,
+	// otherwise only the requested header is fetched and validated for proof of work.
+	Backfill bool
+}
+
+// Tracker keeps the local chain of block headers in sync with the source.
+// The headers are validated for proof of work, difficulty and chain linkage, reorgs are resolved by replacing the stale branch.
+// The headers below the first synced one are fetched on demand (and linked to it when Backfill is enabled).
+// The stored headers are served without waiting for the network requests of a sync in progress.
+// Tracker implements go-sdk chaintracker.ChainTracker.
+type Tracker struct {
+	logger  *slog.Logger
+	source  Source
+	store   *Store
+	options Options
+
+	// mu serializes the syncs of the headers above the first synced one
+	mu       sync.Mutex
+	lastSync time.Time
+
+	// backfillMu serializes the syncs of the headers below the first synced one
+	backfillMu sync.Mutex
+}
+
+// NewTracker creates the Tracker syncing the headers from the source to the store.
+func NewTracker(logger *slog.Logger, source Source, store *Store, options Options) *Tracker {
+	if options.InitialDepth == 0 {
+		options.InitialDepth = DefaultInitialDepth
+	}
+	if options.MaxReorgDepth == 0 {
+		options.MaxReorgDepth = DefaultMaxReorgDepth
+	}
+	if options.SyncInterval == 0 {
+		options.SyncInterval = DefaultSyncInterval
+	}
+	if options.Params == nil {
+		options.Params = &MainNetParams
+	}
+	if options.BatchSize == 0 {
+		options.BatchSize = DefaultBatchSize
+	}
+
+	return &Tracker{
+		logger:  logging.Child(logger, "headers"),
+		source:  source,
+		store:   store,
+		options: options,
+	}
+}
+
+// Sync fetches the headers from the source up to its chain tip.
+func (t *Tracker) Sync(ctx context.Context) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.sync(ctx)
+}
+
+// Height returns the height of the tip of the synced chain, syncing it first if SyncInterval has passed.
+// When the sync fails, the height of the already synced chain is returned.
+func (t *Tracker) Height(ctx context.Context) (uint32, error) {
+	if err := t.syncIfDue(ctx); err != nil {
+		if t.store.IsEmpty() {
+			return 0, err
+		}
+		t.logger.Warn("failed to sync headers, using the synced chain", logging.Error(err))
+	}
+
+	height, _, ok := t.store.Tip()
+	if !ok {
+		return 0, fmt.Errorf("no headers synced")
+	}
+	return height, nil
+}
+
+// HeaderForHeight returns the header of the active chain at given height, syncing the chain if the height is above the tip.
+// The headers below the first synced one are fetched from the source: with Backfill they are stored when they link to it,
+// otherwise only the requested header is fetched and validated for proof of work.
+func (t *Tracker) HeaderForHeight(ctx context.Context, height uint32) (*Header, error) {
+	if tip, _, ok := t.store.Tip(); !ok || height > tip {
+		if err := t.Sync(ctx); err != nil {
+			return nil, err
+		}
+	}
+
+	if header, ok := t.store.At(height); ok {
+		return header, nil
+	}
+	if t.store.IsEmpty() || height >= t.store.Base() {
+		return nil, fmt.Errorf("%w at height %d", ErrHeaderNotFound, height)
+	}
+
+	if !t.options.Backfill {
+		return t.fetchOne(ctx, height)
+	}
+
+	if err := t.syncBelow(ctx, height); err != nil {
+		return nil, err
+	}
+
+	header, ok := t.store.At(height)
+	if !ok {
+		return nil, fmt.Errorf("%w at height %d", ErrHeaderNotFound, height)
+	}
+	return header, nil
+}
+
+// HeaderByHash returns the height and the header with given hash, syncing the chain if the hash is not known.
+func (t *Tracker) HeaderByHash(ctx context.Context, hash chainhash.Hash) (uint32, *Header, error) {
+	height, ok := t.store.HeightOf(hash)
+	if !ok {
+		if err := t.Sync(ctx); err != nil {
+			return 0, nil, err
+		}
+		height, ok = t.store.HeightOf(hash)
+		if !ok {
+			return 0, nil, fmt.Errorf("%w with hash %s", ErrHeaderNotFound, hash)
+		}
+	}
+
+	header, ok := t.store.At(height)
+	if !ok {
+		return 0, nil, fmt.Errorf("%w with hash %s", ErrHeaderNotFound, hash)
+	}
+	return height, header, nil
+}
+
+// IsValidRootForHeight checks if the merkle root is the one of the header of the active chain at given height.
+func (t *Tracker) IsValidRootForHeight(root *chainhash.Hash, height uint32) (bool, error) {
+	header, err := t.HeaderForHeight(context.Background(), height)
+	if errors.Is(err, ErrHeaderNotFound) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return header.MerkleRoot.IsEqual(root), nil
+}
+
+// syncIfDue syncs the chain if SyncInterval has passed since the last sync.
+// A failed sync of the non-empty store is not retried before the next SyncInterval, so the callers are not slowed down by the unavailable source.
+func (t *Tracker) syncIfDue(ctx context.Context) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if time.Since(t.lastSync) < t.options.SyncInterval {
+		return nil
+	}
+
+	err := t.sync(ctx)
+	if err != nil && !t.store.IsEmpty() {
+		t.lastSync = time.Now()
+	}
+	return err
+}
+
+func (t *Tracker) sync(ctx context.Context) error {
+	chainHeight, err := t.source.ChainHeight(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get chain height: %w", err)
+	}
+
+	if t.store.IsEmpty() {
+		if err = t.initialize(ctx, chainHeight); err != nil {
+			return err
+		}
+	}
+
+	// when the source is behind the synced tip, it is treated as lagging and the check is skipped
+	if tip, _, _ := t.store.Tip(); tip <= chainHeight {
+		if _, err = t.resolveReorg(ctx, tip); err != nil {
+			return err
+		}
+	}
+
+	for tip, tipHeader, _ := t.store.Tip(); tip < chainHeight; tip, tipHeader, _ = t.store.Tip() {
+		batch, err := t.fetch(ctx, tip+1, min(chainHeight-tip, t.options.BatchSize))
+		if err != nil {
+			return err
+		}
+		if len(batch) == 0 {
+			return fmt.Errorf("%w at height %d in the source", ErrHeaderNotFound, tip+1)
+		}
+
+		if batch[0].PrevHash != tipHeader.Hash() {
+			reorged, err := t.resolveReorg(ctx, tip)
+			if err != nil {
+				return err
+			}
+			if !reorged {
+				return fmt.Errorf("header at height %d from the source doesn't link to the previous one", tip+1)
+			}
+			continue
+		}
+
+		for i, header := range batch {
+			if err = t.append(tip+1+uint32(i), header); err != nil { //nolint:gosec // batch is not longer than BatchSize
+				return err
+			}
+		}
+	}
+
+	t.lastSync = time.Now()
+	return nil
+}
+
+func (t *Tracker) initialize(ctx context.Context, chainHeight uint32) error {
+	start := chainHeight - min(chainHeight, t.options.InitialDepth)
+	if t.options.StartHeight != nil {
+		start = *t.options.StartHeight
+	}
+	if start > chainHeight {
+		return fmt.Errorf("start height %d is above the chain height %d", start, chainHeight)
+	}
+
+	header, err := t.startHeader(ctx, start)
+	if err != nil {
+		return err
+	}
+
+	if t.options.StartHash != "" && header.Hash().String() != t.options.StartHash {
+		return fmt.Errorf("header at start height %d has hash %s, expected %s", start, header.Hash(), t.options.StartHash)
+	}
+
+	if err = t.store.Reset(start, header); err != nil {
+		return fmt.Errorf("failed to store start header: %w", err)
+	}
+	return nil
+}
+
+func (t *Tracker) startHeader(ctx context.Context, start uint32) (*Header, error) {
+	if start != 0 || t.options.Genesis == nil {
+		return t.fetchOne(ctx, start)
+	}
+
+	if err := t.options.Genesis.ValidateProofOfWork(t.options.Params); err != nil {
+		return nil, fmt.Errorf("invalid genesis header: %w", err)
+	}
+	return t.options.Genesis, nil
+}
+
+// resolveReorg walks down from the tip until the stored header matches the one from the source,
+// and removes the stored headers above it. Returns true if any header was removed.
+func (t *Tracker) resolveReorg(ctx context.Context, tip uint32) (bool, error) {
+	for height := tip; ; height-- {
+		if tip-height > t.options.MaxReorgDepth {
+			return false, fmt.Errorf("reorg is deeper than %d headers", t.options.MaxReorgDepth)
+		}
+
+		stored, ok := t.store.HashAt(height)
+		if !ok {
+			return false, fmt.Errorf("%w at height %d", ErrHeaderNotFound, height)
+		}
+
+		header, err := t.fetchOne(ctx, height)
+		if err != nil {
+			return false, err
+		}
+
+		if header.Hash() == stored {
+			if height == tip {
+				return false, nil
+			}
+
+			t.logger.Warn("reorg detected, removing stale headers", slog.Uint64("fork.height", uint64(height)), slog.Uint64("tip.height", uint64(tip)))
+			if err = t.store.TruncateAbove(height); err != nil {
+				return false, fmt.Errorf("failed to remove stale headers: %w", err)
+			}
+			return true, nil
+		}
+
+		if height == t.store.Base() {
+			return false, fmt.Errorf("reorg is deeper than the first synced header at %d", height)
+		}
+	}
+}
+
+// append validates the difficulty of the header against the stored ancestors and adds it on top of the tip.
+func (t *Tracker) append(height uint32, header *Header) error {
+	if expected, ok := t.options.Params.expectedBits(height, header, t.store.At); ok && header.Bits != expected {
+		return fmt.Errorf("invalid header at height %d: bits %08x don't match the expected difficulty %08x", height, header.Bits, expected)
+	}
+
+	if err := t.store.Append(header); err != nil {
+		return fmt.Errorf("failed to store header at height %d: %w", height, err)
+	}
+	return nil
+}
+
+// syncBelow fetches the headers from given height up to the first synced one and prepends them to the store,
+// the headers are trusted because they link to the first synced header.
+func (t *Tracker) syncBelow(ctx context.Context, height uint32) error {
+	t.backfillMu.Lock()
+	defer t.backfillMu.Unlock()
+
+	for base := t.store.Base(); base > height; base = t.store.Base() {
+		from := max(height, base-min(base, t.options.BatchSize))
+
+		batch, err := t.fetch(ctx, from, base-from)
+		if err != nil {
+			return err
+		}
+		if uint32(len(batch)) != base-from { //nolint:gosec // batch is not longer than BatchSize
+			return fmt.Errorf("source returned %d headers below the first synced header at %d, expected %d", len(batch), base, base-from)
+		}
+
+		if err = t.store.Prepend(batch); err != nil {
+			return fmt.Errorf("failed to store headers below height %d: %w", base, err)
+		}
+	}
+	return nil
+}
+
+func (t *Tracker) fetchOne(ctx context.Context, height uint32) (*Header, error) {
+	headers, err := t.fetch(ctx, height, 1)
+	if err != nil {
+		return nil, err
+	}
+	if len(headers) == 0 {
+		return nil, fmt.Errorf("%w at height %d in the source", ErrHeaderNotFound, height)
+	}
+	return headers[0], nil
+}
+
+// fetch gets up to count headers starting at given height from the source and validates their proof of work.
+func (t *Tracker) fetch(ctx context.Context, height, count uint32) ([]*Header, error) {
+	data, err := t.source.Headers(ctx, height, count)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get headers at height %d: %w", height, err)
+	}
+	if len(data)%Size != 0 || len(data)/Size > int(count) {
+		return nil, fmt.Errorf("source returned %d bytes of headers at height %d, expected up to %d headers", len(data), height, count)
+	}
+
+	headers := make([]*Header, 0, len(data)/Size)
+	for offset := 0; offset < len(data); offset += Size {
+		current := height + uint32(len(headers)) //nolint:gosec // number of headers is not above count
+
+		header, err := ParseHeader(data[offset : offset+Size])
+		if err != nil {
+			return nil, fmt.Errorf("invalid header at height %d: %w", current, err)
+		}
+		if err = header.ValidateProofOfWork(t.options.Params); err != nil {
+			return nil, fmt.Errorf("invalid header at height %d: %w", current, err)
+		}
+		headers = append(headers, header)
+	}
+	return headers, nil
+}
@@ -0,0 +1,468 @@
+package headers_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/4chain-ag/go-wallet-toolbox/pkg/internal/logging"
+	"github.com/4chain-ag/go-wallet-toolbox/pkg/services/internal/headers"
+	"github.com/bsv-blockchain/go-sdk/chainhash"
+	"github.com/go-softwarelab/common/pkg/to"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// easyBits is the proof of work target of regtest, so the headers can be mined in tests
+const easyBits = 0x207fffff
+
+func TestTracker(t *testing.T) {
+	t.Run("syncs headers from the start height and validates merkle roots", func(t *testing.T) {
+		// given:
+		source := &testSource{chain: mineChain(t, chainhash.Hash{}, 10, "main")}
+		tracker := newTracker(t, source, headers.Options{StartHeight: to.Ptr(uint32(2))})
+
+		// when:
+		height, err := tracker.Height(context.Background())
+
+		// then:
+		require.NoError(t, err)
+		assert.Equal(t, uint32(9), height)
+
+		// and:
+		valid, err := tracker.IsValidRootForHeight(&source.chain[5].MerkleRoot, 5)
+		require.NoError(t, err)
+		assert.True(t, valid)
+
+		// and:
+		valid, err = tracker.IsValidRootForHeight(&source.chain[4].MerkleRoot, 5)
+		require.NoError(t, err)
+		assert.False(t, valid)
+	})
+
+	t.Run("syncs new headers when asked for height above the tip", func(t *testing.T) {
+		// given:
+		chain := mineChain(t, chainhash.Hash{}, 10, "main")
+		source := &testSource{chain: chain[:5]}
+		tracker := newTracker(t, source, headers.Options{StartHeight: to.Ptr(uint32(0))})
+		require.NoError(t, tracker.Sync(context.Background()))
+
+		// and:
+		source.chain = chain
+
+		// when:
+		header, err := tracker.HeaderForHeight(context.Background(), 8)
+
+		// then:
+		require.NoError(t, err)
+		assert.Equal(t, chain[8], header)
+	})
+
+	t.Run("returns no valid root for height above the chain", func(t *testing.T) {
+		// given:
+		source := &testSource{chain: mineChain(t, chainhash.Hash{}, 5, "main")}
+		tracker := newTracker(t, source, headers.Options{StartHeight: to.Ptr(uint32(0))})
+
+		// when:
+		valid, err := tracker.IsValidRootForHeight(&source.chain[4].MerkleRoot, 10)
+
+		// then:
+		require.NoError(t, err)
+		assert.False(t, valid)
+	})
+
+	t.Run("fetches headers below the start height linked to the first synced header", func(t *testing.T) {
+		// given:
+		source := &testSource{chain: mineChain(t, chainhash.Hash{}, 10, "main")}
+		store, err := headers.OpenStore("")
+		require.NoError(t, err)
+		tracker := headers.NewTracker(logging.NewTestLogger(t), source, store, headers.Options{
+			StartHeight: to.Ptr(uint32(6)),
+			Params:      &headers.RegTestParams,
+			BatchSize:   2,
+			Backfill:    true,
+		})
+
+		// when:
+		header, err := tracker.HeaderForHeight(context.Background(), 1)
+
+		// then:
+		require.NoError(t, err)
+		assert.Equal(t, source.chain[1], header)
+
+		// and:
+		assert.Equal(t, uint32(1), store.Base())
+		valid, err := tracker.IsValidRootForHeight(&source.chain[3].MerkleRoot, 3)
+		require.NoError(t, err)
+		assert.True(t, valid)
+	})
+
+	t.Run("fails when headers below the start height don't link to the first synced header", func(t *testing.T) {
+		// given:
+		chain := mineChain(t, chainhash.Hash{}, 5, "main")
+		source := &testSource{chain: chain}
+		tracker := newTracker(t, source, headers.Options{StartHeight: to.Ptr(uint32(2)), Backfill: true})
+		require.NoError(t, tracker.Sync(context.Background()))
+
+		// and:
+		source.chain = append(mineChain(t, chainhash.Hash{}, 2, "fork"), chain[2:]...)
+
+		// when:
+		_, err := tracker.HeaderForHeight(context.Background(), 1)
+
+		// then:
+		require.Error(t, err)
+	})
+
+	t.Run("fetches only the requested header below the start height without backfill", func(t *testing.T) {
+		// given:
+		source := &testSource{chain: mineChain(t, chainhash.Hash{}, 10, "main")}
+		store, err := headers.OpenStore("")
+		require.NoError(t, err)
+		tracker := headers.NewTracker(logging.NewTestLogger(t), source, store, headers.Options{
+			StartHeight: to.Ptr(uint32(6)),
+			Params:      &headers.RegTestParams,
+		})
+		require.NoError(t, tracker.Sync(context.Background()))
+
+		// and:
+		source.requested = nil
+
+		// when:
+		header, err := tracker.HeaderForHeight(context.Background(), 1)
+
+		// then:
+		require.NoError(t, err)
+		assert.Equal(t, source.chain[1], header)
+
+		// and:
+		assert.Equal(t, [][2]uint32{{1, 1}}, source.requested)
+		assert.Equal(t, uint32(6), store.Base())
+	})
+
+	t.Run("returns the synced height when the sync fails", func(t *testing.T) {
+		// given:
+		source := &testSource{chain: mineChain(t, chainhash.Hash{}, 5, "main")}
+		tracker := newTracker(t, source, headers.Options{StartHeight: to.Ptr(uint32(0)), SyncInterval: time.Nanosecond})
+		require.NoError(t, tracker.Sync(context.Background()))
+
+		// and:
+		source.err = errors.New("source unavailable")
+
+		// when:
+		height, err := tracker.Height(context.Background())
+
+		// then:
+		require.NoError(t, err)
+		assert.Equal(t, uint32(4), height)
+	})
+
+	t.Run("fails to return the height when nothing is synced", func(t *testing.T) {
+		// given:
+		source := &testSource{chain: mineChain(t, chainhash.Hash{}, 5, "main"), err: errors.New("source unavailable")}
+		tracker := newTracker(t, source, headers.Options{StartHeight: to.Ptr(uint32(0))})
+
+		// when:
+		_, err := tracker.Height(context.Background())
+
+		// then:
+		require.Error(t, err)
+	})
+
+	t.Run("finds header by hash", func(t *testing.T) {
+		// given:
+		source := &testSource{chain: mineChain(t, chainhash.Hash{}, 5, "main")}
+		tracker := newTracker(t, source, headers.Options{StartHeight: to.Ptr(uint32(0))})
+
+		// when:
+		height, header, err := tracker.HeaderByHash(context.Background(), source.chain[3].Hash())
+
+		// then:
+		require.NoError(t, err)
+		assert.Equal(t, uint32(3), height)
+		assert.Equal(t, source.chain[3], header)
+	})
+
+	t.Run("replaces stale headers on reorg", func(t *testing.T) {
+		// given:
+		chain := mineChain(t, chainhash.Hash{}, 10, "main")
+		source := &testSource{chain: chain}
+		tracker := newTracker(t, source, headers.Options{StartHeight: to.Ptr(uint32(0))})
+		require.NoError(t, tracker.Sync(context.Background()))
+
+		// and:
+		fork := append(chain[:7:7], mineChain(t, chain[6].Hash(), 6, "fork")...)
+		source.chain = fork
+
+		// when:
+		err := tracker.Sync(context.Background())
+
+		// then:
+		require.NoError(t, err)
+
+		// and:
+		header, err := tracker.HeaderForHeight(context.Background(), 9)
+		require.NoError(t, err)
+		assert.Equal(t, fork[9], header)
+
+		// and:
+		valid, err := tracker.IsValidRootForHeight(&chain[9].MerkleRoot, 9)
+		require.NoError(t, err)
+		assert.False(t, valid)
+
+		// and:
+		height, err := tracker.Height(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, uint32(12), height)
+	})
+
+	t.Run("replaces stale tip on reorg of the same height", func(t *testing.T) {
+		// given:
+		chain := mineChain(t, chainhash.Hash{}, 10, "main")
+		source := &testSource{chain: chain}
+		tracker := newTracker(t, source, headers.Options{StartHeight: to.Ptr(uint32(0))})
+		require.NoError(t, tracker.Sync(context.Background()))
+
+		// and:
+		fork := append(chain[:8:8], mineChain(t, chain[7].Hash(), 2, "fork")...)
+		source.chain = fork
+
+		// when:
+		err := tracker.Sync(context.Background())
+
+		// then:
+		require.NoError(t, err)
+
+		// and:
+		header, err := tracker.HeaderForHeight(context.Background(), 9)
+		require.NoError(t, err)
+		assert.Equal(t, fork[9], header)
+	})
+
+	t.Run("fails on reorg deeper than max reorg depth", func(t *testing.T) {
+		// given:
+		chain := mineChain(t, chainhash.Hash{}, 10, "main")
+		source := &testSource{chain: chain}
+		tracker := newTracker(t, source, headers.Options{StartHeight: to.Ptr(uint32(0)), MaxReorgDepth: 2})
+		require.NoError(t, tracker.Sync(context.Background()))
+
+		// and:
+		source.chain = append(chain[:5:5], mineChain(t, chain[4].Hash(), 6, "fork")...)
+
+		// when:
+		err := tracker.Sync(context.Background())
+
+		// then:
+		require.Error(t, err)
+	})
+
+	t.Run("fails on header without proof of work", func(t *testing.T) {
+		// given:
+		chain := mineChain(t, chainhash.Hash{}, 5, "main")
+		chain[3].Bits = 0x1d00ffff
+		source := &testSource{chain: chain}
+		tracker := newTracker(t, source, headers.Options{StartHeight: to.Ptr(uint32(0))})
+
+		// when:
+		err := tracker.Sync(context.Background())
+
+		// then:
+		require.Error(t, err)
+	})
+
+	t.Run("fails on header with target above the pow limit", func(t *testing.T) {
+		// given:
+		source := &testSource{chain: mineChain(t, chainhash.Hash{}, 5, "main")}
+		tracker := newTracker(t, source, headers.Options{StartHeight: to.Ptr(uint32(0)), Params: &headers.MainNetParams})
+
+		// when:
+		err := tracker.Sync(context.Background())
+
+		// then:
+		require.Error(t, err)
+	})
+
+	t.Run("fails on header with changed difficulty on network without retargeting", func(t *testing.T) {
+		// given:
+		chain := mineChain(t, chainhash.Hash{}, 3, "main")
+		chain = append(chain, mineChainWith(t, chain[2].Hash(), 2, "harder", 1, 0x2000ffff)...)
+		source := &testSource{chain: chain}
+		tracker := newTracker(t, source, headers.Options{StartHeight: to.Ptr(uint32(0))})
+
+		// when:
+		err := tracker.Sync(context.Background())
+
+		// then:
+		require.Error(t, err)
+	})
+
+	t.Run("validates difficulty adjusted by the DAA", func(t *testing.T) {
+		// given:
+		params := &headers.ChainParams{PowLimitBits: easyBits}
+		source := &testSource{chain: mineChainWith(t, chainhash.Hash{}, 160, "main", 600, easyBits)}
+		tracker := newTracker(t, source, headers.Options{StartHeight: to.Ptr(uint32(0)), Params: params})
+
+		// when:
+		err := tracker.Sync(context.Background())
+
+		// then:
+		require.NoError(t, err)
+	})
+
+	t.Run("fails on header with difficulty not adjusted by the DAA", func(t *testing.T) {
+		// given:
+		params := &headers.ChainParams{PowLimitBits: easyBits}
+		// the blocks mined every second require the higher difficulty than the one of easyBits
+		source := &testSource{chain: mineChainWith(t, chainhash.Hash{}, 160, "main", 1, easyBits)}
+		tracker := newTracker(t, source, headers.Options{StartHeight: to.Ptr(uint32(0)), Params: params})
+
+		// when:
+		err := tracker.Sync(context.Background())
+
+		// then:
+		require.Error(t, err)
+	})
+
+	t.Run("fails on start header with unexpected hash", func(t *testing.T) {
+		// given:
+		source := &testSource{chain: mineChain(t, chainhash.Hash{}, 5, "main")}
+		tracker := newTracker(t, source, headers.Options{
+			StartHeight: to.Ptr(uint32(1)),
+			StartHash:   source.chain[2].Hash().String(),
+		})
+
+		// when:
+		err := tracker.Sync(context.Background())
+
+		// then:
+		require.Error(t, err)
+	})
+
+	t.Run("starts from the configured genesis header", func(t *testing.T) {
+		// given:
+		chain := mineChain(t, chainhash.Hash{}, 5, "regtest")
+		source := &testSource{chain: chain}
+		tracker := newTracker(t, source, headers.Options{
+			StartHeight: to.Ptr(uint32(0)),
+			Genesis:     chain[0],
+		})
+
+		// when:
+		header, err := tracker.HeaderForHeight(context.Background(), 4)
+
+		// then:
+		require.NoError(t, err)
+		assert.Equal(t, chain[4], header)
+	})
+
+	t.Run("fails when the source chain has other genesis than configured", func(t *testing.T) {
+		// given:
+		source := &testSource{chain: mineChain(t, chainhash.Hash{}, 5, "main")}
+		tracker := newTracker(t, source, headers.Options{
+			StartHeight: to.Ptr(uint32(0)),
+			Genesis:     mineChain(t, chainhash.Hash{}, 1, "regtest")[0],
+		})
+
+		// when:
+		err := tracker.Sync(context.Background())
+
+		// then:
+		require.Error(t, err)
+	})
+
+	t.Run("persists headers in the file", func(t *testing.T) {
+		// given:
+		path := filepath.Join(t.TempDir(), "headers.bin")
+		source := &testSource{chain: mineChain(t, chainhash.Hash{}, 10, "main")}
+
+		// and:
+		store, err := headers.OpenStore(path)
+		require.NoError(t, err)
+		tracker := headers.NewTracker(logging.NewTestLogger(t), source, store, headers.Options{
+			StartHeight: to.Ptr(uint32(3)),
+			Params:      &headers.RegTestParams,
+		})
+		require.NoError(t, tracker.Sync(context.Background()))
+
+		// when:
+		reopened, err := headers.OpenStore(path)
+
+		// then:
+		require.NoError(t, err)
+		assert.Equal(t, uint32(3), reopened.Base())
+
+		// and:
+		tip, header, ok := reopened.Tip()
+		require.True(t, ok)
+		assert.Equal(t, uint32(9), tip)
+		assert.Equal(t, source.chain[9], header)
+	})
+}
+
+func newTracker(t *testing.T, source headers.Source, options headers.Options) *headers.Tracker {
+	store, err := headers.OpenStore("")
+	require.NoError(t, err)
+	if options.Params == nil {
+		options.Params = &headers.RegTestParams
+	}
+	return headers.NewTracker(logging.NewTestLogger(t), source, store, options)
+}
+
+type testSource struct {
+	chain []*headers.Header
+	err   error
+	// requested are the heights and counts of the requested headers
+	requested [][2]uint32
+}
+
+func (s *testSource) ChainHeight(context.Context) (uint32, error) {
+	if s.err != nil {
+		return 0, s.err
+	}
+	return uint32(len(s.chain) - 1), nil //nolint:gosec // test chains are short
+}
+
+func (s *testSource) Headers(_ context.Context, height, count uint32) ([]byte, error) {
+	if s.err != nil {
+		return nil, s.err
+	}
+	s.requested = append(s.requested, [2]uint32{height, count})
+
+	var data []byte
+	for h := height; h < height+count && int(h) < len(s.chain); h++ {
+		data = append(data, s.chain[h].Bytes()...)
+	}
+	return data, nil
+}
+
+// mineChain creates the chain of headers with valid proof of work on top of the parent.
+func mineChain(t *testing.T, parent chainhash.Hash, count int, salt string) []*headers.Header {
+	return mineChainWith(t, parent, count, salt, 1, easyBits)
+}
+
+// mineChainWith creates the chain of headers with given time spacing in seconds and bits.
+func mineChainWith(t *testing.T, parent chainhash.Hash, count int, salt string, spacing int, bits uint32) []*headers.Header {
+	powParams := &headers.ChainParams{PowLimitBits: easyBits}
+
+	chain := make([]*headers.Header, 0, count)
+	for i := range count {
+		header := &headers.Header{
+			Version:    1,
+			PrevHash:   parent,
+			MerkleRoot: chainhash.HashH([]byte(fmt.Sprintf("%s-%d", salt, i))),
+			Time:       uint32(1_700_000_000 + i*spacing), //nolint:gosec // test chains are short
+			Bits:       bits,
+		}
+		for header.ValidateProofOfWork(powParams) != nil {
+			header.Nonce++
+		}
+		require.NoError(t, header.ValidateProofOfWork(powParams))
+
+		chain = append(chain, header)
+		parent = header.Hash()
+	}
+	return chain
+}
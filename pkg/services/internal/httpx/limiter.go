@@ -0,0 +1,219 @@
+package httpx
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/go-resty/resty/v2"
+)
+
+const (
+	// DefaultRetryAfter is the back off applied after 429 Too Many Requests without a valid Retry-After header
+	DefaultRetryAfter = time.Second
+	// MaxRetryAfterWait is the longest back off the request waits for, when all the keys must back off longer the request fails immediately
+	MaxRetryAfterWait = 30 * time.Second
+)
+
+// ErrQuotaExhausted is returned when the quota of all the API keys of the provider is exhausted for longer than MaxRetryAfterWait
+var ErrQuotaExhausted = errors.New("request quota of the provider is exhausted")
+
+// Limiter throttles the requests sent to a single provider.
+// It limits the request rate with a token bucket, backs off after 429 Too Many Requests for the time given by Retry-After header
+// and rotates the API keys, sent in Authorization header, when the quota of the current key is exhausted.
+type Limiter struct {
+	mu sync.Mutex
+
+	requestsPerSecond float64
+	burst             float64
+	tokens            float64
+	refilledAt        time.Time
+
+	keys    []apiKey
+	current int
+}
+
+type apiKey struct {
+	value        string
+	blockedUntil time.Time
+}
+
+// NewLimiter creates a limiter allowing requestsPerSecond requests with bursts of the given size,
+// zero requestsPerSecond disables the rate limit, empty keys are skipped.
+func NewLimiter(requestsPerSecond float64, burst int, keys ...string) *Limiter {
+	burst = max(burst, 1)
+
+	l := &Limiter{
+		requestsPerSecond: requestsPerSecond,
+		burst:             float64(burst),
+		tokens:            float64(burst),
+		refilledAt:        time.Now(),
+	}
+
+	seen := make(map[string]struct{}, len(keys))
+	for _, key := range keys {
+		if _, ok := seen[key]; ok || key == "" {
+			continue
+		}
+		seen[key] = struct{}{}
+		l.keys = append(l.keys, apiKey{value: key})
+	}
+	if len(l.keys) == 0 {
+		// requests without a key are backed off the same way
+		l.keys = append(l.keys, apiKey{})
+	}
+
+	return l
+}
+
+// Apply returns a clone of the client dedicated to the provider, which sends the requests through the limiter.
+// The clone keeps all the settings of the given client (retries, hooks, headers, timeout, proxy, transport).
+// Resty clones share the underlying http.Client, so its transport is wrapped only once
+// and applies the limiter of the clone which sent the request, leaving the requests of the given client and its other clones untouched.
+// The clone uses the pre-request hook to select its limiter, so any pre-request hook of the given client is replaced.
+func (l *Limiter) Apply(client *resty.Client) *resty.Client {
+	clone := client.Clone()
+	if _, wrapped := clone.GetClient().Transport.(*limitedTransport); !wrapped {
+		clone.SetTransport(&limitedTransport{base: clone.GetClient().Transport})
+	}
+	clone.SetPreRequestHook(func(_ *resty.Client, req *http.Request) error {
+		*req = *req.WithContext(context.WithValue(req.Context(), limiterContextKey{}, l))
+		return nil
+	})
+	return clone
+}
+
+// limiterContextKey is the key of the request context value holding the limiter to apply
+type limiterContextKey struct{}
+
+// limitedTransport is the http.RoundTripper applying the limiter of the request to the requests sent by the base transport
+type limitedTransport struct {
+	base http.RoundTripper
+}
+
+// RoundTrip implements http.RoundTripper
+func (t *limitedTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	base := t.base
+	if base == nil {
+		base = http.DefaultTransport
+	}
+
+	limiter, ok := req.Context().Value(limiterContextKey{}).(*Limiter)
+	if !ok {
+		return base.RoundTrip(req) //nolint:wrapcheck // the request is not limited, so the transport is transparent
+	}
+
+	key, err := limiter.wait(req.Context())
+	if err != nil {
+		return nil, err
+	}
+	if key != "" {
+		req = req.Clone(req.Context())
+		req.Header.Set("Authorization", key)
+	}
+
+	res, err := base.RoundTrip(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to send request: %w", err)
+	}
+	if res.StatusCode == http.StatusTooManyRequests {
+		limiter.backOff(key, retryAfter(res.Header.Get("Retry-After"), time.Now()))
+	}
+	return res, nil
+}
+
+// wait blocks until the request can be sent and returns the API key to use.
+func (l *Limiter) wait(ctx context.Context) (string, error) {
+	for {
+		key, delay, err := l.reserve(time.Now())
+		if err != nil {
+			return "", err
+		}
+		if delay <= 0 {
+			return key, nil
+		}
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return "", fmt.Errorf("waiting for the rate limit: %w", ctx.Err())
+		case <-timer.C:
+		}
+	}
+}
+
+// reserve takes a token for the request with the first not blocked key or returns the time to wait.
+func (l *Limiter) reserve(now time.Time) (key string, delay time.Duration, err error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	idx, blockedFor := l.availableKey(now)
+	if idx < 0 {
+		if blockedFor > MaxRetryAfterWait {
+			return "", 0, fmt.Errorf("%w for the next %s", ErrQuotaExhausted, blockedFor.Round(time.Second))
+		}
+		return "", blockedFor, nil
+	}
+	l.current = idx
+
+	if l.requestsPerSecond > 0 {
+		l.tokens = min(l.burst, l.tokens+now.Sub(l.refilledAt).Seconds()*l.requestsPerSecond)
+		l.refilledAt = now
+		if l.tokens < 1 {
+			return "", time.Duration((1 - l.tokens) / l.requestsPerSecond * float64(time.Second)), nil
+		}
+		l.tokens--
+	}
+
+	return l.keys[idx].value, 0, nil
+}
+
+// availableKey returns the index of the first not blocked key starting from the current one,
+// or -1 and the time after which the first key gets unblocked.
+func (l *Limiter) availableKey(now time.Time) (int, time.Duration) {
+	var shortest time.Duration
+	for i := range l.keys {
+		idx := (l.current + i) % len(l.keys)
+		blockedFor := l.keys[idx].blockedUntil.Sub(now)
+		if blockedFor <= 0 {
+			return idx, 0
+		}
+		if shortest == 0 || blockedFor < shortest {
+			shortest = blockedFor
+		}
+	}
+	return -1, shortest
+}
+
+// backOff blocks the key for the given time and moves on to the next key.
+func (l *Limiter) backOff(key string, retryAfter time.Duration) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	for i := range l.keys {
+		if l.keys[i].value != key {
+			continue
+		}
+		l.keys[i].blockedUntil = time.Now().Add(retryAfter)
+		if i == l.current {
+			l.current = (i + 1) % len(l.keys)
+		}
+		return
+	}
+}
+
+// retryAfter parses the Retry-After header given either in seconds or as HTTP date.
+func retryAfter(header string, now time.Time) time.Duration {
+	if seconds, err := strconv.Atoi(header); err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second
+	}
+	if date, err := http.ParseTime(header); err == nil {
+		return max(date.Sub(now), 0)
+	}
+	return DefaultRetryAfter
+}
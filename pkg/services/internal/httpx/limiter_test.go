@@ -0,0 +1,233 @@
+package httpx_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/4chain-ag/go-wallet-toolbox/pkg/services/internal/httpx"
+	"github.com/go-resty/resty/v2"
+	"github.com/jarcoal/httpmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const limitedURL = "https://provider.example.com/resource"
+
+func givenLimitedClient(limiter *httpx.Limiter, responder httpmock.Responder) *resty.Client {
+	transport := httpmock.NewMockTransport()
+	transport.RegisterResponder(http.MethodGet, limitedURL, responder)
+
+	client := resty.New()
+	client.GetClient().Transport = transport
+	return limiter.Apply(client)
+}
+
+func TestLimiterKeyRotation(t *testing.T) {
+	t.Run("sends the first key while its quota is not exhausted", func(t *testing.T) {
+		// given:
+		var usedKeys []string
+		client := givenLimitedClient(httpx.NewLimiter(0, 0, "first", "second"), func(req *http.Request) (*http.Response, error) {
+			usedKeys = append(usedKeys, req.Header.Get("Authorization"))
+			return httpmock.NewStringResponse(http.StatusOK, "ok"), nil
+		})
+
+		// when:
+		for range 2 {
+			_, err := client.R().Get(limitedURL)
+			require.NoError(t, err)
+		}
+
+		// then:
+		assert.Equal(t, []string{"first", "first"}, usedKeys)
+	})
+
+	t.Run("rotates the key after too many requests response", func(t *testing.T) {
+		// given:
+		var usedKeys []string
+		client := givenLimitedClient(httpx.NewLimiter(0, 0, "first", "second"), func(req *http.Request) (*http.Response, error) {
+			key := req.Header.Get("Authorization")
+			usedKeys = append(usedKeys, key)
+			if key == "first" {
+				res := httpmock.NewStringResponse(http.StatusTooManyRequests, "quota exceeded")
+				res.Header.Set("Retry-After", "10")
+				return res, nil
+			}
+			return httpmock.NewStringResponse(http.StatusOK, "ok"), nil
+		})
+
+		// when:
+		first, err := client.R().Get(limitedURL)
+		require.NoError(t, err)
+
+		// and:
+		second, err := client.R().Get(limitedURL)
+		require.NoError(t, err)
+
+		// then:
+		assert.Equal(t, http.StatusTooManyRequests, first.StatusCode())
+		assert.Equal(t, http.StatusOK, second.StatusCode())
+		assert.Equal(t, []string{"first", "second"}, usedKeys)
+	})
+
+	t.Run("fails immediately when all keys must back off too long", func(t *testing.T) {
+		// given:
+		calls := 0
+		client := givenLimitedClient(httpx.NewLimiter(0, 0, "only"), func(req *http.Request) (*http.Response, error) {
+			calls++
+			res := httpmock.NewStringResponse(http.StatusTooManyRequests, "quota exceeded")
+			res.Header.Set("Retry-After", "3600")
+			return res, nil
+		})
+
+		// and:
+		_, err := client.R().Get(limitedURL)
+		require.NoError(t, err)
+
+		// when:
+		_, err = client.R().Get(limitedURL)
+
+		// then:
+		assert.ErrorIs(t, err, httpx.ErrQuotaExhausted)
+		assert.Equal(t, 1, calls)
+	})
+}
+
+func TestLimiterBackOff(t *testing.T) {
+	t.Run("waits for the time given by Retry-After header", func(t *testing.T) {
+		// given:
+		calls := 0
+		client := givenLimitedClient(httpx.NewLimiter(0, 0), func(req *http.Request) (*http.Response, error) {
+			calls++
+			if calls == 1 {
+				res := httpmock.NewStringResponse(http.StatusTooManyRequests, "slow down")
+				res.Header.Set("Retry-After", "1")
+				return res, nil
+			}
+			return httpmock.NewStringResponse(http.StatusOK, "ok"), nil
+		})
+
+		// and:
+		_, err := client.R().Get(limitedURL)
+		require.NoError(t, err)
+
+		// when:
+		start := time.Now()
+		res, err := client.R().Get(limitedURL)
+
+		// then:
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusOK, res.StatusCode())
+		assert.GreaterOrEqual(t, time.Since(start), 900*time.Millisecond)
+	})
+
+	t.Run("stops waiting when the context is done", func(t *testing.T) {
+		// given:
+		client := givenLimitedClient(httpx.NewLimiter(0, 0), func(req *http.Request) (*http.Response, error) {
+			res := httpmock.NewStringResponse(http.StatusTooManyRequests, "slow down")
+			res.Header.Set("Retry-After", "20")
+			return res, nil
+		})
+
+		// and:
+		_, err := client.R().Get(limitedURL)
+		require.NoError(t, err)
+
+		// and:
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+
+		// when:
+		_, err = client.R().SetContext(ctx).Get(limitedURL)
+
+		// then:
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+	})
+}
+
+func TestLimiterRate(t *testing.T) {
+	t.Run("spreads the requests over the configured rate", func(t *testing.T) {
+		// given:
+		client := givenLimitedClient(httpx.NewLimiter(20, 2), func(req *http.Request) (*http.Response, error) {
+			return httpmock.NewStringResponse(http.StatusOK, "ok"), nil
+		})
+
+		// when:
+		start := time.Now()
+		for range 4 {
+			_, err := client.R().Get(limitedURL)
+			require.NoError(t, err)
+		}
+
+		// then:
+		// the burst of 2 requests is immediate, the next two wait 50ms each
+		assert.GreaterOrEqual(t, time.Since(start), 90*time.Millisecond)
+	})
+}
+
+func TestLimiterClientsIsolation(t *testing.T) {
+	// given:
+	transport := httpmock.NewMockTransport()
+	var usedKeys []string
+	transport.RegisterResponder(http.MethodGet, limitedURL, func(req *http.Request) (*http.Response, error) {
+		usedKeys = append(usedKeys, req.Header.Get("Authorization"))
+		return httpmock.NewStringResponse(http.StatusOK, "ok"), nil
+	})
+
+	// and:
+	base := resty.New()
+	base.GetClient().Transport = transport
+
+	// and:
+	// the hooks of the base client leave a spare capacity, which is shared by its shallow clones
+	for range 3 {
+		base.OnBeforeRequest(func(*resty.Client, *resty.Request) error { return nil })
+	}
+
+	// and:
+	withToken := httpx.NewLimiter(0, 0, "token").Apply(base.Clone())
+	withoutToken := httpx.NewLimiter(0, 0).Apply(base.Clone())
+
+	// when:
+	for _, client := range []*resty.Client{withToken, withoutToken, base} {
+		_, err := client.R().Get(limitedURL)
+		require.NoError(t, err)
+	}
+
+	// then:
+	assert.Equal(t, []string{"token", "", ""}, usedKeys)
+}
+
+func TestLimiterKeepsClientSettings(t *testing.T) {
+	// given:
+	transport := httpmock.NewMockTransport()
+	var calls int
+	var userAgents []string
+	transport.RegisterResponder(http.MethodGet, limitedURL, func(req *http.Request) (*http.Response, error) {
+		calls++
+		userAgents = append(userAgents, req.Header.Get("User-Agent"))
+		if calls == 1 {
+			return httpmock.NewStringResponse(http.StatusServiceUnavailable, "unavailable"), nil
+		}
+		return httpmock.NewStringResponse(http.StatusOK, "ok"), nil
+	})
+
+	// and:
+	base := resty.New().
+		SetHeader("User-Agent", "wallet").
+		SetRetryCount(1).
+		SetRetryWaitTime(time.Millisecond).
+		AddRetryCondition(func(res *resty.Response, _ error) bool {
+			return res.StatusCode() == http.StatusServiceUnavailable
+		})
+	base.GetClient().Transport = transport
+
+	// when:
+	res, err := httpx.NewLimiter(0, 0, "token").Apply(base).R().Get(limitedURL)
+
+	// then:
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, res.StatusCode())
+	assert.Equal(t, []string{"wallet", "wallet"}, userAgents)
+}
@@ -0,0 +1,211 @@
+package servicequeue
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"math"
+	"slices"
+	"sync"
+	"time"
+)
+
+// ErrCircuitOpen is returned for the service which is skipped because of its open circuit breaker.
+var ErrCircuitOpen = errors.New("service circuit breaker is open")
+
+// ErrRejected should be wrapped by the errors of the services which responded correctly, but rejected the request
+// (e.g. the transaction which is invalid or double spent), the next service is tried but the error is not counted as a failure of the service.
+var ErrRejected = errors.New("request rejected by the service")
+
+// ErrFinalRejection should be wrapped by the errors of the services which rejected the request with the verdict
+// that the other services cannot change (e.g. the double spend of the transaction), so OneByOne doesn't try the next service.
+// It is a kind of ErrRejected, so it's not counted as a failure of the service either.
+var ErrFinalRejection = fmt.Errorf("%w with final verdict", ErrRejected)
+
+// Circuit breaker settings
+const (
+	// FailureThreshold is the number of consecutive failures which opens the circuit of the service.
+	FailureThreshold = 5
+	// OpenTimeout is the duration after which the open circuit lets a single probing call through (half-open state).
+	OpenTimeout = 30 * time.Second
+)
+
+// Health score settings
+const (
+	// ScoreHalfLife is the time after which the score of the service recovers halfway to the full health,
+	// so the demoted service is tried again in its configured order even if it's not called in the meantime.
+	ScoreHalfLife = 5 * time.Minute
+	// scoreWeight is the weight of the outcome of the latest call in the score.
+	scoreWeight = 0.25
+)
+
+// CircuitState is the state of the circuit breaker of the service.
+type CircuitState string
+
+// Possible states of the circuit breaker
+const (
+	// CircuitClosed means that the service is healthy and is called normally.
+	CircuitClosed CircuitState = "closed"
+	// CircuitOpen means that the service failed too many times in a row and is skipped until OpenTimeout passes.
+	CircuitOpen CircuitState = "open"
+	// CircuitHalfOpen means that a single probing call is let through to check if the service recovered.
+	CircuitHalfOpen CircuitState = "half-open"
+)
+
+// Stats are the health statistics of the service.
+type Stats struct {
+	Name                string
+	SuccessCount        uint64
+	FailureCount        uint64
+	ConsecutiveFailures uint64
+	AverageLatency      time.Duration
+	Circuit             CircuitState
+	LastError           string
+	LastFailureAt       time.Time
+	// Score is the decaying success rate of the recent calls, the services with higher score are called first
+	Score float64
+}
+
+// health tracks the calls of the service and implements its circuit breaker.
+type health struct {
+	mu sync.Mutex
+
+	successCount        uint64
+	failureCount        uint64
+	consecutiveFailures uint64
+	totalLatency        time.Duration
+
+	circuit   CircuitState
+	openUntil time.Time
+	probing   bool
+
+	lastError     string
+	lastFailureAt time.Time
+
+	// lastScore is the score at scoredAt, it recovers towards 1 over time (see ScoreHalfLife)
+	lastScore float64
+	scoredAt  time.Time
+}
+
+func newHealth() *health {
+	return &health{circuit: CircuitClosed, lastScore: 1}
+}
+
+// allow returns true if the service can be called, in half-open state only a single probing call is allowed at a time.
+func (h *health) allow(now time.Time) bool {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	switch h.circuit {
+	case CircuitOpen:
+		if now.Before(h.openUntil) {
+			return false
+		}
+		h.circuit = CircuitHalfOpen
+		h.probing = true
+		return true
+	case CircuitHalfOpen:
+		if h.probing {
+			return false
+		}
+		h.probing = true
+		return true
+	default:
+		return true
+	}
+}
+
+func (h *health) recordSuccess(latency time.Duration) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.successCount++
+	h.totalLatency += latency
+	h.consecutiveFailures = 0
+	h.circuit = CircuitClosed
+	h.probing = false
+	h.updateScore(1, time.Now())
+}
+
+// recordInterrupted releases the probing call of the half-open circuit without recording any outcome of the call.
+func (h *health) recordInterrupted() {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.probing = false
+}
+
+func (h *health) recordFailure(latency time.Duration, err error, now time.Time) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.failureCount++
+	h.totalLatency += latency
+	h.consecutiveFailures++
+	h.lastError = err.Error()
+	h.lastFailureAt = now
+	h.probing = false
+	h.updateScore(0, now)
+
+	if h.circuit == CircuitHalfOpen || h.consecutiveFailures >= FailureThreshold {
+		h.circuit = CircuitOpen
+		h.openUntil = now.Add(OpenTimeout)
+	}
+}
+
+func (h *health) stats(name string, now time.Time) Stats {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	stats := Stats{
+		Name:                name,
+		SuccessCount:        h.successCount,
+		FailureCount:        h.failureCount,
+		ConsecutiveFailures: h.consecutiveFailures,
+		Circuit:             h.circuit,
+		LastError:           h.lastError,
+		LastFailureAt:       h.lastFailureAt,
+		Score:               h.score(now),
+	}
+	if calls := h.successCount + h.failureCount; calls > 0 {
+		stats.AverageLatency = h.totalLatency / time.Duration(calls) //nolint:gosec // number of calls fits in int64
+	}
+	return stats
+}
+
+// score returns the score recovered since the last call, the missing part of the score halves every ScoreHalfLife.
+func (h *health) score(now time.Time) float64 {
+	if h.scoredAt.IsZero() {
+		return h.lastScore
+	}
+	elapsed := max(now.Sub(h.scoredAt), 0)
+	return 1 - (1-h.lastScore)*math.Pow(0.5, float64(elapsed)/float64(ScoreHalfLife))
+}
+
+func (h *health) updateScore(outcome float64, now time.Time) {
+	h.lastScore = (1-scoreWeight)*h.score(now) + scoreWeight*outcome
+	h.scoredAt = now
+}
+
+func (h *health) currentScore(now time.Time) float64 {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	return h.score(now)
+}
+
+// byHealth returns the services sorted by their score, the services with equal score are kept in their configured order,
+// so the configured priority is kept as long as the services are equally healthy.
+func byHealth[S serv](services []S) []S {
+	now := time.Now()
+	scores := make(map[*health]float64, len(services))
+	for _, s := range services {
+		scores[s.health()] = s.health().currentScore(now)
+	}
+
+	sorted := slices.Clone(services)
+	slices.SortStableFunc(sorted, func(a, b S) int {
+		return cmp.Compare(scores[b.health()], scores[a.health()])
+	})
+	return sorted
+}
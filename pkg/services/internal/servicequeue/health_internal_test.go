@@ -0,0 +1,110 @@
+package servicequeue
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCircuitHalfOpen(t *testing.T) {
+	givenOpenCircuit := func(now time.Time) *health {
+		h := newHealth()
+		for range FailureThreshold {
+			h.recordFailure(time.Millisecond, errors.New("some error occurred"), now)
+		}
+		return h
+	}
+
+	t.Run("lets a single probe through after the open timeout", func(t *testing.T) {
+		// given:
+		now := time.Now()
+		h := givenOpenCircuit(now)
+
+		// then:
+		assert.False(t, h.allow(now.Add(OpenTimeout-time.Second)))
+
+		// when:
+		probeAllowed := h.allow(now.Add(OpenTimeout))
+
+		// then:
+		assert.True(t, probeAllowed)
+		assert.Equal(t, CircuitHalfOpen, h.stats("test", now).Circuit)
+		assert.False(t, h.allow(now.Add(OpenTimeout)))
+	})
+
+	t.Run("closes the circuit on successful probe", func(t *testing.T) {
+		// given:
+		now := time.Now()
+		h := givenOpenCircuit(now)
+		h.allow(now.Add(OpenTimeout))
+
+		// when:
+		h.recordSuccess(time.Millisecond)
+
+		// then:
+		assert.Equal(t, CircuitClosed, h.stats("test", now).Circuit)
+		assert.True(t, h.allow(now.Add(OpenTimeout)))
+	})
+
+	t.Run("reopens the circuit on failed probe", func(t *testing.T) {
+		// given:
+		now := time.Now()
+		h := givenOpenCircuit(now)
+		probeAt := now.Add(OpenTimeout)
+		h.allow(probeAt)
+
+		// when:
+		h.recordFailure(time.Millisecond, errors.New("still failing"), probeAt)
+
+		// then:
+		assert.Equal(t, CircuitOpen, h.stats("test", now).Circuit)
+		assert.False(t, h.allow(probeAt.Add(OpenTimeout-time.Second)))
+		assert.True(t, h.allow(probeAt.Add(OpenTimeout)))
+	})
+
+	t.Run("lets the next probe through after the interrupted probe", func(t *testing.T) {
+		// given:
+		now := time.Now()
+		h := givenOpenCircuit(now)
+		probeAt := now.Add(OpenTimeout)
+		h.allow(probeAt)
+
+		// when:
+		h.recordInterrupted()
+
+		// then:
+		assert.Equal(t, CircuitHalfOpen, h.stats("test", now).Circuit)
+		assert.True(t, h.allow(probeAt))
+	})
+}
+
+func TestHealthScore(t *testing.T) {
+	t.Run("lowers the score after a few failures in a row", func(t *testing.T) {
+		// given:
+		now := time.Now()
+		h := newHealth()
+
+		// when:
+		for range 3 {
+			h.recordFailure(time.Millisecond, errors.New("some error occurred"), now)
+		}
+
+		// then:
+		assert.InDelta(t, 0.421875, h.currentScore(now), 0.000001)
+	})
+
+	t.Run("recovers the demoted service over time", func(t *testing.T) {
+		// given:
+		now := time.Now()
+		h := newHealth()
+		for range FailureThreshold {
+			h.recordFailure(time.Millisecond, errors.New("some error occurred"), now)
+		}
+
+		// then:
+		score := h.currentScore(now)
+		assert.InDelta(t, 1-(1-score)/2, h.currentScore(now.Add(ScoreHalfLife)), 0.000001)
+	})
+}
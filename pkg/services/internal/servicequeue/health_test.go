@@ -0,0 +1,229 @@
+package servicequeue_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/4chain-ag/go-wallet-toolbox/pkg/internal/logging"
+	"github.com/4chain-ag/go-wallet-toolbox/pkg/services/internal/servicequeue"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestQueueHealth(t *testing.T) {
+	t.Run("opens the circuit after consecutive failures and skips the service", func(t *testing.T) {
+		// given:
+		calls := 0
+		failing := func(ctx context.Context) (*TestServiceResult, error) {
+			calls++
+			return nil, errors.New("some error occurred")
+		}
+
+		// and:
+		queue := servicequeue.NewQueue(
+			logging.NewTestLogger(t),
+			"Do",
+			servicequeue.NewService("failing", failing),
+		)
+
+		// when:
+		for range servicequeue.FailureThreshold {
+			_, err := queue.OneByOne(context.Background())
+			require.Error(t, err)
+		}
+
+		// and:
+		_, err := queue.OneByOne(context.Background())
+
+		// then:
+		assert.ErrorIs(t, err, servicequeue.ErrCircuitOpen)
+		assert.Equal(t, servicequeue.FailureThreshold, calls)
+
+		// and:
+		stats := queue.Stats()
+		require.Len(t, stats, 1)
+		assert.Equal(t, "failing", stats[0].Name)
+		assert.Equal(t, servicequeue.CircuitOpen, stats[0].Circuit)
+		assert.EqualValues(t, servicequeue.FailureThreshold, stats[0].FailureCount)
+		assert.EqualValues(t, servicequeue.FailureThreshold, stats[0].ConsecutiveFailures)
+		assert.Equal(t, "some error occurred", stats[0].LastError)
+	})
+
+	t.Run("calls the healthier service first", func(t *testing.T) {
+		// given:
+		flaky := TestService{Name: "flaky"}.Failing().NewTest(t)
+		healthy := TestService{Name: "healthy"}.Successful().NewTest(t)
+
+		// and:
+		queue := servicequeue.NewQueue1(
+			logging.NewTestLogger(t),
+			"Do1",
+			servicequeue.NewService1(flaky.Name, flaky.Do1),
+			servicequeue.NewService1(healthy.Name, healthy.Do1),
+		)
+
+		// and: the flaky service fails once and the healthy one is called after it
+		_, err := queue.OneByOne(context.Background(), secondArgument)
+		require.NoError(t, err)
+
+		// when:
+		*flaky = *TestService{Name: "flaky"}.ShouldNotBeCalled().NewTest(t)
+		r, err := queue.OneByOne(context.Background(), secondArgument)
+
+		// then:
+		assert.NoError(t, err)
+		assert.Equal(t, &TestServiceResult{200, "success"}, r)
+
+		// and:
+		stats := queue.Stats()
+		require.Len(t, stats, 2)
+		assert.Equal(t, "flaky", stats[0].Name)
+		assert.EqualValues(t, 1, stats[0].FailureCount)
+		assert.Equal(t, "healthy", stats[1].Name)
+		assert.EqualValues(t, 2, stats[1].SuccessCount)
+		assert.Greater(t, stats[1].Score, stats[0].Score)
+	})
+
+	t.Run("keeps the configured order of equally healthy services", func(t *testing.T) {
+		// given:
+		first := TestService{Name: "first"}.Successful().NewTest(t)
+		second := TestService{Name: "second"}.ShouldNotBeCalled().NewTest(t)
+
+		// and:
+		queue := servicequeue.NewQueue1(
+			logging.NewTestLogger(t),
+			"Do1",
+			servicequeue.NewService1(first.Name, first.Do1),
+			servicequeue.NewService1(second.Name, second.Do1),
+		)
+
+		// when:
+		for range 3 {
+			r, err := queue.OneByOne(context.Background(), secondArgument)
+
+			// then:
+			require.NoError(t, err)
+			require.Equal(t, &TestServiceResult{200, "success"}, r)
+		}
+	})
+
+	t.Run("doesn't count the call cancelled by the caller as failure", func(t *testing.T) {
+		// given:
+		calls := 0
+		cancelled := func(ctx context.Context) (*TestServiceResult, error) {
+			calls++
+			return nil, ctx.Err()
+		}
+
+		// and:
+		queue := servicequeue.NewQueue(
+			logging.NewTestLogger(t),
+			"Do",
+			servicequeue.NewService("cancelled", cancelled),
+		)
+
+		// and:
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		// when:
+		for range servicequeue.FailureThreshold + 1 {
+			_, err := queue.OneByOne(ctx)
+			require.ErrorIs(t, err, context.Canceled)
+		}
+
+		// then:
+		assert.Equal(t, servicequeue.FailureThreshold+1, calls)
+
+		// and:
+		stats := queue.Stats()
+		require.Len(t, stats, 1)
+		assert.EqualValues(t, 0, stats[0].FailureCount)
+		assert.Equal(t, servicequeue.CircuitClosed, stats[0].Circuit)
+		assert.InDelta(t, 1, stats[0].Score, 0.001)
+	})
+
+	t.Run("doesn't count rejection of the request as failure", func(t *testing.T) {
+		// given:
+		rejecting := TestService{Name: "rejecting"}.Rejecting().NewTest(t)
+		next := TestService{Name: "next"}.Successful().NewTest(t)
+
+		// and:
+		queue := servicequeue.NewQueue1(
+			logging.NewTestLogger(t),
+			"Do1",
+			servicequeue.NewService1(rejecting.Name, rejecting.Do1),
+			servicequeue.NewService1(next.Name, next.Do1),
+		)
+
+		// when:
+		for range servicequeue.FailureThreshold {
+			r, err := queue.OneByOne(context.Background(), secondArgument)
+			require.NoError(t, err)
+			require.Equal(t, &TestServiceResult{200, "success"}, r)
+		}
+
+		// then:
+		stats := queue.Stats()
+		require.Len(t, stats, 2)
+		assert.EqualValues(t, 0, stats[0].FailureCount)
+		assert.Equal(t, servicequeue.CircuitClosed, stats[0].Circuit)
+		assert.InDelta(t, 1, stats[0].Score, 0.001)
+	})
+
+	t.Run("counts empty result as success", func(t *testing.T) {
+		// given:
+		empty := TestService{Name: "empty"}.ReturningNilResult().NewTest(t)
+
+		// and:
+		queue := servicequeue.NewQueue1(
+			logging.NewTestLogger(t),
+			"Do1",
+			servicequeue.NewService1(empty.Name, empty.Do1),
+		)
+
+		// when:
+		_, err := queue.OneByOne(context.Background(), secondArgument)
+
+		// then:
+		assert.ErrorIs(t, err, servicequeue.ErrEmptyResult)
+
+		// and:
+		stats := queue.Stats()
+		require.Len(t, stats, 1)
+		assert.EqualValues(t, 1, stats[0].SuccessCount)
+		assert.Equal(t, servicequeue.CircuitClosed, stats[0].Circuit)
+	})
+	t.Run("shares the health of the services of the same provider", func(t *testing.T) {
+		// given:
+		failing := TestService{Name: "provider"}.Failing().NewTest(t)
+		other := TestService{Name: "provider"}.ShouldNotBeCalled().NewTest(t)
+
+		// and:
+		failingService := servicequeue.NewService1(failing.Name, failing.Do1)
+		queue := servicequeue.NewQueue1(logging.NewTestLogger(t), "Do1", failingService)
+		otherQueue := servicequeue.NewQueue1(
+			logging.NewTestLogger(t),
+			"Other",
+			servicequeue.ShareHealth(servicequeue.NewService1(other.Name, other.Do1), failingService),
+		)
+
+		// and:
+		for range servicequeue.FailureThreshold {
+			_, err := queue.OneByOne(context.Background(), secondArgument)
+			require.Error(t, err)
+		}
+
+		// when:
+		_, err := otherQueue.OneByOne(context.Background(), secondArgument)
+
+		// then:
+		assert.ErrorIs(t, err, servicequeue.ErrCircuitOpen)
+
+		// and:
+		stats := otherQueue.Stats()
+		require.Len(t, stats, 1)
+		assert.EqualValues(t, servicequeue.FailureThreshold, stats[0].FailureCount)
+	})
+}
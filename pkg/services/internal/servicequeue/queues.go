@@ -7,6 +7,8 @@ import (
 	"iter"
 	"log/slog"
 	"runtime/debug"
+	"sync/atomic"
+	"time"
 
 	"github.com/4chain-ag/go-wallet-toolbox/pkg/internal/logging"
 	"github.com/4chain-ag/go-wallet-toolbox/pkg/services/internal"
@@ -45,10 +47,20 @@ func (q *Queue[R]) All(ctx context.Context) ([]*NamedResult[R], error) {
 	})
 }
 
+// Stats returns the health statistics of the services of the queue in the registration order.
+func (q *Queue[R]) Stats() []Stats {
+	return collectStats(q.services)
+}
+
+// MethodName returns the name of the method provided by the services of the queue.
+func (q *Queue[R]) MethodName() string {
+	return q.methodName
+}
+
 // OneByOne calls the services with provided context, one by one, until a successful result is obtained.
 // Returns the first successful result or an error if all services fail.
 func (q *Queue[R]) OneByOne(ctx context.Context) (R, error) {
-	return processOneByOne(q.logger, q.services, func(s *Service[R]) (R, error) {
+	return processOneByOne(ctx, q.logger, q.services, func(s *Service[R]) (R, error) {
 		return s.service(ctx)
 	})
 }
@@ -60,6 +72,7 @@ type Queue1[A, R any] struct {
 	logger     *slog.Logger
 	methodName string
 	services   []*Service1[A, R]
+	first      *atomic.Uint64
 }
 
 func NewQueue1[A, R any](logger *slog.Logger, methodName string, services ...*Service1[A, R]) Queue1[A, R] {
@@ -69,9 +82,15 @@ func NewQueue1[A, R any](logger *slog.Logger, methodName string, services ...*Se
 		logger:     logger,
 		methodName: methodName,
 		services:   services,
+		first:      &atomic.Uint64{},
 	}
 }
 
+// Next rotates the queue, so the following OneByOne calls start from the next service.
+func (q *Queue1[A, R]) Next() {
+	q.first.Add(1)
+}
+
 // All processes all services in parallel and returns the slice of results of all services.
 func (q *Queue1[A, R]) All(ctx context.Context, a A) ([]*NamedResult[R], error) {
 	return processParallel(ctx, q.logger, q.services, func(ctxParallel context.Context, s *Service1[A, R]) (R, error) {
@@ -79,11 +98,21 @@ func (q *Queue1[A, R]) All(ctx context.Context, a A) ([]*NamedResult[R], error)
 	})
 }
 
+// Stats returns the health statistics of the services of the queue in the registration order.
+func (q *Queue1[A, R]) Stats() []Stats {
+	return collectStats(q.services)
+}
+
+// MethodName returns the name of the method provided by the services of the queue.
+func (q *Queue1[A, R]) MethodName() string {
+	return q.methodName
+}
+
 // OneByOne processes services one by one until a successful result is obtained.
 // The context and argument is passed to each service.
 // Returns the first successful result or an error if all services fail.
 func (q *Queue1[A, R]) OneByOne(ctx context.Context, a A) (R, error) {
-	return processOneByOne(q.logger, q.services, func(s *Service1[A, R]) (R, error) {
+	return processOneByOne(ctx, q.logger, rotate(q.services, q.first.Load()), func(s *Service1[A, R]) (R, error) {
 		return s.service(ctx, a)
 	})
 }
@@ -114,11 +143,21 @@ func (q *Queue2[A, B, R]) All(ctx context.Context, a A, b B) ([]*NamedResult[R],
 	})
 }
 
+// Stats returns the health statistics of the services of the queue in the registration order.
+func (q *Queue2[A, B, R]) Stats() []Stats {
+	return collectStats(q.services)
+}
+
+// MethodName returns the name of the method provided by the services of the queue.
+func (q *Queue2[A, B, R]) MethodName() string {
+	return q.methodName
+}
+
 // OneByOne processes services one by one until a successful result is obtained.
 // The context and arguments are passed to each service.
 // Returns the first successful result or an error if all services fail.
 func (q *Queue2[A, B, R]) OneByOne(ctx context.Context, a A, b B) (R, error) {
-	return processOneByOne(q.logger, q.services, func(s *Service2[A, B, R]) (R, error) {
+	return processOneByOne(ctx, q.logger, q.services, func(s *Service2[A, B, R]) (R, error) {
 		return s.service(ctx, a, b)
 	})
 }
@@ -149,17 +188,28 @@ func (q *Queue3[A, B, C, R]) All(ctx context.Context, a A, b B, c C) ([]*NamedRe
 	})
 }
 
+// Stats returns the health statistics of the services of the queue in the registration order.
+func (q *Queue3[A, B, C, R]) Stats() []Stats {
+	return collectStats(q.services)
+}
+
+// MethodName returns the name of the method provided by the services of the queue.
+func (q *Queue3[A, B, C, R]) MethodName() string {
+	return q.methodName
+}
+
 // OneByOne processes services one by one until a successful result is obtained.
 // The context and arguments are passed to each service.
 // Returns the first successful result or an error if all services fail.
 func (q *Queue3[A, B, C, R]) OneByOne(ctx context.Context, a A, b B, c C) (R, error) {
-	return processOneByOne(q.logger, q.services, func(s *Service3[A, B, C, R]) (R, error) {
+	return processOneByOne(ctx, q.logger, q.services, func(s *Service3[A, B, C, R]) (R, error) {
 		return s.service(ctx, a, b, c)
 	})
 }
 
 type serv interface {
 	Name() string
+	health() *health
 }
 
 func processParallel[S serv, R any](ctx context.Context, logger *slog.Logger, services []S, callService func(context.Context, S) (R, error)) ([]*NamedResult[R], error) {
@@ -167,20 +217,10 @@ func processParallel[S serv, R any](ctx context.Context, logger *slog.Logger, se
 		return nil, ErrNoServicesRegistered
 	}
 
-	results := internal.MapParallel(ctx, seq.FromSlice(services), func(ctxParallel context.Context, s S) (result *NamedResult[R]) {
-		defer func() {
-			if r := recover(); r != nil {
-				var err error
-				var ok bool
-				if err, ok = r.(error); !ok {
-					err = fmt.Errorf("%v", r)
-				}
-				err = fmt.Errorf("service %s has paniced with: %w \n %s", s.Name(), err, debug.Stack())
-				result = NewNamedResult(s.Name(), types.FailureResult[R](err))
-			}
-		}()
-		result = NewNamedResult(s.Name(), types.ResultOf(callService(ctxParallel, s)))
-		return
+	results := internal.MapParallel(ctx, seq.FromSlice(services), func(ctxParallel context.Context, s S) *NamedResult[R] {
+		return callWithHealth(ctxParallel, s, func() (R, error) {
+			return callService(ctxParallel, s)
+		})
 	})
 
 	results = seq.Map(results, func(result *NamedResult[R]) *NamedResult[R] {
@@ -202,26 +242,15 @@ func processParallel[S serv, R any](ctx context.Context, logger *slog.Logger, se
 	return seq.Collect(results), nil
 }
 
-func processOneByOne[S serv, R any](logger *slog.Logger, services []S, callService func(S) (R, error)) (R, error) {
+func processOneByOne[S serv, R any](ctx context.Context, logger *slog.Logger, services []S, callService func(S) (R, error)) (R, error) {
 	if len(services) == 0 {
 		return to.ZeroValue[R](), ErrNoServicesRegistered
 	}
 
-	results := seq.Map(seq.FromSlice(services), func(s S) (result *NamedResult[R]) {
-		defer func() {
-			if r := recover(); r != nil {
-				var err error
-				var ok bool
-				if err, ok = r.(error); !ok {
-					err = fmt.Errorf("%v", r)
-				}
-				err = fmt.Errorf("service %s has paniced with: %w \n %s", s.Name(), err, debug.Stack())
-				result = NewNamedResult(s.Name(), types.FailureResult[R](err))
-			}
-		}()
-		res, err := callService(s)
-		result = NewNamedResult(s.Name(), types.ResultOf(res, err))
-		return
+	results := seq.Map(seq.FromSlice(byHealth(services)), func(s S) *NamedResult[R] {
+		return callWithHealth(ctx, s, func() (R, error) {
+			return callService(s)
+		})
 	})
 
 	results = takeUntilHaveResult[R](results)
@@ -232,6 +261,9 @@ func processOneByOne[S serv, R any](logger *slog.Logger, services []S, callServi
 	for result := range results {
 		if result.IsError() {
 			err = errors.Join(err, fmt.Errorf("error from service %s: %w", result.Name(), result.GetError()))
+			if errors.Is(result.GetError(), ErrFinalRejection) {
+				return to.ZeroValue[R](), fmt.Errorf("service %s finally rejected the request: %w", result.Name(), err)
+			}
 			continue
 		}
 		return result.MustGetValue(), nil
@@ -240,6 +272,56 @@ func processOneByOne[S serv, R any](logger *slog.Logger, services []S, callServi
 	return to.ZeroValue[R](), fmt.Errorf("all services failed: %w", err)
 }
 
+// callWithHealth calls the service unless its circuit is open, recovers from its panic and records the outcome in its health.
+// Empty result and the rejection of the request (see ErrRejected) are treated as a success, because the service responded.
+// The call interrupted by the cancellation of the caller's context is not recorded at all, because it says nothing about the service.
+func callWithHealth[S serv, R any](ctx context.Context, s S, call func() (R, error)) (result *NamedResult[R]) {
+	tracker := s.health()
+	if !tracker.allow(time.Now()) {
+		return NewNamedResult(s.Name(), types.FailureResult[R](ErrCircuitOpen))
+	}
+
+	start := time.Now()
+	defer func() {
+		if r := recover(); r != nil {
+			var err error
+			var ok bool
+			if err, ok = r.(error); !ok {
+				err = fmt.Errorf("%v", r)
+			}
+			err = fmt.Errorf("service %s has paniced with: %w \n %s", s.Name(), err, debug.Stack())
+			result = NewNamedResult(s.Name(), types.FailureResult[R](err))
+		}
+
+		switch {
+		case result.IsError() && (ctx.Err() != nil || errors.Is(result.GetError(), context.Canceled)):
+			tracker.recordInterrupted()
+		case result.IsError() && !errors.Is(result.GetError(), ErrRejected):
+			tracker.recordFailure(time.Since(start), result.GetError(), time.Now())
+		default:
+			tracker.recordSuccess(time.Since(start))
+		}
+	}()
+
+	return NewNamedResult(s.Name(), types.ResultOf(call()))
+}
+
+func collectStats[S serv](services []S) []Stats {
+	stats := make([]Stats, 0, len(services))
+	for _, s := range services {
+		stats = append(stats, s.health().stats(s.Name(), time.Now()))
+	}
+	return stats
+}
+
+func rotate[S any](services []S, first uint64) []S {
+	if len(services) == 0 {
+		return services
+	}
+	split := first % uint64(len(services))
+	return append(services[split:len(services):len(services)], services[:split]...)
+}
+
 func logErrorResult[R any](logger *slog.Logger) func(serviceResult *NamedResult[R]) {
 	return func(serviceResult *NamedResult[R]) {
 		if serviceResult.IsError() {
@@ -3,6 +3,7 @@ package servicequeue_test
 import (
 	"context"
 	"errors"
+	"fmt"
 	"testing"
 
 	"github.com/4chain-ag/go-wallet-toolbox/pkg/internal/logging"
@@ -145,6 +146,25 @@ func TestQueueOneByOne(t *testing.T) {
 				return assert.ErrorIs(t, err, errorFromPanic, msgAndArgs...)
 			},
 		},
+		"rejected request should be tried with the next service": {
+			services: []TestService{
+				TestService{Name: "rejecting"}.Rejecting(),
+				TestService{Name: "success-service"}.Successful(),
+			},
+			expectedResult:   &TestServiceResult{200, "success"},
+			errorExpectation: assert.NoError,
+		},
+		"finally rejected request should not be tried with the next service": {
+			services: []TestService{
+				TestService{Name: "error-service"}.Failing(),
+				TestService{Name: "finally-rejecting"}.FinallyRejecting(),
+				TestService{Name: "should-not-be-called"}.ShouldNotBeCalled(),
+			},
+			expectedResult: nil,
+			errorExpectation: func(t assert.TestingT, err error, msgAndArgs ...interface{}) bool {
+				return assert.ErrorIs(t, err, servicequeue.ErrFinalRejection, msgAndArgs...)
+			},
+		},
 		"return result of second service if first service would panic": {
 			services: []TestService{
 				TestService{Name: "panicking"}.Panicking(),
@@ -597,6 +617,55 @@ func TestQueueParallel(t *testing.T) {
 
 }
 
+func TestQueueNext(t *testing.T) {
+	t.Run("OneByOne starts from the next service after rotation", func(t *testing.T) {
+		// given:
+		first := TestService{Name: "first"}.ShouldNotBeCalled().NewTest(t)
+		second := TestService{Name: "second"}.Failing().NewTest(t)
+		third := TestService{Name: "third"}.Successful().NewTest(t)
+
+		// and:
+		queue := servicequeue.NewQueue1(
+			logging.NewTestLogger(t),
+			"Do1",
+			servicequeue.NewService1(first.Name, first.Do1),
+			servicequeue.NewService1(second.Name, second.Do1),
+			servicequeue.NewService1(third.Name, third.Do1),
+		)
+
+		// when:
+		queue.Next()
+		r, err := queue.OneByOne(context.Background(), secondArgument)
+
+		// then:
+		assert.NoError(t, err)
+		assert.Equal(t, &TestServiceResult{200, "success"}, r)
+	})
+
+	t.Run("rotation wraps around to the first service", func(t *testing.T) {
+		// given:
+		first := TestService{Name: "first"}.Successful().NewTest(t)
+		second := TestService{Name: "second"}.ShouldNotBeCalled().NewTest(t)
+
+		// and:
+		queue := servicequeue.NewQueue1(
+			logging.NewTestLogger(t),
+			"Do1",
+			servicequeue.NewService1(first.Name, first.Do1),
+			servicequeue.NewService1(second.Name, second.Do1),
+		)
+
+		// when:
+		queue.Next()
+		queue.Next()
+		r, err := queue.OneByOne(context.Background(), secondArgument)
+
+		// then:
+		assert.NoError(t, err)
+		assert.Equal(t, &TestServiceResult{200, "success"}, r)
+	})
+}
+
 type TestServiceResult struct {
 	StatusCode int
 	Status     string
@@ -622,6 +691,20 @@ func (s TestService) Failing() TestService {
 	return s
 }
 
+func (s TestService) Rejecting() TestService {
+	s.createResult = func() (*TestServiceResult, error) {
+		return nil, fmt.Errorf("%w: invalid request", servicequeue.ErrRejected)
+	}
+	return s
+}
+
+func (s TestService) FinallyRejecting() TestService {
+	s.createResult = func() (*TestServiceResult, error) {
+		return nil, fmt.Errorf("%w: double spend", servicequeue.ErrFinalRejection)
+	}
+	return s
+}
+
 func (s TestService) ReturningNilResult() TestService {
 	s.createResult = func() (*TestServiceResult, error) {
 		return nil, nil
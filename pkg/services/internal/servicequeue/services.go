@@ -1,10 +1,14 @@
 package servicequeue
 
-import "context"
+import (
+	"context"
+	"time"
+)
 
 // Service represents a named service function accepting only context.Context as an argument.
 type Service[R any] struct {
 	name    string
+	tracker *health
 	service func(context.Context) (R, error)
 }
 
@@ -12,6 +16,7 @@ type Service[R any] struct {
 func NewService[R any](name string, service func(context.Context) (R, error)) *Service[R] {
 	return &Service[R]{
 		name:    name,
+		tracker: newHealth(),
 		service: service,
 	}
 }
@@ -22,9 +27,19 @@ func (s *Service[R]) Name() string {
 	return s.name
 }
 
+// Stats returns the health statistics of the service.
+func (s *Service[R]) Stats() Stats {
+	return s.tracker.stats(s.name, time.Now())
+}
+
+func (s *Service[R]) health() *health {
+	return s.tracker
+}
+
 // Service1 represents a named service function accepting context.Context and one additional argument.
 type Service1[A, R any] struct {
 	name    string
+	tracker *health
 	service func(context.Context, A) (R, error)
 }
 
@@ -32,6 +47,7 @@ type Service1[A, R any] struct {
 func NewService1[A, R any](name string, service func(context.Context, A) (R, error)) *Service1[A, R] {
 	return &Service1[A, R]{
 		name:    name,
+		tracker: newHealth(),
 		service: service,
 	}
 }
@@ -42,10 +58,20 @@ func (s *Service1[A, R]) Name() string {
 	return s.name
 }
 
+// Stats returns the health statistics of the service.
+func (s *Service1[A, R]) Stats() Stats {
+	return s.tracker.stats(s.name, time.Now())
+}
+
+func (s *Service1[A, R]) health() *health {
+	return s.tracker
+}
+
 // Service2 represents a named service function accepting context.Context and two additional arguments.
 // It is used for services that require two parameters to perform their operations.
 type Service2[A, B, R any] struct {
 	name    string
+	tracker *health
 	service func(context.Context, A, B) (R, error)
 }
 
@@ -53,6 +79,7 @@ type Service2[A, B, R any] struct {
 func NewService2[A, B, R any](name string, service func(context.Context, A, B) (R, error)) *Service2[A, B, R] {
 	return &Service2[A, B, R]{
 		name:    name,
+		tracker: newHealth(),
 		service: service,
 	}
 }
@@ -63,9 +90,19 @@ func (s *Service2[A, B, R]) Name() string {
 	return s.name
 }
 
+// Stats returns the health statistics of the service.
+func (s *Service2[A, B, R]) Stats() Stats {
+	return s.tracker.stats(s.name, time.Now())
+}
+
+func (s *Service2[A, B, R]) health() *health {
+	return s.tracker
+}
+
 // Service3 represents a named service function accepting context.Context and three additional arguments.
 type Service3[A, B, C, R any] struct {
 	name    string
+	tracker *health
 	service func(context.Context, A, B, C) (R, error)
 }
 
@@ -73,6 +110,7 @@ type Service3[A, B, C, R any] struct {
 func NewService3[A, B, C, R any](name string, service func(context.Context, A, B, C) (R, error)) *Service3[A, B, C, R] {
 	return &Service3[A, B, C, R]{
 		name:    name,
+		tracker: newHealth(),
 		service: service,
 	}
 }
@@ -82,3 +120,19 @@ func NewService3[A, B, C, R any](name string, service func(context.Context, A, B
 func (s *Service3[A, B, C, R]) Name() string {
 	return s.name
 }
+
+// Stats returns the health statistics of the service.
+func (s *Service3[A, B, C, R]) Stats() Stats {
+	return s.tracker.stats(s.name, time.Now())
+}
+
+func (s *Service3[A, B, C, R]) health() *health {
+	return s.tracker
+}
+
+// ShareHealth makes the service share the health tracking with another service of the same provider,
+// so e.g. the provider rate limiting the calls of one method is demoted for the other method too.
+func ShareHealth[A, R, B, Q any](s *Service1[A, R], with *Service1[B, Q]) *Service1[A, R] {
+	s.tracker = with.tracker
+	return s
+}
@@ -31,6 +31,9 @@ type ArcFixture interface {
 	HttpClient() *resty.Client
 	TxInfoJSON(id string) string
 	WillAlwaysReturnStatus(httpStatus int)
+	WillReturnPolicyWithMiningFee(satoshis, bytes uint64)
+	WillReturnMinedTransaction(txID, blockHash string, merklePath *sdk.MerklePath)
+	WillReturnDoubleSpending(txID string, competingTxs ...string)
 }
 
 type arcFixture struct {
@@ -75,7 +78,7 @@ func (f *arcFixture) WillAlwaysReturnStatus(httpStatus int) {
 		details = "The server encountered an internal error and was unable to complete your request"
 	}
 
-	f.transport.RegisterResponder(http.MethodPost, "=~"+ArcURL+"/v1/tx.*", func(req *http.Request) (*http.Response, error) {
+	responder := func(req *http.Request) (*http.Response, error) {
 		return httpmock.NewJsonResponse(httpStatus, map[string]any{
 			"error":     details,
 			"extraInfo": "",
@@ -85,6 +88,54 @@ func (f *arcFixture) WillAlwaysReturnStatus(httpStatus int) {
 			"txid":      nil,
 			"type":      "https://bitcoin-sv.github.io/arc/#/errors?id=_" + to.StringFromInteger(httpStatus),
 		})
+	}
+
+	f.transport.RegisterResponder(http.MethodPost, "=~"+ArcURL+"/v1/tx.*", responder)
+	f.transport.RegisterResponder(http.MethodGet, "=~"+ArcURL+"/v1/policy", responder)
+}
+
+func (f *arcFixture) WillReturnPolicyWithMiningFee(satoshis, bytes uint64) {
+	f.transport.RegisterResponder(http.MethodGet, "=~/v1/policy$", func(req *http.Request) (*http.Response, error) {
+		return httpmock.NewJsonResponse(http.StatusOK, map[string]any{
+			"policy": map[string]any{
+				"maxscriptsizepolicy":     100000000,
+				"maxtxsigopscountspolicy": 4294967295,
+				"maxtxsizepolicy":         100000000,
+				"miningFee": map[string]any{
+					"satoshis": satoshis,
+					"bytes":    bytes,
+				},
+			},
+			"timestamp": timestamp,
+		})
+	})
+}
+
+func (f *arcFixture) WillReturnMinedTransaction(txID, blockHash string, merklePath *sdk.MerklePath) {
+	known := &knownTransaction{
+		txid:        txID,
+		status:      "MINED",
+		blockHeight: merklePath.BlockHeight,
+		blockHash:   blockHash,
+		merklePath:  merklePath.Hex(),
+	}
+	f.knownTransactions[txID] = known
+
+	f.transport.RegisterResponder(http.MethodGet, "=~/v1/tx/"+txID+"$", func(req *http.Request) (*http.Response, error) {
+		return known.toResponse()
+	})
+}
+
+func (f *arcFixture) WillReturnDoubleSpending(txID string, competingTxs ...string) {
+	known := &knownTransaction{
+		txid:         txID,
+		status:       "DOUBLE_SPEND_ATTEMPTED",
+		competingTxs: competingTxs,
+	}
+	f.knownTransactions[txID] = known
+
+	f.transport.RegisterResponder(http.MethodPost, "=~/v1/tx$", func(req *http.Request) (*http.Response, error) {
+		return known.toResponse()
 	})
 }
 
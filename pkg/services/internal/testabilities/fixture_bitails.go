@@ -0,0 +1,75 @@
+package testabilities
+
+import (
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/4chain-ag/go-wallet-toolbox/pkg/services/internal/bitails"
+	"github.com/jarcoal/httpmock"
+	"github.com/stretchr/testify/require"
+)
+
+const BitailsAPIKey = "bitails_test_api_key"
+
+type BitailsFixture interface {
+	WillRespondWithRawTx(status int, txID, rawTxHex string)
+
+	WillRespondWithMerkleProof(status int, txID, content string)
+
+	WillRespondWithBroadcast(status int, content string)
+
+	WillRespondWithScriptUnspent(status int, scriptHash, content string)
+
+	WillRespondWithScriptHistory(status int, scriptHash, content string)
+}
+
+type bitailsFixture struct {
+	testing.TB
+	transport *httpmock.MockTransport
+}
+
+func NewBitailsFixture(t testing.TB) BitailsFixture {
+	return NewBitailsFixtureWithTransport(t, httpmock.NewMockTransport())
+}
+
+func NewBitailsFixtureWithTransport(t testing.TB, transport *httpmock.MockTransport) BitailsFixture {
+	require.NotNil(t, transport, "transport must be provided")
+	return &bitailsFixture{
+		TB:        t,
+		transport: transport,
+	}
+}
+
+// WillRespondWithRawTx makes the service respond with the binary transaction decoded from rawTxHex.
+func (f *bitailsFixture) WillRespondWithRawTx(status int, txID, rawTxHex string) {
+	rawTx, err := hex.DecodeString(rawTxHex)
+	require.NoError(f, err, "raw tx must be a valid hex")
+
+	url := fmt.Sprintf("%s/download/tx/%s", bitails.TestnetURL, txID)
+	f.transport.RegisterResponder(http.MethodGet, url, func(req *http.Request) (*http.Response, error) {
+		res := httpmock.NewBytesResponse(status, rawTx)
+		res.Header.Set("Content-Type", "application/octet-stream")
+		return res, nil
+	})
+}
+
+func (f *bitailsFixture) WillRespondWithMerkleProof(status int, txID, content string) {
+	url := fmt.Sprintf("%s/tx/%s/proof/tsc", bitails.TestnetURL, txID)
+	f.transport.RegisterResponder(http.MethodGet, url, jsonResponder(status, content))
+}
+
+func (f *bitailsFixture) WillRespondWithBroadcast(status int, content string) {
+	f.transport.RegisterResponder(http.MethodPost, bitails.TestnetURL+"/tx/broadcast/multi", jsonResponder(status, content))
+}
+
+func (f *bitailsFixture) WillRespondWithScriptUnspent(status int, scriptHash, content string) {
+	url := fmt.Sprintf("%s/scripthash/%s/unspent", bitails.TestnetURL, scriptHash)
+	f.transport.RegisterResponder(http.MethodGet, url, jsonResponder(status, content))
+}
+
+func (f *bitailsFixture) WillRespondWithScriptHistory(status int, scriptHash, content string) {
+	url := fmt.Sprintf("%s/scripthash/%s/history", bitails.TestnetURL, scriptHash)
+	f.transport.RegisterResponder(http.MethodGet, url, jsonResponder(status, content))
+}
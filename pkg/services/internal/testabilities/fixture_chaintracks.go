@@ -0,0 +1,121 @@
+package testabilities
+
+import (
+	"net/http"
+	"strconv"
+	"testing"
+
+	"github.com/4chain-ag/go-wallet-toolbox/pkg/services/internal/chaintracks"
+	"github.com/4chain-ag/go-wallet-toolbox/pkg/wdk"
+	"github.com/jarcoal/httpmock"
+	"github.com/stretchr/testify/require"
+)
+
+const ChaintracksURL = "https://chaintracks.example.com"
+
+type ChaintracksFixture interface {
+	IsUpAndRunning()
+	WillRespondWithHeight(height uint32)
+	WillRespondWithHeader(header chaintracks.BlockHeader)
+	WillServeHeaders(headers ...string)
+	WillAlwaysFail()
+	WillRespondWithFiatRates(status int, rates wdk.FiatExchangeRates)
+}
+
+type chaintracksFixture struct {
+	testing.TB
+	transport *httpmock.MockTransport
+}
+
+func NewChaintracksFixture(t testing.TB) ChaintracksFixture {
+	return NewChaintracksFixtureWithTransport(t, httpmock.NewMockTransport())
+}
+
+func NewChaintracksFixtureWithTransport(t testing.TB, transport *httpmock.MockTransport) ChaintracksFixture {
+	require.NotNil(t, transport, "transport must be provided")
+	return &chaintracksFixture{
+		TB:        t,
+		transport: transport,
+	}
+}
+
+// IsUpAndRunning makes the service respond with no value for the headers not registered by WillRespondWithHeader.
+func (f *chaintracksFixture) IsUpAndRunning() {
+	notFound := chaintracksResponder(http.StatusOK, map[string]any{"status": "success"})
+	f.transport.RegisterResponder(http.MethodGet, ChaintracksURL+"/findHeaderHexForHeight", notFound)
+	f.transport.RegisterResponder(http.MethodGet, ChaintracksURL+"/findHeaderHexForBlockHash", notFound)
+}
+
+func (f *chaintracksFixture) WillRespondWithHeight(height uint32) {
+	f.transport.RegisterResponder(http.MethodGet, ChaintracksURL+"/getPresentHeight", chaintracksResponder(http.StatusOK, map[string]any{
+		"status": "success",
+		"value":  height,
+	}))
+}
+
+func (f *chaintracksFixture) WillRespondWithHeader(header chaintracks.BlockHeader) {
+	responder := chaintracksResponder(http.StatusOK, map[string]any{
+		"status": "success",
+		"value":  header,
+	})
+
+	f.transport.RegisterResponderWithQuery(http.MethodGet, ChaintracksURL+"/findHeaderHexForHeight", map[string]string{
+		"height": strconv.FormatUint(uint64(header.Height), 10),
+	}, responder)
+	f.transport.RegisterResponderWithQuery(http.MethodGet, ChaintracksURL+"/findHeaderHexForBlockHash", map[string]string{
+		"hash": header.Hash,
+	}, responder)
+}
+
+// WillServeHeaders makes the service respond to getHeaders with the hex serialized headers of the chain starting at height 0.
+func (f *chaintracksFixture) WillServeHeaders(headers ...string) {
+	f.transport.RegisterResponder(http.MethodGet, ChaintracksURL+"/getHeaders", func(req *http.Request) (*http.Response, error) {
+		height, err := strconv.Atoi(req.URL.Query().Get("height"))
+		require.NoError(f, err)
+		count, err := strconv.Atoi(req.URL.Query().Get("count"))
+		require.NoError(f, err)
+
+		var value string
+		for h := height; h < height+count && h < len(headers); h++ {
+			value += headers[h]
+		}
+		return httpmock.NewJsonResponse(http.StatusOK, map[string]any{
+			"status": "success",
+			"value":  value,
+		})
+	})
+}
+
+func (f *chaintracksFixture) WillAlwaysFail() {
+	f.transport.RegisterResponder(http.MethodGet, "=~^"+ChaintracksURL+"/", chaintracksResponder(http.StatusInternalServerError, map[string]any{
+		"status":      "error",
+		"code":        "ERR_INTERNAL",
+		"description": "An internal error has occurred.",
+	}))
+}
+
+func (f *chaintracksFixture) WillRespondWithFiatRates(status int, rates wdk.FiatExchangeRates) {
+	content := map[string]any{
+		"status": "success",
+		"value": map[string]any{
+			"timestamp": rates.Timestamp,
+			"base":      rates.Base,
+			"rates":     rates.Rates,
+		},
+	}
+	if status != http.StatusOK {
+		content = map[string]any{
+			"status":      "error",
+			"code":        "ERR_INTERNAL",
+			"description": "An internal error has occurred.",
+		}
+	}
+
+	f.transport.RegisterResponder(http.MethodGet, "=~/getFiatExchangeRates$", chaintracksResponder(status, content))
+}
+
+func chaintracksResponder(status int, content map[string]any) httpmock.Responder {
+	return func(req *http.Request) (*http.Response, error) {
+		return httpmock.NewJsonResponse(status, content)
+	}
+}
@@ -0,0 +1,35 @@
+package testabilities
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/4chain-ag/go-wallet-toolbox/pkg/services/internal/exchangeratesapi"
+	"github.com/jarcoal/httpmock"
+	"github.com/stretchr/testify/require"
+)
+
+type ExchangeRatesAPIFixture interface {
+	WillRespondWithRates(status int, content string)
+}
+
+type exchangeRatesAPIFixture struct {
+	testing.TB
+	transport *httpmock.MockTransport
+}
+
+func NewExchangeRatesAPIFixture(t testing.TB) ExchangeRatesAPIFixture {
+	return NewExchangeRatesAPIFixtureWithTransport(t, httpmock.NewMockTransport())
+}
+
+func NewExchangeRatesAPIFixtureWithTransport(t testing.TB, transport *httpmock.MockTransport) ExchangeRatesAPIFixture {
+	require.NotNil(t, transport, "transport must be provided")
+	return &exchangeRatesAPIFixture{
+		TB:        t,
+		transport: transport,
+	}
+}
+
+func (f *exchangeRatesAPIFixture) WillRespondWithRates(status int, content string) {
+	f.transport.RegisterResponder(http.MethodGet, exchangeratesapi.URL, jsonResponder(status, content))
+}
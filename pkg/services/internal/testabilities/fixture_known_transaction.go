@@ -13,6 +13,8 @@ type knownTransaction struct {
 	blockHeight uint32
 	blockHash   string
 	merklePath  string
+
+	competingTxs []string
 }
 
 func (t *knownTransaction) toResponse() (*http.Response, error) {
@@ -42,7 +44,7 @@ func (t *knownTransaction) toResponseContent() (int, map[string]any) {
 	return http.StatusOK, map[string]any{
 		"blockHash":    t.blockHash,
 		"blockHeight":  t.blockHeight,
-		"competingTxs": nil,
+		"competingTxs": t.competingTxs,
 		"extraInfo":    "",
 		"merklePath":   t.merklePath,
 		"timestamp":    timestamp,
@@ -11,6 +11,7 @@ import (
 	"github.com/4chain-ag/go-wallet-toolbox/pkg/services"
 	"github.com/4chain-ag/go-wallet-toolbox/pkg/services/configuration"
 	"github.com/4chain-ag/go-wallet-toolbox/pkg/services/internal/arc"
+	"github.com/4chain-ag/go-wallet-toolbox/pkg/services/internal/chaintracks"
 	"github.com/4chain-ag/go-wallet-toolbox/pkg/services/internal/whatsonchain"
 	"github.com/4chain-ag/go-wallet-toolbox/pkg/wdk"
 	"github.com/go-resty/resty/v2"
@@ -22,6 +23,9 @@ import (
 type ServicesFixture interface {
 	WhatsOnChain() WhatsOnChainFixture
 	ARC() ArcFixture
+	Bitails() BitailsFixture
+	Chaintracks() ChaintracksFixture
+	ExchangeRatesAPI() ExchangeRatesAPIFixture
 
 	Services() WalletServicesFixture
 	NewServicesWithConfig(config configuration.WalletServices) *services.WalletServices
@@ -32,7 +36,11 @@ type WalletServicesFixture interface {
 
 	WithBsvExchangeRate(exchangeRate wdk.BSVExchangeRate) *services.WalletServices
 
+	WithConfig(opts ...func(*configuration.WalletServices)) *services.WalletServices
+
 	NewArcService(opts ...func(*arc.Config)) *arc.Service
+
+	NewChaintracksClient() *chaintracks.Client
 }
 
 type servicesFixture struct {
@@ -45,6 +53,9 @@ type servicesFixture struct {
 	walletServicesConfig *configuration.WalletServices
 	woc                  WhatsOnChainFixture
 	arc                  ArcFixture
+	bitails              BitailsFixture
+	chaintracks          ChaintracksFixture
+	exchangeRatesAPI     ExchangeRatesAPIFixture
 }
 
 func Given(t testing.TB) ServicesFixture {
@@ -56,6 +67,9 @@ func Given(t testing.TB) ServicesFixture {
 
 	wocFx := NewWoCFixtureWithTransport(t, transport)
 	arcFx := NewArcFixtureWithTransport(t, transport)
+	bitailsFx := NewBitailsFixtureWithTransport(t, transport)
+	chaintracksFx := NewChaintracksFixtureWithTransport(t, transport)
+	exchangeRatesAPIFx := NewExchangeRatesAPIFixtureWithTransport(t, transport)
 
 	return &servicesFixture{
 		t:                    t,
@@ -66,6 +80,9 @@ func Given(t testing.TB) ServicesFixture {
 		walletServicesConfig: &servicesConfig,
 		woc:                  wocFx,
 		arc:                  arcFx,
+		bitails:              bitailsFx,
+		chaintracks:          chaintracksFx,
+		exchangeRatesAPI:     exchangeRatesAPIFx,
 	}
 }
 
@@ -77,10 +94,23 @@ func (f *servicesFixture) ARC() ArcFixture {
 	return f.arc
 }
 
+func (f *servicesFixture) Bitails() BitailsFixture {
+	return f.bitails
+}
+
+func (f *servicesFixture) Chaintracks() ChaintracksFixture {
+	return f.chaintracks
+}
+
+func (f *servicesFixture) ExchangeRatesAPI() ExchangeRatesAPIFixture {
+	return f.exchangeRatesAPI
+}
+
 func (f *servicesFixture) WithDefaultConfig() *services.WalletServices {
 	f.t.Helper()
 
-	walletServices := services.New(f.httpClient, f.logger, *f.walletServicesConfig)
+	walletServices, err := services.New(f.httpClient, f.logger, *f.walletServicesConfig)
+	require.NoError(f.t, err)
 	f.services = walletServices
 
 	return f.services
@@ -90,7 +120,21 @@ func (f *servicesFixture) WithBsvExchangeRate(exchangeRate wdk.BSVExchangeRate)
 	f.t.Helper()
 	f.walletServicesConfig.WhatsOnChain.BSVExchangeRate = exchangeRate
 
-	walletServices := services.New(f.httpClient, f.logger, *f.walletServicesConfig)
+	walletServices, err := services.New(f.httpClient, f.logger, *f.walletServicesConfig)
+	require.NoError(f.t, err)
+	f.services = walletServices
+
+	return f.services
+}
+
+func (f *servicesFixture) WithConfig(opts ...func(*configuration.WalletServices)) *services.WalletServices {
+	f.t.Helper()
+	for _, opt := range opts {
+		opt(f.walletServicesConfig)
+	}
+
+	walletServices, err := services.New(f.httpClient, f.logger, *f.walletServicesConfig)
+	require.NoError(f.t, err)
 	f.services = walletServices
 
 	return f.services
@@ -111,6 +155,10 @@ func (f *servicesFixture) NewArcService(opts ...func(*arc.Config)) *arc.Service
 	return arc.NewARCService(logger, httpClient, config)
 }
 
+func (f *servicesFixture) NewChaintracksClient() *chaintracks.Client {
+	return chaintracks.NewClient(logging.NewTestLogger(f.t), f.httpClient, ChaintracksURL)
+}
+
 func (f *servicesFixture) Services() WalletServicesFixture {
 	return f
 }
@@ -118,7 +166,8 @@ func (f *servicesFixture) Services() WalletServicesFixture {
 func (f *servicesFixture) NewServicesWithConfig(config configuration.WalletServices) *services.WalletServices {
 	f.t.Helper()
 
-	walletServices := services.New(f.httpClient, f.logger, config)
+	walletServices, err := services.New(f.httpClient, f.logger, config)
+	require.NoError(f.t, err)
 
 	f.services = walletServices
 
@@ -159,8 +208,10 @@ func servicesCfg(chain defs.BSVNetwork) configuration.WalletServices {
 		DisableMapiCallback:             true, // rely on WalletMonitor by default
 		ExchangeratesApiKey:             "bd539d2ff492bcb5619d5f27726a766f",
 		ChaintracksFiatExchangeRatesUrl: fmt.Sprintf("https://npm-registry.babbage.systems:%d/getFiatExchangeRates", port),
-		Chaintracks:                     nil, // TODO: implement me
 		ArcURL:                          arcUrl,
-		ArcConfig:                       nil, // TODO: implement me
+		ArcConfig: configuration.ARC{
+			Token:        taalApiKey,
+			DeploymentID: DeploymentID,
+		},
 	}
 }
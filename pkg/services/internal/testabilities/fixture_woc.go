@@ -1,6 +1,7 @@
 package testabilities
 
 import (
+	"encoding/json"
 	"fmt"
 	"net/http"
 	"testing"
@@ -13,6 +14,28 @@ type WhatsOnChainFixture interface {
 	WillRespondWithRates(status int, content string, err error)
 
 	WillRespondWithRawTx(status int, txID, rawTx string, err error)
+
+	WillRespondWithMerkleProof(status int, txID, content string)
+
+	WillRespondWithBlockHeader(status int, blockHash, content string)
+
+	WillRespondWithBroadcast(status int, content string)
+
+	WillRespondWithScriptUnspent(status int, scriptHash, content string)
+
+	// WillRespondWithScriptsHistory registers the bulk confirmed and unconfirmed scripts history endpoints,
+	// content is generated for script hashes of every request
+	WillRespondWithScriptsHistory(status int, confirmed, unconfirmed func(scriptHashes []string) string)
+
+	// WillRespondWithScriptHistoryPage registers the page of confirmed or unconfirmed (kind) history of the script for given token
+	WillRespondWithScriptHistoryPage(status int, scriptHash, kind, token, content string)
+
+	WillRespondWithChainInfo(status int, content string)
+
+	WillRespondWithBlockAtHeight(status int, height uint32, content string)
+
+	// WillRespondWithTxsStatus registers the bulk txs status endpoint, content is generated for txids of every request
+	WillRespondWithTxsStatus(status int, content func(txIDs []string) string)
 }
 
 type wocFixture struct {
@@ -63,3 +86,73 @@ func (f *wocFixture) WillRespondWithRawTx(status int, txID, rawTx string, err er
 	url := fmt.Sprintf("https://api.whatsonchain.com/v1/bsv/test/tx/%s/hex", txID)
 	f.transport.RegisterResponder("GET", url, responder(status, rawTx))
 }
+
+func (f *wocFixture) WillRespondWithMerkleProof(status int, txID, content string) {
+	url := fmt.Sprintf("https://api.whatsonchain.com/v1/bsv/test/tx/%s/proof/tsc", txID)
+	f.transport.RegisterResponder("GET", url, jsonResponder(status, content))
+}
+
+func (f *wocFixture) WillRespondWithBlockHeader(status int, blockHash, content string) {
+	url := fmt.Sprintf("https://api.whatsonchain.com/v1/bsv/test/block/%s/header", blockHash)
+	f.transport.RegisterResponder("GET", url, jsonResponder(status, content))
+}
+
+func (f *wocFixture) WillRespondWithBroadcast(status int, content string) {
+	f.transport.RegisterResponder("POST", "https://api.whatsonchain.com/v1/bsv/test/tx/raw", jsonResponder(status, content))
+}
+
+func (f *wocFixture) WillRespondWithScriptUnspent(status int, scriptHash, content string) {
+	url := fmt.Sprintf("https://api.whatsonchain.com/v1/bsv/test/script/%s/unspent/all", scriptHash)
+	f.transport.RegisterResponder("GET", url, jsonResponder(status, content))
+}
+
+func (f *wocFixture) WillRespondWithScriptsHistory(status int, confirmed, unconfirmed func(scriptHashes []string) string) {
+	f.transport.RegisterResponder("POST", "https://api.whatsonchain.com/v1/bsv/test/scripts/confirmed/history", f.scriptsHistoryResponder(status, confirmed))
+	f.transport.RegisterResponder("POST", "https://api.whatsonchain.com/v1/bsv/test/scripts/unconfirmed/history", f.scriptsHistoryResponder(status, unconfirmed))
+}
+
+func (f *wocFixture) scriptsHistoryResponder(status int, content func(scriptHashes []string) string) httpmock.Responder {
+	return func(req *http.Request) (*http.Response, error) {
+		var body struct {
+			Scripts []string `json:"scripts"`
+		}
+		err := json.NewDecoder(req.Body).Decode(&body)
+		require.NoError(f, err, "invalid scripts history request body")
+
+		return jsonResponder(status, content(body.Scripts))(req)
+	}
+}
+
+func (f *wocFixture) WillRespondWithScriptHistoryPage(status int, scriptHash, kind, token, content string) {
+	url := fmt.Sprintf("https://api.whatsonchain.com/v1/bsv/test/script/%s/%s/history?token=%s", scriptHash, kind, token)
+	f.transport.RegisterResponder("GET", url, jsonResponder(status, content))
+}
+
+func (f *wocFixture) WillRespondWithChainInfo(status int, content string) {
+	f.transport.RegisterResponder("GET", "https://api.whatsonchain.com/v1/bsv/test/chain/info", jsonResponder(status, content))
+}
+
+func (f *wocFixture) WillRespondWithBlockAtHeight(status int, height uint32, content string) {
+	url := fmt.Sprintf("https://api.whatsonchain.com/v1/bsv/test/block/height/%d", height)
+	f.transport.RegisterResponder("GET", url, jsonResponder(status, content))
+}
+
+func (f *wocFixture) WillRespondWithTxsStatus(status int, content func(txIDs []string) string) {
+	f.transport.RegisterResponder("POST", "https://api.whatsonchain.com/v1/bsv/test/txs/status", func(req *http.Request) (*http.Response, error) {
+		var body struct {
+			TxIDs []string `json:"txids"`
+		}
+		err := json.NewDecoder(req.Body).Decode(&body)
+		require.NoError(f, err, "invalid txs status request body")
+
+		return jsonResponder(status, content(body.TxIDs))(req)
+	})
+}
+
+func jsonResponder(status int, content string) func(req *http.Request) (*http.Response, error) {
+	return func(req *http.Request) (*http.Response, error) {
+		res := httpmock.NewStringResponse(status, content)
+		res.Header.Set("Content-Type", "application/json")
+		return res, nil
+	}
+}
@@ -0,0 +1,65 @@
+package tsc
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+
+	"github.com/bsv-blockchain/go-sdk/chainhash"
+	"github.com/bsv-blockchain/go-sdk/transaction"
+	"github.com/go-softwarelab/common/pkg/to"
+)
+
+// duplicateNode marks the node of TSC proof which is the duplicate of the calculated hash
+const duplicateNode = "*"
+
+// Proof is the merkle proof in the TSC format, with the block hash as the target
+type Proof struct {
+	Index  uint64   `json:"index"`
+	TxOrID string   `json:"txOrId"`
+	Target string   `json:"target"`
+	Nodes  []string `json:"nodes"`
+}
+
+// ToMerklePath converts the TSC proof to the merkle path,
+// every node of the proof is the sibling of the calculated hash at the consecutive level of the merkle tree.
+func (p *Proof) ToMerklePath(blockHeight uint32) (*transaction.MerklePath, error) {
+	txHash, err := chainhash.NewHashFromHex(p.TxOrID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid txid %s in tsc proof: %w", p.TxOrID, err)
+	}
+
+	txLeaf := &transaction.PathElement{
+		Offset: p.Index,
+		Hash:   txHash,
+		Txid:   to.Ptr(true),
+	}
+
+	if len(p.Nodes) == 0 {
+		return transaction.NewMerklePath(blockHeight, [][]*transaction.PathElement{{txLeaf}}), nil
+	}
+
+	path := make([][]*transaction.PathElement, len(p.Nodes))
+	offset := p.Index
+	for level, node := range p.Nodes {
+		sibling := &transaction.PathElement{Offset: offset ^ 1}
+		if node == duplicateNode {
+			sibling.Duplicate = to.Ptr(true)
+		} else {
+			sibling.Hash, err = chainhash.NewHashFromHex(node)
+			if err != nil {
+				return nil, fmt.Errorf("invalid node %s at level %d of tsc proof: %w", node, level, err)
+			}
+		}
+
+		path[level] = []*transaction.PathElement{sibling}
+		offset >>= 1
+	}
+
+	path[0] = append(path[0], txLeaf)
+	slices.SortFunc(path[0], func(a, b *transaction.PathElement) int {
+		return cmp.Compare(a.Offset, b.Offset)
+	})
+
+	return transaction.NewMerklePath(blockHeight, path), nil
+}
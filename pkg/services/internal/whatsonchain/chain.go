@@ -0,0 +1,64 @@
+package whatsonchain
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/go-resty/resty/v2"
+)
+
+type chainInfoResponse struct {
+	Blocks        uint32 `json:"blocks"`
+	BestBlockHash string `json:"bestblockhash"`
+}
+
+// ChainHeight returns the height of the tip of the active chain.
+func (woc *WhatsOnChain) ChainHeight(ctx context.Context) (uint32, error) {
+	var info chainInfoResponse
+	res, err := woc.httpClient.
+		R().
+		SetContext(ctx).
+		SetResult(&info).
+		AddRetryCondition(func(res *resty.Response, err error) bool {
+			return res.StatusCode() == http.StatusTooManyRequests
+		}).
+		Get(fmt.Sprintf("%s/chain/info", woc.url))
+	if err != nil {
+		return 0, fmt.Errorf("failed to fetch chain info: %w", err)
+	}
+	if res.StatusCode() != http.StatusOK {
+		return 0, fmt.Errorf("failed to retrieve successful response from WOC. Actual status: %d", res.StatusCode())
+	}
+
+	return info.Blocks, nil
+}
+
+// BlockHeaderByHeight fetches the header of the block at given height of the active chain.
+// Returns nil if the block is not found.
+func (woc *WhatsOnChain) BlockHeaderByHeight(ctx context.Context, height uint32) (*BlockHeader, error) {
+	header := &BlockHeader{}
+	res, err := woc.httpClient.
+		R().
+		SetContext(ctx).
+		SetResult(header).
+		AddRetryCondition(func(res *resty.Response, err error) bool {
+			return res.StatusCode() == http.StatusTooManyRequests
+		}).
+		Get(fmt.Sprintf("%s/block/height/%d", woc.url, height))
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch block header: %w", err)
+	}
+	if res.StatusCode() == http.StatusNotFound {
+		return nil, nil
+	}
+	if res.StatusCode() != http.StatusOK {
+		return nil, fmt.Errorf("failed to retrieve successful response from WOC. Actual status: %d", res.StatusCode())
+	}
+
+	if header.Height != height {
+		return nil, fmt.Errorf("got header of block at height %d while querying for %d", header.Height, height)
+	}
+
+	return header, nil
+}
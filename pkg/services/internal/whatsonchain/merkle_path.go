@@ -0,0 +1,100 @@
+package whatsonchain
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/4chain-ag/go-wallet-toolbox/pkg/services/internal/tsc"
+	"github.com/4chain-ag/go-wallet-toolbox/pkg/services/results"
+	"github.com/go-resty/resty/v2"
+)
+
+// BlockHeader is the block header returned by WhatsOnChain
+type BlockHeader struct {
+	Hash              string `json:"hash"`
+	Height            uint32 `json:"height"`
+	Version           int64  `json:"version"`
+	MerkleRoot        string `json:"merkleroot"`
+	Time              int64  `json:"time"`
+	Nonce             int64  `json:"nonce"`
+	Bits              string `json:"bits"`
+	PreviousBlockHash string `json:"previousblockhash"`
+}
+
+// MerklePath fetches the TSC merkle proof of the transaction and converts it to the merkle path.
+// Returns nil if the proof is not found (e.g. the transaction is not mined yet).
+func (woc *WhatsOnChain) MerklePath(ctx context.Context, txID string) (*results.MerklePath, error) {
+	var proofs []tsc.Proof
+	res, err := woc.httpClient.
+		R().
+		SetContext(ctx).
+		SetResult(&proofs).
+		AddRetryCondition(func(res *resty.Response, err error) bool {
+			return res.StatusCode() == http.StatusTooManyRequests
+		}).
+		Get(fmt.Sprintf("%s/tx/%s/proof/tsc", woc.url, txID))
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch tsc proof: %w", err)
+	}
+	if res.StatusCode() == http.StatusNotFound {
+		return nil, nil
+	}
+	if res.StatusCode() != http.StatusOK {
+		return nil, fmt.Errorf("failed to retrieve successful response from WOC. Actual status: %d", res.StatusCode())
+	}
+	if len(proofs) == 0 {
+		return nil, nil
+	}
+
+	proof := proofs[0]
+	if proof.TxOrID != txID {
+		return nil, fmt.Errorf("got tsc proof for tx %s while querying for %s", proof.TxOrID, txID)
+	}
+
+	header, err := woc.BlockHeader(ctx, proof.Target)
+	if err != nil {
+		return nil, err
+	}
+	if header == nil {
+		return nil, fmt.Errorf("block %s of the tsc proof not found", proof.Target)
+	}
+
+	merklePath, err := proof.ToMerklePath(header.Height)
+	if err != nil {
+		return nil, err
+	}
+
+	return &results.MerklePath{
+		MerklePath: merklePath,
+		BlockHash:  proof.Target,
+	}, nil
+}
+
+// BlockHeader fetches the header of the block with given hash.
+// Returns nil if the block is not found.
+func (woc *WhatsOnChain) BlockHeader(ctx context.Context, blockHash string) (*BlockHeader, error) {
+	header := &BlockHeader{}
+	res, err := woc.httpClient.
+		R().
+		SetContext(ctx).
+		SetResult(header).
+		AddRetryCondition(func(res *resty.Response, err error) bool {
+			return res.StatusCode() == http.StatusTooManyRequests
+		}).
+		Get(fmt.Sprintf("%s/block/%s/header", woc.url, blockHash))
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch block header: %w", err)
+	}
+	if res.StatusCode() == http.StatusNotFound {
+		return nil, nil
+	}
+	if res.StatusCode() != http.StatusOK {
+		return nil, fmt.Errorf("failed to retrieve successful response from WOC. Actual status: %d", res.StatusCode())
+	}
+	if header.Hash != blockHash {
+		return nil, fmt.Errorf("got header of block %s while querying for %s", header.Hash, blockHash)
+	}
+
+	return header, nil
+}
@@ -0,0 +1,92 @@
+package whatsonchain
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/4chain-ag/go-wallet-toolbox/pkg/services/results"
+	"github.com/bsv-blockchain/go-sdk/transaction"
+)
+
+type postRawTxRequestBody struct {
+	TxHex string `json:"txhex"`
+}
+
+// Messages returned by WhatsOnChain (the node) when broadcasting a transaction
+const (
+	txAlreadyKnownMessage    = "txn-already-known"
+	txAlreadyInMempool       = "already in the mempool"
+	txMempoolConflictMessage = "txn-mempool-conflict"
+)
+
+// PostBeef broadcasts the transactions with given txIDs from the beef one by one as raw transactions.
+// The transactions should be ordered parents first, WhatsOnChain doesn't accept transactions with unknown inputs.
+func (woc *WhatsOnChain) PostBeef(ctx context.Context, beef *transaction.Beef, txIDs []string) (*results.PostBEEF, error) {
+	if beef == nil {
+		return nil, fmt.Errorf("cannot broadcast nil beef")
+	}
+	if len(txIDs) == 0 {
+		return nil, fmt.Errorf("txIDs to broadcast are required")
+	}
+
+	txIDResults := make([]results.PostTxID, 0, len(txIDs))
+	for _, txID := range txIDs {
+		tx := beef.FindTransaction(txID)
+		if tx == nil {
+			txIDResults = append(txIDResults, results.PostTxID{
+				Result: results.ResultStatusError,
+				TxID:   txID,
+				Error:  fmt.Errorf("transaction %s not found in beef", txID),
+			})
+			continue
+		}
+
+		txIDResults = append(txIDResults, woc.postRawTx(ctx, txID, tx.Hex()))
+	}
+
+	return &results.PostBEEF{
+		TxIDResults: txIDResults,
+	}, nil
+}
+
+func (woc *WhatsOnChain) postRawTx(ctx context.Context, txID string, txHex string) results.PostTxID {
+	result := results.PostTxID{
+		Result: results.ResultStatusError,
+		TxID:   txID,
+	}
+
+	res, err := woc.httpClient.
+		R().
+		SetContext(ctx).
+		SetBody(postRawTxRequestBody{TxHex: txHex}).
+		Post(fmt.Sprintf("%s/tx/raw", woc.url))
+	if err != nil {
+		result.Error = fmt.Errorf("failed to broadcast raw tx: %w", err)
+		return result
+	}
+
+	body := res.String()
+	result.Data = body
+
+	switch {
+	case res.StatusCode() == http.StatusOK:
+		returnedTxID := strings.Trim(body, "\" \n")
+		if returnedTxID != txID {
+			result.Error = fmt.Errorf("got txid %s while broadcasting %s", returnedTxID, txID)
+			return result
+		}
+		result.Result = results.ResultStatusSuccess
+	case strings.Contains(body, txAlreadyKnownMessage), strings.Contains(body, txAlreadyInMempool):
+		result.Result = results.ResultStatusSuccess
+		result.AlreadyKnown = true
+	case strings.Contains(body, txMempoolConflictMessage):
+		result.DoubleSpend = true
+		result.Error = fmt.Errorf("transaction %s double spends inputs of a transaction in mempool", txID)
+	default:
+		result.Error = fmt.Errorf("failed to broadcast raw tx to WOC. Actual status: %d, response: %s", res.StatusCode(), body)
+	}
+
+	return result
+}
@@ -0,0 +1,138 @@
+package whatsonchain
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"slices"
+
+	"github.com/4chain-ag/go-wallet-toolbox/pkg/services/results"
+	"github.com/go-resty/resty/v2"
+)
+
+// MaxScriptsPerHistoryRequest is the maximal number of scripts WhatsOnChain accepts in a single bulk history request
+const MaxScriptsPerHistoryRequest = 20
+
+type scriptsHistoryRequestBody struct {
+	Scripts []string `json:"scripts"`
+}
+
+// scriptHistoryItem is a transaction touching a script returned by WhatsOnChain
+type scriptHistoryItem struct {
+	TxHash string `json:"tx_hash"`
+	Height int64  `json:"height"`
+}
+
+type scriptHistoryResponse struct {
+	Script        string              `json:"script"`
+	Result        []scriptHistoryItem `json:"result"`
+	Error         string              `json:"error"`
+	NextPageToken string              `json:"nextPageToken"`
+}
+
+// ScriptHistory returns confirmed and unconfirmed transactions touching the scripts with given script hashes,
+// in the order of scriptHashes. Unconfirmed transactions are returned with zero height.
+// The script hashes are sha256 of the locking scripts in big-endian (reversed) byte order,
+// they are queried with the bulk endpoints in batches of MaxScriptsPerHistoryRequest.
+func (woc *WhatsOnChain) ScriptHistory(ctx context.Context, scriptHashes []string) ([]results.ScriptHistory, error) {
+	histories := make([]results.ScriptHistory, 0, len(scriptHashes))
+	for batch := range slices.Chunk(scriptHashes, MaxScriptsPerHistoryRequest) {
+		confirmed, err := woc.scriptsHistoryBatch(ctx, batch, "confirmed")
+		if err != nil {
+			return nil, err
+		}
+
+		unconfirmed, err := woc.scriptsHistoryBatch(ctx, batch, "unconfirmed")
+		if err != nil {
+			return nil, err
+		}
+
+		for i, scriptHash := range batch {
+			items := make([]results.ScriptHistoryItem, 0, len(confirmed[i])+len(unconfirmed[i]))
+			for _, item := range confirmed[i] {
+				items = append(items, results.ScriptHistoryItem{TxHash: item.TxHash, Height: item.Height})
+			}
+			for _, item := range unconfirmed[i] {
+				items = append(items, results.ScriptHistoryItem{TxHash: item.TxHash})
+			}
+			histories = append(histories, results.ScriptHistory{ScriptHash: scriptHash, Items: items})
+		}
+	}
+	return histories, nil
+}
+
+// scriptsHistoryBatch fetches the confirmed or unconfirmed history of the scripts in the order of scriptHashes,
+// the following pages of the long histories are fetched separately for every script.
+func (woc *WhatsOnChain) scriptsHistoryBatch(ctx context.Context, scriptHashes []string, kind string) ([][]scriptHistoryItem, error) {
+	var response []scriptHistoryResponse
+	res, err := woc.httpClient.
+		R().
+		SetContext(ctx).
+		SetBody(scriptsHistoryRequestBody{Scripts: scriptHashes}).
+		SetResult(&response).
+		AddRetryCondition(func(res *resty.Response, err error) bool {
+			return res.StatusCode() == http.StatusTooManyRequests
+		}).
+		Post(fmt.Sprintf("%s/scripts/%s/history", woc.url, kind))
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch %s scripts history: %w", kind, err)
+	}
+	if res.StatusCode() != http.StatusOK {
+		return nil, fmt.Errorf("failed to retrieve successful response from WOC. Actual status: %d", res.StatusCode())
+	}
+
+	byScript := make(map[string]scriptHistoryResponse, len(response))
+	for _, history := range response {
+		byScript[history.Script] = history
+	}
+
+	histories := make([][]scriptHistoryItem, 0, len(scriptHashes))
+	for _, scriptHash := range scriptHashes {
+		history, ok := byScript[scriptHash]
+		if !ok {
+			return nil, fmt.Errorf("WOC didn't return %s history of script %s", kind, scriptHash)
+		}
+		if history.Error != "" {
+			return nil, fmt.Errorf("WOC returned error for %s history of script %s: %s", kind, scriptHash, history.Error)
+		}
+
+		items := history.Result
+		if history.NextPageToken != "" {
+			next, err := woc.scriptHistoryPages(ctx, scriptHash, kind, history.NextPageToken)
+			if err != nil {
+				return nil, err
+			}
+			items = append(items, next...)
+		}
+		histories = append(histories, items)
+	}
+	return histories, nil
+}
+
+// scriptHistoryPages fetches the pages of the confirmed or unconfirmed history of the script starting from the token
+func (woc *WhatsOnChain) scriptHistoryPages(ctx context.Context, scriptHash, kind, token string) ([]scriptHistoryItem, error) {
+	var history []scriptHistoryItem
+	for token != "" {
+		var response scriptHistoryResponse
+
+		res, err := woc.httpClient.
+			R().
+			SetContext(ctx).
+			SetResult(&response).
+			SetQueryParam("token", token).
+			Get(fmt.Sprintf("%s/script/%s/%s/history", woc.url, scriptHash, kind))
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch %s script history: %w", kind, err)
+		}
+		if res.StatusCode() != http.StatusOK {
+			return nil, fmt.Errorf("failed to retrieve successful response from WOC. Actual status: %d", res.StatusCode())
+		}
+		if response.Error != "" {
+			return nil, fmt.Errorf("WOC returned error for %s script history: %s", kind, response.Error)
+		}
+
+		history = append(history, response.Result...)
+		token = response.NextPageToken
+	}
+	return history, nil
+}
@@ -6,6 +6,8 @@ import (
 	"fmt"
 	"log/slog"
 	"net/http"
+	"strings"
+	"sync"
 	"time"
 
 	"github.com/4chain-ag/go-wallet-toolbox/pkg/defs"
@@ -15,7 +17,7 @@ import (
 	"github.com/4chain-ag/go-wallet-toolbox/pkg/services/internal/httpx"
 	"github.com/4chain-ag/go-wallet-toolbox/pkg/wdk"
 	"github.com/go-resty/resty/v2"
-	"github.com/go-softwarelab/common/pkg/to"
+	"github.com/go-softwarelab/common/pkg/optional"
 )
 
 // bsvExchangeRateResponse is the response from WhatsOnChain for bsv exchange range
@@ -33,6 +35,7 @@ type WhatsOnChain struct {
 	apiKey     string
 	logger     *slog.Logger
 
+	bsvExchangeRateMu sync.Mutex
 	bsvExchangeRate   wdk.BSVExchangeRate // TODO: possibly handle by some caching structure/redis
 	bsvUpdateInterval time.Duration
 }
@@ -44,22 +47,27 @@ func New(httpClient *resty.Client, logger *slog.Logger, network defs.BSVNetwork,
 
 	headers := httpx.NewHeaders().
 		AcceptJSON().
-		UserAgent().Value("go-wallet-toolbox").
-		Authorization().IfNotEmpty(config.APIKey)
+		UserAgent().Value("go-wallet-toolbox")
 
-	client := httpClient.Clone().
+	limiter := httpx.NewLimiter(config.RateLimit.RequestsPerSecond, config.RateLimit.Burst, append([]string{config.APIKey}, config.APIKeys...)...)
+	client := limiter.Apply(httpClient).
 		SetRetryCount(Retries).
 		SetRetryWaitTime(RetriesWaitTime).
 		SetRetryMaxWaitTime(Retries * RetriesWaitTime).
 		SetHeaders(headers)
 
+	url := config.URL
+	if url == "" {
+		url = fmt.Sprintf("https://api.whatsonchain.com/v1/bsv/%s", network)
+	}
+
 	return &WhatsOnChain{
 		httpClient:        client,
 		apiKey:            config.APIKey,
-		url:               fmt.Sprintf("https://api.whatsonchain.com/v1/bsv/%s", network),
+		url:               strings.TrimSuffix(url, "/"),
 		logger:            logging.Child(logger, "WoC").With(slog.String("network", string(network))),
 		bsvExchangeRate:   config.BSVExchangeRate,
-		bsvUpdateInterval: to.IfThen(config.BSVUpdateInterval != nil, *config.BSVUpdateInterval).ElseThen(DefaultBSVExchangeUpdateInterval),
+		bsvUpdateInterval: optional.OfPtr(config.BSVUpdateInterval).OrElse(DefaultBSVExchangeUpdateInterval),
 	}
 }
 
@@ -101,6 +109,10 @@ func (woc *WhatsOnChain) RawTx(ctx context.Context, txID string) (*wdk.RawTxResu
 }
 
 func (woc *WhatsOnChain) UpdateBsvExchangeRate() (wdk.BSVExchangeRate, error) {
+	// concurrent callers wait for the single update instead of querying WhatsOnChain each
+	woc.bsvExchangeRateMu.Lock()
+	defer woc.bsvExchangeRateMu.Unlock()
+
 	nextUpdate := woc.bsvExchangeRate.Timestamp.Add(woc.bsvUpdateInterval)
 
 	// Check if the rate timestamp is newer than the threshold time
@@ -0,0 +1,91 @@
+package whatsonchain
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"slices"
+
+	"github.com/4chain-ag/go-wallet-toolbox/pkg/services/results"
+	"github.com/go-resty/resty/v2"
+	"github.com/go-softwarelab/common/pkg/to"
+)
+
+// MaxTxIDsPerStatusRequest is the maximal number of txids WhatsOnChain accepts in a single bulk status request
+const MaxTxIDsPerStatusRequest = 20
+
+type txsStatusRequestBody struct {
+	TxIDs []string `json:"txids"`
+}
+
+type txStatus struct {
+	TxID          string `json:"txid"`
+	BlockHash     string `json:"blockhash"`
+	BlockHeight   uint32 `json:"blockheight"`
+	Confirmations uint32 `json:"confirmations"`
+	Error         string `json:"error"`
+}
+
+// TxsStatus returns the statuses of the transactions with given txIDs, in the order of txIDs.
+// The txIDs are queried in batches of MaxTxIDsPerStatusRequest.
+func (woc *WhatsOnChain) TxsStatus(ctx context.Context, txIDs []string) ([]results.TxIDStatus, error) {
+	statuses := make([]results.TxIDStatus, 0, len(txIDs))
+	for batch := range slices.Chunk(txIDs, MaxTxIDsPerStatusRequest) {
+		batchStatuses, err := woc.txsStatusBatch(ctx, batch)
+		if err != nil {
+			return nil, err
+		}
+		statuses = append(statuses, batchStatuses...)
+	}
+	return statuses, nil
+}
+
+func (woc *WhatsOnChain) txsStatusBatch(ctx context.Context, txIDs []string) ([]results.TxIDStatus, error) {
+	var response []txStatus
+	res, err := woc.httpClient.
+		R().
+		SetContext(ctx).
+		SetBody(txsStatusRequestBody{TxIDs: txIDs}).
+		SetResult(&response).
+		AddRetryCondition(func(res *resty.Response, err error) bool {
+			return res.StatusCode() == http.StatusTooManyRequests
+		}).
+		Post(fmt.Sprintf("%s/txs/status", woc.url))
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch txs status: %w", err)
+	}
+	if res.StatusCode() != http.StatusOK {
+		return nil, fmt.Errorf("failed to retrieve successful response from WOC. Actual status: %d", res.StatusCode())
+	}
+
+	byTxID := make(map[string]txStatus, len(response))
+	for _, status := range response {
+		byTxID[status.TxID] = status
+	}
+
+	statuses := make([]results.TxIDStatus, 0, len(txIDs))
+	for _, txID := range txIDs {
+		status, ok := byTxID[txID]
+		if !ok {
+			return nil, fmt.Errorf("WOC didn't return status of tx %s", txID)
+		}
+		statuses = append(statuses, status.toTxIDStatus())
+	}
+	return statuses, nil
+}
+
+func (s txStatus) toTxIDStatus() results.TxIDStatus {
+	switch {
+	case s.Error != "":
+		return results.TxIDStatus{TxID: s.TxID, Status: results.TxStatusUnknown}
+	case s.BlockHash != "" || s.Confirmations > 0:
+		return results.TxIDStatus{
+			TxID:        s.TxID,
+			Status:      results.TxStatusMined,
+			Depth:       to.Ptr(s.Confirmations),
+			BlockHeight: to.Ptr(s.BlockHeight),
+		}
+	default:
+		return results.TxIDStatus{TxID: s.TxID, Status: results.TxStatusKnown}
+	}
+}
@@ -0,0 +1,58 @@
+package whatsonchain
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/4chain-ag/go-wallet-toolbox/pkg/services/results"
+)
+
+// scriptUnspent is an unspent output of a script returned by WhatsOnChain
+type scriptUnspent struct {
+	Height int64  `json:"height"`
+	TxPos  int64  `json:"tx_pos"`
+	TxHash string `json:"tx_hash"`
+	Value  uint64 `json:"value"`
+}
+
+type scriptUnspentResponse struct {
+	Script string          `json:"script"`
+	Result []scriptUnspent `json:"result"`
+	Error  string          `json:"error"`
+}
+
+// ScriptUnspent returns confirmed and unconfirmed unspent outputs of the script with given script hash.
+// The script hash is sha256 of the locking script in big-endian (reversed) byte order.
+func (woc *WhatsOnChain) ScriptUnspent(ctx context.Context, scriptHash string) ([]results.ScriptUnspent, error) {
+	var response scriptUnspentResponse
+
+	res, err := woc.httpClient.
+		R().
+		SetContext(ctx).
+		SetResult(&response).
+		Get(fmt.Sprintf("%s/script/%s/unspent/all", woc.url, scriptHash))
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch script unspent outputs: %w", err)
+	}
+	if res.StatusCode() == http.StatusNotFound {
+		return []results.ScriptUnspent{}, nil
+	}
+	if res.StatusCode() != http.StatusOK {
+		return nil, fmt.Errorf("failed to retrieve successful response from WOC. Actual status: %d", res.StatusCode())
+	}
+	if response.Error != "" {
+		return nil, fmt.Errorf("WOC returned error for script unspent outputs: %s", response.Error)
+	}
+
+	unspent := make([]results.ScriptUnspent, 0, len(response.Result))
+	for _, utxo := range response.Result {
+		unspent = append(unspent, results.ScriptUnspent{
+			Height: utxo.Height,
+			TxPos:  utxo.TxPos,
+			TxHash: utxo.TxHash,
+			Value:  utxo.Value,
+		})
+	}
+	return unspent, nil
+}
@@ -0,0 +1,103 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/4chain-ag/go-wallet-toolbox/pkg/services/internal/servicequeue"
+	"github.com/4chain-ag/go-wallet-toolbox/pkg/services/results"
+	"github.com/4chain-ag/go-wallet-toolbox/pkg/wdk"
+	"github.com/bsv-blockchain/go-sdk/chainhash"
+	"github.com/go-softwarelab/common/pkg/to"
+)
+
+// merklePathQuery is the argument of MerklePath services, it collects the notes of every attempt.
+// Services of the queue are called one by one, so the notes can be appended without synchronization.
+type merklePathQuery struct {
+	txID  string
+	notes results.Notes
+}
+
+func (q *merklePathQuery) note(what string, serviceName string, args map[string]any) {
+	if args == nil {
+		args = map[string]any{}
+	}
+	args["name"] = serviceName
+	args["txid"] = q.txID
+
+	q.notes = append(q.notes, wdk.ReqHistoryNote{
+		When: to.Ptr(time.Now()),
+		What: what,
+		Args: args,
+	})
+}
+
+type merklePathFetcher func(ctx context.Context, txID string) (*results.MerklePath, error)
+
+// merklePathService wraps the fetcher of a single provider with the block header validation and notes collection.
+func (s *WalletServices) merklePathService(name string, fetch merklePathFetcher) *servicequeue.Service1[*merklePathQuery, *MerklePathResult] {
+	return servicequeue.NewService1(name, func(ctx context.Context, query *merklePathQuery) (*MerklePathResult, error) {
+		found, err := fetch(ctx, query.txID)
+		if err != nil {
+			query.note("getMerklePathError", name, map[string]any{"error": err.Error()})
+			return nil, err
+		}
+		if found == nil {
+			query.note("getMerklePathNoData", name, nil)
+			return nil, nil
+		}
+
+		header, err := s.validMerklePathHeader(ctx, query.txID, found)
+		if err != nil {
+			query.note("getMerklePathInvalid", name, map[string]any{"error": err.Error()})
+			return nil, err
+		}
+
+		query.note("getMerklePathSuccess", name, nil)
+		return &MerklePathResult{
+			Name:       to.Ptr(name),
+			MerklePath: found.MerklePath,
+			Header:     header,
+		}, nil
+	})
+}
+
+// validMerklePathHeader returns the header of the block of the merkle path,
+// the merkle path is valid if it leads to the merkle root of the header of the active chain at the same height.
+func (s *WalletServices) validMerklePathHeader(ctx context.Context, txID string, found *results.MerklePath) (*BlockHeader, error) {
+	height := found.MerklePath.BlockHeight
+	header, err := s.headers.HeaderForHeight(ctx, height)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get block header at height %d: %w", height, err)
+	}
+
+	hash := header.Hash().String()
+	if found.BlockHash != "" && found.BlockHash != hash {
+		return nil, fmt.Errorf("merkle path block %s is not the block %s of the active chain at height %d", found.BlockHash, hash, height)
+	}
+
+	root, err := found.MerklePath.ComputeRootHex(&txID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to compute merkle root: %w", err)
+	}
+	if root != header.MerkleRoot.String() {
+		return nil, fmt.Errorf("computed merkle root %s doesn't match merkle root %s of block %s", root, header.MerkleRoot, hash)
+	}
+
+	return fromStoredHeader(height, header), nil
+}
+
+// blockHeight returns the height of the block with given hash, for the providers not returning it with the merkle proof
+func (s *WalletServices) blockHeight(ctx context.Context, blockHash string) (uint32, error) {
+	hash, err := chainhash.NewHashFromHex(blockHash)
+	if err != nil {
+		return 0, fmt.Errorf("invalid block hash %s: %w", blockHash, err)
+	}
+
+	height, _, err := s.headers.HeaderByHash(ctx, *hash)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get block header: %w", err)
+	}
+	return height, nil
+}
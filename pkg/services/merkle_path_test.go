@@ -0,0 +1,254 @@
+package services_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/4chain-ag/go-wallet-toolbox/pkg/services/configuration"
+	"github.com/4chain-ag/go-wallet-toolbox/pkg/services/internal/chaintracks"
+	"github.com/4chain-ag/go-wallet-toolbox/pkg/services/internal/headers"
+	"github.com/4chain-ag/go-wallet-toolbox/pkg/services/internal/testabilities"
+	"github.com/bsv-blockchain/go-sdk/chainhash"
+	"github.com/bsv-blockchain/go-sdk/transaction"
+	"github.com/go-softwarelab/common/pkg/to"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	merklePathTxID      = "a3e2ad5e5f84b1d1c1d3f3e5f0b2f1e0c9a8b7c6d5e4f3011223344556677889"
+	merklePathSibling   = "0f0e0d0c0b0a09080706050403020100f0e0d0c0b0a090807060504030201000"
+	merklePathBlockHash = "00000000000000000a1b2c3d4e5f60718293a4b5c6d7e8f90123456789abcdef"
+	staleBlockHash      = "00000000000000000fedcba9876543210fedcba9876543210fedcba987654321"
+	merklePathHeight    = 881_234
+)
+
+func TestMerklePath(t *testing.T) {
+	expectedPath := testMerklePath(t)
+	merkleRoot, err := expectedPath.ComputeRootHex(to.Ptr(merklePathTxID))
+	require.NoError(t, err)
+
+	wocProof := func(blockHash string) string {
+		return fmt.Sprintf(`[{
+			"index": 1,
+			"txOrId": "%s",
+			"target": "%s",
+			"nodes": ["%s", "*"]
+		}]`, merklePathTxID, blockHash, merklePathSibling)
+	}
+
+	t.Run("returns merkle path from WhatsOnChain", func(t *testing.T) {
+		// given:
+		given := testabilities.Given(t)
+		blockHash := givenActiveChainBlock(t, given, merkleRoot)
+		given.WhatsOnChain().WillRespondWithMerkleProof(http.StatusOK, merklePathTxID, wocProof(blockHash))
+		given.WhatsOnChain().WillRespondWithBlockHeader(http.StatusOK, blockHash, blockHeaderJSON(blockHash, merkleRoot))
+
+		// and:
+		services := given.Services().WithConfig(withChaintracks)
+
+		// when:
+		result, err := services.MerklePath(context.Background(), merklePathTxID, false)
+
+		// then:
+		require.NoError(t, err)
+		require.NotNil(t, result.Name)
+		assert.Equal(t, "WhatsOnChain", *result.Name)
+		assert.Equal(t, expectedPath.Hex(), result.MerklePath.Hex())
+
+		// and:
+		require.NotNil(t, result.Header)
+		assert.Equal(t, uint(merklePathHeight), result.Header.Height)
+		assert.Equal(t, blockHash, result.Header.Hash)
+		assert.Equal(t, merkleRoot, result.Header.MerkleRoot)
+		assert.Equal(t, int64(0x1d00ffff), result.Header.Bits)
+
+		// and:
+		require.Len(t, result.Notes, 1)
+		assert.Equal(t, "getMerklePathSuccess", result.Notes[0].What)
+	})
+
+	t.Run("falls back to ARC when WhatsOnChain has no proof", func(t *testing.T) {
+		// given:
+		given := testabilities.Given(t)
+		blockHash := givenActiveChainBlock(t, given, merkleRoot)
+		given.WhatsOnChain().WillRespondWithMerkleProof(http.StatusNotFound, merklePathTxID, "")
+		given.ARC().WillReturnMinedTransaction(merklePathTxID, blockHash, expectedPath)
+
+		// and:
+		services := given.Services().WithConfig(withChaintracks)
+
+		// when:
+		result, err := services.MerklePath(context.Background(), merklePathTxID, false)
+
+		// then:
+		require.NoError(t, err)
+		require.NotNil(t, result.Name)
+		assert.Equal(t, "ARC", *result.Name)
+		assert.Equal(t, expectedPath.Hex(), result.MerklePath.Hex())
+		assert.Equal(t, blockHash, result.Header.Hash)
+
+		// and:
+		require.Len(t, result.Notes, 2)
+		assert.Equal(t, "getMerklePathNoData", result.Notes[0].What)
+		assert.Equal(t, "WhatsOnChain", result.Notes[0].Args["name"])
+		assert.Equal(t, "getMerklePathSuccess", result.Notes[1].What)
+		assert.Equal(t, "ARC", result.Notes[1].Args["name"])
+	})
+
+	t.Run("tries the next service first when useNext is set", func(t *testing.T) {
+		// given:
+		given := testabilities.Given(t)
+		blockHash := givenActiveChainBlock(t, given, merkleRoot)
+		given.WhatsOnChain().WillRespondWithMerkleProof(http.StatusOK, merklePathTxID, wocProof(blockHash))
+		given.WhatsOnChain().WillRespondWithBlockHeader(http.StatusOK, blockHash, blockHeaderJSON(blockHash, merkleRoot))
+		given.ARC().WillReturnMinedTransaction(merklePathTxID, blockHash, expectedPath)
+
+		// and:
+		services := given.Services().WithConfig(withChaintracks)
+
+		// when:
+		result, err := services.MerklePath(context.Background(), merklePathTxID, true)
+
+		// then:
+		require.NoError(t, err)
+		require.NotNil(t, result.Name)
+		assert.Equal(t, "ARC", *result.Name)
+
+		// and:
+		require.Len(t, result.Notes, 1)
+		assert.Equal(t, "getMerklePathSuccess", result.Notes[0].What)
+	})
+
+	t.Run("falls back to ARC when merkle path block is not in the active chain", func(t *testing.T) {
+		// given:
+		given := testabilities.Given(t)
+		blockHash := givenActiveChainBlock(t, given, merkleRoot)
+		given.WhatsOnChain().WillRespondWithMerkleProof(http.StatusOK, merklePathTxID, wocProof(staleBlockHash))
+		given.WhatsOnChain().WillRespondWithBlockHeader(http.StatusOK, staleBlockHash, blockHeaderJSON(staleBlockHash, merkleRoot))
+		given.ARC().WillReturnMinedTransaction(merklePathTxID, blockHash, expectedPath)
+
+		// and:
+		services := given.Services().WithConfig(withChaintracks)
+
+		// when:
+		result, err := services.MerklePath(context.Background(), merklePathTxID, false)
+
+		// then:
+		require.NoError(t, err)
+		assert.Equal(t, "ARC", *result.Name)
+		assert.Equal(t, blockHash, result.Header.Hash)
+
+		// and:
+		require.Len(t, result.Notes, 2)
+		assert.Equal(t, "getMerklePathInvalid", result.Notes[0].What)
+		assert.Equal(t, "getMerklePathSuccess", result.Notes[1].What)
+	})
+
+	t.Run("rejects merkle path not leading to merkle root of the active chain block", func(t *testing.T) {
+		// given:
+		given := testabilities.Given(t)
+		blockHash := givenActiveChainBlock(t, given, merklePathSibling)
+		given.WhatsOnChain().WillRespondWithMerkleProof(http.StatusOK, merklePathTxID, wocProof(blockHash))
+		given.WhatsOnChain().WillRespondWithBlockHeader(http.StatusOK, blockHash, blockHeaderJSON(blockHash, merklePathSibling))
+		given.ARC().WillAlwaysReturnStatus(http.StatusNotFound)
+
+		// and:
+		services := given.Services().WithConfig(withChaintracks)
+
+		// when:
+		_, err := services.MerklePath(context.Background(), merklePathTxID, false)
+
+		// then:
+		require.Error(t, err)
+	})
+
+	t.Run("returns error with notes when no service has the merkle path", func(t *testing.T) {
+		// given:
+		given := testabilities.Given(t)
+		given.WhatsOnChain().WillRespondWithMerkleProof(http.StatusNotFound, merklePathTxID, "")
+
+		// and:
+		services := given.Services().WithDefaultConfig()
+
+		// when:
+		result, err := services.MerklePath(context.Background(), merklePathTxID, false)
+
+		// then:
+		require.Error(t, err)
+		assert.Nil(t, result.MerklePath)
+
+		// and:
+		require.Len(t, result.Notes, 2)
+		assert.Equal(t, "getMerklePathNoData", result.Notes[0].What)
+		assert.Equal(t, "getMerklePathError", result.Notes[1].What)
+	})
+}
+
+// testMerklePath returns the merkle path of the transaction at index 1 with one sibling and a duplicated hash at the next level.
+func testMerklePath(t testing.TB) *transaction.MerklePath {
+	txHash, err := chainhash.NewHashFromHex(merklePathTxID)
+	require.NoError(t, err)
+
+	siblingHash, err := chainhash.NewHashFromHex(merklePathSibling)
+	require.NoError(t, err)
+
+	return transaction.NewMerklePath(merklePathHeight, [][]*transaction.PathElement{
+		{
+			{Offset: 0, Hash: siblingHash},
+			{Offset: 1, Hash: txHash, Txid: to.Ptr(true)},
+		},
+		{
+			{Offset: 1, Duplicate: to.Ptr(true)},
+		},
+	})
+}
+
+func withChaintracks(config *configuration.WalletServices) {
+	config.Chaintracks.URL = testabilities.ChaintracksURL
+}
+
+// givenActiveChainBlock makes Chaintracks return the block with given merkle root at merklePathHeight, returns the block hash.
+func givenActiveChainBlock(t testing.TB, given testabilities.ServicesFixture, merkleRoot string) string {
+	root, err := chainhash.NewHashFromHex(merkleRoot)
+	require.NoError(t, err)
+	prevHash, err := chainhash.NewHashFromHex("000000000000000001d8f1e1c1a5e1b0a3f1d0c4b1e2a3f4d5c6b7a8e9f0a1b2")
+	require.NoError(t, err)
+
+	header := headers.Header{
+		Version:    536870912,
+		PrevHash:   *prevHash,
+		MerkleRoot: *root,
+		Time:       1740000000,
+		Bits:       0x1d00ffff,
+		Nonce:      12345,
+	}
+
+	given.Chaintracks().IsUpAndRunning()
+	given.Chaintracks().WillRespondWithHeader(chaintracks.BlockHeader{
+		Version:      uint32(header.Version),
+		PreviousHash: prevHash.String(),
+		MerkleRoot:   merkleRoot,
+		Time:         header.Time,
+		Bits:         header.Bits,
+		Nonce:        header.Nonce,
+		Height:       merklePathHeight,
+		Hash:         header.Hash().String(),
+	})
+	return header.Hash().String()
+}
+
+func blockHeaderJSON(hash, merkleRoot string) string {
+	return fmt.Sprintf(`{
+		"hash": "%s",
+		"height": %d,
+		"version": 536870912,
+		"merkleroot": "%s",
+		"time": 1740000000,
+		"nonce": 12345,
+		"bits": "1d00ffff",
+		"previousblockhash": "000000000000000001d8f1e1c1a5e1b0a3f1d0c4b1e2a3f4d5c6b7a8e9f0a1b2"
+	}`, hash, merklePathHeight, merkleRoot)
+}
@@ -0,0 +1,167 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/4chain-ag/go-wallet-toolbox/pkg/services/internal/servicequeue"
+	"github.com/4chain-ag/go-wallet-toolbox/pkg/services/results"
+	"github.com/4chain-ag/go-wallet-toolbox/pkg/wdk"
+	"github.com/bsv-blockchain/go-sdk/transaction"
+	"github.com/go-softwarelab/common/pkg/to"
+)
+
+// postBeefQuery is the argument of PostBeef services, it collects the results of every called service.
+// Services can be called in parallel, so the results are appended under the mutex.
+type postBeefQuery struct {
+	beef  *transaction.Beef
+	txIDs []string
+
+	mu      sync.Mutex
+	results []*PostBeefResult
+}
+
+func (q *postBeefQuery) add(result *PostBeefResult) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.results = append(q.results, result)
+}
+
+type beefBroadcaster func(ctx context.Context, beef *transaction.Beef, txIDs []string) (*results.PostBEEF, error)
+
+// postBeefService wraps the broadcaster of a single provider with the results collection.
+// It fails when not all the transactions were accepted, so the next service is tried in one by one mode.
+// The double spend is the final verdict, the other services cannot accept the transaction, so no other service is tried.
+func postBeefService(name string, post beefBroadcaster) *servicequeue.Service1[*postBeefQuery, *PostBeefResult] {
+	return servicequeue.NewService1(name, func(ctx context.Context, query *postBeefQuery) (*PostBeefResult, error) {
+		result := &PostBeefResult{Name: name}
+
+		posted, err := post(ctx, query.beef, query.txIDs)
+		if err != nil {
+			var postBeefErr *results.PostBEEFError
+			if errors.As(err, &postBeefErr) {
+				result.TxIDResults = toPostTxResultsForTxIDs(postBeefErr.TxIDResults)
+				result.Notes = postBeefErr.Notes
+				result.Data = postBeefErr
+			}
+			result.Error = err
+			result.Notes = append(result.Notes, postBeefNote("postBeefError", name, map[string]any{"error": err.Error()}))
+			query.add(result)
+			return nil, err
+		}
+
+		result.TxIDResults = toPostTxResultsForTxIDs(posted.TxIDResults)
+		result.Notes = posted.Notes
+		result.Data = posted
+
+		if slices.ContainsFunc(posted.TxIDResults, func(txResult results.PostTxID) bool { return txResult.DoubleSpend }) {
+			result.Notes = append(result.Notes, postBeefNote("postBeefDoubleSpend", name, nil))
+			query.add(result)
+			return nil, fmt.Errorf("%w: %s reported a double spend", servicequeue.ErrFinalRejection, name)
+		}
+
+		failed := slices.ContainsFunc(posted.TxIDResults, func(txResult results.PostTxID) bool {
+			return txResult.Result != results.ResultStatusSuccess
+		})
+		if failed {
+			result.Notes = append(result.Notes, postBeefNote("postBeefStatusError", name, nil))
+			query.add(result)
+			return nil, fmt.Errorf("%w: %s didn't accept all the transactions", servicequeue.ErrRejected, name)
+		}
+
+		result.Notes = append(result.Notes, postBeefNote("postBeefSuccess", name, nil))
+		query.add(result)
+		return result, nil
+	})
+}
+
+func toPostTxResultsForTxIDs(txResults []results.PostTxID) []PostTxResultForTxID {
+	converted := make([]PostTxResultForTxID, 0, len(txResults))
+	for _, txResult := range txResults {
+		result := PostTxResultForTxID{
+			TxID:         txResult.TxID,
+			Result:       txResult.Result,
+			AlreadyKnown: txResult.AlreadyKnown,
+			DoubleSpend:  txResult.DoubleSpend,
+			MerklePath:   txResult.MerklePath,
+			CompetingTxs: txResult.CompetingTxs,
+			Data:         txResult.Data,
+			Notes:        txResult.Notes,
+		}
+		if txResult.BlockHash != "" {
+			result.BlockHash = to.Ptr(txResult.BlockHash)
+			result.BlockHeight = to.Ptr(txResult.BlockHeight)
+		}
+		if result.Data == nil && txResult.Error != nil {
+			result.Data = txResult.Error
+		}
+		converted = append(converted, result)
+	}
+	return converted
+}
+
+func postBeefNote(what string, serviceName string, args map[string]any) wdk.ReqHistoryNote {
+	if args == nil {
+		args = map[string]any{}
+	}
+	args["name"] = serviceName
+
+	return wdk.ReqHistoryNote{
+		When: to.Ptr(time.Now()),
+		What: what,
+		Args: args,
+	}
+}
+
+// aggregatePostBeefResults resolves the verdict on every txID from the results of all called services.
+// A double spend reported by any service wins over the successes reported by the others.
+func aggregatePostBeefResults(txIDs []string, serviceResults []*PostBeefResult) []*AggregatedPostTxID {
+	aggregated := make([]*AggregatedPostTxID, 0, len(txIDs))
+	for _, txID := range txIDs {
+		verdict := &AggregatedPostTxID{TxID: txID}
+
+		for _, serviceResult := range serviceResults {
+			index := slices.IndexFunc(serviceResult.TxIDResults, func(txResult PostTxResultForTxID) bool {
+				return txResult.TxID == txID
+			})
+			if index < 0 {
+				verdict.ServiceErrorCount++
+				continue
+			}
+
+			txResult := serviceResult.TxIDResults[index]
+			switch {
+			case txResult.DoubleSpend:
+				verdict.DoubleSpendCount++
+				for _, competingTx := range txResult.CompetingTxs {
+					if !slices.Contains(verdict.CompetingTxs, competingTx) {
+						verdict.CompetingTxs = append(verdict.CompetingTxs, competingTx)
+					}
+				}
+			case txResult.Result == results.ResultStatusSuccess:
+				verdict.SuccessCount++
+				verdict.AlreadyKnown = verdict.AlreadyKnown || txResult.AlreadyKnown
+			default:
+				verdict.StatusErrorCount++
+			}
+		}
+
+		switch {
+		case verdict.DoubleSpendCount > 0:
+			verdict.Status = PostTxIDStatusDoubleSpend
+		case verdict.SuccessCount > 0:
+			verdict.Status = PostTxIDStatusSuccess
+		case verdict.StatusErrorCount > 0:
+			verdict.Status = PostTxIDStatusInvalidTx
+		default:
+			verdict.Status = PostTxIDStatusServiceError
+		}
+
+		aggregated = append(aggregated, verdict)
+	}
+	return aggregated
+}
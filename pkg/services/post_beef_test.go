@@ -0,0 +1,253 @@
+package services_test
+
+import (
+	"context"
+	"net/http"
+	"slices"
+	"testing"
+
+	"github.com/4chain-ag/go-wallet-toolbox/pkg/defs"
+	"github.com/4chain-ag/go-wallet-toolbox/pkg/internal/logging"
+	"github.com/4chain-ag/go-wallet-toolbox/pkg/services"
+	"github.com/4chain-ag/go-wallet-toolbox/pkg/services/configuration"
+	"github.com/4chain-ag/go-wallet-toolbox/pkg/services/internal/testabilities"
+	sdk "github.com/bsv-blockchain/go-sdk/transaction"
+	txtestabilities "github.com/bsv-blockchain/universal-test-vectors/pkg/testabilities"
+	"github.com/go-resty/resty/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const competingTxID = "0f0e0d0c0b0a09080706050403020100f0e0d0c0b0a090807060504030201000"
+
+func TestPostBeef(t *testing.T) {
+	withArc := func(mode defs.PostBeefMode) func(*configuration.WalletServices) {
+		return func(config *configuration.WalletServices) {
+			config.ArcURL = testabilities.ArcURL
+			config.PostBeefMode = mode
+		}
+	}
+
+	givenBeef := func(t *testing.T) (*sdk.Beef, string) {
+		tx := txtestabilities.GivenTX().WithInput(100).WithP2PKHOutput(99).TX()
+		beef, err := sdk.NewBeefFromTransaction(tx)
+		require.NoError(t, err)
+		return beef, tx.TxID().String()
+	}
+
+	t.Run("stops on the first broadcaster accepting the transactions", func(t *testing.T) {
+		// given:
+		given := testabilities.Given(t)
+		given.ARC().IsUpAndRunning()
+
+		// and:
+		beef, txID := givenBeef(t)
+
+		// and:
+		walletServices := given.Services().WithConfig(withArc(defs.PostBeefModeOneByOne))
+
+		// when:
+		result, err := walletServices.PostBeef(context.Background(), beef, []string{txID})
+
+		// then:
+		require.NoError(t, err)
+		require.Len(t, result.Results, 1)
+		assert.Equal(t, "ARC", result.Results[0].Name)
+		assert.NoError(t, result.Results[0].Error)
+
+		// and:
+		require.Len(t, result.TxIDResults, 1)
+		assert.Equal(t, txID, result.TxIDResults[0].TxID)
+		assert.Equal(t, services.PostTxIDStatusSuccess, result.TxIDResults[0].Status)
+		assert.Equal(t, 1, result.TxIDResults[0].SuccessCount)
+	})
+
+	t.Run("falls back to WhatsOnChain when ARC fails", func(t *testing.T) {
+		// given:
+		given := testabilities.Given(t)
+		given.ARC().WillAlwaysReturnStatus(http.StatusInternalServerError)
+
+		// and:
+		beef, txID := givenBeef(t)
+		given.WhatsOnChain().WillRespondWithBroadcast(http.StatusOK, `"`+txID+`"`)
+
+		// and:
+		walletServices := given.Services().WithConfig(withArc(defs.PostBeefModeOneByOne))
+
+		// when:
+		result, err := walletServices.PostBeef(context.Background(), beef, []string{txID})
+
+		// then:
+		require.NoError(t, err)
+		require.Len(t, result.Results, 2)
+		assert.Equal(t, "ARC", result.Results[0].Name)
+		assert.Error(t, result.Results[0].Error)
+		assert.Equal(t, "WhatsOnChain", result.Results[1].Name)
+		assert.NoError(t, result.Results[1].Error)
+
+		// and:
+		require.Len(t, result.TxIDResults, 1)
+		assert.Equal(t, services.PostTxIDStatusSuccess, result.TxIDResults[0].Status)
+		assert.Equal(t, 1, result.TxIDResults[0].SuccessCount)
+		assert.Equal(t, 1, result.TxIDResults[0].ServiceErrorCount)
+	})
+
+	t.Run("treats already known transaction as success", func(t *testing.T) {
+		// given:
+		given := testabilities.Given(t)
+		given.ARC().WillAlwaysReturnStatus(http.StatusInternalServerError)
+		given.WhatsOnChain().WillRespondWithBroadcast(http.StatusBadRequest, `"257: txn-already-known"`)
+
+		// and:
+		beef, txID := givenBeef(t)
+
+		// and:
+		walletServices := given.Services().WithConfig(withArc(defs.PostBeefModeOneByOne))
+
+		// when:
+		result, err := walletServices.PostBeef(context.Background(), beef, []string{txID})
+
+		// then:
+		require.NoError(t, err)
+		require.Len(t, result.TxIDResults, 1)
+		assert.Equal(t, services.PostTxIDStatusSuccess, result.TxIDResults[0].Status)
+		assert.True(t, result.TxIDResults[0].AlreadyKnown)
+	})
+
+	t.Run("resolves double spend reported by any broadcaster in all mode", func(t *testing.T) {
+		// given:
+		given := testabilities.Given(t)
+
+		// and:
+		beef, txID := givenBeef(t)
+		given.ARC().WillReturnDoubleSpending(txID, competingTxID)
+		given.WhatsOnChain().WillRespondWithBroadcast(http.StatusOK, `"`+txID+`"`)
+
+		// and:
+		walletServices := given.Services().WithConfig(withArc(defs.PostBeefModeAll))
+
+		// when:
+		result, err := walletServices.PostBeef(context.Background(), beef, []string{txID})
+
+		// then:
+		require.NoError(t, err)
+		require.Len(t, result.Results, 2)
+
+		// and:
+		require.Len(t, result.TxIDResults, 1)
+		assert.Equal(t, services.PostTxIDStatusDoubleSpend, result.TxIDResults[0].Status)
+		assert.Equal(t, []string{competingTxID}, result.TxIDResults[0].CompetingTxs)
+		assert.Equal(t, 1, result.TxIDResults[0].DoubleSpendCount)
+		assert.Equal(t, 1, result.TxIDResults[0].SuccessCount)
+	})
+
+	t.Run("doesn't try the next broadcaster after double spend in one by one mode", func(t *testing.T) {
+		// given:
+		given := testabilities.Given(t)
+
+		// and:
+		beef, txID := givenBeef(t)
+		given.ARC().WillReturnDoubleSpending(txID, competingTxID)
+		given.WhatsOnChain().WillRespondWithBroadcast(http.StatusOK, `"`+txID+`"`)
+
+		// and:
+		walletServices := given.Services().WithConfig(withArc(defs.PostBeefModeOneByOne))
+
+		// when:
+		result, err := walletServices.PostBeef(context.Background(), beef, []string{txID})
+
+		// then:
+		require.NoError(t, err)
+		require.Len(t, result.Results, 1)
+		assert.Equal(t, "ARC", result.Results[0].Name)
+		require.Len(t, result.Results[0].TxIDResults, 1)
+		assert.True(t, result.Results[0].TxIDResults[0].DoubleSpend)
+
+		// and:
+		require.Len(t, result.TxIDResults, 1)
+		assert.Equal(t, services.PostTxIDStatusDoubleSpend, result.TxIDResults[0].Status)
+		assert.Equal(t, []string{competingTxID}, result.TxIDResults[0].CompetingTxs)
+	})
+
+	t.Run("returns invalid tx when all broadcasters reject the transaction", func(t *testing.T) {
+		// given:
+		given := testabilities.Given(t)
+		given.ARC().WillAlwaysReturnStatus(http.StatusInternalServerError)
+		given.WhatsOnChain().WillRespondWithBroadcast(http.StatusBadRequest, `"16: mandatory-script-verify-flag-failed"`)
+
+		// and:
+		beef, txID := givenBeef(t)
+
+		// and:
+		walletServices := given.Services().WithConfig(withArc(defs.PostBeefModeOneByOne))
+
+		// when:
+		result, err := walletServices.PostBeef(context.Background(), beef, []string{txID})
+
+		// then:
+		require.NoError(t, err)
+		require.Len(t, result.Results, 2)
+
+		// and:
+		require.Len(t, result.TxIDResults, 1)
+		assert.Equal(t, services.PostTxIDStatusInvalidTx, result.TxIDResults[0].Status)
+		assert.Equal(t, 1, result.TxIDResults[0].StatusErrorCount)
+		assert.Equal(t, 1, result.TxIDResults[0].ServiceErrorCount)
+	})
+
+	t.Run("doesn't count rejected transaction as failure of the broadcaster", func(t *testing.T) {
+		// given:
+		given := testabilities.Given(t)
+		given.ARC().WillAlwaysReturnStatus(http.StatusInternalServerError)
+		given.WhatsOnChain().WillRespondWithBroadcast(http.StatusBadRequest, `"16: mandatory-script-verify-flag-failed"`)
+
+		// and:
+		beef, txID := givenBeef(t)
+
+		// and:
+		walletServices := given.Services().WithConfig(withArc(defs.PostBeefModeOneByOne))
+
+		// when:
+		_, err := walletServices.PostBeef(context.Background(), beef, []string{txID})
+
+		// then:
+		require.NoError(t, err)
+
+		// and:
+		stats := walletServices.ServiceStats()
+		idx := slices.IndexFunc(stats, func(it services.ServiceStats) bool {
+			return it.Method == "PostBeef" && it.Name == "WhatsOnChain"
+		})
+		require.GreaterOrEqual(t, idx, 0)
+		assert.EqualValues(t, 1, stats[idx].SuccessCount)
+		assert.EqualValues(t, 0, stats[idx].FailureCount)
+	})
+
+	t.Run("requires txIDs to post", func(t *testing.T) {
+		// given:
+		given := testabilities.Given(t)
+		beef, _ := givenBeef(t)
+
+		// and:
+		walletServices := given.Services().WithDefaultConfig()
+
+		// when:
+		_, err := walletServices.PostBeef(context.Background(), beef, nil)
+
+		// then:
+		require.Error(t, err)
+	})
+	t.Run("fails on invalid post beef mode", func(t *testing.T) {
+		// given:
+		config := configuration.WalletServices{
+			Chain:        defs.NetworkTestnet,
+			PostBeefMode: "sometimes",
+		}
+
+		// when:
+		_, err := services.New(resty.New(), logging.NewTestLogger(t), config)
+
+		// then:
+		require.Error(t, err)
+	})
+}
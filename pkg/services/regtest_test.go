@@ -0,0 +1,117 @@
+package services_test
+
+import (
+	"context"
+	"fmt"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/4chain-ag/go-wallet-toolbox/pkg/defs"
+	"github.com/4chain-ag/go-wallet-toolbox/pkg/internal/fakenetwork"
+	"github.com/4chain-ag/go-wallet-toolbox/pkg/internal/logging"
+	"github.com/4chain-ag/go-wallet-toolbox/pkg/services"
+	"github.com/4chain-ag/go-wallet-toolbox/pkg/services/configuration"
+	sdk "github.com/bsv-blockchain/go-sdk/transaction"
+	txtestabilities "github.com/bsv-blockchain/universal-test-vectors/pkg/testabilities"
+	"github.com/go-resty/resty/v2"
+	"github.com/go-softwarelab/common/pkg/to"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRegtestNetwork(t *testing.T) {
+	givenRegtestServices := func(t *testing.T) (*fakenetwork.Network, *services.WalletServices) {
+		network := fakenetwork.New(fakenetwork.WithNetwork(defs.NetworkRegtest))
+		server := httptest.NewServer(network.Handler())
+		t.Cleanup(server.Close)
+
+		genesis := network.BlockAt(0)
+		walletServices, err := services.New(resty.New(), logging.NewTestLogger(t), configuration.WalletServices{
+			Chain:  defs.NetworkRegtest,
+			ArcURL: fakenetwork.ARCURL(server.URL),
+			WhatsOnChain: configuration.WhatsOnChain{
+				URL: network.WhatsOnChainURL(server.URL),
+			},
+			HeaderStore: configuration.HeaderStore{
+				StartHeight: to.Ptr(uint32(0)),
+				Genesis: &configuration.GenesisHeader{
+					Version:    genesis.Version,
+					MerkleRoot: genesis.MerkleRoot.String(),
+					Time:       genesis.Time,
+					Bits:       fmt.Sprintf("%x", genesis.Bits),
+					Nonce:      genesis.Nonce,
+					Hash:       genesis.Hash.String(),
+				},
+			},
+		})
+		require.NoError(t, err)
+		return network, walletServices
+	}
+
+	t.Run("broadcasts and proves transaction on the regtest network", func(t *testing.T) {
+		// given:
+		network, walletServices := givenRegtestServices(t)
+
+		// and:
+		tx := txtestabilities.GivenTX().WithInput(100).WithP2PKHOutput(99).TX()
+		beef, err := sdk.NewBeefFromTransaction(tx)
+		require.NoError(t, err)
+		txID := tx.TxID().String()
+
+		// when:
+		result, err := walletServices.PostBeef(context.Background(), beef, []string{txID})
+
+		// then:
+		require.NoError(t, err)
+		require.Len(t, result.TxIDResults, 1)
+		assert.Equal(t, services.PostTxIDStatusSuccess, result.TxIDResults[0].Status)
+
+		// when:
+		block, err := network.MineBlock()
+		require.NoError(t, err)
+
+		// and:
+		proof, err := walletServices.MerklePath(context.Background(), txID, false)
+
+		// then:
+		require.NoError(t, err)
+		require.NotNil(t, proof.MerklePath)
+		root, err := proof.MerklePath.ComputeRoot(tx.TxID())
+		require.NoError(t, err)
+		assert.Equal(t, block.MerkleRoot.String(), root.String())
+
+		// and:
+		valid, err := walletServices.ChainTracker().IsValidRootForHeight(root, block.Height)
+		require.NoError(t, err)
+		assert.True(t, valid)
+	})
+}
+
+func TestRegtestConfigErrors(t *testing.T) {
+	tests := map[string]configuration.WalletServices{
+		"WhatsOnChain URL is not configured": {
+			Chain: defs.NetworkRegtest,
+		},
+		"Bitails URL is not configured": {
+			Chain:         defs.NetworkRegtest,
+			WhatsOnChain:  configuration.WhatsOnChain{URL: "http://localhost:8080/woc"},
+			BitailsAPIKey: to.Ptr(""),
+		},
+		"genesis header is invalid": {
+			Chain:        defs.NetworkRegtest,
+			WhatsOnChain: configuration.WhatsOnChain{URL: "http://localhost:8080/woc"},
+			HeaderStore: configuration.HeaderStore{
+				Genesis: &configuration.GenesisHeader{Bits: "not hex"},
+			},
+		},
+	}
+	for name, config := range tests {
+		t.Run("fails when "+name, func(t *testing.T) {
+			// when:
+			_, err := services.New(resty.New(), logging.NewTestLogger(t), config)
+
+			// then:
+			require.Error(t, err)
+		})
+	}
+}
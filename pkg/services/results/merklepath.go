@@ -0,0 +1,10 @@
+package results
+
+import "github.com/bsv-blockchain/go-sdk/transaction"
+
+// MerklePath is the success result of the single service MerklePath method.
+type MerklePath struct {
+	MerklePath *transaction.MerklePath
+	// BlockHash is the hash of the block the merkle path leads to
+	BlockHash string
+}
@@ -0,0 +1,15 @@
+package results
+
+// ScriptHistoryItem is a transaction touching a script, the success result of the single service ScriptHistory method.
+type ScriptHistoryItem struct {
+	TxHash string
+	// Height is the height of the block with the transaction, 0 for unconfirmed transaction
+	Height int64
+}
+
+// ScriptHistory is the history of a single script, the success result of the single service ScriptHistory method.
+type ScriptHistory struct {
+	// ScriptHash is in big-endian (reversed) byte order
+	ScriptHash string
+	Items      []ScriptHistoryItem
+}
@@ -0,0 +1,9 @@
+package results
+
+// ScriptUnspent is an unspent output of a script, the success result of the single service ScriptUnspent method.
+type ScriptUnspent struct {
+	Height int64
+	TxPos  int64
+	TxHash string
+	Value  uint64
+}
@@ -0,0 +1,24 @@
+package results
+
+// TxStatus is the status of the transaction known to the service
+type TxStatus string
+
+// Possible statuses of the transaction
+const (
+	// TxStatusMined means that the transaction is included in a block
+	TxStatusMined TxStatus = "mined"
+	// TxStatusKnown means that the transaction is known to the service, but not mined yet
+	TxStatusKnown TxStatus = "known"
+	// TxStatusUnknown means that the service doesn't know the transaction
+	TxStatusUnknown TxStatus = "unknown"
+)
+
+// TxIDStatus is the status of a single transaction, the success result of the single service StatusForTxIDs method.
+type TxIDStatus struct {
+	TxID   string
+	Status TxStatus
+	// Depth is the number of confirmations of the mined transaction, 1 for the transaction in the chain tip
+	Depth *uint32
+	// BlockHeight is the height of the block of the mined transaction, used to calculate the Depth when the service doesn't provide it
+	BlockHeight *uint32
+}
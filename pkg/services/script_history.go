@@ -0,0 +1,51 @@
+package services
+
+import (
+	"context"
+
+	"github.com/4chain-ag/go-wallet-toolbox/pkg/services/internal/servicequeue"
+	"github.com/4chain-ag/go-wallet-toolbox/pkg/services/results"
+	"github.com/go-softwarelab/common/pkg/to"
+)
+
+type scriptHistoryFetcher func(ctx context.Context, scriptHashes []string) ([]results.ScriptHistory, error)
+
+// scriptHistoryService wraps the fetcher of scripts history of a single provider.
+// It accepts the script hashes in big-endian (reversed) byte order.
+// The service shares the health with the UtxoStatus service of the same provider,
+// so the scan of many script hashes demotes the provider which fails or rate limits it for both methods.
+func scriptHistoryService(fetch scriptHistoryFetcher, utxoStatus *servicequeue.Service1[string, *UtxoStatusResult]) *servicequeue.Service1[[]string, []ScriptHistoryResult] {
+	name := utxoStatus.Name()
+	service := servicequeue.NewService1(name, func(ctx context.Context, scriptHashes []string) ([]ScriptHistoryResult, error) {
+		histories, err := fetch(ctx, scriptHashes)
+		if err != nil {
+			return nil, err
+		}
+
+		result := make([]ScriptHistoryResult, 0, len(histories))
+		for _, history := range histories {
+			result = append(result, toScriptHistoryResult(name, history))
+		}
+		return result, nil
+	})
+	return servicequeue.ShareHealth(service, utxoStatus)
+}
+
+func toScriptHistoryResult(name string, history results.ScriptHistory) ScriptHistoryResult {
+	result := ScriptHistoryResult{
+		Name:        name,
+		ScriptHash:  history.ScriptHash,
+		Confirmed:   []ScriptHistoryItem{},
+		Unconfirmed: []ScriptHistoryItem{},
+	}
+
+	for _, item := range history.Items {
+		if item.Height > 0 {
+			result.Confirmed = append(result.Confirmed, ScriptHistoryItem{TxID: item.TxHash, Height: to.Ptr(item.Height)})
+		} else {
+			result.Unconfirmed = append(result.Unconfirmed, ScriptHistoryItem{TxID: item.TxHash})
+		}
+	}
+
+	return result
+}
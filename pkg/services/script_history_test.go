@@ -0,0 +1,236 @@
+package services_test
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/4chain-ag/go-wallet-toolbox/pkg/services"
+	"github.com/4chain-ag/go-wallet-toolbox/pkg/services/internal/testabilities"
+	"github.com/4chain-ag/go-wallet-toolbox/pkg/services/internal/whatsonchain"
+	"github.com/go-softwarelab/common/pkg/to"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	historyConfirmedTxID   = "3c64c621c0070ea56ca2ef13ef699483c3938f48e030b184f1d094678eda7ab8"
+	historyUnconfirmedTxID = "0b56b1a6d7b0b4f1a8c3e1f6d2a1e2b3c4d5e6f708192a3b4c5d6e7f80919293"
+)
+
+func givenScriptHashes(t *testing.T) (hashLE, hashBE string) {
+	scriptBytes, err := hex.DecodeString(utxoLockingScript)
+	require.NoError(t, err)
+
+	hash := sha256.Sum256(scriptBytes)
+	hashLE = hex.EncodeToString(hash[:])
+	slices.Reverse(hash[:])
+	hashBE = hex.EncodeToString(hash[:])
+	return hashLE, hashBE
+}
+
+// wocScriptsHistory generates the bulk scripts history response with the same result for every script
+func wocScriptsHistory(result string) func(scriptHashes []string) string {
+	return func(scriptHashes []string) string {
+		histories := make([]string, 0, len(scriptHashes))
+		for _, scriptHash := range scriptHashes {
+			histories = append(histories, fmt.Sprintf(`{"script": "%s", "result": %s, "error": ""}`, scriptHash, result))
+		}
+		return "[" + strings.Join(histories, ",") + "]"
+	}
+}
+
+func TestScriptHistory(t *testing.T) {
+	t.Run("returns confirmed and unconfirmed history from WhatsOnChain", func(t *testing.T) {
+		// given:
+		hashLE, hashBE := givenScriptHashes(t)
+
+		// and:
+		given := testabilities.Given(t)
+		given.WhatsOnChain().WillRespondWithScriptsHistory(http.StatusOK,
+			wocScriptsHistory(fmt.Sprintf(`[{"tx_hash": "%s", "height": 881234}]`, historyConfirmedTxID)),
+			wocScriptsHistory(fmt.Sprintf(`[{"tx_hash": "%s"}]`, historyUnconfirmedTxID)),
+		)
+
+		// and:
+		walletServices := given.Services().WithDefaultConfig()
+
+		// when:
+		result, err := walletServices.ScriptHistory(context.Background(), hashLE)
+
+		// then:
+		require.NoError(t, err)
+		assert.Equal(t, services.ScriptHistoryResult{
+			Name:        "WhatsOnChain",
+			ScriptHash:  hashBE,
+			Confirmed:   []services.ScriptHistoryItem{{TxID: historyConfirmedTxID, Height: to.Ptr(int64(881234))}},
+			Unconfirmed: []services.ScriptHistoryItem{{TxID: historyUnconfirmedTxID}},
+		}, result)
+	})
+
+	t.Run("returns history of many scripts queried in batches", func(t *testing.T) {
+		// given:
+		scriptsCount := whatsonchain.MaxScriptsPerHistoryRequest + 5
+		hashesLE := make([]string, 0, scriptsCount)
+		for i := range scriptsCount {
+			hash := sha256.Sum256([]byte{byte(i)})
+			hashesLE = append(hashesLE, hex.EncodeToString(hash[:]))
+		}
+
+		// and:
+		var batchSizes []int
+		given := testabilities.Given(t)
+		given.WhatsOnChain().WillRespondWithScriptsHistory(http.StatusOK,
+			func(scriptHashes []string) string {
+				batchSizes = append(batchSizes, len(scriptHashes))
+				return wocScriptsHistory(fmt.Sprintf(`[{"tx_hash": "%s", "height": 881234}]`, historyConfirmedTxID))(scriptHashes)
+			},
+			wocScriptsHistory(`[]`),
+		)
+
+		// and:
+		walletServices := given.Services().WithDefaultConfig()
+
+		// when:
+		result, err := walletServices.ScriptsHistory(context.Background(), hashesLE)
+
+		// then:
+		require.NoError(t, err)
+		assert.Equal(t, []int{whatsonchain.MaxScriptsPerHistoryRequest, 5}, batchSizes)
+
+		// and:
+		require.Len(t, result, scriptsCount)
+		for i, history := range result {
+			hashBE, err := hex.DecodeString(hashesLE[i])
+			require.NoError(t, err)
+			slices.Reverse(hashBE)
+
+			assert.Equal(t, hex.EncodeToString(hashBE), history.ScriptHash)
+			assert.Len(t, history.Confirmed, 1)
+		}
+	})
+
+	t.Run("fetches the following pages of the long history", func(t *testing.T) {
+		// given:
+		hashLE, hashBE := givenScriptHashes(t)
+
+		// and:
+		given := testabilities.Given(t)
+		given.WhatsOnChain().WillRespondWithScriptsHistory(http.StatusOK,
+			func(scriptHashes []string) string {
+				return fmt.Sprintf(`[{"script": "%s", "result": [{"tx_hash": "%s", "height": 881234}], "error": "", "nextPageToken": "page2"}]`, hashBE, historyConfirmedTxID)
+			},
+			wocScriptsHistory(`[]`),
+		)
+		given.WhatsOnChain().WillRespondWithScriptHistoryPage(http.StatusOK, hashBE, "confirmed", "page2",
+			fmt.Sprintf(`{"script": "%s", "result": [{"tx_hash": "%s", "height": 881235}], "error": ""}`, hashBE, historyUnconfirmedTxID),
+		)
+
+		// and:
+		walletServices := given.Services().WithDefaultConfig()
+
+		// when:
+		result, err := walletServices.ScriptHistory(context.Background(), hashLE)
+
+		// then:
+		require.NoError(t, err)
+		assert.Equal(t, []services.ScriptHistoryItem{
+			{TxID: historyConfirmedTxID, Height: to.Ptr(int64(881234))},
+			{TxID: historyUnconfirmedTxID, Height: to.Ptr(int64(881235))},
+		}, result.Confirmed)
+	})
+
+	t.Run("returns empty history of unknown script", func(t *testing.T) {
+		// given:
+		hashLE, _ := givenScriptHashes(t)
+
+		// and:
+		given := testabilities.Given(t)
+		given.WhatsOnChain().WillRespondWithScriptsHistory(http.StatusOK, wocScriptsHistory(`[]`), wocScriptsHistory(`[]`))
+
+		// and:
+		walletServices := given.Services().WithDefaultConfig()
+
+		// when:
+		result, err := walletServices.ScriptHistory(context.Background(), hashLE)
+
+		// then:
+		require.NoError(t, err)
+		assert.Empty(t, result.Confirmed)
+		assert.Empty(t, result.Unconfirmed)
+	})
+
+	t.Run("falls back to Bitails when WhatsOnChain fails", func(t *testing.T) {
+		// given:
+		hashLE, hashBE := givenScriptHashes(t)
+
+		// and:
+		given := testabilities.Given(t)
+		given.WhatsOnChain().WillRespondWithScriptsHistory(http.StatusInternalServerError, wocScriptsHistory(`[]`), wocScriptsHistory(`[]`))
+		given.Bitails().WillRespondWithScriptHistory(http.StatusOK, hashBE, fmt.Sprintf(`{
+			"scripthash": "%s",
+			"history": [{"txid": "%s", "blockheight": 881234}, {"txid": "%s"}]
+		}`, hashBE, historyConfirmedTxID, historyUnconfirmedTxID))
+
+		// and:
+		walletServices := given.Services().WithConfig(withBitails)
+
+		// when:
+		result, err := walletServices.ScriptHistory(context.Background(), hashLE)
+
+		// then:
+		require.NoError(t, err)
+		assert.Equal(t, services.ScriptHistoryResult{
+			Name:        "Bitails",
+			ScriptHash:  hashBE,
+			Confirmed:   []services.ScriptHistoryItem{{TxID: historyConfirmedTxID, Height: to.Ptr(int64(881234))}},
+			Unconfirmed: []services.ScriptHistoryItem{{TxID: historyUnconfirmedTxID}},
+		}, result)
+	})
+
+	t.Run("shares the services health with UtxoStatus", func(t *testing.T) {
+		// given:
+		hashLE, hashBE := givenScriptHashes(t)
+
+		// and:
+		given := testabilities.Given(t)
+		given.WhatsOnChain().WillRespondWithScriptsHistory(http.StatusOK, wocScriptsHistory(`[]`), wocScriptsHistory(`[]`))
+		given.WhatsOnChain().WillRespondWithScriptUnspent(http.StatusOK, hashBE, fmt.Sprintf(`{"script": "%s", "result": [], "error": ""}`, hashBE))
+
+		// and:
+		walletServices := given.Services().WithDefaultConfig()
+
+		// when:
+		_, err := walletServices.ScriptHistory(context.Background(), hashLE)
+		require.NoError(t, err)
+
+		_, err = walletServices.UtxoStatus(context.Background(), hashLE, services.HashLE, false)
+		require.NoError(t, err)
+
+		// then:
+		stats := walletServices.ServiceStats()
+		for _, method := range []string{"UtxoStatus", "ScriptHistory"} {
+			idx := slices.IndexFunc(stats, func(it services.ServiceStats) bool {
+				return it.Method == method && it.Name == "WhatsOnChain"
+			})
+			require.GreaterOrEqual(t, idx, 0, method)
+			assert.EqualValues(t, 2, stats[idx].SuccessCount, method)
+		}
+	})
+
+	t.Run("rejects invalid script hash", func(t *testing.T) {
+		// given:
+		walletServices := testabilities.Given(t).Services().WithDefaultConfig()
+
+		// when:
+		_, err := walletServices.ScriptHistory(context.Background(), "not-a-hash")
+
+		// then:
+		require.Error(t, err)
+	})
+}
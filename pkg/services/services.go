@@ -7,13 +7,21 @@ import (
 	"log/slog"
 
 	"github.com/4chain-ag/go-wallet-toolbox/pkg/defs"
+	"github.com/4chain-ag/go-wallet-toolbox/pkg/internal/logging"
 	"github.com/4chain-ag/go-wallet-toolbox/pkg/services/configuration"
+	"github.com/4chain-ag/go-wallet-toolbox/pkg/services/internal/arc"
+	"github.com/4chain-ag/go-wallet-toolbox/pkg/services/internal/bitails"
+	"github.com/4chain-ag/go-wallet-toolbox/pkg/services/internal/chaintracks"
+	"github.com/4chain-ag/go-wallet-toolbox/pkg/services/internal/exchangeratesapi"
 	"github.com/4chain-ag/go-wallet-toolbox/pkg/services/internal/servicequeue"
 	"github.com/4chain-ag/go-wallet-toolbox/pkg/services/internal/whatsonchain"
+	"github.com/4chain-ag/go-wallet-toolbox/pkg/services/results"
 	"github.com/4chain-ag/go-wallet-toolbox/pkg/wdk"
+	"github.com/bsv-blockchain/go-sdk/chainhash"
 	"github.com/bsv-blockchain/go-sdk/transaction"
 	"github.com/bsv-blockchain/go-sdk/transaction/chaintracker"
 	"github.com/go-resty/resty/v2"
+	"github.com/go-softwarelab/common/pkg/optional"
 )
 
 // WalletServices is a struct that contains services used by a wallet
@@ -23,35 +31,157 @@ type WalletServices struct {
 	chain         defs.BSVNetwork
 	config        *configuration.WalletServices
 	whatsonchain  *whatsonchain.WhatsOnChain
+	arc           *arc.Service
 	rawTxServices servicequeue.Queue1[string, *wdk.RawTxResult]
 
-	// getMerklePathServices: ServiceCollection<sdk.GetMerklePathService>
-	// getRawTxServices: ServiceCollection<sdk.GetRawTxService>
-	// postBeefServices: ServiceCollection<sdk.PostBeefService>
-	// getUtxoStatusServices: ServiceCollection<sdk.GetUtxoStatusService>
-	// updateFiatExchangeRateServices: ServiceCollection<sdk.UpdateFiatExchangeRateService>
+	merklePathServices servicequeue.Queue1[*merklePathQuery, *MerklePathResult]
+
+	postBeefServices servicequeue.Queue1[*postBeefQuery, *PostBeefResult]
+	postBeefMode     defs.PostBeefMode
+
+	utxoStatusServices servicequeue.Queue1[string, *UtxoStatusResult]
+
+	// scriptHistoryServices share the health with utxoStatusServices of the same providers
+	scriptHistoryServices servicequeue.Queue1[[]string, []ScriptHistoryResult]
+
+	statusForTxIDsServices servicequeue.Queue1[[]string, *StatusForTxIDsResult]
+
+	headers headersProvider
+
+	fiatRates *fiatRatesCache
 }
 
 // New will return a new WalletServices
-func New(httpClient *resty.Client, logger *slog.Logger, config configuration.WalletServices) *WalletServices {
+func New(httpClient *resty.Client, logger *slog.Logger, config configuration.WalletServices) (*WalletServices, error) {
 	if httpClient == nil {
 		panic("httpClient is required")
 	}
 
+	if err := config.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid services configuration: %w", err)
+	}
+
 	woc := whatsonchain.New(httpClient, logger, config.Chain, config.WhatsOnChain)
 
-	return &WalletServices{
+	var arcService *arc.Service
+	if config.ArcURL != "" {
+		arcService = arc.NewARCService(logger, httpClient, toArcConfig(config.ArcURL, config.ArcConfig))
+	}
+
+	postBeefMode := config.PostBeefMode
+	if postBeefMode == "" {
+		postBeefMode = defs.DefaultPostBeefMode()
+	}
+
+	headersProvider, err := newHeadersProvider(logger, httpClient, woc, config)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create headers provider: %w", err)
+	}
+
+	s := &WalletServices{
 		httpClient:   httpClient,
 		chain:        config.Chain,
 		config:       &config,
 		logger:       logger,
 		whatsonchain: woc,
+		arc:          arcService,
+		postBeefMode: postBeefMode,
+		headers:      headersProvider,
+	}
+
+	// Bitails is used only when configured, the empty API key is allowed for the free plan
+	var bitailsService *bitails.Bitails
+	if config.BitailsAPIKey != nil {
+		bitailsService = bitails.New(httpClient, logger, config.Chain, config.BitailsURL, *config.BitailsAPIKey)
+	}
+
+	rawTxServices := []*servicequeue.Service1[string, *wdk.RawTxResult]{
+		servicequeue.NewService1(whatsonchain.ServiceName, woc.RawTx),
+	}
+	wocUtxoStatus := utxoStatusService(whatsonchain.ServiceName, woc.ScriptUnspent)
+	utxoStatusServices := []*servicequeue.Service1[string, *UtxoStatusResult]{wocUtxoStatus}
+	scriptHistoryServices := []*servicequeue.Service1[[]string, []ScriptHistoryResult]{
+		scriptHistoryService(woc.ScriptHistory, wocUtxoStatus),
+	}
+	if bitailsService != nil {
+		rawTxServices = append(rawTxServices, servicequeue.NewService1(bitails.ServiceName, bitailsService.RawTx))
+		bitailsUtxoStatus := utxoStatusService(bitails.ServiceName, bitailsService.ScriptUnspent)
+		utxoStatusServices = append(utxoStatusServices, bitailsUtxoStatus)
+		scriptHistoryServices = append(scriptHistoryServices, scriptHistoryService(bitailsService.ScriptHistory, bitailsUtxoStatus))
+	}
+	s.rawTxServices = servicequeue.NewQueue1(logger, "RawTx", rawTxServices...)
+	s.utxoStatusServices = servicequeue.NewQueue1(logger, "UtxoStatus", utxoStatusServices...)
+	s.scriptHistoryServices = servicequeue.NewQueue1(logger, "ScriptHistory", scriptHistoryServices...)
+
+	merklePathServices := []*servicequeue.Service1[*merklePathQuery, *MerklePathResult]{
+		s.merklePathService(whatsonchain.ServiceName, woc.MerklePath),
+	}
+	if arcService != nil {
+		merklePathServices = append(merklePathServices, s.merklePathService(arc.ServiceName, arcService.MerklePath))
+	}
+	if bitailsService != nil {
+		merklePathServices = append(merklePathServices, s.merklePathService(bitails.ServiceName, func(ctx context.Context, txID string) (*results.MerklePath, error) {
+			return bitailsService.MerklePath(ctx, txID, s.blockHeight)
+		}))
+	}
+	s.merklePathServices = servicequeue.NewQueue1(logger, "MerklePath", merklePathServices...)
+
+	statusForTxIDsServices := []*servicequeue.Service1[[]string, *StatusForTxIDsResult]{
+		s.statusForTxIDsService(whatsonchain.ServiceName, woc.TxsStatus),
+	}
+	if arcService != nil {
+		statusForTxIDsServices = append(statusForTxIDsServices, s.statusForTxIDsService(arc.ServiceName, arcService.TxsStatus))
+	}
+	s.statusForTxIDsServices = servicequeue.NewQueue1(logger, "StatusForTxIDs", statusForTxIDsServices...)
+
+	var postBeefServices []*servicequeue.Service1[*postBeefQuery, *PostBeefResult]
+	if arcService != nil {
+		postBeefServices = append(postBeefServices, postBeefService(arc.ServiceName, arcService.PostBeef))
+	}
+	for _, additional := range config.AdditionalArcs {
+		additionalArc := arc.NewARCService(logger, httpClient, toArcConfig(additional.URL, additional.ARC))
+		postBeefServices = append(postBeefServices, postBeefService(additional.Name, additionalArc.PostBeef))
+	}
+	postBeefServices = append(postBeefServices, postBeefService(whatsonchain.ServiceName, woc.PostBeef))
+	if bitailsService != nil {
+		postBeefServices = append(postBeefServices, postBeefService(bitails.ServiceName, bitailsService.PostBeef))
+	}
+	s.postBeefServices = servicequeue.NewQueue1(logger, "PostBeef", postBeefServices...)
+
+	var fiatServices []*servicequeue.Service[*wdk.FiatExchangeRates]
+	if config.ChaintracksFiatExchangeRatesUrl != "" {
+		fiatServices = append(fiatServices, servicequeue.NewService(chaintracks.FiatServiceName, func(ctx context.Context) (*wdk.FiatExchangeRates, error) {
+			return chaintracks.FiatExchangeRates(ctx, httpClient, config.ChaintracksFiatExchangeRatesUrl)
+		}))
+	}
+	if config.ExchangeratesApiKey != "" {
+		exchangeRatesAPI := exchangeratesapi.NewClient(logger, httpClient, config.ExchangeratesApiKey)
+		fiatServices = append(fiatServices, servicequeue.NewService(exchangeratesapi.ServiceName, exchangeRatesAPI.FiatExchangeRates))
+	}
+	if len(fiatServices) == 0 {
+		logging.DefaultIfNil(logger).Warn("no fiat exchange rates providers configured, the configured rates will never be refreshed")
+	}
+	s.fiatRates = &fiatRatesCache{
+		logger:         logging.Child(logger, "fiatRates"),
+		services:       servicequeue.NewQueue(logger, "FiatExchangeRate", fiatServices...),
+		noProviders:    len(fiatServices) == 0,
+		updateInterval: optional.OfPtr(config.FiatUpdateInterval).OrElse(whatsonchain.DefaultFiatExchangeUpdateInterval),
+		rates:          config.FiatExchangeRates,
+	}
 
-		rawTxServices: servicequeue.NewQueue1(
-			logger,
-			"RawTx",
-			servicequeue.NewService1(whatsonchain.ServiceName, woc.RawTx),
-		),
+	return s, nil
+}
+
+func toArcConfig(url string, config configuration.ARC) arc.Config {
+	return arc.Config{
+		URL:           url,
+		Token:         config.Token,
+		DeploymentID:  config.DeploymentID,
+		WaitFor:       config.WaitFor,
+		CallbackURL:   config.CallbackURL,
+		CallbackToken: config.CallbackToken,
+		Tokens:        config.Tokens,
+		RateLimit:     config.RateLimit,
 	}
 }
 
@@ -67,19 +197,28 @@ func (s *WalletServices) RawTx(txID string) (wdk.RawTxResult, error) {
 	return *result, nil
 }
 
-// ChainTracker returns service, which requires `options.chaintracks` be valid.
+// ChainTracker returns the chain tracker backed by the Chaintracks service if configured,
+// otherwise by the local block headers store synced from WhatsOnChain.
 func (s *WalletServices) ChainTracker() chaintracker.ChainTracker {
-	panic("Not implemented yet")
+	return s.headers
 }
 
 // HeaderForHeight returns serialized block header for height on active chain
-func (s *WalletServices) HeaderForHeight(height int64) ([]int64, error) {
-	panic("Not implemented yet")
+func (s *WalletServices) HeaderForHeight(ctx context.Context, height uint32) ([]byte, error) {
+	header, err := s.headers.HeaderForHeight(ctx, height)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't get header for height %d: %w", height, err)
+	}
+	return header.Bytes(), nil
 }
 
 // Height returns the height of the active chain
-func (s *WalletServices) Height() int64 {
-	panic("Not implemented yet")
+func (s *WalletServices) Height(ctx context.Context) (uint32, error) {
+	height, err := s.headers.Height(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("couldn't get chain height: %w", err)
+	}
+	return height, nil
 }
 
 // BsvExchangeRate returns approximate exchange rate US Dollar / BSV, USD / BSV
@@ -93,49 +232,223 @@ func (s *WalletServices) BsvExchangeRate() (float64, error) {
 	return bsvExchangeRate.Rate, nil
 }
 
-// FiatExchangeRate returns approximate exchange rate currency per base.
-func (s *WalletServices) FiatExchangeRate(currency wdk.Currency, base *wdk.Currency) float64 {
-	panic("Not implemented yet")
+// MiningFeeModel returns the fee model based on the mining fee from the ARC policy.
+// The rate of the policy is kept exact (see defs.SatPerBytes), so the fee of small transactions is not overcharged.
+func (s *WalletServices) MiningFeeModel(ctx context.Context) (defs.FeeModel, error) {
+	if s.arc == nil {
+		return defs.FeeModel{}, fmt.Errorf("arc is not configured")
+	}
+
+	policy, err := s.arc.Policy(ctx)
+	if err != nil {
+		return defs.FeeModel{}, fmt.Errorf("failed to fetch arc policy: %w", err)
+	}
+
+	feeModel, err := policy.MiningFee.FeeModel()
+	if err != nil {
+		return defs.FeeModel{}, fmt.Errorf("invalid arc policy: %w", err)
+	}
+
+	return feeModel, nil
+}
+
+// FiatExchangeRate returns approximate exchange rate currency per base, base defaults to USD.
+//
+// The rates are cached and refreshed from the configured providers (Chaintracks, exchangeratesapi)
+// when they are older than FiatUpdateInterval.
+func (s *WalletServices) FiatExchangeRate(ctx context.Context, currency wdk.Currency, base *wdk.Currency) (float64, error) {
+	rates, err := s.fiatRates.get(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	rate, err := fiatRate(rates, currency, optional.OfPtr(base).OrElse(wdk.USD))
+	if err != nil {
+		return 0, fmt.Errorf("couldn't get fiat exchange rate: %w", err)
+	}
+	return rate, nil
+}
+
+// MinedMerklePath returns the valid merkle path of the transaction, or nil if no service has it,
+// which means the transaction is not mined yet.
+func (s *WalletServices) MinedMerklePath(ctx context.Context, txID string) (*transaction.MerklePath, error) {
+	result, err := s.merklePathServices.OneByOne(ctx, &merklePathQuery{txID: txID})
+	if errors.Is(err, servicequeue.ErrEmptyResult) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("couldn't get merkle path for id %s: %w", txID, err)
+	}
+	return result.MerklePath, nil
 }
 
 // MerklePath attempts to obtain the merkle proof associated with a 32 byte transaction hash (txid).
 //
-// Cycles through configured transaction processing services attempting to get a valid response.
+// Cycles through configured services (WhatsOnChain, ARC) attempting to get a valid response.
+// The merkle path is valid when it leads to the merkle root of the block header.
 //
 // On success:
-// Result txid is the requested transaction hash
-// Result proof will be the merkle proof.
+// Result merkle path will be the merkle proof.
+// Result header will be the header of the block containing the transaction.
 // Result name will be the responding service's identifying name.
-// Returns result without incrementing active service.
 //
 // On failure:
-// Result txid is the requested transaction hash
-// Result mapi will be the first mapi response obtained (service name and response), or null
-// Result error will be the first error thrown (service name and CwiError), or null
-// Increments to next configured service and tries again until all services have been tried.
-func (s *WalletServices) MerklePath(txid string, useNext bool) (MerklePathResult, error) {
-	panic("Not implemented yet")
+// Result notes describe the attempts of every service
+// and the error joins the errors of all services.
+//
+// If useNext is true, the services are rotated, so the next service is tried first.
+func (s *WalletServices) MerklePath(ctx context.Context, txID string, useNext bool) (MerklePathResult, error) {
+	query := &merklePathQuery{txID: txID}
+
+	if useNext {
+		s.merklePathServices.Next()
+	}
+
+	result, err := s.merklePathServices.OneByOne(ctx, query)
+	if err != nil {
+		if errors.Is(err, servicequeue.ErrEmptyResult) {
+			return MerklePathResult{Notes: query.notes}, fmt.Errorf("merkle path for txID: %s not found", txID)
+		}
+		return MerklePathResult{Notes: query.notes}, fmt.Errorf("couldn't get merkle path for id %s: %w", txID, err)
+	}
+
+	result.Notes = query.notes
+	return *result, nil
 }
 
 // PostBeef attempts to post beef with given txIDs
-func (s *WalletServices) PostBeef(beef *transaction.Beef, txids []string) ([]*PostBeefResult, error) {
-	panic("Not implemented yet")
+//
+// Depending on the configured mode, broadcasts to the services (ARC instances and WhatsOnChain as a fallback)
+// one by one until the first one accepts all the transactions, or to all of them in parallel.
+//
+// Result contains the results of every called service
+// and the verdict on every txID aggregated from these results.
+// Error is returned only when the beef couldn't be posted at all.
+func (s *WalletServices) PostBeef(ctx context.Context, beef *transaction.Beef, txIDs []string) (*PostBeefResults, error) {
+	if beef == nil {
+		return nil, fmt.Errorf("beef is required")
+	}
+	if len(txIDs) == 0 {
+		return nil, fmt.Errorf("txIDs to post are required")
+	}
+
+	query := &postBeefQuery{beef: beef, txIDs: txIDs}
+
+	var err error
+	if s.postBeefMode == defs.PostBeefModeAll {
+		_, err = s.postBeefServices.All(ctx, query)
+	} else {
+		_, err = s.postBeefServices.OneByOne(ctx, query)
+	}
+	if errors.Is(err, servicequeue.ErrNoServicesRegistered) {
+		return nil, fmt.Errorf("couldn't post beef: %w", err)
+	}
+	// other errors are reflected in the results of particular services
+
+	return &PostBeefResults{
+		Results:     query.results,
+		TxIDResults: aggregatePostBeefResults(txIDs, query.results),
+	}, nil
 }
 
 // UtxoStatus attempts to determine the UTXO status of a transaction output.
 //
 // Cycles through configured transaction processing services attempting to get a valid response.
+// The output is a locking script or its sha256 hash in the byte order given by outputFormat,
+// when outputFormat is empty, 64 characters long output is treated as hashLE and any other as script.
+// If useNext is true, the services are rotated, so the next service is tried first.
 func (s *WalletServices) UtxoStatus(
+	ctx context.Context,
 	output string,
 	outputFormat UtxoStatusOutputFormat,
 	useNext bool,
 ) (UtxoStatusResult, error) {
-	panic("Not implemented yet")
+	scriptHash, err := scriptHashBE(output, outputFormat)
+	if err != nil {
+		return UtxoStatusResult{}, fmt.Errorf("invalid output: %w", err)
+	}
+
+	if useNext {
+		s.utxoStatusServices.Next()
+	}
+
+	result, err := s.utxoStatusServices.OneByOne(ctx, scriptHash)
+	if err != nil {
+		return UtxoStatusResult{}, fmt.Errorf("couldn't get utxo status of script hash %s: %w", scriptHash, err)
+	}
+	return *result, nil
+}
+
+// ScriptHistory returns the confirmed and unconfirmed transactions touching the locking script, e.g. for the wallet recovery.
+//
+// The scriptHash is sha256 of the locking script (hashLE, the same as the hash accepted by UtxoStatus).
+// To scan many scripts use ScriptsHistory, which queries them in batches.
+func (s *WalletServices) ScriptHistory(ctx context.Context, scriptHash string) (ScriptHistoryResult, error) {
+	result, err := s.ScriptsHistory(ctx, []string{scriptHash})
+	if err != nil {
+		return ScriptHistoryResult{}, err
+	}
+	return result[0], nil
+}
+
+// ScriptsHistory returns the history of every script in the order of scriptHashes, see ScriptHistory.
+//
+// WhatsOnChain is queried in batches with its bulk endpoints, Bitails (used as a fallback) is queried for every script separately.
+// The services share the health with UtxoStatus, so a provider failing or rate limiting the scan
+// is demoted for both methods.
+func (s *WalletServices) ScriptsHistory(ctx context.Context, scriptHashes []string) ([]ScriptHistoryResult, error) {
+	if len(scriptHashes) == 0 {
+		return nil, fmt.Errorf("script hashes are required")
+	}
+
+	hashesBE := make([]string, 0, len(scriptHashes))
+	for _, scriptHash := range scriptHashes {
+		hashBE, err := scriptHashBE(scriptHash, HashLE)
+		if err != nil {
+			return nil, fmt.Errorf("invalid script hash %s: %w", scriptHash, err)
+		}
+		hashesBE = append(hashesBE, hashBE)
+	}
+
+	result, err := s.scriptHistoryServices.OneByOne(ctx, hashesBE)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't get history of script hashes: %w", err)
+	}
+	if len(result) != len(hashesBE) {
+		return nil, fmt.Errorf("expected history of %d script hashes, got %d", len(hashesBE), len(result))
+	}
+	return result, nil
+}
+
+// StatusForTxIDs returns the status of every transaction: mined (with its depth), known (e.g. in the mempool) or unknown.
+//
+// The services are called one by one until the first one returns the statuses of all the transactions.
+// WhatsOnChain is queried in batches, ARC (used as a fallback) is queried for every transaction separately
+// and fails when any of the transactions failed to be queried.
+func (s *WalletServices) StatusForTxIDs(ctx context.Context, txIDs []string) (StatusForTxIDsResult, error) {
+	if len(txIDs) == 0 {
+		return StatusForTxIDsResult{}, fmt.Errorf("txIDs are required")
+	}
+
+	result, err := s.statusForTxIDsServices.OneByOne(ctx, txIDs)
+	if err != nil {
+		return StatusForTxIDsResult{}, fmt.Errorf("couldn't get status of txs: %w", err)
+	}
+	return *result, nil
 }
 
 // HashToHeader attempts to retrieve BlockHeader by its hash
-func (s *WalletServices) HashToHeader(hash string) (*BlockHeader, error) {
-	panic("Not implemented yet")
+func (s *WalletServices) HashToHeader(ctx context.Context, hash string) (*BlockHeader, error) {
+	blockHash, err := chainhash.NewHashFromHex(hash)
+	if err != nil {
+		return nil, fmt.Errorf("invalid block hash %s: %w", hash, err)
+	}
+
+	height, header, err := s.headers.HeaderByHash(ctx, *blockHash)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't get header for hash %s: %w", hash, err)
+	}
+	return fromStoredHeader(height, header), nil
 }
 
 // NLockTimeIsFinal returns whether the locktime value allows the transaction to be mined at the current chain height
@@ -143,3 +456,34 @@ func (s *WalletServices) HashToHeader(hash string) (*BlockHeader, error) {
 func (s *WalletServices) NLockTimeIsFinal(txOrLockTime any) bool {
 	panic("Not implemented yet")
 }
+
+// ServiceStats returns the health statistics of all the services used by WalletServices, for monitoring purposes
+func (s *WalletServices) ServiceStats() []ServiceStats {
+	var stats []ServiceStats
+	stats = appendServiceStats(stats, s.rawTxServices.MethodName(), s.rawTxServices.Stats())
+	stats = appendServiceStats(stats, s.merklePathServices.MethodName(), s.merklePathServices.Stats())
+	stats = appendServiceStats(stats, s.postBeefServices.MethodName(), s.postBeefServices.Stats())
+	stats = appendServiceStats(stats, s.utxoStatusServices.MethodName(), s.utxoStatusServices.Stats())
+	stats = appendServiceStats(stats, s.scriptHistoryServices.MethodName(), s.scriptHistoryServices.Stats())
+	stats = appendServiceStats(stats, s.statusForTxIDsServices.MethodName(), s.statusForTxIDsServices.Stats())
+	stats = appendServiceStats(stats, s.fiatRates.services.MethodName(), s.fiatRates.services.Stats())
+	return stats
+}
+
+func appendServiceStats(stats []ServiceStats, method string, queueStats []servicequeue.Stats) []ServiceStats {
+	for _, st := range queueStats {
+		stats = append(stats, ServiceStats{
+			Method:              method,
+			Name:                st.Name,
+			SuccessCount:        st.SuccessCount,
+			FailureCount:        st.FailureCount,
+			ConsecutiveFailures: st.ConsecutiveFailures,
+			AverageLatency:      st.AverageLatency,
+			CircuitState:        string(st.Circuit),
+			LastError:           st.LastError,
+			LastFailureAt:       st.LastFailureAt,
+			Score:               st.Score,
+		})
+	}
+	return stats
+}
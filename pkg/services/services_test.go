@@ -1,11 +1,16 @@
 package services_test
 
 import (
+	"context"
 	"encoding/hex"
 	"fmt"
+	"slices"
 	"testing"
 	"time"
 
+	"github.com/4chain-ag/go-wallet-toolbox/pkg/defs"
+	"github.com/4chain-ag/go-wallet-toolbox/pkg/services"
+	"github.com/4chain-ag/go-wallet-toolbox/pkg/services/configuration"
 	"github.com/4chain-ag/go-wallet-toolbox/pkg/services/internal/testabilities"
 	"github.com/4chain-ag/go-wallet-toolbox/pkg/wdk"
 	"github.com/stretchr/testify/assert"
@@ -268,3 +273,78 @@ func TestRawTxFailure(t *testing.T) {
 		assert.Contains(t, err.Error(), "all services failed")
 	})
 }
+
+func TestMiningFeeModel(t *testing.T) {
+	t.Run("returns fee model from arc policy", func(t *testing.T) {
+		// given:
+		given := testabilities.Given(t)
+		given.ARC().WillReturnPolicyWithMiningFee(50, 1000)
+
+		// and:
+		services := given.Services().WithDefaultConfig()
+
+		// when:
+		feeModel, err := services.MiningFeeModel(context.Background())
+
+		// then:
+		require.NoError(t, err)
+		assert.Equal(t, defs.FeeModel{Type: defs.SatPerBytes, Value: 50, Bytes: 1000}, feeModel)
+	})
+
+	t.Run("returns error when arc policy cannot be fetched", func(t *testing.T) {
+		// given:
+		given := testabilities.Given(t)
+
+		// and:
+		services := given.Services().WithDefaultConfig()
+
+		// when:
+		_, err := services.MiningFeeModel(context.Background())
+
+		// then:
+		require.Error(t, err)
+	})
+
+	t.Run("returns error when arc is not configured", func(t *testing.T) {
+		// given:
+		given := testabilities.Given(t)
+
+		// and:
+		services := given.NewServicesWithConfig(configuration.WalletServices{Chain: defs.NetworkTestnet})
+
+		// when:
+		_, err := services.MiningFeeModel(context.Background())
+
+		// then:
+		require.Error(t, err)
+	})
+}
+
+func TestServiceStats(t *testing.T) {
+	t.Run("collects health statistics of called services", func(t *testing.T) {
+		// given:
+		given := testabilities.Given(t)
+		txID := "3c64c621c0070ea56ca2ef13ef699483c3938f48e030b184f1d094678eda7ab8"
+		given.WhatsOnChain().WillRespondWithRawTx(500, txID, "some internal error", nil)
+
+		// and:
+		walletServices := given.Services().WithDefaultConfig()
+
+		// and:
+		_, err := walletServices.RawTx(txID)
+		require.Error(t, err)
+
+		// when:
+		stats := walletServices.ServiceStats()
+
+		// then:
+		idx := slices.IndexFunc(stats, func(it services.ServiceStats) bool {
+			return it.Method == "RawTx" && it.Name == "WhatsOnChain"
+		})
+		require.GreaterOrEqual(t, idx, 0)
+		assert.EqualValues(t, 0, stats[idx].SuccessCount)
+		assert.EqualValues(t, 1, stats[idx].FailureCount)
+		assert.Equal(t, "closed", stats[idx].CircuitState)
+		assert.NotEmpty(t, stats[idx].LastError)
+	})
+}
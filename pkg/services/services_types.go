@@ -1,6 +1,9 @@
 package services
 
 import (
+	"time"
+
+	"github.com/4chain-ag/go-wallet-toolbox/pkg/services/results"
 	"github.com/4chain-ag/go-wallet-toolbox/pkg/wdk"
 	"github.com/bsv-blockchain/go-sdk/transaction"
 )
@@ -89,6 +92,8 @@ type UtxoStatusResult struct {
 // PostTxResultForTxID is the struct representing postTX result for particular TxID
 type PostTxResultForTxID struct {
 	TxID string
+	// Result is the status of the transaction reported by the service
+	Result results.ResultStatus
 	// AlreadyKnown if true, the transaction was already known to this service. Usually treat as a success.
 	// Potentially stop posting to additional transaction processors.
 	AlreadyKnown bool
@@ -99,7 +104,7 @@ type PostTxResultForTxID struct {
 	BlockHeight  *int64
 	MerklePath   *transaction.MerklePath
 	CompetingTxs []string
-	// TODO: Data type is object | string | PostTxResultForTxidError
+	// Data is the service response for the transaction, or its error when the service didn't return any data
 	Data  any
 	Notes []wdk.ReqHistoryNote
 }
@@ -110,6 +115,109 @@ type PostBeefResult struct {
 	Name        string
 	TxIDResults []PostTxResultForTxID
 	// Data is service response object. Use service name and status to infer type of object.
-	Data  any
+	Data any
+	// Error is the error of the whole service call, nil if the service processed the request
+	Error error
 	Notes []wdk.ReqHistoryNote
 }
+
+// PostTxIDStatus is the status of a transaction broadcast aggregated from results of all called services
+type PostTxIDStatus string
+
+// Possible aggregated statuses of a transaction broadcast
+const (
+	// PostTxIDStatusSuccess means that at least one service accepted the transaction and none of them reported a double spend
+	PostTxIDStatusSuccess PostTxIDStatus = "success"
+	// PostTxIDStatusDoubleSpend means that at least one service reported a double spend
+	PostTxIDStatusDoubleSpend PostTxIDStatus = "doubleSpend"
+	// PostTxIDStatusInvalidTx means that the services rejected the transaction
+	PostTxIDStatusInvalidTx PostTxIDStatus = "invalidTx"
+	// PostTxIDStatusServiceError means that none of the services were able to process the transaction
+	PostTxIDStatusServiceError PostTxIDStatus = "serviceError"
+)
+
+// AggregatedPostTxID is the verdict on a transaction broadcast aggregated from results of all called services
+type AggregatedPostTxID struct {
+	TxID   string
+	Status PostTxIDStatus
+	// AlreadyKnown is true if any of the services accepting the transaction already knew it
+	AlreadyKnown bool
+	// CompetingTxs are the txids reported by services as the double spends of the transaction
+	CompetingTxs []string
+
+	SuccessCount      int
+	DoubleSpendCount  int
+	StatusErrorCount  int
+	ServiceErrorCount int
+}
+
+// PostBeefResults is the result of PostBeef method
+type PostBeefResults struct {
+	// Results are the results of every called service
+	Results []*PostBeefResult
+	// TxIDResults are the verdicts on every posted txID, in the order of the posted txIDs
+	TxIDResults []*AggregatedPostTxID
+}
+
+// ServiceStats are the health statistics of a service providing one of the methods of WalletServices
+type ServiceStats struct {
+	// Method is the name of the WalletServices method provided by the service
+	Method string
+	// Name is the name of the service
+	Name string
+
+	SuccessCount        uint64
+	FailureCount        uint64
+	ConsecutiveFailures uint64
+	AverageLatency      time.Duration
+
+	// CircuitState is one of "closed", "open" or "half-open"
+	CircuitState  string
+	LastError     string
+	LastFailureAt time.Time
+	// Score is the decaying success rate of the recent calls, services with higher score are called first
+	Score float64
+}
+
+// TxStatusDetails is the status of a single transaction returned by StatusForTxIDs method
+type TxStatusDetails struct {
+	TxID string
+
+	// Status is mined, known (e.g. in the mempool) or unknown to the service
+	Status results.TxStatus
+
+	// Depth is the number of confirmations of the mined transaction, 1 when it is in the chain tip, nil when not mined
+	Depth *uint32
+}
+
+// StatusForTxIDsResult represents the result of a StatusForTxIDs operation
+type StatusForTxIDsResult struct {
+	// Name is the name of the service returning the statuses
+	Name string
+
+	// Results contains the status of every requested txID, in the requested order
+	Results []TxStatusDetails
+}
+
+// ScriptHistoryItem is a transaction touching the script
+type ScriptHistoryItem struct {
+	TxID string
+
+	// Height is the height of the block containing the transaction, nil for unconfirmed transaction
+	Height *int64
+}
+
+// ScriptHistoryResult represents the result of a ScriptHistory operation
+type ScriptHistoryResult struct {
+	// Name is the name of the service returning the history
+	Name string
+
+	// ScriptHash is the sha256 hash of the locking script in big-endian (reversed) byte order, as requested from the service
+	ScriptHash string
+
+	// Confirmed are the transactions mined in blocks
+	Confirmed []ScriptHistoryItem
+
+	// Unconfirmed are the transactions not mined yet
+	Unconfirmed []ScriptHistoryItem
+}
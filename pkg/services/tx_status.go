@@ -0,0 +1,65 @@
+package services
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/4chain-ag/go-wallet-toolbox/pkg/services/internal/servicequeue"
+	"github.com/4chain-ag/go-wallet-toolbox/pkg/services/results"
+	"github.com/go-softwarelab/common/pkg/to"
+)
+
+type txsStatusFetcher func(ctx context.Context, txIDs []string) ([]results.TxIDStatus, error)
+
+// statusForTxIDsService wraps the fetcher of transactions statuses of a single provider.
+// The depth of mined transactions is calculated from the chain height when the provider returns only the block height.
+func (s *WalletServices) statusForTxIDsService(name string, fetch txsStatusFetcher) *servicequeue.Service1[[]string, *StatusForTxIDsResult] {
+	return servicequeue.NewService1(name, func(ctx context.Context, txIDs []string) (*StatusForTxIDsResult, error) {
+		statuses, err := fetch(ctx, txIDs)
+		if err != nil {
+			return nil, err
+		}
+		if len(statuses) != len(txIDs) {
+			return nil, fmt.Errorf("got %d statuses for %d txids", len(statuses), len(txIDs))
+		}
+
+		var chainHeight uint32
+		details := make([]TxStatusDetails, 0, len(statuses))
+		for i, status := range statuses {
+			if status.TxID != txIDs[i] {
+				return nil, fmt.Errorf("got status of tx %s while querying for %s", status.TxID, txIDs[i])
+			}
+
+			depth := status.Depth
+			if status.Status == results.TxStatusMined && depth == nil && status.BlockHeight != nil {
+				if chainHeight == 0 {
+					chainHeight, err = s.Height(ctx)
+					if err != nil {
+						return nil, err
+					}
+				}
+				depth = to.Ptr(txDepth(chainHeight, *status.BlockHeight))
+			}
+
+			details = append(details, TxStatusDetails{
+				TxID:   status.TxID,
+				Status: status.Status,
+				Depth:  depth,
+			})
+		}
+
+		return &StatusForTxIDsResult{
+			Name:    name,
+			Results: details,
+		}, nil
+	})
+}
+
+// txDepth returns the number of confirmations of the transaction mined in the block of given height
+func txDepth(chainHeight, blockHeight uint32) uint32 {
+	if blockHeight > chainHeight {
+		// the local headers may lag behind the service
+		return 1
+	}
+	return chainHeight - blockHeight + 1
+}
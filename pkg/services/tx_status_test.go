@@ -0,0 +1,243 @@
+package services_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/4chain-ag/go-wallet-toolbox/pkg/services"
+	"github.com/4chain-ag/go-wallet-toolbox/pkg/services/configuration"
+	"github.com/4chain-ag/go-wallet-toolbox/pkg/services/internal/arc"
+	"github.com/4chain-ag/go-wallet-toolbox/pkg/services/internal/testabilities"
+	"github.com/4chain-ag/go-wallet-toolbox/pkg/services/internal/whatsonchain"
+	"github.com/4chain-ag/go-wallet-toolbox/pkg/services/results"
+	"github.com/bsv-blockchain/go-sdk/chainhash"
+	sdk "github.com/bsv-blockchain/go-sdk/transaction"
+	"github.com/go-softwarelab/common/pkg/to"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	minedTxID   = "3c64c621c0070ea56ca2ef13ef699483c3938f48e030b184f1d094678eda7ab8"
+	knownTxID   = "0b56b1a6d7b0b4f1a8c3e1f6d2a1e2b3c4d5e6f708192a3b4c5d6e7f80919293"
+	unknownTxID = "0f0e0d0c0b0a09080706050403020100f0e0d0c0b0a090807060504030201000"
+)
+
+func wocTxsStatusContent(statusOf func(txID string) string) func(txIDs []string) string {
+	return func(txIDs []string) string {
+		statuses := make([]string, 0, len(txIDs))
+		for _, txID := range txIDs {
+			statuses = append(statuses, statusOf(txID))
+		}
+		return "[" + strings.Join(statuses, ",") + "]"
+	}
+}
+
+func TestStatusForTxIDs(t *testing.T) {
+	t.Run("returns statuses of mined, known and unknown transactions", func(t *testing.T) {
+		// given:
+		given := testabilities.Given(t)
+		given.WhatsOnChain().WillRespondWithTxsStatus(http.StatusOK, wocTxsStatusContent(func(txID string) string {
+			switch txID {
+			case minedTxID:
+				return fmt.Sprintf(`{"txid": "%s", "blockhash": "%s", "blockheight": 881234, "confirmations": 3}`, txID, merklePathBlockHash)
+			case knownTxID:
+				return fmt.Sprintf(`{"txid": "%s", "confirmations": 0}`, txID)
+			default:
+				return fmt.Sprintf(`{"txid": "%s", "error": "unknown"}`, txID)
+			}
+		}))
+
+		// and:
+		walletServices := given.Services().WithDefaultConfig()
+
+		// when:
+		result, err := walletServices.StatusForTxIDs(context.Background(), []string{minedTxID, knownTxID, unknownTxID})
+
+		// then:
+		require.NoError(t, err)
+		assert.Equal(t, services.StatusForTxIDsResult{
+			Name: whatsonchain.ServiceName,
+			Results: []services.TxStatusDetails{
+				{TxID: minedTxID, Status: results.TxStatusMined, Depth: to.Ptr(uint32(3))},
+				{TxID: knownTxID, Status: results.TxStatusKnown},
+				{TxID: unknownTxID, Status: results.TxStatusUnknown},
+			},
+		}, result)
+	})
+
+	t.Run("queries WhatsOnChain in batches", func(t *testing.T) {
+		// given:
+		txIDs := make([]string, 0, 2*whatsonchain.MaxTxIDsPerStatusRequest+5)
+		for i := range cap(txIDs) {
+			txIDs = append(txIDs, fmt.Sprintf("%064x", i+1))
+		}
+
+		// and:
+		var requestedBatches [][]string
+		given := testabilities.Given(t)
+		given.WhatsOnChain().WillRespondWithTxsStatus(http.StatusOK, func(batch []string) string {
+			requestedBatches = append(requestedBatches, batch)
+			return wocTxsStatusContent(func(txID string) string {
+				return fmt.Sprintf(`{"txid": "%s", "confirmations": 0}`, txID)
+			})(batch)
+		})
+
+		// and:
+		walletServices := given.Services().WithDefaultConfig()
+
+		// when:
+		result, err := walletServices.StatusForTxIDs(context.Background(), txIDs)
+
+		// then:
+		require.NoError(t, err)
+		require.Len(t, requestedBatches, 3)
+		assert.Len(t, requestedBatches[0], whatsonchain.MaxTxIDsPerStatusRequest)
+		assert.Len(t, requestedBatches[1], whatsonchain.MaxTxIDsPerStatusRequest)
+		assert.Len(t, requestedBatches[2], 5)
+
+		// and:
+		require.Len(t, result.Results, len(txIDs))
+		for i, status := range result.Results {
+			assert.Equal(t, txIDs[i], status.TxID)
+			assert.Equal(t, results.TxStatusKnown, status.Status)
+		}
+	})
+
+	t.Run("falls back to ARC and calculates depth from the chain height", func(t *testing.T) {
+		// given:
+		given := testabilities.Given(t)
+		given.WhatsOnChain().WillRespondWithTxsStatus(http.StatusInternalServerError, func([]string) string {
+			return `"internal error"`
+		})
+		given.WhatsOnChain().WillRespondWithChainInfo(http.StatusOK, chainInfoResponse)
+		for height, content := range firstBlocks {
+			given.WhatsOnChain().WillRespondWithBlockAtHeight(http.StatusOK, height, content)
+		}
+
+		// and:
+		txHash, err := chainhash.NewHashFromHex(minedTxID)
+		require.NoError(t, err)
+		given.ARC().WillReturnMinedTransaction(minedTxID, block1Hash, sdk.NewMerklePath(1, [][]*sdk.PathElement{{
+			{Offset: 0, Hash: txHash, Txid: to.Ptr(true)},
+		}}))
+
+		// and:
+		walletServices := given.Services().WithConfig(func(config *configuration.WalletServices) {
+			config.HeaderStore.StartHeight = to.Ptr(uint32(0))
+		})
+
+		// when:
+		result, err := walletServices.StatusForTxIDs(context.Background(), []string{minedTxID})
+
+		// then:
+		require.NoError(t, err)
+		assert.Equal(t, services.StatusForTxIDsResult{
+			Name: "ARC",
+			Results: []services.TxStatusDetails{
+				{TxID: minedTxID, Status: results.TxStatusMined, Depth: to.Ptr(uint32(2))},
+			},
+		}, result)
+	})
+
+	t.Run("fails when a transaction failed to be queried from ARC", func(t *testing.T) {
+		// given:
+		given := testabilities.Given(t)
+		given.WhatsOnChain().WillRespondWithTxsStatus(http.StatusInternalServerError, func([]string) string {
+			return `"internal error"`
+		})
+		given.WhatsOnChain().WillRespondWithChainInfo(http.StatusOK, chainInfoResponse)
+		for height, content := range firstBlocks {
+			given.WhatsOnChain().WillRespondWithBlockAtHeight(http.StatusOK, height, content)
+		}
+
+		// and: only the mined transaction can be queried from ARC
+		txHash, err := chainhash.NewHashFromHex(minedTxID)
+		require.NoError(t, err)
+		given.ARC().WillReturnMinedTransaction(minedTxID, block1Hash, sdk.NewMerklePath(1, [][]*sdk.PathElement{{
+			{Offset: 0, Hash: txHash, Txid: to.Ptr(true)},
+		}}))
+
+		// and:
+		walletServices := given.Services().WithConfig(func(config *configuration.WalletServices) {
+			config.HeaderStore.StartHeight = to.Ptr(uint32(0))
+		})
+
+		// when:
+		_, err = walletServices.StatusForTxIDs(context.Background(), []string{minedTxID, knownTxID})
+
+		// then:
+		require.Error(t, err)
+	})
+
+	t.Run("queries ARC for more transactions than queried at once", func(t *testing.T) {
+		// given:
+		given := testabilities.Given(t)
+		given.WhatsOnChain().WillRespondWithTxsStatus(http.StatusInternalServerError, func([]string) string {
+			return `"internal error"`
+		})
+		given.WhatsOnChain().WillRespondWithChainInfo(http.StatusOK, chainInfoResponse)
+		for height, content := range firstBlocks {
+			given.WhatsOnChain().WillRespondWithBlockAtHeight(http.StatusOK, height, content)
+		}
+
+		// and:
+		txIDs := make([]string, 0, 3*arc.MaxConcurrentTxStatusQueries)
+		for i := range cap(txIDs) {
+			txHash := chainhash.HashH([]byte(fmt.Sprintf("tx-%d", i)))
+			given.ARC().WillReturnMinedTransaction(txHash.String(), block1Hash, sdk.NewMerklePath(1, [][]*sdk.PathElement{{
+				{Offset: 0, Hash: &txHash, Txid: to.Ptr(true)},
+			}}))
+			txIDs = append(txIDs, txHash.String())
+		}
+
+		// and:
+		walletServices := given.Services().WithConfig(func(config *configuration.WalletServices) {
+			config.HeaderStore.StartHeight = to.Ptr(uint32(0))
+		})
+
+		// when:
+		result, err := walletServices.StatusForTxIDs(context.Background(), txIDs)
+
+		// then:
+		require.NoError(t, err)
+		assert.Equal(t, "ARC", result.Name)
+		require.Len(t, result.Results, len(txIDs))
+		for i, status := range result.Results {
+			assert.Equal(t, txIDs[i], status.TxID)
+			assert.Equal(t, results.TxStatusMined, status.Status)
+		}
+	})
+
+	t.Run("fails when WhatsOnChain skips a transaction", func(t *testing.T) {
+		// given:
+		given := testabilities.Given(t)
+		given.WhatsOnChain().WillRespondWithTxsStatus(http.StatusOK, func([]string) string {
+			return fmt.Sprintf(`[{"txid": "%s", "confirmations": 0}]`, knownTxID)
+		})
+		given.ARC().WillAlwaysReturnStatus(http.StatusInternalServerError)
+
+		// and:
+		walletServices := given.Services().WithDefaultConfig()
+
+		// when:
+		_, err := walletServices.StatusForTxIDs(context.Background(), []string{knownTxID, unknownTxID})
+
+		// then:
+		require.Error(t, err)
+	})
+
+	t.Run("requires txids", func(t *testing.T) {
+		// given:
+		walletServices := testabilities.Given(t).Services().WithDefaultConfig()
+
+		// when:
+		_, err := walletServices.StatusForTxIDs(context.Background(), nil)
+
+		// then:
+		require.Error(t, err)
+	})
+}
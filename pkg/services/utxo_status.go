@@ -0,0 +1,80 @@
+package services
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+	"slices"
+
+	"github.com/4chain-ag/go-wallet-toolbox/pkg/services/internal/servicequeue"
+	"github.com/4chain-ag/go-wallet-toolbox/pkg/services/results"
+	crypto "github.com/bsv-blockchain/go-sdk/primitives/hash"
+	"github.com/go-softwarelab/common/pkg/to"
+)
+
+const scriptHashLength = 32
+
+type scriptUnspentFetcher func(ctx context.Context, scriptHash string) ([]results.ScriptUnspent, error)
+
+// utxoStatusService wraps the fetcher of script unspent outputs of a single provider.
+// It accepts the script hash in big-endian (reversed) byte order.
+func utxoStatusService(name string, fetch scriptUnspentFetcher) *servicequeue.Service1[string, *UtxoStatusResult] {
+	return servicequeue.NewService1(name, func(ctx context.Context, scriptHash string) (*UtxoStatusResult, error) {
+		unspent, err := fetch(ctx, scriptHash)
+		if err != nil {
+			return nil, err
+		}
+		return toUtxoStatusResult(name, unspent), nil
+	})
+}
+
+func toUtxoStatusResult(name string, unspent []results.ScriptUnspent) *UtxoStatusResult {
+	details := make([]UtxoStatusDetails, 0, len(unspent))
+	for _, utxo := range unspent {
+		details = append(details, UtxoStatusDetails{
+			Height:   to.Ptr(utxo.Height),
+			Txid:     to.Ptr(utxo.TxHash),
+			Index:    to.Ptr(utxo.TxPos),
+			Satoshis: to.Ptr(utxo.Value),
+		})
+	}
+
+	return &UtxoStatusResult{
+		Name:    name,
+		IsUtxo:  to.Ptr(len(details) > 0),
+		Details: details,
+	}
+}
+
+// scriptHashBE converts the output in given format to the script hash in big-endian (reversed) byte order.
+// When the format is not provided, 64 characters long output is treated as hashLE and any other as script.
+func scriptHashBE(output string, outputFormat UtxoStatusOutputFormat) (string, error) {
+	if outputFormat == "" {
+		outputFormat = Script
+		if len(output) == scriptHashLength*2 {
+			outputFormat = HashLE
+		}
+	}
+
+	outputBytes, err := hex.DecodeString(output)
+	if err != nil {
+		return "", fmt.Errorf("output is not a valid hex string: %w", err)
+	}
+
+	switch outputFormat {
+	case HashBE:
+	case HashLE:
+		slices.Reverse(outputBytes)
+	case Script:
+		outputBytes = crypto.Sha256(outputBytes)
+		slices.Reverse(outputBytes)
+	default:
+		return "", fmt.Errorf("unsupported output format %q", outputFormat)
+	}
+
+	if len(outputBytes) != scriptHashLength {
+		return "", fmt.Errorf("script hash must be %d bytes long, got %d", scriptHashLength, len(outputBytes))
+	}
+
+	return hex.EncodeToString(outputBytes), nil
+}
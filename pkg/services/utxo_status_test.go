@@ -0,0 +1,155 @@
+package services_test
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"slices"
+	"testing"
+
+	"github.com/4chain-ag/go-wallet-toolbox/pkg/services"
+	"github.com/4chain-ag/go-wallet-toolbox/pkg/services/internal/testabilities"
+	"github.com/go-softwarelab/common/pkg/to"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	utxoLockingScript = "76a9144b5a9d3c4ce1e4b4a7f3e4b3f0a1c2d3e4f5a6b788ac"
+	utxoTxID          = "a3e2ad5e5f84b1d1c1d3f3e5f0b2f1e0c9a8b7c6d5e4f3011223344556677889"
+)
+
+func TestUtxoStatus(t *testing.T) {
+	scriptBytes, err := hex.DecodeString(utxoLockingScript)
+	require.NoError(t, err)
+
+	hash := sha256.Sum256(scriptBytes)
+	hashLE := hex.EncodeToString(hash[:])
+	slices.Reverse(hash[:])
+	hashBE := hex.EncodeToString(hash[:])
+
+	unspentResponse := fmt.Sprintf(`{
+		"script": "%s",
+		"result": [{"height": 881234, "tx_pos": 1, "tx_hash": "%s", "value": 1000, "isSpentInMempoolTx": false, "status": "confirmed"}],
+		"error": ""
+	}`, hashBE, utxoTxID)
+
+	expectedUtxo := services.UtxoStatusResult{
+		Name:   "WhatsOnChain",
+		IsUtxo: to.Ptr(true),
+		Details: []services.UtxoStatusDetails{{
+			Height:   to.Ptr(int64(881234)),
+			Txid:     to.Ptr(utxoTxID),
+			Index:    to.Ptr(int64(1)),
+			Satoshis: to.Ptr(uint64(1000)),
+		}},
+	}
+
+	formats := map[string]struct {
+		output string
+		format services.UtxoStatusOutputFormat
+	}{
+		"hashBE": {
+			output: hashBE,
+			format: services.HashBE,
+		},
+		"hashLE": {
+			output: hashLE,
+			format: services.HashLE,
+		},
+		"script": {
+			output: utxoLockingScript,
+			format: services.Script,
+		},
+		"hashLE by default": {
+			output: hashLE,
+		},
+		"script by default": {
+			output: utxoLockingScript,
+		},
+	}
+	for name, test := range formats {
+		t.Run("returns utxo status for output in format "+name, func(t *testing.T) {
+			// given:
+			given := testabilities.Given(t)
+			given.WhatsOnChain().WillRespondWithScriptUnspent(http.StatusOK, hashBE, unspentResponse)
+
+			// and:
+			walletServices := given.Services().WithDefaultConfig()
+
+			// when:
+			result, err := walletServices.UtxoStatus(context.Background(), test.output, test.format, false)
+
+			// then:
+			require.NoError(t, err)
+			assert.Equal(t, expectedUtxo, result)
+		})
+	}
+
+	t.Run("returns no utxo when script has no unspent outputs", func(t *testing.T) {
+		// given:
+		given := testabilities.Given(t)
+		given.WhatsOnChain().WillRespondWithScriptUnspent(http.StatusOK, hashBE, fmt.Sprintf(`{"script": "%s", "result": [], "error": ""}`, hashBE))
+
+		// and:
+		walletServices := given.Services().WithDefaultConfig()
+
+		// when:
+		result, err := walletServices.UtxoStatus(context.Background(), utxoLockingScript, services.Script, true)
+
+		// then:
+		require.NoError(t, err)
+		assert.False(t, *result.IsUtxo)
+		assert.Empty(t, result.Details)
+	})
+
+	t.Run("returns error when service fails", func(t *testing.T) {
+		// given:
+		given := testabilities.Given(t)
+		given.WhatsOnChain().WillRespondWithScriptUnspent(http.StatusInternalServerError, hashBE, `{}`)
+
+		// and:
+		walletServices := given.Services().WithDefaultConfig()
+
+		// when:
+		_, err := walletServices.UtxoStatus(context.Background(), hashBE, services.HashBE, false)
+
+		// then:
+		require.Error(t, err)
+	})
+
+	invalidOutputs := map[string]struct {
+		output string
+		format services.UtxoStatusOutputFormat
+	}{
+		"not a hex": {
+			output: "not a hex",
+			format: services.Script,
+		},
+		"hash of wrong length": {
+			output: utxoLockingScript,
+			format: services.HashBE,
+		},
+		"unsupported format": {
+			output: hashBE,
+			format: "hash",
+		},
+	}
+	for name, test := range invalidOutputs {
+		t.Run("returns error for invalid output: "+name, func(t *testing.T) {
+			// given:
+			given := testabilities.Given(t)
+
+			// and:
+			walletServices := given.Services().WithDefaultConfig()
+
+			// when:
+			_, err := walletServices.UtxoStatus(context.Background(), test.output, test.format, false)
+
+			// then:
+			require.Error(t, err)
+		})
+	}
+}
@@ -0,0 +1,142 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/4chain-ag/go-wallet-toolbox/pkg/storage/internal/entity"
+	"github.com/4chain-ag/go-wallet-toolbox/pkg/wdk"
+	"github.com/bsv-blockchain/go-sdk/chainhash"
+	"github.com/bsv-blockchain/go-sdk/transaction"
+	"github.com/bsv-blockchain/go-sdk/transaction/chaintracker"
+	"github.com/go-softwarelab/common/pkg/to"
+)
+
+// Transaction statuses sent by ARC to the callback URL, which change the status of ProvenTxReq
+const (
+	ArcStatusSeenOnNetwork        = "SEEN_ON_NETWORK"
+	ArcStatusMined                = "MINED"
+	ArcStatusRejected             = "REJECTED"
+	ArcStatusDoubleSpendAttempted = "DOUBLE_SPEND_ATTEMPTED"
+)
+
+// ArcCallback is the transaction status update sent by ARC to the callback URL (X-CallbackUrl)
+type ArcCallback struct {
+	Timestamp    time.Time `json:"timestamp"`
+	TxID         string    `json:"txid"`
+	TxStatus     string    `json:"txStatus"`
+	ExtraInfo    string    `json:"extraInfo,omitempty"`
+	BlockHash    string    `json:"blockHash,omitempty"`
+	BlockHeight  uint32    `json:"blockHeight,omitempty"`
+	MerklePath   string    `json:"merklePath,omitempty"`
+	CompetingTxs []string  `json:"competingTxs,omitempty"`
+}
+
+// ProcessArcCallback updates the ProvenTxReq of the transaction with the status reported by ARC:
+// SEEN_ON_NETWORK changes it to callback, MINED stores the merkle path verified by the chain tracker (see WithChainTracker) and completes it,
+// REJECTED marks it invalid and the related transactions as failed.
+// DOUBLE_SPEND_ATTEMPTED is not final in ARC (the transaction can still be mined), so it only changes the status to unconfirmed.
+// Other statuses are only recorded in the history of ProvenTxReq.
+func (p *Provider) ProcessArcCallback(ctx context.Context, callback ArcCallback) error {
+	if callback.TxID == "" {
+		return fmt.Errorf("txid is required")
+	}
+
+	update, err := toProvenTxReqStatusUpdate(callback, p.chainTracker)
+	if err != nil {
+		return fmt.Errorf("invalid callback for tx %s: %w", callback.TxID, err)
+	}
+
+	historyAttrs := map[string]any{
+		"txStatus": callback.TxStatus,
+	}
+	if callback.ExtraInfo != "" {
+		historyAttrs["extraInfo"] = callback.ExtraInfo
+	}
+	if callback.BlockHash != "" {
+		historyAttrs["blockHash"] = callback.BlockHash
+		historyAttrs["blockHeight"] = callback.BlockHeight
+	}
+	if len(callback.CompetingTxs) > 0 {
+		historyAttrs["competingTxs"] = callback.CompetingTxs
+	}
+
+	err = p.repo.UpdateProvenTxReqStatus(ctx, update, "arcCallback", historyAttrs)
+	if err != nil {
+		return fmt.Errorf("failed to process arc callback for tx %s: %w", callback.TxID, err)
+	}
+	return nil
+}
+
+func toProvenTxReqStatusUpdate(callback ArcCallback, chainTracker chaintracker.ChainTracker) (*entity.ProvenTxReqStatusUpdate, error) {
+	update := &entity.ProvenTxReqStatusUpdate{
+		TxID: callback.TxID,
+	}
+
+	switch callback.TxStatus {
+	case ArcStatusSeenOnNetwork:
+		update.Status = wdk.ProvenTxStatusCallback
+	case ArcStatusMined:
+		proof, err := toTxProof(callback, chainTracker)
+		if err != nil {
+			return nil, err
+		}
+		update.Status = wdk.ProvenTxStatusCompleted
+		update.TxStatus = to.Ptr(wdk.TxStatusCompleted)
+		update.Proof = proof
+	case ArcStatusRejected:
+		update.Status = wdk.ProvenTxStatusInvalid
+		update.TxStatus = to.Ptr(wdk.TxStatusFailed)
+	case ArcStatusDoubleSpendAttempted:
+		// the competing transactions are recorded in the history, the transaction is failed only when ARC rejects it
+		update.Status = wdk.ProvenTxStatusUnconfirmed
+	default:
+		// intermediate statuses (e.g. STORED, ANNOUNCED_TO_NETWORK) don't change the processing state
+	}
+
+	return update, nil
+}
+
+func toTxProof(callback ArcCallback, chainTracker chaintracker.ChainTracker) (*entity.TxProof, error) {
+	if callback.MerklePath == "" {
+		return nil, fmt.Errorf("merkle path of mined transaction is required")
+	}
+	if chainTracker == nil {
+		return nil, fmt.Errorf("chain tracker is required to verify the merkle path of mined transaction")
+	}
+
+	txID, err := chainhash.NewHashFromHex(callback.TxID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid txid: %w", err)
+	}
+
+	merklePath, err := transaction.NewMerklePathFromHex(callback.MerklePath)
+	if err != nil {
+		return nil, fmt.Errorf("invalid merkle path: %w", err)
+	}
+
+	merkleRoot, err := merklePath.ComputeRootHex(&callback.TxID)
+	if err != nil {
+		return nil, fmt.Errorf("merkle path doesn't prove the transaction: %w", err)
+	}
+
+	if callback.BlockHeight != 0 && callback.BlockHeight != merklePath.BlockHeight {
+		return nil, fmt.Errorf("merkle path height %d doesn't match block height %d", merklePath.BlockHeight, callback.BlockHeight)
+	}
+
+	valid, err := merklePath.Verify(txID, chainTracker)
+	if err != nil {
+		return nil, fmt.Errorf("failed to verify merkle path: %w", err)
+	}
+	if !valid {
+		return nil, fmt.Errorf("merkle root %s is not valid for the active chain at height %d", merkleRoot, merklePath.BlockHeight)
+	}
+
+	return &entity.TxProof{
+		Height:     merklePath.BlockHeight,
+		BlockHash:  callback.BlockHash,
+		MerkleRoot: merkleRoot,
+		MerklePath: merklePath.Bytes(),
+	}, nil
+}
@@ -0,0 +1,83 @@
+package storage
+
+import (
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"github.com/4chain-ag/go-wallet-toolbox/pkg/internal/logging"
+	"github.com/4chain-ag/go-wallet-toolbox/pkg/storage/internal/entity"
+)
+
+// maxArcCallbackSize limits the size of the callback payload, the merkle path of a huge block still fits in it
+const maxArcCallbackSize = 1 << 20
+
+// ArcCallbackProcessor processes the transaction status updates sent by ARC
+type ArcCallbackProcessor interface {
+	ProcessArcCallback(ctx context.Context, callback ArcCallback) error
+}
+
+type arcCallbackHandler struct {
+	logger    *slog.Logger
+	processor ArcCallbackProcessor
+	token     string
+}
+
+// NewArcCallbackHandler creates the HTTP handler receiving the ARC callbacks (see ARC config CallbackURL).
+// ARC sends the CallbackToken as the bearer token, the requests with other token are rejected.
+// All the requests are rejected when the callbackToken is empty.
+func NewArcCallbackHandler(logger *slog.Logger, processor ArcCallbackProcessor, callbackToken string) http.Handler {
+	return &arcCallbackHandler{
+		logger:    logging.Child(logger, "arc_callback"),
+		processor: processor,
+		token:     callbackToken,
+	}
+}
+
+func (h *arcCallbackHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	if !h.authorized(r) {
+		http.Error(w, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	var callback ArcCallback
+	err := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxArcCallbackSize)).Decode(&callback)
+	if err != nil {
+		http.Error(w, "invalid callback payload", http.StatusBadRequest)
+		return
+	}
+
+	logger := h.logger.With(slog.String("txid", callback.TxID), slog.String("txStatus", callback.TxStatus))
+
+	err = h.processor.ProcessArcCallback(r.Context(), callback)
+	switch {
+	case err == nil:
+		logger.Debug("processed arc callback")
+	case errors.Is(err, entity.ErrNotFound):
+		// ARC retries the callbacks which were not accepted, there is no point in retrying the unknown transaction
+		logger.Warn("received arc callback for unknown transaction")
+	default:
+		logger.Error("failed to process arc callback", logging.Error(err))
+		http.Error(w, "failed to process callback", http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
+
+func (h *arcCallbackHandler) authorized(r *http.Request) bool {
+	if h.token == "" {
+		return false
+	}
+	expected := "Bearer " + h.token
+	return subtle.ConstantTimeCompare([]byte(r.Header.Get("Authorization")), []byte(expected)) == 1
+}
@@ -0,0 +1,184 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/4chain-ag/go-wallet-toolbox/pkg/internal/brc104"
+	"github.com/4chain-ag/go-wallet-toolbox/pkg/wdk"
+)
+
+var errAccessDenied = errors.New("access is denied due to an authorization error")
+
+// authorizedStorage exposes the storage to the clients authenticated by BRC-103,
+// it allows the client to act only as the user with the authenticated identity key.
+// The user ID of the AuthID is resolved by the identity key, so it can't be spoofed by the client.
+// The administrative methods (Migrate, MakeAvailable) are allowed only for the server's own identity key.
+type authorizedStorage struct {
+	storage          wdk.WalletStorageWriter
+	adminIdentityKey string
+}
+
+func newAuthorizedStorage(storage wdk.WalletStorageWriter, adminIdentityKey string) wdk.WalletStorageWriter {
+	return &authorizedStorage{
+		storage:          storage,
+		adminIdentityKey: adminIdentityKey,
+	}
+}
+
+func (s *authorizedStorage) authorizeIdentityKey(ctx context.Context, identityKey string) error {
+	authenticated, ok := brc104.IdentityKey(ctx)
+	if !ok {
+		return fmt.Errorf("%w: request is not authenticated", errAccessDenied)
+	}
+	if identityKey != authenticated {
+		return fmt.Errorf("%w: identity key doesn't match the authenticated one", errAccessDenied)
+	}
+	return nil
+}
+
+func (s *authorizedStorage) authorizeAdmin(ctx context.Context) error {
+	authenticated, ok := brc104.IdentityKey(ctx)
+	if !ok {
+		return fmt.Errorf("%w: request is not authenticated", errAccessDenied)
+	}
+	if authenticated != s.adminIdentityKey {
+		return fmt.Errorf("%w: method is allowed only for the storage server identity key", errAccessDenied)
+	}
+	return nil
+}
+
+func (s *authorizedStorage) authorize(ctx context.Context, auth wdk.AuthID) (wdk.AuthID, error) {
+	if err := s.authorizeIdentityKey(ctx, auth.IdentityKey); err != nil {
+		return wdk.AuthID{}, err
+	}
+
+	user, err := s.storage.FindOrInsertUser(ctx, auth.IdentityKey)
+	if err != nil {
+		return wdk.AuthID{}, fmt.Errorf("failed to find user: %w", err)
+	}
+	if auth.UserID != nil && *auth.UserID != user.User.UserID {
+		return wdk.AuthID{}, fmt.Errorf("%w: user ID doesn't match the authenticated identity key", errAccessDenied)
+	}
+
+	auth.UserID = &user.User.UserID
+	return auth, nil
+}
+
+func (s *authorizedStorage) Migrate(ctx context.Context, storageName string, storageIdentityKey string) (string, error) {
+	if err := s.authorizeAdmin(ctx); err != nil {
+		return "", err
+	}
+	return s.storage.Migrate(ctx, storageName, storageIdentityKey)
+}
+
+func (s *authorizedStorage) MakeAvailable(ctx context.Context) (*wdk.TableSettings, error) {
+	if err := s.authorizeAdmin(ctx); err != nil {
+		return nil, err
+	}
+	return s.storage.MakeAvailable(ctx)
+}
+
+func (s *authorizedStorage) FindOrInsertUser(ctx context.Context, identityKey string) (*wdk.FindOrInsertUserResponse, error) {
+	if err := s.authorizeIdentityKey(ctx, identityKey); err != nil {
+		return nil, err
+	}
+	return s.storage.FindOrInsertUser(ctx, identityKey)
+}
+
+func (s *authorizedStorage) CreateAction(ctx context.Context, auth wdk.AuthID, args wdk.ValidCreateActionArgs) (*wdk.StorageCreateActionResult, error) {
+	auth, err := s.authorize(ctx, auth)
+	if err != nil {
+		return nil, err
+	}
+	return s.storage.CreateAction(ctx, auth, args)
+}
+
+func (s *authorizedStorage) EstimateCreateAction(ctx context.Context, auth wdk.AuthID, args wdk.ValidCreateActionArgs) (*wdk.StorageEstimateCreateActionResult, error) {
+	auth, err := s.authorize(ctx, auth)
+	if err != nil {
+		return nil, err
+	}
+	return s.storage.EstimateCreateAction(ctx, auth, args)
+}
+
+func (s *authorizedStorage) InsertCertificateAuth(ctx context.Context, auth wdk.AuthID, certificate *wdk.TableCertificateX) (uint, error) {
+	auth, err := s.authorize(ctx, auth)
+	if err != nil {
+		return 0, err
+	}
+	return s.storage.InsertCertificateAuth(ctx, auth, certificate)
+}
+
+func (s *authorizedStorage) RelinquishCertificate(ctx context.Context, auth wdk.AuthID, args wdk.RelinquishCertificateArgs) error {
+	auth, err := s.authorize(ctx, auth)
+	if err != nil {
+		return err
+	}
+	return s.storage.RelinquishCertificate(ctx, auth, args)
+}
+
+func (s *authorizedStorage) ListCertificates(ctx context.Context, auth wdk.AuthID, args wdk.ListCertificatesArgs) (*wdk.ListCertificatesResult, error) {
+	auth, err := s.authorize(ctx, auth)
+	if err != nil {
+		return nil, err
+	}
+	return s.storage.ListCertificates(ctx, auth, args)
+}
+
+func (s *authorizedStorage) ListOutputs(ctx context.Context, auth wdk.AuthID, args wdk.ListOutputsArgs) (*wdk.ListOutputsResult, error) {
+	auth, err := s.authorize(ctx, auth)
+	if err != nil {
+		return nil, err
+	}
+	return s.storage.ListOutputs(ctx, auth, args)
+}
+
+func (s *authorizedStorage) AddOutputTags(ctx context.Context, auth wdk.AuthID, args wdk.OutputTagsArgs) error {
+	auth, err := s.authorize(ctx, auth)
+	if err != nil {
+		return err
+	}
+	return s.storage.AddOutputTags(ctx, auth, args)
+}
+
+func (s *authorizedStorage) RemoveOutputTags(ctx context.Context, auth wdk.AuthID, args wdk.OutputTagsArgs) error {
+	auth, err := s.authorize(ctx, auth)
+	if err != nil {
+		return err
+	}
+	return s.storage.RemoveOutputTags(ctx, auth, args)
+}
+
+func (s *authorizedStorage) ListBaskets(ctx context.Context, auth wdk.AuthID) (*wdk.ListBasketsResult, error) {
+	auth, err := s.authorize(ctx, auth)
+	if err != nil {
+		return nil, err
+	}
+	return s.storage.ListBaskets(ctx, auth)
+}
+
+func (s *authorizedStorage) UpdateBasketConfiguration(ctx context.Context, auth wdk.AuthID, args wdk.UpdateBasketConfigurationArgs) error {
+	auth, err := s.authorize(ctx, auth)
+	if err != nil {
+		return err
+	}
+	return s.storage.UpdateBasketConfiguration(ctx, auth, args)
+}
+
+func (s *authorizedStorage) RemoveBasket(ctx context.Context, auth wdk.AuthID, args wdk.RemoveBasketArgs) error {
+	auth, err := s.authorize(ctx, auth)
+	if err != nil {
+		return err
+	}
+	return s.storage.RemoveBasket(ctx, auth, args)
+}
+
+func (s *authorizedStorage) WalletStats(ctx context.Context, auth wdk.AuthID) (*wdk.WalletStatsResult, error) {
+	auth, err := s.authorize(ctx, auth)
+	if err != nil {
+		return nil, err
+	}
+	return s.storage.WalletStats(ctx, auth)
+}
@@ -0,0 +1,236 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"github.com/4chain-ag/go-wallet-toolbox/pkg/internal/logging"
+	"github.com/4chain-ag/go-wallet-toolbox/pkg/storage/internal/entity"
+	"github.com/bsv-blockchain/go-sdk/transaction"
+	"github.com/bsv-blockchain/go-sdk/transaction/chaintracker"
+)
+
+// DefaultBeefMaxDepth is the default number of not mined ancestors generations included in BEEF
+const DefaultBeefMaxDepth = 12
+
+// BeefOptions are the options of GetBeefForTransaction
+type BeefOptions struct {
+	// KnownTxIDs are the transactions already known to the recipient, they are included as txid only
+	// and their ancestors are not included at all.
+	KnownTxIDs []string
+	// TrustSelf includes the transactions with proof stored in this storage as txid only,
+	// it's applicable when the recipient trusts this storage to validate them.
+	TrustSelf bool
+	// MaxDepth limits the number of not mined ancestors generations, zero means DefaultBeefMaxDepth.
+	MaxDepth int
+}
+
+// GetBeefForTransaction builds the BEEF of the transaction with all its ancestors down to the mined ones.
+// The raw transactions and proofs stored in the storage are used first,
+// the missing ones are fetched from services (see WithBeefServices).
+// Returned BEEF is validated, so every input of every transaction is proven or known to the recipient.
+func (p *Provider) GetBeefForTransaction(ctx context.Context, txID string, options BeefOptions) (*transaction.Beef, error) {
+	if txID == "" {
+		return nil, fmt.Errorf("txid is required")
+	}
+
+	if options.MaxDepth <= 0 {
+		options.MaxDepth = DefaultBeefMaxDepth
+	}
+
+	builder := &beefBuilder{
+		provider: p,
+		options:  options,
+		beef:     transaction.NewBeefV2(),
+		known:    make(map[string]bool, len(options.KnownTxIDs)),
+	}
+	for _, knownTxID := range options.KnownTxIDs {
+		builder.known[knownTxID] = true
+	}
+
+	err := builder.merge(ctx, txID, 0)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build beef for tx %s: %w", txID, err)
+	}
+
+	err = validateBeef(builder.beef, p.chainTracker)
+	if err != nil {
+		return nil, fmt.Errorf("invalid beef for tx %s: %w", txID, err)
+	}
+
+	return builder.beef, nil
+}
+
+type beefBuilder struct {
+	provider *Provider
+	options  BeefOptions
+	beef     *transaction.Beef
+	known    map[string]bool
+	// inputBeefs are the input BEEFs of stored transactions, they provide the ancestors not stored separately
+	inputBeefs []*transaction.Beef
+}
+
+func (b *beefBuilder) merge(ctx context.Context, txID string, depth int) error {
+	if _, merged := b.beef.Transactions[txID]; merged {
+		return nil
+	}
+	if b.known[txID] {
+		b.beef.MergeTxidOnly(txID)
+		return nil
+	}
+
+	tx, err := b.findTransaction(ctx, txID)
+	if err != nil {
+		return err
+	}
+	if tx == nil {
+		// trusted transaction, merged as txid only
+		return nil
+	}
+	if tx.MerklePath == nil && depth > b.options.MaxDepth {
+		return fmt.Errorf("not mined tx %s exceeds the maximal depth %d of ancestors", txID, b.options.MaxDepth)
+	}
+
+	_, err = b.beef.MergeTransaction(tx)
+	if err != nil {
+		return fmt.Errorf("failed to merge tx %s: %w", txID, err)
+	}
+
+	if tx.MerklePath != nil {
+		return nil
+	}
+
+	for _, input := range tx.Inputs {
+		err = b.merge(ctx, input.SourceTXID.String(), depth+1)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// findTransaction returns the transaction with its merkle path if it is mined,
+// or nil when it was merged as the trusted one (see BeefOptions.TrustSelf).
+func (b *beefBuilder) findTransaction(ctx context.Context, txID string) (*transaction.Transaction, error) {
+	stored, err := b.provider.repo.FindKnownTx(ctx, txID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find tx %s in storage: %w", txID, err)
+	}
+	if stored != nil {
+		if stored.MerklePath != nil && b.options.TrustSelf {
+			b.beef.MergeTxidOnly(txID)
+			return nil, nil
+		}
+		return b.fromStorage(stored)
+	}
+
+	for _, inputBeef := range b.inputBeefs {
+		if tx := inputBeef.FindTransaction(txID); tx != nil {
+			return tx, nil
+		}
+	}
+
+	return b.fromServices(ctx, txID)
+}
+
+func (b *beefBuilder) fromStorage(stored *entity.KnownTx) (*transaction.Transaction, error) {
+	tx, err := transaction.NewTransactionFromBytes(stored.RawTx)
+	if err != nil {
+		return nil, fmt.Errorf("invalid raw tx %s stored: %w", stored.TxID, err)
+	}
+
+	if stored.MerklePath != nil {
+		tx.MerklePath, err = transaction.NewMerklePathFromBinary(stored.MerklePath)
+		if err != nil {
+			return nil, fmt.Errorf("invalid merkle path of tx %s stored: %w", stored.TxID, err)
+		}
+		return tx, nil
+	}
+
+	if len(stored.InputBeef) > 0 {
+		inputBeef, err := transaction.NewBeefFromBytes(stored.InputBeef)
+		if err != nil {
+			return nil, fmt.Errorf("invalid input beef of tx %s stored: %w", stored.TxID, err)
+		}
+		b.inputBeefs = append(b.inputBeefs, inputBeef)
+	}
+
+	return tx, nil
+}
+
+func (b *beefBuilder) fromServices(ctx context.Context, txID string) (*transaction.Transaction, error) {
+	if b.provider.beefServices == nil {
+		return nil, fmt.Errorf("tx %s is not known to the storage and services are not configured", txID)
+	}
+
+	rawTx, err := b.provider.beefServices.RawTx(txID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get raw tx %s from services: %w", txID, err)
+	}
+
+	tx, err := transaction.NewTransactionFromBytes(rawTx.RawTx)
+	if err != nil {
+		return nil, fmt.Errorf("invalid raw tx %s returned by %s: %w", txID, rawTx.Name, err)
+	}
+	if tx.TxID().String() != txID {
+		return nil, fmt.Errorf("%s returned tx %s while requested %s", rawTx.Name, tx.TxID().String(), txID)
+	}
+
+	// the transaction without merkle path is included with its ancestors, so the failure is not fatal
+	tx.MerklePath, err = b.provider.beefServices.MinedMerklePath(ctx, txID)
+	if err != nil {
+		b.provider.logger.Warn("failed to get merkle path, the transaction is included with its ancestors", slog.String("txid", txID), logging.Error(err))
+	}
+
+	return tx, nil
+}
+
+// validateBeef checks that every input of not mined transaction is included in the BEEF
+// and that merkle paths of mined transactions prove them.
+func validateBeef(beef *transaction.Beef, chainTracker chaintracker.ChainTracker) error {
+	for txID, beefTx := range beef.Transactions {
+		if beefTx.DataFormat == transaction.TxIDOnly {
+			continue
+		}
+
+		tx := beefTx.Transaction
+		if tx.MerklePath != nil {
+			err := validateMerklePath(txID, tx, chainTracker)
+			if err != nil {
+				return err
+			}
+			continue
+		}
+
+		for _, input := range tx.Inputs {
+			if _, ok := beef.Transactions[input.SourceTXID.String()]; !ok {
+				return fmt.Errorf("missing source tx %s of tx %s", input.SourceTXID.String(), txID)
+			}
+		}
+	}
+
+	return nil
+}
+
+// validateMerklePath checks that the merkle path proves the tx and, when the chain tracker is provided,
+// that its root belongs to the block of the active chain.
+// Beef.Verify is not used, because it requires the inputs of mined transactions to be included in the BEEF.
+func validateMerklePath(txID string, tx *transaction.Transaction, chainTracker chaintracker.ChainTracker) error {
+	if chainTracker == nil {
+		_, err := tx.MerklePath.ComputeRoot(tx.TxID())
+		if err != nil {
+			return fmt.Errorf("merkle path doesn't prove tx %s: %w", txID, err)
+		}
+		return nil
+	}
+
+	valid, err := tx.MerklePath.Verify(tx.TxID(), chainTracker)
+	if err != nil {
+		return fmt.Errorf("failed to verify merkle path of tx %s: %w", txID, err)
+	}
+	if !valid {
+		return fmt.Errorf("merkle root of tx %s is not valid for the active chain", txID)
+	}
+	return nil
+}
@@ -4,11 +4,14 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/4chain-ag/go-wallet-toolbox/pkg/internal/brc104"
+	primitives "github.com/bsv-blockchain/go-sdk/primitives/ec"
 	"github.com/filecoin-project/go-jsonrpc"
 )
 
-// NewClient returns WalletStorageWriterClient that allows connection to rpc server
-func NewClient(addr string, overrideOptions ...ClientOptions) (*WalletStorageWriterClient, func(), error) {
+// NewClient returns WalletStorageWriterClient that allows connection to rpc server,
+// the client authenticates to the server by BRC-103 over BRC-104 transport with given private key of the user.
+func NewClient(addr string, privateKey *primitives.PrivateKey, overrideOptions ...ClientOptions) (*WalletStorageWriterClient, func(), error) {
 	opts := defaultClientOptions()
 	client := &WalletStorageWriterClient{
 		client: &rpcWalletStorageWriter{},
@@ -18,13 +21,16 @@ func NewClient(addr string, overrideOptions ...ClientOptions) (*WalletStorageWri
 		opt(&opts)
 	}
 
+	httpClient := *opts.httpClient
+	httpClient.Transport = brc104.NewTransport(privateKey, httpClient.Transport)
+
 	cleanup, err := jsonrpc.NewMergeClient(
 		context.Background(),
 		addr,
 		"remote_storage",
 		[]any{client.client},
 		nil,
-		opts.options...,
+		append(opts.options, jsonrpc.WithHTTPClient(&httpClient))...,
 	)
 	if err != nil {
 		return nil, nil, fmt.Errorf("failed to initialize new RPC client: %w", err)
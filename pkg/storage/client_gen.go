@@ -28,6 +28,10 @@ func (c *WalletStorageWriterClient) CreateAction(ctx context.Context, auth wdk.A
 	return c.client.CreateAction(ctx, auth, args)
 }
 
+func (c *WalletStorageWriterClient) EstimateCreateAction(ctx context.Context, auth wdk.AuthID, args wdk.ValidCreateActionArgs) (*wdk.StorageEstimateCreateActionResult, error) {
+	return c.client.EstimateCreateAction(ctx, auth, args)
+}
+
 func (c *WalletStorageWriterClient) InsertCertificateAuth(ctx context.Context, auth wdk.AuthID, certificate *wdk.TableCertificateX) (uint, error) {
 	return c.client.InsertCertificateAuth(ctx, auth, certificate)
 }
@@ -40,12 +44,48 @@ func (c *WalletStorageWriterClient) ListCertificates(ctx context.Context, auth w
 	return c.client.ListCertificates(ctx, auth, args)
 }
 
+func (c *WalletStorageWriterClient) ListOutputs(ctx context.Context, auth wdk.AuthID, args wdk.ListOutputsArgs) (*wdk.ListOutputsResult, error) {
+	return c.client.ListOutputs(ctx, auth, args)
+}
+
+func (c *WalletStorageWriterClient) AddOutputTags(ctx context.Context, auth wdk.AuthID, args wdk.OutputTagsArgs) error {
+	return c.client.AddOutputTags(ctx, auth, args)
+}
+
+func (c *WalletStorageWriterClient) RemoveOutputTags(ctx context.Context, auth wdk.AuthID, args wdk.OutputTagsArgs) error {
+	return c.client.RemoveOutputTags(ctx, auth, args)
+}
+
+func (c *WalletStorageWriterClient) ListBaskets(ctx context.Context, auth wdk.AuthID) (*wdk.ListBasketsResult, error) {
+	return c.client.ListBaskets(ctx, auth)
+}
+
+func (c *WalletStorageWriterClient) UpdateBasketConfiguration(ctx context.Context, auth wdk.AuthID, args wdk.UpdateBasketConfigurationArgs) error {
+	return c.client.UpdateBasketConfiguration(ctx, auth, args)
+}
+
+func (c *WalletStorageWriterClient) RemoveBasket(ctx context.Context, auth wdk.AuthID, args wdk.RemoveBasketArgs) error {
+	return c.client.RemoveBasket(ctx, auth, args)
+}
+
+func (c *WalletStorageWriterClient) WalletStats(ctx context.Context, auth wdk.AuthID) (*wdk.WalletStatsResult, error) {
+	return c.client.WalletStats(ctx, auth)
+}
+
 type rpcWalletStorageWriter struct {
-	Migrate               func(context.Context, string, string) (string, error)
-	MakeAvailable         func(context.Context) (*wdk.TableSettings, error)
-	FindOrInsertUser      func(context.Context, string) (*wdk.FindOrInsertUserResponse, error)
-	CreateAction          func(context.Context, wdk.AuthID, wdk.ValidCreateActionArgs) (*wdk.StorageCreateActionResult, error)
-	InsertCertificateAuth func(context.Context, wdk.AuthID, *wdk.TableCertificateX) (uint, error)
-	RelinquishCertificate func(context.Context, wdk.AuthID, wdk.RelinquishCertificateArgs) error
-	ListCertificates      func(context.Context, wdk.AuthID, wdk.ListCertificatesArgs) (*wdk.ListCertificatesResult, error)
+	Migrate                   func(context.Context, string, string) (string, error)
+	MakeAvailable             func(context.Context) (*wdk.TableSettings, error)
+	FindOrInsertUser          func(context.Context, string) (*wdk.FindOrInsertUserResponse, error)
+	CreateAction              func(context.Context, wdk.AuthID, wdk.ValidCreateActionArgs) (*wdk.StorageCreateActionResult, error)
+	EstimateCreateAction      func(context.Context, wdk.AuthID, wdk.ValidCreateActionArgs) (*wdk.StorageEstimateCreateActionResult, error)
+	InsertCertificateAuth     func(context.Context, wdk.AuthID, *wdk.TableCertificateX) (uint, error)
+	RelinquishCertificate     func(context.Context, wdk.AuthID, wdk.RelinquishCertificateArgs) error
+	ListCertificates          func(context.Context, wdk.AuthID, wdk.ListCertificatesArgs) (*wdk.ListCertificatesResult, error)
+	ListOutputs               func(context.Context, wdk.AuthID, wdk.ListOutputsArgs) (*wdk.ListOutputsResult, error)
+	AddOutputTags             func(context.Context, wdk.AuthID, wdk.OutputTagsArgs) error
+	RemoveOutputTags          func(context.Context, wdk.AuthID, wdk.OutputTagsArgs) error
+	ListBaskets               func(context.Context, wdk.AuthID) (*wdk.ListBasketsResult, error)
+	UpdateBasketConfiguration func(context.Context, wdk.AuthID, wdk.UpdateBasketConfigurationArgs) error
+	RemoveBasket              func(context.Context, wdk.AuthID, wdk.RemoveBasketArgs) error
+	WalletStats               func(context.Context, wdk.AuthID) (*wdk.WalletStatsResult, error)
 }
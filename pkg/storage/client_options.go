@@ -11,7 +11,8 @@ import (
 type ClientOptions = func(*clientOptions)
 
 type clientOptions struct {
-	options []jsonrpc.Option
+	options    []jsonrpc.Option
+	httpClient *http.Client
 }
 
 func defaultClientOptions() clientOptions {
@@ -19,13 +20,15 @@ func defaultClientOptions() clientOptions {
 		options: []jsonrpc.Option{
 			jsonrpc.WithMethodNameFormatter(jsonrpc.NewMethodNameFormatter(false, jsonrpc.LowerFirstCharCase)),
 		},
+		httpClient: http.DefaultClient,
 	}
 }
 
 // WithHttpClient is a function that can be used to override the http.Client used by the client.
+// The requests are still authenticated, the transport of the given client is used to send them.
 // This is meant to be used for testing purposes.
 func WithHttpClient(httpClient *http.Client) ClientOptions {
 	return func(o *clientOptions) {
-		o.options = append(o.options, jsonrpc.WithHTTPClient(httpClient))
+		o.httpClient = httpClient
 	}
 }
@@ -2,6 +2,7 @@ package actions
 
 import (
 	"log/slog"
+	"time"
 
 	"github.com/4chain-ag/go-wallet-toolbox/pkg/defs"
 	"github.com/4chain-ag/go-wallet-toolbox/pkg/storage/internal/repo"
@@ -14,17 +15,20 @@ type Actions struct {
 	*process
 }
 
-func New(logger *slog.Logger, funder Funder, commission defs.Commission, repos *repo.Repositories, randomizer wdk.Randomizer) *Actions {
+func New(logger *slog.Logger, funder Funder, chain defs.BSVNetwork, commission defs.Commission, repos *repo.Repositories, randomizer wdk.Randomizer, reservationTimeout time.Duration, feeModel defs.FeeModel) *Actions {
 	return &Actions{
 		create: newCreateAction(
 			logger,
 			funder,
+			chain,
 			commission,
 			repos.OutputBaskets,
 			repos.Transactions,
 			repos.Outputs,
 			repos.ProvenTxReq,
 			randomizer,
+			reservationTimeout,
+			feeModel.DustLimit,
 		),
 		internalize: newInternalizeAction(
 			logger,
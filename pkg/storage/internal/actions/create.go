@@ -2,9 +2,11 @@ package actions
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"iter"
 	"log/slog"
+	"time"
 
 	"github.com/4chain-ag/go-wallet-toolbox/pkg/defs"
 	"github.com/4chain-ag/go-wallet-toolbox/pkg/internal/logging"
@@ -12,6 +14,7 @@ import (
 	"github.com/4chain-ag/go-wallet-toolbox/pkg/internal/txutils"
 	"github.com/4chain-ag/go-wallet-toolbox/pkg/storage/internal/commission"
 	"github.com/4chain-ag/go-wallet-toolbox/pkg/storage/internal/entity"
+	"github.com/4chain-ag/go-wallet-toolbox/pkg/storage/internal/feemodel"
 	"github.com/4chain-ag/go-wallet-toolbox/pkg/wdk"
 	"github.com/4chain-ag/go-wallet-toolbox/pkg/wdk/primitives"
 	"github.com/bsv-blockchain/go-sdk/transaction"
@@ -26,11 +29,19 @@ import (
 const (
 	derivationLength = 16
 	referenceLength  = 12
+	// maxAllocationAttempts is the number of funding attempts when allocated UTXOs are taken by concurrent createAction calls
+	maxAllocationAttempts = 10
+	// allocationRetryDelay is the base delay before repeating the funding, it doubles with every attempt and is randomized by the jitter
+	allocationRetryDelay = 5 * time.Millisecond
+	// maxAllocationRetryDelay caps the delay before repeating the funding
+	maxAllocationRetryDelay = 250 * time.Millisecond
 )
 
 type UTXO struct {
 	OutputID uint
 	Satoshis satoshi.Value
+	// EstimatedInputSize is the estimated size of the input spending this UTXO
+	EstimatedInputSize uint64
 }
 
 type FundingResult struct {
@@ -38,6 +49,8 @@ type FundingResult struct {
 	ChangeCount    uint64
 	ChangeAmount   satoshi.Value
 	Fee            satoshi.Value
+	// FeeModel is the fee model applied to calculate the Fee
+	FeeModel feemodel.Applied
 }
 
 func (fr *FundingResult) TotalAllocated() (satoshi.Value, error) {
@@ -80,54 +93,143 @@ type Funder interface {
 	// Fund
 	// @param targetSat - the target amount of satoshis to fund (total inputs - total outputs)
 	// @param currentTxSize - the current size of the transaction in bytes (size of tx + current inputs + current outputs)
+	// @param dataSize - the part of currentTxSize taken by data (OP_RETURN) outputs
 	// @param numberOfDesiredUTXOs - the number of UTXOs in basket #TakeFromBasket
 	// @param minimumDesiredUTXOValue - the minimum value of UTXO in basket #TakeFromBasket
 	// @param userID - the user ID
-	Fund(ctx context.Context, targetSat satoshi.Value, currentTxSize uint64, basket *wdk.TableOutputBasket, userID int) (*FundingResult, error)
+	Fund(ctx context.Context, targetSat satoshi.Value, currentTxSize uint64, dataSize uint64, basket *wdk.TableOutputBasket, userID int) (*FundingResult, error)
 }
 
 type create struct {
-	logger        *slog.Logger
-	funder        Funder
-	basketRepo    BasketRepo
-	txRepo        TransactionsRepo
-	outputRepo    OutputRepo
-	provenTxRepo  ProvenTxRepo
-	commission    *commission.ScriptGenerator
-	commissionCfg defs.Commission
-	random        wdk.Randomizer
+	logger             *slog.Logger
+	funder             Funder
+	basketRepo         BasketRepo
+	txRepo             TransactionsRepo
+	outputRepo         OutputRepo
+	provenTxRepo       ProvenTxRepo
+	commission         *commission.ScriptGenerator
+	commissionCfg      defs.Commission
+	random             wdk.Randomizer
+	reservationTimeout time.Duration
+	dustLimit          satoshi.Value
 }
 
 func newCreateAction(
 	logger *slog.Logger,
 	funder Funder,
+	chain defs.BSVNetwork,
 	commissionCfg defs.Commission,
 	basketRepo BasketRepo,
 	txRepo TransactionsRepo,
 	outputRepo OutputRepo,
 	provenTxRepo ProvenTxRepo,
 	random wdk.Randomizer,
+	reservationTimeout time.Duration,
+	dustLimit uint64,
 ) *create {
 	logger = logging.Child(logger, "createAction")
 	c := &create{
-		logger:        logger,
-		funder:        funder,
-		basketRepo:    basketRepo,
-		txRepo:        txRepo,
-		commissionCfg: commissionCfg,
-		outputRepo:    outputRepo,
-		provenTxRepo:  provenTxRepo,
-		random:        random,
+		logger:             logger,
+		funder:             funder,
+		basketRepo:         basketRepo,
+		txRepo:             txRepo,
+		commissionCfg:      commissionCfg,
+		outputRepo:         outputRepo,
+		provenTxRepo:       provenTxRepo,
+		random:             random,
+		reservationTimeout: reservationTimeout,
+		dustLimit:          satoshi.MustFrom(dustLimit),
 	}
 
 	if commissionCfg.Enabled() {
-		c.commission = commission.NewScriptGenerator(string(commissionCfg.PubKeyHex))
+		c.commission = commission.NewScriptGenerator(string(commissionCfg.PubKeyHex), chain)
 	}
 
 	return c
 }
 
 func (c *create) Create(ctx context.Context, userID int, params CreateActionParams) (*wdk.StorageCreateActionResult, error) {
+	prepared, err := c.prepare(ctx, userID, params)
+	if err != nil {
+		return nil, err
+	}
+
+	created, err := c.fundAndStoreTransaction(ctx, userID, params, prepared)
+	if err != nil {
+		return nil, err
+	}
+
+	resultInputs, err := c.resultInputs(ctx, created.funding.AllocatedUTXOs, params.IncludeInputSourceRawTxs)
+	if err != nil {
+		return nil, err
+	}
+
+	return &wdk.StorageCreateActionResult{
+		Reference:        created.reference,
+		Version:          params.Version,
+		LockTime:         params.LockTime,
+		DerivationPrefix: created.derivationPrefix,
+		Outputs:          c.resultOutputs(created.newOutputs),
+		Inputs:           resultInputs,
+		InputBeef:        created.inputBeef,
+	}, nil
+}
+
+// Estimate runs the createAction pipeline (commission, size calculation, funding and change distribution)
+// without reserving the allocated UTXOs and without storing the transaction.
+func (c *create) Estimate(ctx context.Context, userID int, params CreateActionParams) (*wdk.StorageEstimateCreateActionResult, error) {
+	prepared, err := c.prepare(ctx, userID, params)
+	if err != nil {
+		return nil, err
+	}
+
+	funding, err := c.funder.Fund(ctx, prepared.targetSat, prepared.initialTxSize, prepared.dataSize, prepared.basket, userID)
+	if err != nil {
+		return nil, fmt.Errorf("funding failed: %w", err)
+	}
+
+	changeDistribution, err := c.changeDistribution(prepared.basket, funding)
+	if err != nil {
+		return nil, err
+	}
+
+	change, err := satoshi.Sum(changeDistribution)
+	if err != nil {
+		return nil, fmt.Errorf("failed to sum change outputs: %w", err)
+	}
+
+	size, err := c.finalTxSize(prepared.xinputs, prepared.xoutputs, funding.AllocatedUTXOs, funding.ChangeCount)
+	if err != nil {
+		return nil, err
+	}
+
+	resultInputs, err := c.resultInputs(ctx, funding.AllocatedUTXOs, false)
+	if err != nil {
+		return nil, err
+	}
+
+	return &wdk.StorageEstimateCreateActionResult{
+		Fee:            primitives.SatoshiValue(funding.Fee.MustUInt64()),
+		FeeModel:       funding.FeeModel.String(),
+		Inputs:         resultInputs,
+		ChangeCount:    funding.ChangeCount,
+		ChangeSatoshis: primitives.SatoshiValue(change.MustUInt64()),
+		Size:           size,
+	}, nil
+}
+
+type preparedAction struct {
+	basket        *wdk.TableOutputBasket
+	xinputs       iter.Seq[*wdk.ValidCreateActionInput]
+	xoutputs      iter.Seq[*wdk.ValidCreateActionOutput]
+	commOut       *serviceChargeOutput
+	targetSat     satoshi.Value
+	initialTxSize uint64
+	dataSize      uint64
+}
+
+// prepare collects everything needed to fund the action: the change basket, the commission output, the initial size and the target amount.
+func (c *create) prepare(ctx context.Context, userID int, params CreateActionParams) (*preparedAction, error) {
 	basket, err := c.basketRepo.FindBasketByName(ctx, userID, wdk.BasketNameForChange)
 	if err != nil {
 		return nil, fmt.Errorf("failed to find basket for change: %w", err)
@@ -153,18 +255,91 @@ func (c *create) Create(ctx context.Context, userID int, params CreateActionPara
 		return nil, err
 	}
 
+	dataSize, err := c.dataSize(xoutputs)
+	if err != nil {
+		return nil, err
+	}
+
 	targetSat, err := c.targetSat(xinputs, xoutputs)
 	if err != nil {
 		return nil, fmt.Errorf("failed to calculate target satoshis: %w", err)
 	}
 
-	funding, err := c.funder.Fund(ctx, targetSat, initialTxSize, basket, userID)
+	return &preparedAction{
+		basket:        basket,
+		xinputs:       xinputs,
+		xoutputs:      xoutputs,
+		commOut:       commOut,
+		targetSat:     targetSat,
+		initialTxSize: initialTxSize,
+		dataSize:      dataSize,
+	}, nil
+}
+
+type createdTransaction struct {
+	funding          *FundingResult
+	derivationPrefix string
+	reference        string
+	newOutputs       []*entity.NewOutput
+	inputBeef        []byte
+}
+
+// fundAndStoreTransaction funds the transaction and stores it together with the reservation of allocated UTXOs.
+// When the allocated UTXOs are reserved by a concurrent createAction in the meantime, the funding is repeated
+// after a jittered backoff, so the competing calls don't keep colliding on the same UTXOs.
+func (c *create) fundAndStoreTransaction(
+	ctx context.Context,
+	userID int,
+	params CreateActionParams,
+	prepared *preparedAction,
+) (*createdTransaction, error) {
+	for attempt := 1; ; attempt++ {
+		created, err := c.tryFundAndStoreTransaction(ctx, userID, params, prepared)
+		if errors.Is(err, entity.ErrUTXOsAlreadyReserved) && attempt < maxAllocationAttempts {
+			delay := c.allocationRetryDelay(attempt)
+			c.logger.DebugContext(ctx, "allocated utxos reserved by concurrent transaction, retrying", slog.Int("attempt", attempt), slog.Duration("delay", delay))
+			if err := waitForRetry(ctx, delay); err != nil {
+				return nil, err
+			}
+			continue
+		}
+		return created, err
+	}
+}
+
+// allocationRetryDelay returns the exponential backoff before the next funding attempt with a full jitter.
+func (c *create) allocationRetryDelay(attempt int) time.Duration {
+	backoff := min(allocationRetryDelay<<(attempt-1), maxAllocationRetryDelay)
+	return time.Duration(c.random.Uint64(uint64(backoff))) + 1
+}
+
+func waitForRetry(ctx context.Context, delay time.Duration) error {
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return fmt.Errorf("waiting to repeat the funding: %w", ctx.Err())
+	case <-timer.C:
+		return nil
+	}
+}
+
+func (c *create) tryFundAndStoreTransaction(
+	ctx context.Context,
+	userID int,
+	params CreateActionParams,
+	prepared *preparedAction,
+) (*createdTransaction, error) {
+	funding, err := c.funder.Fund(ctx, prepared.targetSat, prepared.initialTxSize, prepared.dataSize, prepared.basket, userID)
 	if err != nil {
 		return nil, fmt.Errorf("funding failed: %w", err)
 	}
 
-	changeDistribution := txutils.NewChangeDistribution(satoshi.MustFrom(basket.MinimumDesiredUTXOValue), c.random.Uint64).
-		Distribute(funding.ChangeCount, funding.ChangeAmount)
+	changeDistribution, err := c.changeDistribution(prepared.basket, funding)
+	if err != nil {
+		return nil, err
+	}
 
 	derivationPrefix, reference, err := c.randomValues()
 	if err != nil {
@@ -176,7 +351,7 @@ func (c *create) Create(ctx context.Context, userID int, params CreateActionPara
 		funding.ChangeCount,
 		derivationPrefix,
 		params.Outputs,
-		commOut,
+		prepared.commOut,
 		params.RandomizeOutputs,
 	)
 	if err != nil {
@@ -208,27 +383,41 @@ func (c *create) Create(ctx context.Context, userID int, params CreateActionPara
 		ReservedOutputIDs: slices.Map(funding.AllocatedUTXOs, func(utxo *UTXO) uint {
 			return utxo.OutputID
 		}),
-		Labels:    params.Labels,
-		InputBeef: inputBeef,
+		ReservedUntil: c.reservedUntil(),
+		Labels:        params.Labels,
+		InputBeef:     inputBeef,
+		Fee:           to.Ptr(funding.Fee.Int64()),
+		FeeModel:      to.Ptr(funding.FeeModel.String()),
 	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to create transaction: %w", err)
 	}
 
-	resultInputs, err := c.resultInputs(ctx, funding.AllocatedUTXOs, params.IncludeInputSourceRawTxs)
+	return &createdTransaction{
+		funding:          funding,
+		derivationPrefix: derivationPrefix,
+		reference:        reference,
+		newOutputs:       newOutputs,
+		inputBeef:        inputBeef,
+	}, nil
+}
+
+func (c *create) changeDistribution(basket *wdk.TableOutputBasket, funding *FundingResult) (iter.Seq[satoshi.Value], error) {
+	distribution, err := txutils.NewChangeDistribution(satoshi.MustFrom(basket.MinimumDesiredUTXOValue), c.random.Uint64).
+		WithDustLimit(c.dustLimit).
+		Distribute(funding.ChangeCount, funding.ChangeAmount)
 	if err != nil {
-		return nil, err
+		return nil, fmt.Errorf("failed to distribute change: %w", err)
 	}
+	return distribution, nil
+}
 
-	return &wdk.StorageCreateActionResult{
-		Reference:        reference,
-		Version:          params.Version,
-		LockTime:         params.LockTime,
-		DerivationPrefix: derivationPrefix,
-		Outputs:          c.resultOutputs(newOutputs),
-		Inputs:           resultInputs,
-		InputBeef:        inputBeef,
-	}, nil
+// reservedUntil returns the expiration time of the inputs reservation, nil if reservations don't expire
+func (c *create) reservedUntil() *time.Time {
+	if c.reservationTimeout <= 0 {
+		return nil
+	}
+	return to.Ptr(time.Now().Add(c.reservationTimeout))
 }
 
 type serviceChargeOutput struct {
@@ -288,6 +477,51 @@ func (c *create) txSize(xinputs iter.Seq[*wdk.ValidCreateActionInput], xoutputs
 	return txSize, nil
 }
 
+// finalTxSize returns the size of the transaction after adding allocated inputs (of their estimated sizes) and change (P2PKH) outputs.
+func (c *create) finalTxSize(xinputs iter.Seq[*wdk.ValidCreateActionInput], xoutputs iter.Seq[*wdk.ValidCreateActionOutput], allocated []*UTXO, changeCount uint64) (uint64, error) {
+	inputScriptSizes, err := seqerr.Collect(seqerr.MapSeq(xinputs, func(o *wdk.ValidCreateActionInput) (uint64, error) {
+		return o.ScriptLength()
+	}))
+	if err != nil {
+		return 0, fmt.Errorf("failed to calculate unlocking script size: %w", err)
+	}
+
+	outputScriptSizes, err := seqerr.Collect(seqerr.MapSeq(xoutputs, func(o *wdk.ValidCreateActionOutput) (uint64, error) {
+		return o.ScriptLength()
+	}))
+	if err != nil {
+		return 0, fmt.Errorf("failed to calculate locking script size: %w", err)
+	}
+
+	allocatedInputSizes := seq.Map(seq.FromSlice(allocated), func(utxo *UTXO) uint64 {
+		return utxo.EstimatedInputSize
+	})
+	outputSizes := seq.Concat(seq.FromSlice(outputScriptSizes), seq.Repeat(uint64(txutils.P2PKHLockingScriptLength), changeCount))
+
+	txSize, err := txutils.TransactionSizeWithEstimatedInputs(seqerr.FromSeq(seq.FromSlice(inputScriptSizes)), seqerr.FromSeq(outputSizes), allocatedInputSizes)
+	if err != nil {
+		return 0, fmt.Errorf("failed to calculate transaction size: %w", err)
+	}
+
+	return txSize, nil
+}
+
+// dataSize returns the number of bytes taken by the locking scripts of data (OP_RETURN) outputs.
+func (c *create) dataSize(xoutputs iter.Seq[*wdk.ValidCreateActionOutput]) (uint64, error) {
+	var size uint64
+	for output := range xoutputs {
+		if !output.IsData() {
+			continue
+		}
+		scriptSize, err := output.ScriptLength()
+		if err != nil {
+			return 0, fmt.Errorf("failed to calculate data output size: %w", err)
+		}
+		size += scriptSize
+	}
+	return size, nil
+}
+
 func (c *create) randomValues() (derivationPrefix string, reference string, err error) {
 	derivationPrefix, err = c.randomDerivation()
 	if err != nil {
@@ -330,6 +564,7 @@ func (c *create) newOutputs(
 			LockingScript:      &output.LockingScript,
 			CustomInstructions: output.CustomInstructions,
 			Description:        string(output.OutputDescription),
+			Tags:               output.Tags,
 		})
 	}
 
@@ -0,0 +1,241 @@
+package funder
+
+import (
+	"fmt"
+	"iter"
+
+	"github.com/4chain-ag/go-wallet-toolbox/pkg/defs"
+	"github.com/4chain-ag/go-wallet-toolbox/pkg/internal/satoshi"
+	"github.com/4chain-ag/go-wallet-toolbox/pkg/storage/internal/database/models"
+	"github.com/4chain-ag/go-wallet-toolbox/pkg/storage/internal/paging"
+	"github.com/4chain-ag/go-wallet-toolbox/pkg/wdk"
+)
+
+const (
+	// branchAndBoundMaxCandidates is the maximum number of the biggest UTXOs considered by the branch-and-bound search
+	branchAndBoundMaxCandidates = utxoBatchSize
+	// branchAndBoundMaxTries limits the number of visited branches, so the search time is bounded
+	branchAndBoundMaxTries = 100_000
+)
+
+// UTXOsOrder defines the order in which the UTXOs are loaded from the basket
+type UTXOsOrder int
+
+const (
+	LargestFirst UTXOsOrder = iota
+	SmallestFirst
+	OldestFirst
+)
+
+func (o UTXOsOrder) firstPage() *paging.Page {
+	switch o {
+	case SmallestFirst:
+		return &paging.Page{Limit: utxoBatchSize, SortBy: "satoshis", Sort: "asc"}
+	case OldestFirst:
+		return &paging.Page{Limit: utxoBatchSize, SortBy: "created_at", Sort: "asc"}
+	case LargestFirst:
+	}
+	return &paging.Page{Limit: utxoBatchSize, SortBy: "satoshis", Sort: "desc"}
+}
+
+// UTXOSource provides the not reserved UTXOs from the basket which can be used to fund the transaction
+type UTXOSource interface {
+	// All returns all available UTXOs in given order
+	All(order UTXOsOrder) iter.Seq2[*models.UserUTXO, error]
+	// Batches returns all available UTXOs in given order, split into batches loaded one by one
+	Batches(order UTXOsOrder) iter.Seq2[[]*models.UserUTXO, error]
+}
+
+// Collector gathers the allocated UTXOs and calculates the fee and change of the funded transaction
+type Collector interface {
+	// Allocate allocates UTXOs one by one until the transaction is funded
+	Allocate(utxos iter.Seq2[*models.UserUTXO, error]) error
+	// AllocateWithoutChange allocates all given UTXOs, the transaction won't have any change and the excess goes to the miner
+	AllocateWithoutChange(utxos []*models.UserUTXO) error
+	// IsFunded returns true if the allocated UTXOs cover the transaction outputs and the fee
+	IsFunded() bool
+	// ExcessWithoutChange returns how many satoshis would be left (or missing if negative)
+	// after covering the transaction and the fee, when the UTXOs with given total value and size are allocated and no change is added.
+	ExcessWithoutChange(sats satoshi.Value, inputsSize uint64) (satoshi.Value, error)
+	// CostOfChange returns the fee for adding the change output to the transaction and for spending it later.
+	CostOfChange() (satoshi.Value, error)
+}
+
+// CoinSelection is a strategy of choosing user's UTXOs to fund the transaction
+type CoinSelection interface {
+	Select(utxos UTXOSource, collector Collector) error
+}
+
+// NewCoinSelection creates the coin selection for given strategy, the randomizer is used by the random strategy.
+// Empty strategy means the default one (see defs.DefaultCoinSelectionStrategy).
+func NewCoinSelection(strategy defs.CoinSelectionStrategy, random wdk.Randomizer) (CoinSelection, error) {
+	if strategy == "" {
+		strategy = defs.DefaultCoinSelectionStrategy()
+	}
+
+	switch strategy {
+	case defs.CoinSelectionLargestFirst:
+		return &orderedSelection{order: LargestFirst}, nil
+	case defs.CoinSelectionSmallestFirst:
+		return &orderedSelection{order: SmallestFirst}, nil
+	case defs.CoinSelectionBranchAndBound:
+		return &branchAndBoundSelection{fallback: &orderedSelection{order: LargestFirst}}, nil
+	case defs.CoinSelectionRandom:
+		if random == nil {
+			return nil, fmt.Errorf("randomizer is required for %s coin selection", strategy)
+		}
+		return &randomSelection{random: random}, nil
+	default:
+		return nil, fmt.Errorf("unsupported coin selection strategy: %s", strategy)
+	}
+}
+
+type orderedSelection struct {
+	order UTXOsOrder
+}
+
+func (s *orderedSelection) Select(utxos UTXOSource, collector Collector) error {
+	err := collector.Allocate(utxos.All(s.order))
+	if err != nil {
+		return fmt.Errorf("failed to allocate utxos: %w", err)
+	}
+	return nil
+}
+
+type randomSelection struct {
+	random wdk.Randomizer
+}
+
+func (s *randomSelection) Select(utxos UTXOSource, collector Collector) error {
+	for batch, err := range utxos.Batches(OldestFirst) {
+		if err != nil {
+			return err
+		}
+
+		s.random.Shuffle(len(batch), func(i, j int) {
+			batch[i], batch[j] = batch[j], batch[i]
+		})
+
+		err = collector.Allocate(utxosSeq(batch))
+		if err != nil {
+			return fmt.Errorf("failed to allocate utxos: %w", err)
+		}
+
+		if collector.IsFunded() {
+			return nil
+		}
+	}
+	return nil
+}
+
+type branchAndBoundSelection struct {
+	fallback CoinSelection
+}
+
+func (s *branchAndBoundSelection) Select(utxos UTXOSource, collector Collector) error {
+	if collector.IsFunded() {
+		return s.fallback.Select(utxos, collector)
+	}
+
+	candidates := make([]*models.UserUTXO, 0)
+	for utxo, err := range utxos.All(LargestFirst) {
+		if err != nil {
+			return err
+		}
+		candidates = append(candidates, utxo)
+		if len(candidates) >= branchAndBoundMaxCandidates {
+			break
+		}
+	}
+
+	costOfChange, err := collector.CostOfChange()
+	if err != nil {
+		return fmt.Errorf("failed to calculate cost of change: %w", err)
+	}
+
+	search := &branchAndBoundSearch{
+		candidates:   candidates,
+		collector:    collector,
+		costOfChange: costOfChange,
+	}
+	found, err := search.run()
+	if err != nil {
+		return err
+	}
+	if !found {
+		return s.fallback.Select(utxos, collector)
+	}
+
+	err = collector.AllocateWithoutChange(search.selected)
+	if err != nil {
+		return fmt.Errorf("failed to allocate utxos: %w", err)
+	}
+	return nil
+}
+
+// branchAndBoundSearch performs depth-first search over the candidates (sorted descending by satoshis)
+// looking for a subset which covers the transaction with excess not greater than the cost of change.
+type branchAndBoundSearch struct {
+	candidates   []*models.UserUTXO
+	collector    Collector
+	costOfChange satoshi.Value
+
+	tries    int
+	selected []*models.UserUTXO
+}
+
+func (s *branchAndBoundSearch) run() (bool, error) {
+	remaining := satoshi.Zero()
+	for _, utxo := range s.candidates {
+		var err error
+		remaining, err = satoshi.Add(remaining, utxo.Satoshis)
+		if err != nil {
+			return false, fmt.Errorf("failed to sum candidates: %w", err)
+		}
+	}
+
+	return s.search(0, satoshi.Zero(), 0, remaining)
+}
+
+func (s *branchAndBoundSearch) search(index int, sats satoshi.Value, inputsSize uint64, remaining satoshi.Value) (bool, error) {
+	s.tries++
+	if s.tries > branchAndBoundMaxTries {
+		return false, nil
+	}
+
+	excess, err := s.collector.ExcessWithoutChange(sats, inputsSize)
+	if err != nil {
+		return false, fmt.Errorf("failed to calculate excess: %w", err)
+	}
+	if excess >= 0 {
+		// going deeper would only increase the excess
+		return excess <= s.costOfChange, nil
+	}
+	if index >= len(s.candidates) || excess+remaining < 0 {
+		// fees only grow with more inputs, so the remaining candidates are not enough
+		return false, nil
+	}
+
+	utxo := s.candidates[index]
+	utxoSats := satoshi.MustFrom(utxo.Satoshis)
+	remaining = satoshi.MustSubtract(remaining, utxoSats)
+
+	s.selected = append(s.selected, utxo)
+	found, err := s.search(index+1, satoshi.MustAdd(sats, utxoSats), inputsSize+utxo.EstimatedInputSize, remaining)
+	if err != nil || found {
+		return found, err
+	}
+	s.selected = s.selected[:len(s.selected)-1]
+
+	return s.search(index+1, sats, inputsSize, remaining)
+}
+
+func utxosSeq(utxos []*models.UserUTXO) iter.Seq2[*models.UserUTXO, error] {
+	return func(yield func(*models.UserUTXO, error) bool) {
+		for _, utxo := range utxos {
+			if !yield(utxo, nil) {
+				return
+			}
+		}
+	}
+}
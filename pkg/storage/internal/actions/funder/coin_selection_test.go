@@ -0,0 +1,151 @@
+package funder_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/4chain-ag/go-wallet-toolbox/pkg/defs"
+	"github.com/4chain-ag/go-wallet-toolbox/pkg/internal/satoshi"
+	"github.com/4chain-ag/go-wallet-toolbox/pkg/storage/internal/actions/funder"
+	"github.com/4chain-ag/go-wallet-toolbox/pkg/storage/internal/actions/funder/testabilities"
+	"github.com/4chain-ag/go-wallet-toolbox/pkg/storage/internal/testabilities/testusers"
+	"github.com/4chain-ag/go-wallet-toolbox/pkg/wdk"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFunderSQLCoinSelection(t *testing.T) {
+	const smallTransactionSize = 44
+	var ctx = context.Background()
+
+	severalUTXOs := func(given testabilities.FunderFixture, basket *wdk.TableOutputBasket) {
+		given.UTXO().InBasket(basket).OwnedBy(testusers.Alice).WithSatoshis(200).P2PKH().Stored()
+		given.UTXO().InBasket(basket).OwnedBy(testusers.Alice).WithSatoshis(100).P2PKH().Stored()
+		given.UTXO().InBasket(basket).OwnedBy(testusers.Alice).WithSatoshis(10101).P2PKH().Stored()
+		given.UTXO().InBasket(basket).OwnedBy(testusers.Alice).WithSatoshis(1).P2PKH().Stored()
+		given.UTXO().InBasket(basket).OwnedBy(testusers.Alice).WithSatoshis(300).P2PKH().Stored()
+	}
+
+	tests := map[string]struct {
+		strategy        defs.CoinSelectionStrategy
+		havingUTXOsInDB func(testabilities.FunderFixture, *wdk.TableOutputBasket)
+		targetSatoshis  satoshi.Value
+		expectations    func(testabilities.SuccessFundingResultAssertion)
+	}{
+		"largest-first allocates biggest utxos first": {
+			strategy:        defs.CoinSelectionLargestFirst,
+			havingUTXOsInDB: severalUTXOs,
+			targetSatoshis:  100,
+
+			expectations: func(thenResult testabilities.SuccessFundingResultAssertion) {
+				thenResult.HasAllocatedUTXOs().RowIndexes(2).
+					HasFee(1).
+					HasChangeCount(1).ForAmount(10000)
+			},
+		},
+		"smallest-first allocates smallest utxos first": {
+			strategy:        defs.CoinSelectionSmallestFirst,
+			havingUTXOsInDB: severalUTXOs,
+			targetSatoshis:  100,
+
+			expectations: func(thenResult testabilities.SuccessFundingResultAssertion) {
+				thenResult.HasAllocatedUTXOs().RowIndexes(3, 1).
+					HasFee(1).
+					HasNoChange()
+			},
+		},
+		"branch-and-bound finds utxos funding the transaction without change": {
+			strategy:        defs.CoinSelectionBranchAndBound,
+			havingUTXOsInDB: severalUTXOs,
+			targetSatoshis:  499,
+
+			expectations: func(thenResult testabilities.SuccessFundingResultAssertion) {
+				thenResult.HasAllocatedUTXOs().RowIndexes(0, 4).
+					HasFee(1).
+					HasNoChange()
+			},
+		},
+		"branch-and-bound gives the excess below the cost of change to the miner": {
+			strategy:        defs.CoinSelectionBranchAndBound,
+			havingUTXOsInDB: severalUTXOs,
+			targetSatoshis:  498,
+
+			expectations: func(thenResult testabilities.SuccessFundingResultAssertion) {
+				thenResult.HasAllocatedUTXOs().RowIndexes(0, 4).
+					HasFee(2).
+					HasNoChange()
+			},
+		},
+		"branch-and-bound falls back to largest-first when there is no exact match": {
+			strategy: defs.CoinSelectionBranchAndBound,
+			havingUTXOsInDB: func(given testabilities.FunderFixture, basket *wdk.TableOutputBasket) {
+				given.UTXO().InBasket(basket).OwnedBy(testusers.Alice).WithSatoshis(10101).P2PKH().Stored()
+				given.UTXO().InBasket(basket).OwnedBy(testusers.Alice).WithSatoshis(5000).P2PKH().Stored()
+			},
+			targetSatoshis: 100,
+
+			expectations: func(thenResult testabilities.SuccessFundingResultAssertion) {
+				thenResult.HasAllocatedUTXOs().RowIndexes(0).
+					HasFee(1).
+					HasChangeCount(1).ForAmount(10000)
+			},
+		},
+		"random allocates utxos in order given by randomizer": {
+			// test randomizer doesn't change the order, so the oldest utxo is allocated
+			strategy:        defs.CoinSelectionRandom,
+			havingUTXOsInDB: severalUTXOs,
+			targetSatoshis:  100,
+
+			expectations: func(thenResult testabilities.SuccessFundingResultAssertion) {
+				thenResult.HasAllocatedUTXOs().RowIndexes(0).
+					HasFee(1).
+					HasChangeCount(1).ForAmount(99)
+			},
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			// given:
+			given, then, cleanup := testabilities.New(t)
+			defer cleanup()
+
+			// and:
+			funder := given.NewFunderServiceWithCoinSelection(test.strategy)
+
+			// and:
+			basket := given.BasketFor(testusers.Alice).ThatPrefersSingleChange()
+
+			// and:
+			test.havingUTXOsInDB(given, basket)
+
+			// when:
+			result, err := funder.Fund(ctx, test.targetSatoshis, smallTransactionSize, 0, basket, testusers.Alice.ID)
+
+			// then:
+			test.expectations(then.Result(result).WithoutError(err))
+		})
+	}
+}
+
+func TestNewCoinSelectionErrors(t *testing.T) {
+	tests := map[string]struct {
+		strategy defs.CoinSelectionStrategy
+		random   wdk.Randomizer
+	}{
+		"unknown strategy": {
+			strategy: "unknown",
+		},
+		"random strategy without randomizer": {
+			strategy: defs.CoinSelectionRandom,
+			random:   nil,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			// when:
+			_, err := funder.NewCoinSelection(test.strategy, test.random)
+
+			// then:
+			require.Error(t, err)
+		})
+	}
+}
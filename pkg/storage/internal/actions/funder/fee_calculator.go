@@ -11,16 +11,37 @@ import (
 
 type feeCalc struct {
 	bytes float64
-	value float64
+	// proportional fee is rounded up on the whole amount, otherwise each started unit of bytes is paid in full
+	proportional bool
+	value        float64
+	dataValue    float64
+	minFee       satoshi.Value
+	dustLimit    satoshi.Value
 }
 
 func newFeeCalculator(model defs.FeeModel) *feeCalc {
-	if model.Type != defs.SatPerKB {
+	var bytes float64
+	var proportional bool
+	switch model.Type {
+	case defs.SatPerKB:
+		bytes = 1000
+	case defs.SatPerByte:
+		bytes = 1
+	case defs.SatPerBytes:
+		if model.Bytes == 0 {
+			panic("fee model bytes cannot be zero")
+		}
+		var err error
+		if bytes, err = to.Float64FromUnsigned(model.Bytes); err != nil {
+			panic("invalid fee model bytes: " + err.Error())
+		}
+		proportional = true
+	default:
 		panic("unsupported fee model")
 	}
 
-	if model.Value < 0 {
-		panic("fee model value cannot be negative")
+	if model.Value < 0 || model.DataValue < 0 || model.MinFee < 0 {
+		panic("fee model values cannot be negative")
 	}
 
 	feeValue, err := to.Float64(model.Value)
@@ -28,21 +49,94 @@ func newFeeCalculator(model defs.FeeModel) *feeCalc {
 		panic("invalid fee model value: " + err.Error())
 	}
 
+	dataFeeValue, err := to.Float64(model.DataRate())
+	if err != nil {
+		panic("invalid fee model data value: " + err.Error())
+	}
+
 	return &feeCalc{
-		value: feeValue,
-		bytes: 1000,
+		value:        feeValue,
+		dataValue:    dataFeeValue,
+		bytes:        bytes,
+		proportional: proportional,
+		minFee:       satoshi.MustFrom(model.MinFee),
+		dustLimit:    satoshi.MustFrom(model.DustLimit),
+	}
+}
+
+// Calculate returns the fee for the whole transaction of given size,
+// the dataSize is the part of txSize taken by data (OP_RETURN) outputs.
+// The fee is rounded once for the whole transaction, the data bytes are priced separately only when their rate differs.
+func (f *feeCalc) Calculate(txSize uint64, dataSize uint64) (satoshi.Value, error) {
+	if dataSize > txSize {
+		return 0, fmt.Errorf("data size (%d) cannot be greater than transaction size (%d)", dataSize, txSize)
+	}
+
+	var fee satoshi.Value
+	var err error
+	if dataSize == 0 || f.dataValue == f.value {
+		fee, err = f.feeForBytes(txSize, f.value)
+	} else {
+		fee, err = f.feeForMixedBytes(txSize-dataSize, dataSize)
+	}
+	if err != nil {
+		return 0, err
 	}
+
+	return max(fee, f.minFee), nil
 }
 
-func (f *feeCalc) Calculate(txSize uint64) (satoshi.Value, error) {
-	size, err := to.Float64FromUnsigned(txSize)
+// CalculateForStandardBytes returns the fee for given number of standard (not data) bytes, without the minimum fee applied.
+// It is meant to calculate the fee of a part of the transaction, like a single input or output.
+func (f *feeCalc) CalculateForStandardBytes(size uint64) (satoshi.Value, error) {
+	return f.feeForBytes(size, f.value)
+}
+
+// DustLimit returns the minimum value of a change output.
+func (f *feeCalc) DustLimit() satoshi.Value {
+	return f.dustLimit
+}
+
+func (f *feeCalc) feeForBytes(size uint64, rate float64) (satoshi.Value, error) {
+	sizeFloat, err := to.Float64FromUnsigned(size)
 	if err != nil {
 		return 0, fmt.Errorf("invalid transaction size: %w", err)
 	}
 
-	multiplier := math.Ceil(size / f.bytes)
+	var feeFloat float64
+	if f.proportional {
+		feeFloat = math.Ceil(sizeFloat * rate / f.bytes)
+	} else {
+		feeFloat = math.Ceil(sizeFloat/f.bytes) * rate
+	}
+
+	fee, err := to.Int64(feeFloat)
+	if err != nil {
+		return 0, fmt.Errorf("failed to calculate fee value: %w", err)
+	}
+
+	sats, err := satoshi.From(fee)
+	if err != nil {
+		return 0, fmt.Errorf("failed to convert fee to satoshi: %w", err)
+	}
+
+	return sats, nil
+}
+
+// feeForMixedBytes returns the fee for the standard and data bytes priced with different rates,
+// rounded up once on the combined amount.
+func (f *feeCalc) feeForMixedBytes(standardSize, dataSize uint64) (satoshi.Value, error) {
+	standardFloat, err := to.Float64FromUnsigned(standardSize)
+	if err != nil {
+		return 0, fmt.Errorf("invalid transaction size: %w", err)
+	}
+
+	dataFloat, err := to.Float64FromUnsigned(dataSize)
+	if err != nil {
+		return 0, fmt.Errorf("invalid data size: %w", err)
+	}
 
-	fee, err := to.Int64(multiplier * f.value)
+	fee, err := to.Int64(math.Ceil((standardFloat*f.value + dataFloat*f.dataValue) / f.bytes))
 	if err != nil {
 		return 0, fmt.Errorf("failed to calculate fee value: %w", err)
 	}
@@ -0,0 +1,163 @@
+package funder_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/4chain-ag/go-wallet-toolbox/pkg/defs"
+	"github.com/4chain-ag/go-wallet-toolbox/pkg/internal/satoshi"
+	"github.com/4chain-ag/go-wallet-toolbox/pkg/storage/internal/actions/funder/testabilities"
+	"github.com/4chain-ag/go-wallet-toolbox/pkg/storage/internal/testabilities/testusers"
+)
+
+func TestFunderSQLFeeModel(t *testing.T) {
+	const smallTransactionSize = 44
+	var ctx = context.Background()
+
+	tests := map[string]struct {
+		feeModel       defs.FeeModel
+		utxoSatoshis   int64
+		targetSatoshis satoshi.Value
+		txSize         uint64
+		dataSize       uint64
+		expectations   func(testabilities.SuccessFundingResultAssertion)
+	}{
+		"sat/byte fee model": {
+			feeModel:       defs.FeeModel{Type: defs.SatPerByte, Value: 1},
+			utxoSatoshis:   10101,
+			targetSatoshis: 100,
+			txSize:         smallTransactionSize,
+
+			expectations: func(thenResult testabilities.SuccessFundingResultAssertion) {
+				// 44 (tx) + 148 (input) + 34 (change output) bytes
+				thenResult.HasAllocatedUTXOs().RowIndexes(0).
+					HasFee(226).
+					HasChangeCount(1).ForAmount(9775)
+			},
+		},
+		"sat/bytes fee model": {
+			feeModel:       defs.FeeModel{Type: defs.SatPerBytes, Value: 50, Bytes: 1000},
+			utxoSatoshis:   10101,
+			targetSatoshis: 100,
+			txSize:         smallTransactionSize,
+
+			expectations: func(thenResult testabilities.SuccessFundingResultAssertion) {
+				// 226 bytes * 50 sat / 1000 bytes = 11.3, rounded up once instead of paying the whole started kilobyte
+				thenResult.HasAllocatedUTXOs().RowIndexes(0).
+					HasFee(12).
+					HasChangeCount(1).ForAmount(9989)
+			},
+		},
+		"data bytes with the standard rate": {
+			feeModel:       defs.FeeModel{Type: defs.SatPerKB, Value: 10},
+			utxoSatoshis:   10101,
+			targetSatoshis: 100,
+			txSize:         smallTransactionSize + 1000,
+			dataSize:       1000,
+
+			expectations: func(thenResult testabilities.SuccessFundingResultAssertion) {
+				thenResult.HasAllocatedUTXOs().RowIndexes(0).
+					HasFee(20).
+					HasChangeCount(1).ForAmount(9981)
+			},
+		},
+		"data bytes with discounted rate": {
+			feeModel:       defs.FeeModel{Type: defs.SatPerKB, Value: 10, DataValue: 1},
+			utxoSatoshis:   10101,
+			targetSatoshis: 100,
+			txSize:         smallTransactionSize + 1000,
+			dataSize:       1000,
+
+			expectations: func(thenResult testabilities.SuccessFundingResultAssertion) {
+				// 226 standard bytes * 10 sat/kB + 1000 data bytes * 1 sat/kB = 3.26, rounded up once
+				thenResult.HasAllocatedUTXOs().RowIndexes(0).
+					HasFee(4).
+					HasChangeCount(1).ForAmount(9997)
+			},
+		},
+		"minimum fee": {
+			feeModel:       defs.FeeModel{Type: defs.SatPerKB, Value: 1, MinFee: 50},
+			utxoSatoshis:   10101,
+			targetSatoshis: 100,
+			txSize:         smallTransactionSize,
+
+			expectations: func(thenResult testabilities.SuccessFundingResultAssertion) {
+				thenResult.HasAllocatedUTXOs().RowIndexes(0).
+					HasFee(50).
+					HasChangeCount(1).ForAmount(9951)
+			},
+		},
+		"change above dust limit": {
+			feeModel:       defs.FeeModel{Type: defs.SatPerKB, Value: 1, DustLimit: 500},
+			utxoSatoshis:   601,
+			targetSatoshis: 100,
+			txSize:         smallTransactionSize,
+
+			expectations: func(thenResult testabilities.SuccessFundingResultAssertion) {
+				thenResult.HasAllocatedUTXOs().RowIndexes(0).
+					HasFee(1).
+					HasChangeCount(1).ForAmount(500)
+			},
+		},
+		"change below dust limit is given to the miner": {
+			feeModel:       defs.FeeModel{Type: defs.SatPerKB, Value: 1, DustLimit: 500},
+			utxoSatoshis:   600,
+			targetSatoshis: 100,
+			txSize:         smallTransactionSize,
+
+			expectations: func(thenResult testabilities.SuccessFundingResultAssertion) {
+				thenResult.HasAllocatedUTXOs().RowIndexes(0).
+					HasFee(500).
+					HasNoChange()
+			},
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			// given:
+			given, then, cleanup := testabilities.New(t)
+			defer cleanup()
+
+			// and:
+			funder := given.NewFunderServiceWithFeeModel(test.feeModel)
+
+			// and:
+			basket := given.BasketFor(testusers.Alice).ThatPrefersSingleChange()
+
+			// and:
+			given.UTXO().InBasket(basket).OwnedBy(testusers.Alice).WithSatoshis(test.utxoSatoshis).P2PKH().Stored()
+
+			// when:
+			result, err := funder.Fund(ctx, test.targetSatoshis, test.txSize, test.dataSize, basket, testusers.Alice.ID)
+
+			// then:
+			test.expectations(then.Result(result).WithoutError(err))
+		})
+	}
+}
+
+func TestFunderSQLChangeCountWithDustLimit(t *testing.T) {
+	// given:
+	given, then, cleanup := testabilities.New(t)
+	defer cleanup()
+
+	// and:
+	funder := given.NewFunderServiceWithFeeModel(defs.FeeModel{Type: defs.SatPerByte, Value: 1, DustLimit: 1000})
+
+	// and:
+	basket := given.BasketFor(testusers.Alice).WithNumberOfDesiredUTXOs(10)
+
+	// and:
+	given.UTXO().InBasket(basket).OwnedBy(testusers.Alice).WithSatoshis(2292).P2PKH().Stored()
+
+	// when:
+	result, err := funder.Fund(context.Background(), 100, 44, 0, basket, testusers.Alice.ID)
+
+	// then:
+	// 2000 satoshis remain before the change outputs are added, but the fee for the second change output
+	// would make both of them dust, so only one change output is created
+	then.Result(result).WithoutError(err).
+		HasAllocatedUTXOs().RowIndexes(0).
+		HasFee(226).
+		HasChangeCount(1).ForAmount(1966)
+}
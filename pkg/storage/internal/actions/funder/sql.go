@@ -6,13 +6,13 @@ import (
 	"iter"
 	"log/slog"
 
-	"github.com/4chain-ag/go-wallet-toolbox/pkg/defs"
 	"github.com/4chain-ag/go-wallet-toolbox/pkg/internal/logging"
 	"github.com/4chain-ag/go-wallet-toolbox/pkg/internal/satoshi"
 	"github.com/4chain-ag/go-wallet-toolbox/pkg/internal/txutils"
 	"github.com/4chain-ag/go-wallet-toolbox/pkg/storage/internal/actions"
 	"github.com/4chain-ag/go-wallet-toolbox/pkg/storage/internal/actions/funder/errfunder"
 	"github.com/4chain-ag/go-wallet-toolbox/pkg/storage/internal/database/models"
+	"github.com/4chain-ag/go-wallet-toolbox/pkg/storage/internal/feemodel"
 	"github.com/4chain-ag/go-wallet-toolbox/pkg/storage/internal/paging"
 	"github.com/4chain-ag/go-wallet-toolbox/pkg/wdk"
 	"github.com/go-softwarelab/common/pkg/must"
@@ -20,7 +20,10 @@ import (
 	"github.com/go-softwarelab/common/pkg/to"
 )
 
-var changeOutputSize = txutils.P2PKHOutputSize
+var (
+	changeOutputSize = txutils.P2PKHOutputSize
+	changeInputSize  = txutils.P2PKHEstimatedInputSize
+)
 
 const utxoBatchSize = 1000
 
@@ -29,71 +32,98 @@ type UTXORepository interface {
 	CountUTXOs(ctx context.Context, userID int, basketID int) (int64, error)
 }
 
+// FeeModels provides the fee model which should be applied to the funded transaction
+type FeeModels interface {
+	Current() feemodel.Applied
+}
+
 type SQL struct {
 	logger         *slog.Logger
 	utxoRepository UTXORepository
-	feeCalculator  *feeCalc
+	feeModels      FeeModels
+	coinSelection  CoinSelection
 }
 
-func NewSQL(logger *slog.Logger, utxoRepository UTXORepository, feeModel defs.FeeModel) *SQL {
+func NewSQL(logger *slog.Logger, utxoRepository UTXORepository, feeModels FeeModels, coinSelection CoinSelection) *SQL {
 	logger = logging.Child(logger, "funderSQL")
-	feeCalculator := newFeeCalculator(feeModel)
 
 	return &SQL{
 		logger:         logger,
 		utxoRepository: utxoRepository,
-		feeCalculator:  feeCalculator,
+		feeModels:      feeModels,
+		coinSelection:  coinSelection,
 	}
 }
 
 // Fund
 // @param targetSat - the target amount of satoshis to fund (total inputs - total outputs)
 // @param currentTxSize - the current size of the transaction in bytes (size of tx + current inputs + current outputs)
+// @param dataSize - the part of currentTxSize taken by data (OP_RETURN) outputs
 // @param numberOfDesiredUTXOs - the number of UTXOs in basket #TakeFromBasket
 // @param minimumDesiredUTXOValue - the minimum value of UTXO in basket #TakeFromBasket
 // @param userID - the user ID.
-func (f *SQL) Fund(ctx context.Context, targetSat satoshi.Value, currentTxSize uint64, basket *wdk.TableOutputBasket, userID int) (*actions.FundingResult, error) {
+func (f *SQL) Fund(ctx context.Context, targetSat satoshi.Value, currentTxSize uint64, dataSize uint64, basket *wdk.TableOutputBasket, userID int) (*actions.FundingResult, error) {
 	existing, err := f.utxoRepository.CountUTXOs(ctx, userID, basket.BasketID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to calculate desired utxo number in basket: %w", err)
 	}
 
-	collector, err := newCollector(targetSat, currentTxSize, basket.NumberOfDesiredUTXOs-existing, basket.MinimumDesiredUTXOValue, f.feeCalculator)
+	feeModel := f.feeModels.Current()
+
+	collector, err := newCollector(targetSat, currentTxSize, dataSize, basket.NumberOfDesiredUTXOs-existing, basket.MinimumDesiredUTXOValue, newFeeCalculator(feeModel.FeeModel))
 	if err != nil {
 		return nil, fmt.Errorf("failed to start collecting utxo: %w", err)
 	}
 
-	utxos := f.loadUTXOs(ctx, userID, basket.BasketID)
+	utxos := &sqlUTXOSource{
+		ctx:            ctx,
+		utxoRepository: f.utxoRepository,
+		userID:         userID,
+		basketID:       basket.BasketID,
+	}
 
-	err = collector.Allocate(utxos)
+	err = f.coinSelection.Select(utxos, collector)
 	if err != nil {
 		return nil, fmt.Errorf("failed to allocate utxos: %w", err)
 	}
 
-	return collector.GetResult()
+	result, err := collector.GetResult()
+	if err != nil {
+		return nil, err
+	}
+
+	result.FeeModel = feeModel
+	return result, nil
 }
 
-func (f *SQL) loadUTXOs(ctx context.Context, userID int, basketID int) iter.Seq2[*models.UserUTXO, error] {
-	batches := seqerr.ProduceWithArg(
+type sqlUTXOSource struct {
+	ctx            context.Context
+	utxoRepository UTXORepository
+	userID         int
+	basketID       int
+}
+
+func (s *sqlUTXOSource) All(order UTXOsOrder) iter.Seq2[*models.UserUTXO, error] {
+	return seqerr.FlattenSlices(s.Batches(order))
+}
+
+func (s *sqlUTXOSource) Batches(order UTXOsOrder) iter.Seq2[[]*models.UserUTXO, error] {
+	return seqerr.ProduceWithArg(
 		func(page *paging.Page) ([]*models.UserUTXO, *paging.Page, error) {
-			utxos, err := f.utxoRepository.FindNotReservedUTXOs(ctx, userID, basketID, page)
+			utxos, err := s.utxoRepository.FindNotReservedUTXOs(s.ctx, s.userID, s.basketID, page)
 			if err != nil {
 				return nil, nil, fmt.Errorf("failed to load utxos: %w", err)
 			}
 			page.Next()
 			return utxos, page, nil
 		},
-		&paging.Page{
-			Limit:  utxoBatchSize,
-			SortBy: "satoshis",
-		})
-
-	return seqerr.FlattenSlices(batches)
+		order.firstPage())
 }
 
 type utxoCollector struct {
-	txSats satoshi.Value
-	txSize uint64
+	txSats   satoshi.Value
+	txSize   uint64
+	dataSize uint64
 
 	fee           satoshi.Value
 	feeCalculator *feeCalc
@@ -105,11 +135,16 @@ type utxoCollector struct {
 	minimumDesiredUTXOValue uint64
 	changeOutputsCount      uint64
 	minimumChange           uint64
+
+	// noChange is set when the allocated UTXOs were selected to fund the transaction without change,
+	// in such case the excess of allocated satoshis is given to the miner.
+	noChange bool
 }
 
-func newCollector(txSats satoshi.Value, txSize uint64, numberOfDesiredUTXOs int64, minimumDesiredUTXOValue uint64, feeCalculator *feeCalc) (c *utxoCollector, err error) {
+func newCollector(txSats satoshi.Value, txSize uint64, dataSize uint64, numberOfDesiredUTXOs int64, minimumDesiredUTXOValue uint64, feeCalculator *feeCalc) (c *utxoCollector, err error) {
 	c = &utxoCollector{
 		txSats:                  txSats,
+		dataSize:                dataSize,
 		minimumDesiredUTXOValue: minimumDesiredUTXOValue,
 		feeCalculator:           feeCalculator,
 		allocatedUTXOs:          make([]*actions.UTXO, 0),
@@ -141,6 +176,54 @@ func (c *utxoCollector) Allocate(utxos iter.Seq2[*models.UserUTXO, error]) error
 	return nil
 }
 
+func (c *utxoCollector) AllocateWithoutChange(utxos []*models.UserUTXO) error {
+	c.noChange = true
+	for _, utxo := range utxos {
+		err := c.allocateUTXO(utxo)
+		if err != nil {
+			return fmt.Errorf("failed to allocate utxo: %w", err)
+		}
+	}
+	return nil
+}
+
+func (c *utxoCollector) ExcessWithoutChange(sats satoshi.Value, inputsSize uint64) (satoshi.Value, error) {
+	fee, err := c.feeCalculator.Calculate(c.txSize+inputsSize, c.dataSize)
+	if err != nil {
+		return 0, fmt.Errorf("failed to calculate fee: %w", err)
+	}
+
+	toCover, err := satoshi.Add(c.txSats, fee)
+	if err != nil {
+		return 0, fmt.Errorf("failed to calculate satoshis to cover: %w", err)
+	}
+
+	excess, err := satoshi.Subtract(sats, toCover)
+	if err != nil {
+		return 0, fmt.Errorf("failed to calculate excess: %w", err)
+	}
+	return excess, nil
+}
+
+// CostOfChange is the fee for adding the change output to the transaction and for spending it later.
+func (c *utxoCollector) CostOfChange() (satoshi.Value, error) {
+	outputFee, err := c.feeCalculator.CalculateForStandardBytes(changeOutputSize)
+	if err != nil {
+		return 0, fmt.Errorf("failed to calculate fee for change output: %w", err)
+	}
+
+	inputFee, err := c.feeCalculator.CalculateForStandardBytes(changeInputSize)
+	if err != nil {
+		return 0, fmt.Errorf("failed to calculate fee for spending change output: %w", err)
+	}
+
+	cost, err := satoshi.Add(outputFee, inputFee)
+	if err != nil {
+		return 0, fmt.Errorf("failed to calculate cost of change: %w", err)
+	}
+	return cost, nil
+}
+
 func (c *utxoCollector) IsFunded() bool {
 	return c.satsCovered >= c.satsToCover()
 }
@@ -175,14 +258,15 @@ func (c *utxoCollector) allocateUTXO(utxo *models.UserUTXO) (err error) {
 
 func (c *utxoCollector) addToAllocated(utxo *models.UserUTXO) {
 	c.allocatedUTXOs = append(c.allocatedUTXOs, &actions.UTXO{
-		OutputID: utxo.OutputID,
-		Satoshis: satoshi.MustFrom(utxo.Satoshis),
+		OutputID:           utxo.OutputID,
+		Satoshis:           satoshi.MustFrom(utxo.Satoshis),
+		EstimatedInputSize: utxo.EstimatedInputSize,
 	})
 }
 
 func (c *utxoCollector) increaseSize(size uint64) (err error) {
 	c.txSize += size
-	c.fee, err = c.feeCalculator.Calculate(c.txSize)
+	c.fee, err = c.feeCalculator.Calculate(c.txSize, c.dataSize)
 	if err != nil {
 		return fmt.Errorf("failed to calculate fee: %w", err)
 	}
@@ -207,7 +291,17 @@ func (c *utxoCollector) change() satoshi.Value {
 }
 
 func (c *utxoCollector) prepareResult() (*actions.FundingResult, error) {
+	if c.noChange {
+		return &actions.FundingResult{
+			AllocatedUTXOs: c.allocatedUTXOs,
+			Fee:            satoshi.MustSubtract(c.satsCovered, c.txSats),
+			ChangeAmount:   0,
+			ChangeCount:    0,
+		}, nil
+	}
+
 	changeAmount := c.change()
+	fee := c.fee
 
 	// If adding a change output increases the fee to the point where no change remains,
 	// the change outputs are discarded, and the additional amount is given as a higher fee to the miner.
@@ -215,9 +309,16 @@ func (c *utxoCollector) prepareResult() (*actions.FundingResult, error) {
 		c.changeOutputsCount = 0
 	}
 
+	// The change below the dust limit is not worth a separate output, so it's given to the miner too.
+	if changeAmount > 0 && changeAmount < c.feeCalculator.DustLimit() {
+		fee = satoshi.MustAdd(fee, changeAmount)
+		changeAmount = 0
+		c.changeOutputsCount = 0
+	}
+
 	return &actions.FundingResult{
 		AllocatedUTXOs: c.allocatedUTXOs,
-		Fee:            c.fee,
+		Fee:            fee,
 		ChangeAmount:   changeAmount,
 		ChangeCount:    c.changeOutputsCount,
 	}, nil
@@ -225,13 +326,16 @@ func (c *utxoCollector) prepareResult() (*actions.FundingResult, error) {
 
 func (c *utxoCollector) calculateChangeOutputs() error {
 	change := c.change()
-	if change <= 0 {
+	if change <= 0 || c.noChange {
 		return nil
 	}
 
-	c.calculateChangeCount(must.ConvertToUInt64(change))
+	err := c.calculateChangeCount(must.ConvertToUInt64(change))
+	if err != nil {
+		return err
+	}
 
-	err := c.increaseSize(c.changeOutputsCount * changeOutputSize)
+	err = c.increaseSize(c.changeOutputsCount * changeOutputSize)
 	if err != nil {
 		return fmt.Errorf("failed to increase transaction size: %w", err)
 	}
@@ -239,14 +343,44 @@ func (c *utxoCollector) calculateChangeOutputs() error {
 	return nil
 }
 
-func (c *utxoCollector) calculateChangeCount(changeVal uint64) {
-	c.changeOutputsCount = changeVal/c.minimumDesiredUTXOValue + 1
+// calculateChangeCount determines the number of change outputs for the given change,
+// the count is reduced until the change which remains after paying the fee for the change outputs themselves
+// is still enough for that count of outputs (so none of them is dust).
+func (c *utxoCollector) calculateChangeCount(changeVal uint64) error {
+	c.changeOutputsCount = c.changeCountFor(changeVal)
+
+	for c.changeOutputsCount > 1 {
+		fee, err := c.feeCalculator.Calculate(c.txSize+c.changeOutputsCount*changeOutputSize, c.dataSize)
+		if err != nil {
+			return fmt.Errorf("failed to calculate fee for change outputs: %w", err)
+		}
+
+		changeOutputsFee := satoshi.MustSubtract(fee, c.fee).MustUInt64()
+		if changeOutputsFee < changeVal && c.changeCountFor(changeVal-changeOutputsFee) >= c.changeOutputsCount {
+			break
+		}
+
+		c.changeOutputsCount--
+	}
+
+	return nil
+}
+
+func (c *utxoCollector) changeCountFor(changeVal uint64) uint64 {
+	count := changeVal/c.minimumDesiredUTXOValue + 1
 
 	if changeVal%c.minimumDesiredUTXOValue < c.minimumChange {
-		c.changeOutputsCount -= 1
+		count -= 1
+	}
+
+	count = to.ValueBetween(count, 1, c.numberOfDesiredUTXOs)
+
+	// each of the change outputs must be at least the dust limit
+	if dustLimit := c.feeCalculator.DustLimit().MustUInt64(); dustLimit > 0 {
+		count = to.ValueBetween(count, 1, to.NoLessThan(changeVal/dustLimit, 1))
 	}
 
-	c.changeOutputsCount = to.ValueBetween(c.changeOutputsCount, 1, c.numberOfDesiredUTXOs)
+	return count
 }
 
 // calculateMinimumChange determines the minimum change amount based on the **Desired** minimum UTXO value.
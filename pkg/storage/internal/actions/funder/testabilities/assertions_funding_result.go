@@ -94,8 +94,9 @@ func (a *funderAssertion) RowIndexes(indexes ...int) SuccessFundingResultAsserti
 	a.Helper()
 	expected := slices.Map(indexes, func(index int) *actions.UTXO {
 		return &actions.UTXO{
-			OutputID: a.fixture.createdUTXOs[index].OutputID,
-			Satoshis: satoshi.MustFrom(a.fixture.createdUTXOs[index].Satoshis),
+			OutputID:           a.fixture.createdUTXOs[index].OutputID,
+			Satoshis:           satoshi.MustFrom(a.fixture.createdUTXOs[index].Satoshis),
+			EstimatedInputSize: a.fixture.createdUTXOs[index].EstimatedInputSize,
 		}
 	})
 
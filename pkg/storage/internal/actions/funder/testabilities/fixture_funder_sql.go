@@ -6,9 +6,11 @@ import (
 
 	"github.com/4chain-ag/go-wallet-toolbox/pkg/defs"
 	"github.com/4chain-ag/go-wallet-toolbox/pkg/internal/logging"
+	"github.com/4chain-ag/go-wallet-toolbox/pkg/randomizer"
 	"github.com/4chain-ag/go-wallet-toolbox/pkg/storage/internal/actions/funder"
 	"github.com/4chain-ag/go-wallet-toolbox/pkg/storage/internal/database"
 	"github.com/4chain-ag/go-wallet-toolbox/pkg/storage/internal/database/models"
+	"github.com/4chain-ag/go-wallet-toolbox/pkg/storage/internal/feemodel"
 	"github.com/4chain-ag/go-wallet-toolbox/pkg/storage/internal/testabilities/testusers"
 	"github.com/stretchr/testify/require"
 )
@@ -17,6 +19,8 @@ var exampleDate = time.Date(2006, 02, 01, 15, 4, 5, 7, time.UTC)
 
 type FunderFixture interface {
 	NewFunderService() *funder.SQL
+	NewFunderServiceWithCoinSelection(strategy defs.CoinSelectionStrategy) *funder.SQL
+	NewFunderServiceWithFeeModel(model defs.FeeModel) *funder.SQL
 	UTXO() UserUTXOFixture
 	BasketFor(user testusers.User) BasketFixture
 }
@@ -41,8 +45,23 @@ func newFixture(t testing.TB, db *database.Database) FunderFixture {
 }
 
 func (f *funderFixture) NewFunderService() *funder.SQL {
+	return f.NewFunderServiceWithCoinSelection(defs.DefaultCoinSelectionStrategy())
+}
+
+func (f *funderFixture) NewFunderServiceWithCoinSelection(strategy defs.CoinSelectionStrategy) *funder.SQL {
+	return f.newFunderService(strategy, feeModel)
+}
+
+func (f *funderFixture) NewFunderServiceWithFeeModel(model defs.FeeModel) *funder.SQL {
+	return f.newFunderService(defs.DefaultCoinSelectionStrategy(), model)
+}
+
+func (f *funderFixture) newFunderService(strategy defs.CoinSelectionStrategy, model defs.FeeModel) *funder.SQL {
+	coinSelection, err := funder.NewCoinSelection(strategy, randomizer.NewTestRandomizer())
+	require.NoError(f.t, err)
+
 	repo := f.db.CreateRepositories().UTXOs
-	return funder.NewSQL(logging.NewTestLogger(f.t), repo, feeModel)
+	return funder.NewSQL(logging.NewTestLogger(f.t), repo, feemodel.NewStatic(model), coinSelection)
 }
 
 func (f *funderFixture) UTXO() UserUTXOFixture {
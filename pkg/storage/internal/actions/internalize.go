@@ -159,6 +159,7 @@ func (in *internalize) newOutputs(ctx context.Context, userID int, tx *transacti
 				CustomInstructions: remittance.CustomInstructions,
 				Change:             false,
 				ProvidedBy:         wdk.ProvidedByYou,
+				Tags:               remittance.Tags,
 			})
 		}
 	}
@@ -3,6 +3,7 @@ package commission
 import (
 	"fmt"
 
+	"github.com/4chain-ag/go-wallet-toolbox/pkg/defs"
 	primitives "github.com/bsv-blockchain/go-sdk/primitives/ec"
 	crypto "github.com/bsv-blockchain/go-sdk/primitives/hash"
 	"github.com/bsv-blockchain/go-sdk/script"
@@ -13,13 +14,15 @@ import (
 type ScriptGenerator struct {
 	offsetPrivGenerator func() (*primitives.PrivateKey, error)
 	pubKey              string
+	network             defs.BSVNetwork
 }
 
-// NewScriptGenerator creates a new instance of ScriptGenerator.
-func NewScriptGenerator(pubKey string) *ScriptGenerator {
+// NewScriptGenerator creates a new instance of ScriptGenerator for the addresses of given network.
+func NewScriptGenerator(pubKey string, network defs.BSVNetwork) *ScriptGenerator {
 	return &ScriptGenerator{
 		offsetPrivGenerator: randomPrivateKey,
 		pubKey:              pubKey,
+		network:             network,
 	}
 }
 
@@ -37,7 +40,7 @@ func (l *ScriptGenerator) Generate() (lockingScript string, keyOffset string, er
 		return "", "", err
 	}
 
-	address, err := script.NewAddressFromPublicKey(offsetPub, true)
+	address, err := script.NewAddressFromPublicKey(offsetPub, l.network.IsMainnet())
 	if err != nil {
 		return "", "", fmt.Errorf("failed to create address from public key: %w", err)
 	}
@@ -3,6 +3,7 @@ package commission_test
 import (
 	"testing"
 
+	"github.com/4chain-ag/go-wallet-toolbox/pkg/defs"
 	"github.com/4chain-ag/go-wallet-toolbox/pkg/storage/internal/commission"
 	primitives "github.com/bsv-blockchain/go-sdk/primitives/ec"
 	"github.com/bsv-blockchain/go-sdk/script"
@@ -16,7 +17,7 @@ func TestLockScriptWithKeyOffsetFromPubKey(t *testing.T) {
 	pubKey := "02f40c35f798e2ece03ae1ebf749545336db8402eb7e620bfe04d50da8ca8b06cc"
 
 	// and:
-	generator := commission.NewScriptGenerator(pubKey)
+	generator := commission.NewScriptGenerator(pubKey, defs.NetworkMainnet)
 
 	// and:
 	// mocking the offset private key generator
@@ -40,7 +41,7 @@ func TestLockScriptWithKeyOffset_Uniqueness(t *testing.T) {
 	pubKey := "02f40c35f798e2ece03ae1ebf749545336db8402eb7e620bfe04d50da8ca8b06cc"
 
 	// and:
-	generator := commission.NewScriptGenerator(pubKey)
+	generator := commission.NewScriptGenerator(pubKey, defs.NetworkMainnet)
 
 	lockingScripts := make(map[string]struct{})
 	keyOffsets := make(map[string]struct{})
@@ -69,7 +70,7 @@ func TestLockScriptWithKeyOffset_Uniqueness(t *testing.T) {
 
 func TestLockScriptWithKeyOffset_WrongPubKey(t *testing.T) {
 	// given:
-	generator := commission.NewScriptGenerator("wrong_pub_key")
+	generator := commission.NewScriptGenerator("wrong_pub_key", defs.NetworkMainnet)
 
 	// when:
 	_, _, err := generator.Generate()
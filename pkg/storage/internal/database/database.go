@@ -10,6 +10,7 @@ import (
 	"github.com/4chain-ag/go-wallet-toolbox/pkg/storage/internal/actions"
 	"github.com/4chain-ag/go-wallet-toolbox/pkg/storage/internal/actions/funder"
 	"github.com/4chain-ag/go-wallet-toolbox/pkg/storage/internal/repo"
+	"github.com/4chain-ag/go-wallet-toolbox/pkg/wdk"
 	"gorm.io/gorm"
 	glogger "gorm.io/gorm/logger"
 	"gorm.io/gorm/schema"
@@ -56,9 +57,14 @@ func (d *Database) CreateRepositories() *repo.Repositories {
 	return repo.NewSQLRepositories(d.DB)
 }
 
-func (d *Database) CreateFunder(feeModel defs.FeeModel) actions.Funder {
+func (d *Database) CreateFunder(feeModels funder.FeeModels, strategy defs.CoinSelectionStrategy, random wdk.Randomizer) (actions.Funder, error) {
+	coinSelection, err := funder.NewCoinSelection(strategy, random)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create coin selection: %w", err)
+	}
+
 	utxoRepo := repo.NewUTXOs(d.DB)
-	return funder.NewSQL(d.baseLogger, utxoRepo, feeModel)
+	return funder.NewSQL(d.baseLogger, utxoRepo, feeModels, coinSelection), nil
 }
 
 func createAndConfigureDatabaseConnection(dialector gorm.Dialector, cfg defs.Database, logger glogger.Interface) (*gorm.DB, error) {
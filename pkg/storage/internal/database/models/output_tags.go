@@ -0,0 +1,29 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// OutputTag is the database model of the user's output tags
+type OutputTag struct {
+	CreatedAt time.Time
+	UpdatedAt time.Time
+	DeletedAt gorm.DeletedAt `gorm:"index"`
+
+	TagID  uint   `gorm:"primaryKey"`
+	Tag    string `gorm:"type:varchar(300);not null;uniqueIndex:idx_tag_user_id"`
+	UserID int    `gorm:"not null;uniqueIndex:idx_tag_user_id"`
+}
+
+// OutputTagMap connects outputs with their tags
+type OutputTagMap struct {
+	CreatedAt time.Time
+	UpdatedAt time.Time
+	DeletedAt gorm.DeletedAt `gorm:"index"`
+
+	OutputID    uint       `gorm:"primaryKey"`
+	OutputTagID uint       `gorm:"primaryKey"`
+	OutputTag   *OutputTag `gorm:"foreignKey:OutputTagID;references:TagID"`
+}
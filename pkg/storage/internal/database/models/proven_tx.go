@@ -0,0 +1,17 @@
+package models
+
+import "time"
+
+// ProvenTx is the mined transaction with its proof of inclusion in the block
+type ProvenTx struct {
+	CreatedAt time.Time
+	UpdatedAt time.Time
+
+	TxID string `gorm:"type:varchar(64);primaryKey"`
+
+	Height     uint32
+	BlockHash  string `gorm:"type:varchar(64)"`
+	MerkleRoot string `gorm:"type:varchar(64)"`
+	MerklePath []byte
+	RawTx      []byte
+}
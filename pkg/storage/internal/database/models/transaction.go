@@ -19,6 +19,11 @@ type Transaction struct {
 	TxID        *string
 	InputBeef   []byte
 
+	// Fee is the fee (in satoshis) calculated for the transaction by createAction
+	Fee *int64
+	// FeeModel describes the fee model applied by createAction to calculate the Fee
+	FeeModel *string `gorm:"type:string"`
+
 	Outputs       []*Output   `gorm:"foreignKey:TransactionID"`
 	Inputs        []*Output   `gorm:"foreignKey:SpentBy"`
 	Labels        []*Label    `gorm:"many2many:transaction_labels;"`
@@ -17,4 +17,7 @@ type UserUTXO struct {
 
 	ReservedByID *uint
 	ReservedBy   *Transaction
+	// ReservedUntil is the moment after which the reservation is considered expired.
+	// It is nil for UTXOs that are not reserved or for reservations that don't expire (e.g. of already processed transactions).
+	ReservedUntil *time.Time
 }
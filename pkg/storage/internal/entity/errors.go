@@ -0,0 +1,10 @@
+package entity
+
+import "errors"
+
+// ErrUTXOsAlreadyReserved is returned when some of the UTXOs chosen to fund a transaction
+// have been reserved by another transaction in the meantime.
+var ErrUTXOsAlreadyReserved = errors.New("utxos already reserved by another transaction")
+
+// ErrNotFound is returned when the requested entity doesn't exist in the storage
+var ErrNotFound = errors.New("not found")
@@ -0,0 +1,11 @@
+package entity
+
+// KnownTx is the transaction stored in the storage, with its proof when it is mined
+type KnownTx struct {
+	TxID  string
+	RawTx []byte
+	// MerklePath is the serialized merkle path of the mined transaction, nil when the proof is not known yet
+	MerklePath []byte
+	// InputBeef is the BEEF with ancestors of not mined transaction, nil when not available
+	InputBeef []byte
+}
@@ -1,6 +1,8 @@
 package entity
 
 import (
+	"time"
+
 	"github.com/4chain-ag/go-wallet-toolbox/pkg/internal/satoshi"
 	"github.com/4chain-ag/go-wallet-toolbox/pkg/wdk"
 	"github.com/4chain-ag/go-wallet-toolbox/pkg/wdk/primitives"
@@ -22,8 +24,15 @@ type NewTx struct {
 
 	TxID *string
 
+	// Fee is the fee calculated for the transaction, nil if unknown
+	Fee *int64
+	// FeeModel describes the fee model applied to calculate the Fee
+	FeeModel *string
+
 	ReservedOutputIDs []uint
-	Outputs           []*NewOutput
+	// ReservedUntil is the expiration time of reservation of the ReservedOutputIDs, nil means that the reservation never expires
+	ReservedUntil *time.Time
+	Outputs       []*NewOutput
 
 	Labels []primitives.StringUnder300
 }
@@ -44,4 +53,5 @@ type NewOutput struct {
 	Description        string
 	Vout               uint32
 	SenderIdentityKey  *string
+	Tags               []primitives.StringUnder300
 }
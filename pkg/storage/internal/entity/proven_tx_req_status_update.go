@@ -0,0 +1,22 @@
+package entity
+
+import "github.com/4chain-ag/go-wallet-toolbox/pkg/wdk"
+
+// ProvenTxReqStatusUpdate is the status change of ProvenTxReq reported by the transaction processor
+type ProvenTxReqStatusUpdate struct {
+	TxID string
+	// Status is the new status of ProvenTxReq, empty value keeps its status unchanged
+	Status wdk.ProvenTxReqStatus
+	// TxStatus is the new status of the transactions with the TxID, nil keeps their status unchanged
+	TxStatus *wdk.TxStatus
+	// Proof is the proof of the mined transaction, it is stored together with the completed status
+	Proof *TxProof
+}
+
+// TxProof is the merkle proof of the transaction inclusion in the block
+type TxProof struct {
+	Height     uint32
+	BlockHash  string
+	MerkleRoot string
+	MerklePath []byte
+}
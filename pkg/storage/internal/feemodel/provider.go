@@ -0,0 +1,101 @@
+package feemodel
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"sync/atomic"
+
+	"github.com/4chain-ag/go-wallet-toolbox/pkg/defs"
+	"github.com/4chain-ag/go-wallet-toolbox/pkg/internal/logging"
+)
+
+// Source describes where the applied fee model comes from
+type Source string
+
+// Possible sources of the applied fee model
+const (
+	// SourceStatic is the fee model from the configuration
+	SourceStatic Source = "static"
+	// SourceBroadcasterPolicy is the fee model fetched from the broadcaster's (ARC) policy
+	SourceBroadcasterPolicy Source = "arc-policy"
+)
+
+// Applied is the fee model applied to calculate the fee of a transaction together with its source
+type Applied struct {
+	defs.FeeModel
+	Source Source
+}
+
+// String returns human-readable description of the applied fee model, e.g. "1 sat/kb (static)"
+func (a Applied) String() string {
+	return fmt.Sprintf("%d %s (%s)", a.Value, a.Unit(), a.Source)
+}
+
+// Fetcher fetches the current mining fee model, e.g. from the broadcaster's policy
+type Fetcher interface {
+	MiningFeeModel(ctx context.Context) (defs.FeeModel, error)
+}
+
+// Provider provides the fee model which should be applied to newly created transactions.
+// When the fetcher is configured, the fee rate is periodically refreshed from it (see Refresh),
+// otherwise (or when fetching fails) the static fee model is applied.
+type Provider struct {
+	logger  *slog.Logger
+	static  defs.FeeModel
+	fetcher Fetcher
+	current atomic.Pointer[Applied]
+}
+
+// NewProvider creates a new fee model provider, the fetcher is optional.
+func NewProvider(logger *slog.Logger, static defs.FeeModel, fetcher Fetcher) *Provider {
+	p := &Provider{
+		logger:  logging.Child(logger, "feeModel"),
+		static:  static,
+		fetcher: fetcher,
+	}
+	p.current.Store(&Applied{FeeModel: static, Source: SourceStatic})
+	return p
+}
+
+// NewStatic creates a fee model provider which always applies given fee model.
+func NewStatic(model defs.FeeModel) *Provider {
+	return NewProvider(logging.New().Nop().Logger(), model, nil)
+}
+
+// Current returns the fee model which should be applied now
+func (p *Provider) Current() Applied {
+	return *p.current.Load()
+}
+
+// Refresh fetches the fee model from the fetcher.
+// The fetched fee rates (of standard and data bytes) replace the rates of the static fee model,
+// so both rates come from the same source, other settings (like the dust limit) are kept.
+// The data rate not provided by the fetcher means the standard rate is applied to data too (see defs.FeeModel.DataRate).
+// On failure, the static fee model is applied until the next successful refresh.
+func (p *Provider) Refresh(ctx context.Context) error {
+	if p.fetcher == nil {
+		return nil
+	}
+
+	fetched, err := p.fetcher.MiningFeeModel(ctx)
+	if err == nil {
+		err = fetched.Validate()
+	}
+	if err != nil {
+		p.current.Store(&Applied{FeeModel: p.static, Source: SourceStatic})
+		return fmt.Errorf("failed to fetch fee model, falling back to static fee model: %w", err)
+	}
+
+	model := p.static
+	model.Type = fetched.Type
+	model.Value = fetched.Value
+	model.Bytes = fetched.Bytes
+	model.DataValue = fetched.DataValue
+
+	previous := p.current.Swap(&Applied{FeeModel: model, Source: SourceBroadcasterPolicy})
+	if previous.FeeModel != model {
+		p.logger.Info("fee model changed", slog.String("feeModel", p.Current().String()))
+	}
+	return nil
+}
@@ -0,0 +1,160 @@
+package feemodel_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/4chain-ag/go-wallet-toolbox/pkg/defs"
+	"github.com/4chain-ag/go-wallet-toolbox/pkg/internal/logging"
+	"github.com/4chain-ag/go-wallet-toolbox/pkg/storage/internal/feemodel"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fetcherFunc func(ctx context.Context) (defs.FeeModel, error)
+
+func (f fetcherFunc) MiningFeeModel(ctx context.Context) (defs.FeeModel, error) {
+	return f(ctx)
+}
+
+func staticModel() defs.FeeModel {
+	return defs.FeeModel{
+		Type:      defs.SatPerKB,
+		Value:     1,
+		MinFee:    10,
+		DustLimit: 1,
+	}
+}
+
+func TestStaticFeeModel(t *testing.T) {
+	// given:
+	provider := feemodel.NewStatic(staticModel())
+
+	// when:
+	err := provider.Refresh(context.Background())
+
+	// then:
+	require.NoError(t, err)
+	assert.Equal(t, feemodel.Applied{FeeModel: staticModel(), Source: feemodel.SourceStatic}, provider.Current())
+	assert.Equal(t, "1 sat/kb (static)", provider.Current().String())
+}
+
+func TestRefreshFeeModel(t *testing.T) {
+	// given:
+	fetched := defs.FeeModel{Type: defs.SatPerKB, Value: 50}
+	provider := feemodel.NewProvider(logging.New().Nop().Logger(), staticModel(), fetcherFunc(func(context.Context) (defs.FeeModel, error) {
+		return fetched, nil
+	}))
+
+	// when:
+	err := provider.Refresh(context.Background())
+
+	// then:
+	require.NoError(t, err)
+
+	current := provider.Current()
+	assert.Equal(t, feemodel.SourceBroadcasterPolicy, current.Source)
+	assert.Equal(t, int64(50), current.Value)
+	assert.Equal(t, "50 sat/kb (arc-policy)", current.String())
+
+	// and: the settings not provided by the broadcaster are kept from the static fee model
+	assert.Equal(t, int64(10), current.MinFee)
+	assert.Equal(t, uint64(1), current.DustLimit)
+}
+
+func TestRefreshFeeModelWithExactRate(t *testing.T) {
+	// given:
+	fetched := defs.FeeModel{Type: defs.SatPerBytes, Value: 1, Bytes: 3000}
+	provider := feemodel.NewProvider(logging.New().Nop().Logger(), staticModel(), fetcherFunc(func(context.Context) (defs.FeeModel, error) {
+		return fetched, nil
+	}))
+
+	// when:
+	err := provider.Refresh(context.Background())
+
+	// then:
+	require.NoError(t, err)
+
+	current := provider.Current()
+	assert.Equal(t, defs.SatPerBytes, current.Type)
+	assert.Equal(t, uint64(3000), current.Bytes)
+	assert.Equal(t, "1 sat/3000 bytes (arc-policy)", current.String())
+}
+
+func TestRefreshFeeModelDataRate(t *testing.T) {
+	tests := map[string]struct {
+		fetched          defs.FeeModel
+		expectedDataRate int64
+	}{
+		"fetched data rate": {
+			fetched:          defs.FeeModel{Type: defs.SatPerKB, Value: 50, DataValue: 5},
+			expectedDataRate: 5,
+		},
+		"fetched standard rate when data rate is not fetched": {
+			fetched:          defs.FeeModel{Type: defs.SatPerKB, Value: 50},
+			expectedDataRate: 50,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			// given:
+			static := staticModel()
+			static.DataValue = 100
+
+			// and:
+			provider := feemodel.NewProvider(logging.New().Nop().Logger(), static, fetcherFunc(func(context.Context) (defs.FeeModel, error) {
+				return test.fetched, nil
+			}))
+
+			// when:
+			err := provider.Refresh(context.Background())
+
+			// then:
+			require.NoError(t, err)
+			current := provider.Current()
+			assert.Equal(t, test.expectedDataRate, current.DataRate())
+		})
+	}
+}
+
+func TestRefreshFeeModelFallbackToStatic(t *testing.T) {
+	tests := map[string]struct {
+		fetched defs.FeeModel
+		err     error
+	}{
+		"fetch error": {
+			err: errors.New("arc unavailable"),
+		},
+		"zero fee rate": {
+			fetched: defs.FeeModel{Type: defs.SatPerKB, Value: 0},
+		},
+		"unknown fee model type": {
+			fetched: defs.FeeModel{Type: "sat/tx", Value: 1},
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			// given:
+			fail := false
+			provider := feemodel.NewProvider(logging.New().Nop().Logger(), staticModel(), fetcherFunc(func(context.Context) (defs.FeeModel, error) {
+				if fail {
+					return test.fetched, test.err
+				}
+				return defs.FeeModel{Type: defs.SatPerKB, Value: 50}, nil
+			}))
+
+			// and: previously fetched fee model is applied
+			require.NoError(t, provider.Refresh(context.Background()))
+			require.Equal(t, feemodel.SourceBroadcasterPolicy, provider.Current().Source)
+
+			// when:
+			fail = true
+			err := provider.Refresh(context.Background())
+
+			// then:
+			require.Error(t, err)
+			assert.Equal(t, feemodel.Applied{FeeModel: staticModel(), Source: feemodel.SourceStatic}, provider.Current())
+		})
+	}
+}
@@ -5,6 +5,7 @@ import (
 	_ "embed"
 	"encoding/json"
 	"testing"
+	"time"
 
 	"github.com/4chain-ag/go-wallet-toolbox/pkg/internal/fixtures"
 	"github.com/4chain-ag/go-wallet-toolbox/pkg/randomizer"
@@ -31,25 +32,7 @@ func TestInternalizeThenCreateThenProcess(t *testing.T) {
 
 	t.Run("Internalize", func(t *testing.T) {
 		// given:
-		args := wdk.InternalizeActionArgs{
-			Tx: tsgenerated.AtomicBeefToInternalize(t),
-			Outputs: []*wdk.InternalizeOutput{
-				{
-					OutputIndex: 0,
-					Protocol:    wdk.WalletPaymentProtocol,
-					PaymentRemittance: &wdk.WalletPayment{
-						DerivationPrefix:  fixtures.DerivationPrefix,
-						DerivationSuffix:  fixtures.DerivationSuffix,
-						SenderIdentityKey: fixtures.AnyoneIdentityKey,
-					},
-				},
-			},
-			Labels: []primitives.StringUnder300{
-				"label1", "label2",
-			},
-			Description:    "description",
-			SeekPermission: nil,
-		}
+		args := internalizeBRC29Args(t)
 
 		// when:
 		result, err := activeStorage.InternalizeAction(
@@ -77,36 +60,7 @@ func TestInternalizeThenCreateThenProcess(t *testing.T) {
 
 	t.Run("Create", func(t *testing.T) {
 		// given:
-		args := wdk.ValidCreateActionArgs{
-			Description: "outputBRC29",
-			Inputs:      []wdk.ValidCreateActionInput{},
-			Outputs: []wdk.ValidCreateActionOutput{
-				{
-					LockingScript:      "76a9144b0d6cbef5a813d2d12dcec1de2584b250dc96a388ac",
-					Satoshis:           1000,
-					OutputDescription:  "outputBRC29",
-					CustomInstructions: to.Ptr(`{"derivationPrefix":"Pr==","derivationSuffix":"Su==","type":"BRC29"}`),
-				},
-			},
-			LockTime: 0,
-			Version:  1,
-			Labels:   []primitives.StringUnder300{"outputbrc29"},
-			Options: wdk.ValidCreateActionOptions{
-				AcceptDelayedBroadcast: to.Ptr[primitives.BooleanDefaultTrue](false),
-				SendWith:               []primitives.TXIDHexString{},
-				SignAndProcess:         to.Ptr(primitives.BooleanDefaultTrue(true)),
-				KnownTxids:             []primitives.TXIDHexString{},
-				NoSendChange:           []wdk.OutPoint{},
-				RandomizeOutputs:       false,
-			},
-			IsSendWith:                   false,
-			IsDelayed:                    false,
-			IsNoSend:                     false,
-			IsNewTx:                      true,
-			IsRemixChange:                false,
-			IsSignAction:                 false,
-			IncludeAllSourceTransactions: true,
-		}
+		args := outputBRC29CreateActionArgs()
 
 		// when:
 		result, err := activeStorage.CreateAction(
@@ -131,20 +85,7 @@ func TestInternalizeThenCreateThenProcess(t *testing.T) {
 
 	t.Run("Process", func(t *testing.T) {
 		// given:
-		tx := tsgenerated.SignedTransaction(t)
-		txID := tx.TxID().String()
-
-		// and:
-		args := wdk.ProcessActionArgs{
-			IsNewTx:    true,
-			IsSendWith: false,
-			IsNoSend:   false,
-			IsDelayed:  false,
-			Reference:  to.Ptr(createdTxReference),
-			TxID:       to.Ptr(primitives.TXIDHexString(txID)),
-			RawTx:      tx.Bytes(),
-			SendWith:   []string{},
-		}
+		args := processSignedTransactionArgs(t, createdTxReference)
 
 		// when:
 		_, err := activeStorage.ProcessAction(context.Background(), testusers.Alice.AuthID(), args)
@@ -227,3 +168,104 @@ func TestInternalizeBasketInsertionThenCreate(t *testing.T) {
 		require.ErrorIs(t, err, errfunder.NotEnoughFunds)
 	})
 }
+
+func TestProcessActionAfterReservationExpired(t *testing.T) {
+	given := testabilities.Given(t)
+
+	// given:
+	activeStorage := given.Provider().
+		WithRandomizer(randomizer.NewTestRandomizer()).
+		WithReservationTimeout(time.Nanosecond).
+		GORM()
+
+	// and:
+	_, err := activeStorage.InternalizeAction(context.Background(), testusers.Alice.AuthID(), internalizeBRC29Args(t))
+	require.NoError(t, err)
+
+	// and:
+	created, err := activeStorage.CreateAction(context.Background(), testusers.Alice.AuthID(), outputBRC29CreateActionArgs())
+	require.NoError(t, err)
+
+	// and: the reservation of the created transaction expires and its input is reserved by another transaction
+	_, err = activeStorage.CreateAction(context.Background(), testusers.Alice.AuthID(), outputBRC29CreateActionArgs())
+	require.NoError(t, err)
+
+	// when:
+	_, err = activeStorage.ProcessAction(context.Background(), testusers.Alice.AuthID(), processSignedTransactionArgs(t, created.Reference))
+
+	// then:
+	require.ErrorContains(t, err, "not in a valid status for processing")
+}
+
+func internalizeBRC29Args(t *testing.T) wdk.InternalizeActionArgs {
+	t.Helper()
+	return wdk.InternalizeActionArgs{
+		Tx: tsgenerated.AtomicBeefToInternalize(t),
+		Outputs: []*wdk.InternalizeOutput{
+			{
+				OutputIndex: 0,
+				Protocol:    wdk.WalletPaymentProtocol,
+				PaymentRemittance: &wdk.WalletPayment{
+					DerivationPrefix:  fixtures.DerivationPrefix,
+					DerivationSuffix:  fixtures.DerivationSuffix,
+					SenderIdentityKey: fixtures.AnyoneIdentityKey,
+				},
+			},
+		},
+		Labels: []primitives.StringUnder300{
+			"label1", "label2",
+		},
+		Description:    "description",
+		SeekPermission: nil,
+	}
+}
+
+func outputBRC29CreateActionArgs() wdk.ValidCreateActionArgs {
+	return wdk.ValidCreateActionArgs{
+		Description: "outputBRC29",
+		Inputs:      []wdk.ValidCreateActionInput{},
+		Outputs: []wdk.ValidCreateActionOutput{
+			{
+				LockingScript:      "76a9144b0d6cbef5a813d2d12dcec1de2584b250dc96a388ac",
+				Satoshis:           1000,
+				OutputDescription:  "outputBRC29",
+				CustomInstructions: to.Ptr(`{"derivationPrefix":"Pr==","derivationSuffix":"Su==","type":"BRC29"}`),
+			},
+		},
+		LockTime: 0,
+		Version:  1,
+		Labels:   []primitives.StringUnder300{"outputbrc29"},
+		Options: wdk.ValidCreateActionOptions{
+			AcceptDelayedBroadcast: to.Ptr[primitives.BooleanDefaultTrue](false),
+			SendWith:               []primitives.TXIDHexString{},
+			SignAndProcess:         to.Ptr(primitives.BooleanDefaultTrue(true)),
+			KnownTxids:             []primitives.TXIDHexString{},
+			NoSendChange:           []wdk.OutPoint{},
+			RandomizeOutputs:       false,
+		},
+		IsSendWith:                   false,
+		IsDelayed:                    false,
+		IsNoSend:                     false,
+		IsNewTx:                      true,
+		IsRemixChange:                false,
+		IsSignAction:                 false,
+		IncludeAllSourceTransactions: true,
+	}
+}
+
+func processSignedTransactionArgs(t *testing.T, reference string) wdk.ProcessActionArgs {
+	t.Helper()
+	tx := tsgenerated.SignedTransaction(t)
+	txID := tx.TxID().String()
+
+	return wdk.ProcessActionArgs{
+		IsNewTx:    true,
+		IsSendWith: false,
+		IsNoSend:   false,
+		IsDelayed:  false,
+		Reference:  to.Ptr(reference),
+		TxID:       to.Ptr(primitives.TXIDHexString(txID)),
+		RawTx:      tx.Bytes(),
+		SendWith:   []string{},
+	}
+}
@@ -0,0 +1,366 @@
+package methodtests
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/4chain-ag/go-wallet-toolbox/pkg/storage"
+	"github.com/4chain-ag/go-wallet-toolbox/pkg/storage/internal/testabilities"
+	"github.com/4chain-ag/go-wallet-toolbox/pkg/storage/internal/testabilities/testusers"
+	"github.com/4chain-ag/go-wallet-toolbox/pkg/wdk"
+	"github.com/bsv-blockchain/go-sdk/chainhash"
+	"github.com/bsv-blockchain/go-sdk/transaction"
+	"github.com/go-softwarelab/common/pkg/to"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	callbackToken     = "arc-callback-token"
+	callbackBlockHash = "00000000000000000a1b2c3d4e5f60718293a4b5c6d7e8f90123456789abcdef"
+	callbackHeight    = 881_234
+)
+
+func TestArcCallbackStatuses(t *testing.T) {
+	tests := map[string]struct {
+		txStatus                 string
+		expectedProvenTxStatus   wdk.ProvenTxReqStatus
+		expectedTransactionState wdk.TxStatus
+	}{
+		"seen on network": {
+			txStatus:                 storage.ArcStatusSeenOnNetwork,
+			expectedProvenTxStatus:   wdk.ProvenTxStatusCallback,
+			expectedTransactionState: wdk.TxStatusCompleted,
+		},
+		"rejected": {
+			txStatus:                 storage.ArcStatusRejected,
+			expectedProvenTxStatus:   wdk.ProvenTxStatusInvalid,
+			expectedTransactionState: wdk.TxStatusFailed,
+		},
+		"double spend attempted": {
+			txStatus:                 storage.ArcStatusDoubleSpendAttempted,
+			expectedProvenTxStatus:   wdk.ProvenTxStatusUnconfirmed,
+			expectedTransactionState: wdk.TxStatusCompleted,
+		},
+		"intermediate status": {
+			txStatus:                 "ANNOUNCED_TO_NETWORK",
+			expectedProvenTxStatus:   wdk.ProvenTxStatusUnmined,
+			expectedTransactionState: wdk.TxStatusCompleted,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			given := testabilities.Given(t)
+
+			// given:
+			activeStorage := given.Provider().GORM()
+
+			// and:
+			spec, _ := given.Faucet(activeStorage, testusers.Alice).TopUp(100_000)
+
+			// when:
+			err := activeStorage.ProcessArcCallback(context.Background(), storage.ArcCallback{
+				TxID:      spec.ID(),
+				TxStatus:  test.txStatus,
+				ExtraInfo: "some extra info",
+			})
+
+			// then:
+			require.NoError(t, err)
+
+			provenTxReq := given.StoredProvenTxReq(spec.ID())
+			assert.Equal(t, test.expectedProvenTxStatus, provenTxReq.Status)
+			assert.Equal(t, test.expectedTransactionState, given.StoredTransaction(testabilities.MockReference).Status)
+			assert.Nil(t, given.StoredProvenTx(spec.ID()))
+
+			// and:
+			notes := provenTxReq.History.Data().Notes
+			lastNote := notes[len(notes)-1]
+			assert.Equal(t, "arcCallback", lastNote.What)
+			assert.Equal(t, test.txStatus, lastNote.Attrs["txStatus"])
+			assert.Equal(t, "some extra info", lastNote.Attrs["extraInfo"])
+		})
+	}
+}
+
+func TestArcCallbackMined(t *testing.T) {
+	t.Run("stores the proof and completes the proven tx req", func(t *testing.T) {
+		given := testabilities.Given(t)
+
+		// given:
+		activeStorage := given.Provider().WithChainTracker(&chainTrackerMock{valid: true}).GORM()
+
+		// and:
+		spec, _ := given.Faucet(activeStorage, testusers.Alice).TopUp(100_000)
+		merklePath := givenMerklePath(t, spec.ID())
+
+		// when:
+		err := activeStorage.ProcessArcCallback(context.Background(), storage.ArcCallback{
+			TxID:        spec.ID(),
+			TxStatus:    storage.ArcStatusMined,
+			BlockHash:   callbackBlockHash,
+			BlockHeight: callbackHeight,
+			MerklePath:  merklePath.Hex(),
+		})
+
+		// then:
+		require.NoError(t, err)
+		assert.Equal(t, wdk.ProvenTxStatusCompleted, given.StoredProvenTxReq(spec.ID()).Status)
+		assert.Equal(t, wdk.TxStatusCompleted, given.StoredTransaction(testabilities.MockReference).Status)
+
+		// and:
+		expectedRoot, err := merklePath.ComputeRootHex(to.Ptr(spec.ID()))
+		require.NoError(t, err)
+
+		provenTx := given.StoredProvenTx(spec.ID())
+		require.NotNil(t, provenTx)
+		assert.Equal(t, uint32(callbackHeight), provenTx.Height)
+		assert.Equal(t, callbackBlockHash, provenTx.BlockHash)
+		assert.Equal(t, expectedRoot, provenTx.MerkleRoot)
+		assert.Equal(t, merklePath.Bytes(), provenTx.MerklePath)
+		assert.Equal(t, spec.TX().Bytes(), provenTx.RawTx)
+	})
+
+	t.Run("doesn't change the status of completed proven tx req", func(t *testing.T) {
+		given := testabilities.Given(t)
+
+		// given:
+		activeStorage := given.Provider().WithChainTracker(&chainTrackerMock{valid: true}).GORM()
+
+		// and:
+		spec, _ := given.Faucet(activeStorage, testusers.Alice).TopUp(100_000)
+		err := activeStorage.ProcessArcCallback(context.Background(), storage.ArcCallback{
+			TxID:        spec.ID(),
+			TxStatus:    storage.ArcStatusMined,
+			BlockHash:   callbackBlockHash,
+			BlockHeight: callbackHeight,
+			MerklePath:  givenMerklePath(t, spec.ID()).Hex(),
+		})
+		require.NoError(t, err)
+
+		// when:
+		err = activeStorage.ProcessArcCallback(context.Background(), storage.ArcCallback{
+			TxID:     spec.ID(),
+			TxStatus: storage.ArcStatusSeenOnNetwork,
+		})
+
+		// then:
+		require.NoError(t, err)
+		assert.Equal(t, wdk.ProvenTxStatusCompleted, given.StoredProvenTxReq(spec.ID()).Status)
+	})
+
+	t.Run("rejects merkle path of other transaction", func(t *testing.T) {
+		given := testabilities.Given(t)
+
+		// given:
+		activeStorage := given.Provider().WithChainTracker(&chainTrackerMock{valid: true}).GORM()
+
+		// and:
+		spec, _ := given.Faucet(activeStorage, testusers.Alice).TopUp(100_000)
+
+		// when:
+		err := activeStorage.ProcessArcCallback(context.Background(), storage.ArcCallback{
+			TxID:        spec.ID(),
+			TxStatus:    storage.ArcStatusMined,
+			BlockHash:   callbackBlockHash,
+			BlockHeight: callbackHeight,
+			MerklePath:  givenMerklePath(t, "0f0e0d0c0b0a09080706050403020100f0e0d0c0b0a090807060504030201000").Hex(),
+		})
+
+		// then:
+		require.Error(t, err)
+		assert.Equal(t, wdk.ProvenTxStatusUnmined, given.StoredProvenTxReq(spec.ID()).Status)
+	})
+
+	t.Run("rejects merkle path with root unknown to the chain tracker", func(t *testing.T) {
+		given := testabilities.Given(t)
+
+		// given:
+		chainTracker := &chainTrackerMock{valid: false}
+		activeStorage := given.Provider().WithChainTracker(chainTracker).GORM()
+
+		// and:
+		spec, _ := given.Faucet(activeStorage, testusers.Alice).TopUp(100_000)
+
+		// when:
+		err := activeStorage.ProcessArcCallback(context.Background(), storage.ArcCallback{
+			TxID:        spec.ID(),
+			TxStatus:    storage.ArcStatusMined,
+			BlockHash:   callbackBlockHash,
+			BlockHeight: callbackHeight,
+			MerklePath:  givenMerklePath(t, spec.ID()).Hex(),
+		})
+
+		// then:
+		require.Error(t, err)
+		assert.Equal(t, []uint32{callbackHeight}, chainTracker.heights)
+		assert.Equal(t, wdk.ProvenTxStatusUnmined, given.StoredProvenTxReq(spec.ID()).Status)
+		assert.Nil(t, given.StoredProvenTx(spec.ID()))
+	})
+
+	t.Run("rejects mined transaction without chain tracker", func(t *testing.T) {
+		given := testabilities.Given(t)
+
+		// given:
+		activeStorage := given.Provider().GORM()
+
+		// and:
+		spec, _ := given.Faucet(activeStorage, testusers.Alice).TopUp(100_000)
+
+		// when:
+		err := activeStorage.ProcessArcCallback(context.Background(), storage.ArcCallback{
+			TxID:        spec.ID(),
+			TxStatus:    storage.ArcStatusMined,
+			BlockHash:   callbackBlockHash,
+			BlockHeight: callbackHeight,
+			MerklePath:  givenMerklePath(t, spec.ID()).Hex(),
+		})
+
+		// then:
+		require.Error(t, err)
+		assert.Equal(t, wdk.ProvenTxStatusUnmined, given.StoredProvenTxReq(spec.ID()).Status)
+	})
+}
+
+func TestArcCallbackAfterFinalStatus(t *testing.T) {
+	tests := map[string]struct {
+		finalTxStatus            string
+		expectedProvenTxStatus   wdk.ProvenTxReqStatus
+		expectedTransactionState wdk.TxStatus
+	}{
+		"rejected": {
+			finalTxStatus:            storage.ArcStatusRejected,
+			expectedProvenTxStatus:   wdk.ProvenTxStatusInvalid,
+			expectedTransactionState: wdk.TxStatusFailed,
+		},
+		"mined": {
+			finalTxStatus:            storage.ArcStatusMined,
+			expectedProvenTxStatus:   wdk.ProvenTxStatusCompleted,
+			expectedTransactionState: wdk.TxStatusCompleted,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			given := testabilities.Given(t)
+
+			// given:
+			activeStorage := given.Provider().WithChainTracker(&chainTrackerMock{valid: true}).GORM()
+
+			// and:
+			spec, _ := given.Faucet(activeStorage, testusers.Alice).TopUp(100_000)
+
+			// and:
+			err := activeStorage.ProcessArcCallback(context.Background(), storage.ArcCallback{
+				TxID:         spec.ID(),
+				TxStatus:     storage.ArcStatusDoubleSpendAttempted,
+				CompetingTxs: []string{"0f0e0d0c0b0a09080706050403020100f0e0d0c0b0a090807060504030201000"},
+			})
+			require.NoError(t, err)
+
+			// and:
+			err = activeStorage.ProcessArcCallback(context.Background(), storage.ArcCallback{
+				TxID:        spec.ID(),
+				TxStatus:    test.finalTxStatus,
+				BlockHash:   callbackBlockHash,
+				BlockHeight: callbackHeight,
+				MerklePath:  givenMerklePath(t, spec.ID()).Hex(),
+			})
+			require.NoError(t, err)
+
+			// when:
+			err = activeStorage.ProcessArcCallback(context.Background(), storage.ArcCallback{
+				TxID:     spec.ID(),
+				TxStatus: storage.ArcStatusSeenOnNetwork,
+			})
+
+			// then:
+			require.NoError(t, err)
+			assert.Equal(t, test.expectedProvenTxStatus, given.StoredProvenTxReq(spec.ID()).Status)
+			assert.Equal(t, test.expectedTransactionState, given.StoredTransaction(testabilities.MockReference).Status)
+		})
+	}
+}
+
+func TestArcCallbackHandler(t *testing.T) {
+	tests := map[string]struct {
+		token          string
+		authorization  string
+		txID           func(spec string) string
+		expectedStatus int
+	}{
+		"accepts callback with valid token": {
+			token:          callbackToken,
+			authorization:  "Bearer " + callbackToken,
+			txID:           func(spec string) string { return spec },
+			expectedStatus: http.StatusOK,
+		},
+		"rejects callback with invalid token": {
+			token:          callbackToken,
+			authorization:  "Bearer other-token",
+			txID:           func(spec string) string { return spec },
+			expectedStatus: http.StatusUnauthorized,
+		},
+		"rejects callback when no token is configured": {
+			token:          "",
+			authorization:  "Bearer ",
+			txID:           func(spec string) string { return spec },
+			expectedStatus: http.StatusUnauthorized,
+		},
+		"accepts callback for unknown transaction": {
+			token:          callbackToken,
+			authorization:  "Bearer " + callbackToken,
+			txID:           func(string) string { return "0f0e0d0c0b0a09080706050403020100f0e0d0c0b0a090807060504030201000" },
+			expectedStatus: http.StatusOK,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			given := testabilities.Given(t)
+
+			// given:
+			activeStorage := given.Provider().GORM()
+			spec, _ := given.Faucet(activeStorage, testusers.Alice).TopUp(100_000)
+
+			// and:
+			server := httptest.NewServer(storage.NewArcCallbackHandler(nil, activeStorage, test.token))
+			defer server.Close()
+
+			// and:
+			body, err := json.Marshal(storage.ArcCallback{
+				TxID:     test.txID(spec.ID()),
+				TxStatus: storage.ArcStatusSeenOnNetwork,
+			})
+			require.NoError(t, err)
+
+			req, err := http.NewRequestWithContext(context.Background(), http.MethodPost, server.URL, bytes.NewReader(body))
+			require.NoError(t, err)
+			req.Header.Set("Authorization", test.authorization)
+			req.Header.Set("Content-Type", "application/json")
+
+			// when:
+			res, err := server.Client().Do(req)
+
+			// then:
+			require.NoError(t, err)
+			defer res.Body.Close()
+			assert.Equal(t, test.expectedStatus, res.StatusCode)
+		})
+	}
+}
+
+func givenMerklePath(t *testing.T, txID string) *transaction.MerklePath {
+	txHash, err := chainhash.NewHashFromHex(txID)
+	require.NoError(t, err)
+
+	sibling, err := chainhash.NewHashFromHex(callbackBlockHash)
+	require.NoError(t, err)
+
+	return transaction.NewMerklePath(callbackHeight, [][]*transaction.PathElement{{
+		{Offset: 0, Hash: txHash, Txid: to.Ptr(true)},
+		{Offset: 1, Hash: sibling},
+	}})
+}
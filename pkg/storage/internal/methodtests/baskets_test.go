@@ -0,0 +1,254 @@
+package methodtests
+
+import (
+	"context"
+	"testing"
+
+	"github.com/4chain-ag/go-wallet-toolbox/pkg/internal/fixtures"
+	"github.com/4chain-ag/go-wallet-toolbox/pkg/storage"
+	"github.com/4chain-ag/go-wallet-toolbox/pkg/storage/internal/testabilities"
+	"github.com/4chain-ag/go-wallet-toolbox/pkg/storage/internal/testabilities/testusers"
+	"github.com/4chain-ag/go-wallet-toolbox/pkg/wdk"
+	"github.com/4chain-ag/go-wallet-toolbox/pkg/wdk/primitives"
+	"github.com/go-softwarelab/common/pkg/to"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestListBasketsNilAuth(t *testing.T) {
+	given := testabilities.Given(t)
+
+	// given:
+	activeStorage := given.Provider().GORM()
+
+	// when:
+	_, err := activeStorage.ListBaskets(context.Background(), wdk.AuthID{UserID: nil})
+
+	// then:
+	require.Error(t, err)
+}
+
+func TestListBaskets(t *testing.T) {
+	given := testabilities.Given(t)
+
+	// given:
+	activeStorage := given.Provider().GORM()
+
+	// and:
+	given.Faucet(activeStorage, testusers.Alice).TopUp(100_000)
+	internalizeOutputToCustomBasket(t, activeStorage)
+
+	// when:
+	result, err := activeStorage.ListBaskets(context.Background(), testusers.Alice.AuthID())
+
+	// then:
+	require.NoError(t, err)
+	require.Len(t, result.Baskets, 2)
+
+	assert.Equal(t, fixtures.CustomBasket, result.Baskets[0].Name)
+	assert.Equal(t, uint64(1), result.Baskets[0].UTXOsCount)
+	assert.Equal(t, primitives.SatoshiValue(fixtures.ExpectedValueToInternalize), result.Baskets[0].Balance)
+
+	assert.Equal(t, &wdk.OutputBasketSummary{
+		BasketConfiguration: wdk.DefaultBasketConfiguration(),
+		UTXOsCount:          1,
+		Balance:             100_000,
+	}, result.Baskets[1])
+}
+
+func TestListBasketsOfOtherUser(t *testing.T) {
+	given := testabilities.Given(t)
+
+	// given:
+	activeStorage := given.Provider().GORM()
+
+	// and:
+	given.Faucet(activeStorage, testusers.Alice).TopUp(100_000)
+
+	// when:
+	result, err := activeStorage.ListBaskets(context.Background(), testusers.Bob.AuthID())
+
+	// then:
+	require.NoError(t, err)
+	require.Len(t, result.Baskets, 1)
+	assert.Equal(t, wdk.BasketNameForChange, result.Baskets[0].Name)
+	assert.Equal(t, uint64(0), result.Baskets[0].UTXOsCount)
+	assert.Equal(t, primitives.SatoshiValue(0), result.Baskets[0].Balance)
+}
+
+func TestUpdateBasketConfiguration(t *testing.T) {
+	tests := map[string]struct {
+		args     wdk.UpdateBasketConfigurationArgs
+		expected wdk.BasketConfiguration
+	}{
+		"both parameters": {
+			args: wdk.UpdateBasketConfigurationArgs{
+				Name:                    wdk.BasketNameForChange,
+				NumberOfDesiredUTXOs:    to.Ptr(int64(10)),
+				MinimumDesiredUTXOValue: to.Ptr(uint64(5000)),
+			},
+			expected: wdk.BasketConfiguration{
+				Name:                    wdk.BasketNameForChange,
+				NumberOfDesiredUTXOs:    10,
+				MinimumDesiredUTXOValue: 5000,
+			},
+		},
+		"only number of desired UTXOs": {
+			args: wdk.UpdateBasketConfigurationArgs{
+				Name:                 wdk.BasketNameForChange,
+				NumberOfDesiredUTXOs: to.Ptr(int64(0)),
+			},
+			expected: wdk.BasketConfiguration{
+				Name:                    wdk.BasketNameForChange,
+				NumberOfDesiredUTXOs:    0,
+				MinimumDesiredUTXOValue: wdk.MinimumDesiredUTXOValueForChange,
+			},
+		},
+		"only minimum desired UTXO value": {
+			args: wdk.UpdateBasketConfigurationArgs{
+				Name:                    wdk.BasketNameForChange,
+				MinimumDesiredUTXOValue: to.Ptr(uint64(2000)),
+			},
+			expected: wdk.BasketConfiguration{
+				Name:                    wdk.BasketNameForChange,
+				NumberOfDesiredUTXOs:    wdk.NumberOfDesiredUTXOsForChange,
+				MinimumDesiredUTXOValue: 2000,
+			},
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			given := testabilities.Given(t)
+
+			// given:
+			activeStorage := given.Provider().GORM()
+
+			// when:
+			err := activeStorage.UpdateBasketConfiguration(context.Background(), testusers.Alice.AuthID(), test.args)
+
+			// then:
+			require.NoError(t, err)
+
+			baskets := listedBaskets(t, activeStorage, testusers.Alice)
+			require.Len(t, baskets, 1)
+			assert.Equal(t, test.expected, baskets[0].BasketConfiguration)
+		})
+	}
+}
+
+func TestUpdateBasketConfigurationErrorCases(t *testing.T) {
+	tests := map[string]struct {
+		auth wdk.AuthID
+		args wdk.UpdateBasketConfigurationArgs
+	}{
+		"nil auth": {
+			auth: wdk.AuthID{UserID: nil},
+			args: *fixtures.DefaultValidUpdateBasketConfigurationArgs(),
+		},
+		"unknown basket": {
+			auth: testusers.Alice.AuthID(),
+			args: *fixtures.DefaultValidUpdateBasketConfigurationArgs(),
+		},
+		"invalid args": {
+			auth: testusers.Alice.AuthID(),
+			args: wdk.UpdateBasketConfigurationArgs{Name: wdk.BasketNameForChange},
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			given := testabilities.Given(t)
+
+			// given:
+			activeStorage := given.Provider().GORM()
+
+			// when:
+			err := activeStorage.UpdateBasketConfiguration(context.Background(), test.auth, test.args)
+
+			// then:
+			require.Error(t, err)
+		})
+	}
+}
+
+func TestRemoveEmptyBasket(t *testing.T) {
+	given := testabilities.Given(t)
+
+	// given:
+	activeStorage := given.Provider().GORM()
+
+	// and:
+	given.Faucet(activeStorage, testusers.Alice).TopUp(100_000)
+
+	// and: basket created by createAction holds only not yet spendable outputs
+	args := fixtures.DefaultValidCreateActionArgs()
+	args.Outputs[0].Basket = to.Ptr(primitives.StringUnder300(fixtures.CustomBasket))
+	_, err := activeStorage.CreateAction(context.Background(), testusers.Alice.AuthID(), args)
+	require.NoError(t, err)
+
+	// when:
+	err = activeStorage.RemoveBasket(context.Background(), testusers.Alice.AuthID(), *fixtures.DefaultValidRemoveBasketArgs())
+
+	// then:
+	require.NoError(t, err)
+
+	baskets := listedBaskets(t, activeStorage, testusers.Alice)
+	require.Len(t, baskets, 1)
+	assert.Equal(t, wdk.BasketNameForChange, baskets[0].Name)
+
+	// when:
+	internalizeOutputToCustomBasket(t, activeStorage)
+
+	// then:
+	baskets = listedBaskets(t, activeStorage, testusers.Alice)
+	require.Len(t, baskets, 2)
+	assert.Equal(t, fixtures.CustomBasket, baskets[0].Name)
+	assert.Equal(t, uint64(1), baskets[0].UTXOsCount)
+}
+
+func TestRemoveBasketErrorCases(t *testing.T) {
+	tests := map[string]struct {
+		auth wdk.AuthID
+		args wdk.RemoveBasketArgs
+	}{
+		"nil auth": {
+			auth: wdk.AuthID{UserID: nil},
+			args: *fixtures.DefaultValidRemoveBasketArgs(),
+		},
+		"not empty basket": {
+			auth: testusers.Alice.AuthID(),
+			args: *fixtures.DefaultValidRemoveBasketArgs(),
+		},
+		"basket of other user": {
+			auth: testusers.Bob.AuthID(),
+			args: *fixtures.DefaultValidRemoveBasketArgs(),
+		},
+		"basket for change": {
+			auth: testusers.Alice.AuthID(),
+			args: wdk.RemoveBasketArgs{Name: wdk.BasketNameForChange},
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			given := testabilities.Given(t)
+
+			// given:
+			activeStorage := given.Provider().GORM()
+			internalizeOutputToCustomBasket(t, activeStorage)
+
+			// when:
+			err := activeStorage.RemoveBasket(context.Background(), test.auth, test.args)
+
+			// then:
+			require.Error(t, err)
+		})
+	}
+}
+
+func listedBaskets(t *testing.T, activeStorage *storage.Provider, user testusers.User) []*wdk.OutputBasketSummary {
+	t.Helper()
+
+	result, err := activeStorage.ListBaskets(context.Background(), user.AuthID())
+	require.NoError(t, err)
+
+	return result.Baskets
+}
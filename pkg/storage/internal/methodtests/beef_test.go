@@ -0,0 +1,286 @@
+package methodtests
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/4chain-ag/go-wallet-toolbox/pkg/storage"
+	"github.com/4chain-ag/go-wallet-toolbox/pkg/storage/internal/testabilities"
+	"github.com/4chain-ag/go-wallet-toolbox/pkg/storage/internal/testabilities/testusers"
+	"github.com/4chain-ag/go-wallet-toolbox/pkg/wdk"
+	"github.com/bsv-blockchain/go-sdk/chainhash"
+	"github.com/bsv-blockchain/go-sdk/transaction"
+	txtestabilities "github.com/bsv-blockchain/universal-test-vectors/pkg/testabilities"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// beefServicesMock serves the given transactions, the ones with merkle path are treated as mined
+type beefServicesMock struct {
+	txs          map[string]*transaction.Transaction
+	rawTxQueries []string
+	// merklePathErr is returned instead of no merkle path for not mined transactions
+	merklePathErr error
+}
+
+func newBeefServicesMock(txs ...*transaction.Transaction) *beefServicesMock {
+	mock := &beefServicesMock{txs: map[string]*transaction.Transaction{}}
+	for _, tx := range txs {
+		mock.txs[tx.TxID().String()] = tx
+	}
+	return mock
+}
+
+func (m *beefServicesMock) RawTx(txID string) (wdk.RawTxResult, error) {
+	m.rawTxQueries = append(m.rawTxQueries, txID)
+	tx, ok := m.txs[txID]
+	if !ok {
+		return wdk.RawTxResult{}, fmt.Errorf("transaction with txID: %s not found", txID)
+	}
+	return wdk.RawTxResult{TxID: txID, Name: "mock", RawTx: tx.Bytes()}, nil
+}
+
+func (m *beefServicesMock) MinedMerklePath(_ context.Context, txID string) (*transaction.MerklePath, error) {
+	tx, ok := m.txs[txID]
+	if !ok || tx.MerklePath == nil {
+		return nil, m.merklePathErr
+	}
+	return tx.MerklePath, nil
+}
+
+// chainTrackerMock accepts every merkle root when valid is true
+type chainTrackerMock struct {
+	valid   bool
+	heights []uint32
+}
+
+func (m *chainTrackerMock) IsValidRootForHeight(_ *chainhash.Hash, height uint32) (bool, error) {
+	m.heights = append(m.heights, height)
+	return m.valid, nil
+}
+
+func TestGetBeefForTransactionFromStorage(t *testing.T) {
+	t.Run("includes stored transaction with ancestors from its input beef", func(t *testing.T) {
+		given := testabilities.Given(t)
+
+		// given:
+		activeStorage := given.Provider().GORM()
+		spec, _ := given.Faucet(activeStorage, testusers.Alice).TopUp(100_000)
+		parentTxID := spec.TX().Inputs[0].SourceTXID.String()
+
+		// when:
+		beef, err := activeStorage.GetBeefForTransaction(context.Background(), spec.ID(), storage.BeefOptions{})
+
+		// then:
+		require.NoError(t, err)
+		require.Len(t, beef.Transactions, 2)
+		assert.Equal(t, spec.ID(), beef.FindTransaction(spec.ID()).TxID().String())
+		assert.NotNil(t, beef.FindBump(parentTxID))
+	})
+
+	t.Run("includes only the stored proof of mined transaction", func(t *testing.T) {
+		given := testabilities.Given(t)
+
+		// given:
+		activeStorage := given.Provider().WithChainTracker(&chainTrackerMock{valid: true}).GORM()
+		spec, _ := given.Faucet(activeStorage, testusers.Alice).TopUp(100_000)
+		givenMinedByArc(t, activeStorage, spec.ID())
+
+		// when:
+		beef, err := activeStorage.GetBeefForTransaction(context.Background(), spec.ID(), storage.BeefOptions{})
+
+		// then:
+		require.NoError(t, err)
+		require.Len(t, beef.Transactions, 1)
+		assert.NotNil(t, beef.FindBump(spec.ID()))
+	})
+
+	t.Run("includes mined transaction as txid only when trusting self", func(t *testing.T) {
+		given := testabilities.Given(t)
+
+		// given:
+		activeStorage := given.Provider().WithChainTracker(&chainTrackerMock{valid: true}).GORM()
+		spec, _ := given.Faucet(activeStorage, testusers.Alice).TopUp(100_000)
+		givenMinedByArc(t, activeStorage, spec.ID())
+
+		// when:
+		beef, err := activeStorage.GetBeefForTransaction(context.Background(), spec.ID(), storage.BeefOptions{TrustSelf: true})
+
+		// then:
+		require.NoError(t, err)
+		require.Len(t, beef.Transactions, 1)
+		assert.Equal(t, transaction.TxIDOnly, beef.Transactions[spec.ID()].DataFormat)
+	})
+
+	t.Run("includes known ancestors as txid only", func(t *testing.T) {
+		given := testabilities.Given(t)
+
+		// given:
+		activeStorage := given.Provider().GORM()
+		spec, _ := given.Faucet(activeStorage, testusers.Alice).TopUp(100_000)
+		parentTxID := spec.TX().Inputs[0].SourceTXID.String()
+
+		// when:
+		beef, err := activeStorage.GetBeefForTransaction(context.Background(), spec.ID(), storage.BeefOptions{
+			KnownTxIDs: []string{parentTxID},
+		})
+
+		// then:
+		require.NoError(t, err)
+		require.Len(t, beef.Transactions, 2)
+		assert.Equal(t, transaction.TxIDOnly, beef.Transactions[parentTxID].DataFormat)
+		assert.Nil(t, beef.FindBump(parentTxID))
+	})
+}
+
+func TestGetBeefForTransactionFromServices(t *testing.T) {
+	t.Run("fetches transactions not known to storage from services", func(t *testing.T) {
+		// given:
+		parent := txtestabilities.GivenTX().WithInput(1001).WithP2PKHOutput(1000)
+		child := txtestabilities.GivenTX().WithInputFromUTXO(parent.TX(), 0).WithP2PKHOutput(999)
+		grandparent := parent.TX().Inputs[0].SourceTransaction
+
+		// and:
+		servicesMock := newBeefServicesMock(child.TX(), parent.TX(), grandparent)
+
+		// and:
+		given := testabilities.Given(t)
+		activeStorage := given.Provider().WithBeefServices(servicesMock).GORM()
+
+		// when:
+		beef, err := activeStorage.GetBeefForTransaction(context.Background(), child.ID(), storage.BeefOptions{})
+
+		// then:
+		require.NoError(t, err)
+		require.Len(t, beef.Transactions, 3)
+		assert.NotNil(t, beef.FindTransaction(child.ID()))
+		assert.NotNil(t, beef.FindTransaction(parent.ID()))
+		assert.NotNil(t, beef.FindBump(grandparent.TxID().String()))
+	})
+
+	t.Run("includes ancestors of transaction when services fail to get its merkle path", func(t *testing.T) {
+		// given:
+		parent := txtestabilities.GivenTX().WithInput(1001).WithP2PKHOutput(1000)
+		child := txtestabilities.GivenTX().WithInputFromUTXO(parent.TX(), 0).WithP2PKHOutput(999)
+		grandparent := parent.TX().Inputs[0].SourceTransaction
+
+		// and:
+		servicesMock := newBeefServicesMock(child.TX(), parent.TX(), grandparent)
+		servicesMock.merklePathErr = fmt.Errorf("service unavailable")
+
+		// and:
+		given := testabilities.Given(t)
+		activeStorage := given.Provider().WithBeefServices(servicesMock).GORM()
+
+		// when:
+		beef, err := activeStorage.GetBeefForTransaction(context.Background(), child.ID(), storage.BeefOptions{})
+
+		// then:
+		require.NoError(t, err)
+		require.Len(t, beef.Transactions, 3)
+		assert.NotNil(t, beef.FindBump(grandparent.TxID().String()))
+	})
+
+	t.Run("verifies merkle roots with the chain tracker", func(t *testing.T) {
+		// given:
+		parent := txtestabilities.GivenTX().WithInput(1001).WithP2PKHOutput(1000)
+		grandparent := parent.TX().Inputs[0].SourceTransaction
+
+		// and:
+		chainTracker := &chainTrackerMock{valid: true}
+
+		// and:
+		given := testabilities.Given(t)
+		activeStorage := given.Provider().
+			WithBeefServices(newBeefServicesMock(parent.TX(), grandparent)).
+			WithChainTracker(chainTracker).
+			GORM()
+
+		// when:
+		_, err := activeStorage.GetBeefForTransaction(context.Background(), parent.ID(), storage.BeefOptions{})
+
+		// then:
+		require.NoError(t, err)
+		assert.Equal(t, []uint32{grandparent.MerklePath.BlockHeight}, chainTracker.heights)
+	})
+
+	t.Run("fails when merkle root is not valid for the chain tracker", func(t *testing.T) {
+		// given:
+		parent := txtestabilities.GivenTX().WithInput(1001).WithP2PKHOutput(1000)
+		grandparent := parent.TX().Inputs[0].SourceTransaction
+
+		// and:
+		given := testabilities.Given(t)
+		activeStorage := given.Provider().
+			WithBeefServices(newBeefServicesMock(parent.TX(), grandparent)).
+			WithChainTracker(&chainTrackerMock{valid: false}).
+			GORM()
+
+		// when:
+		_, err := activeStorage.GetBeefForTransaction(context.Background(), parent.ID(), storage.BeefOptions{})
+
+		// then:
+		require.Error(t, err)
+	})
+
+	t.Run("prefers stored transactions over services", func(t *testing.T) {
+		given := testabilities.Given(t)
+
+		// given:
+		servicesMock := newBeefServicesMock()
+		activeStorage := given.Provider().WithBeefServices(servicesMock).GORM()
+		spec, _ := given.Faucet(activeStorage, testusers.Alice).TopUp(100_000)
+
+		// when:
+		_, err := activeStorage.GetBeefForTransaction(context.Background(), spec.ID(), storage.BeefOptions{})
+
+		// then:
+		require.NoError(t, err)
+		assert.Empty(t, servicesMock.rawTxQueries)
+	})
+
+	t.Run("fails when not mined ancestors exceed the maximal depth", func(t *testing.T) {
+		// given:
+		parent := txtestabilities.GivenTX().WithInput(1001).WithP2PKHOutput(1000)
+		child := txtestabilities.GivenTX().WithInputFromUTXO(parent.TX(), 0).WithP2PKHOutput(999)
+		grandchild := txtestabilities.GivenTX().WithInputFromUTXO(child.TX(), 0).WithP2PKHOutput(998)
+		grandparent := parent.TX().Inputs[0].SourceTransaction
+
+		// and:
+		given := testabilities.Given(t)
+		activeStorage := given.Provider().WithBeefServices(newBeefServicesMock(grandchild.TX(), child.TX(), parent.TX(), grandparent)).GORM()
+
+		// when:
+		_, err := activeStorage.GetBeefForTransaction(context.Background(), grandchild.ID(), storage.BeefOptions{MaxDepth: 1})
+
+		// then:
+		require.Error(t, err)
+	})
+
+	t.Run("fails when the transaction is unknown", func(t *testing.T) {
+		given := testabilities.Given(t)
+
+		// given:
+		activeStorage := given.Provider().GORM()
+
+		// when:
+		_, err := activeStorage.GetBeefForTransaction(context.Background(), "0f0e0d0c0b0a09080706050403020100f0e0d0c0b0a090807060504030201000", storage.BeefOptions{})
+
+		// then:
+		require.Error(t, err)
+	})
+}
+
+func givenMinedByArc(t *testing.T, activeStorage *storage.Provider, txID string) {
+	t.Helper()
+
+	err := activeStorage.ProcessArcCallback(context.Background(), storage.ArcCallback{
+		TxID:        txID,
+		TxStatus:    storage.ArcStatusMined,
+		BlockHash:   callbackBlockHash,
+		BlockHeight: callbackHeight,
+		MerklePath:  givenMerklePath(t, txID).Hex(),
+	})
+	require.NoError(t, err)
+}
@@ -0,0 +1,72 @@
+package methodtests
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/4chain-ag/go-wallet-toolbox/pkg/internal/fixtures"
+	"github.com/4chain-ag/go-wallet-toolbox/pkg/internal/satoshi"
+	"github.com/4chain-ag/go-wallet-toolbox/pkg/storage/internal/testabilities"
+	"github.com/4chain-ag/go-wallet-toolbox/pkg/storage/internal/testabilities/testusers"
+	"github.com/4chain-ag/go-wallet-toolbox/pkg/wdk"
+	"github.com/4chain-ag/go-wallet-toolbox/pkg/wdk/primitives"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConcurrentCreateActionsDontDoubleAllocateUTXOs(t *testing.T) {
+	// more parallel actions than the funding attempts of a single createAction, so most of them must back off and retry
+	// NOTE: run with TEST_DB_MODE=postgres to check the row locking of an actual Postgres
+	const parallelActions = 25
+
+	given := testabilities.Given(t)
+
+	// given:
+	activeStorage := given.Provider().GORM()
+
+	// and: each top-up has different amount, so the faucet transactions are distinct
+	var toppedUp satoshi.Value
+	for i := range parallelActions {
+		amount := satoshi.Value(100_000 + i)
+		given.Faucet(activeStorage, testusers.Alice).TopUp(amount)
+		toppedUp = satoshi.MustAdd(toppedUp, amount)
+	}
+
+	// when:
+	results := make([]*wdk.StorageCreateActionResult, parallelActions)
+	errs := make([]error, parallelActions)
+
+	start := make(chan struct{})
+	var wg sync.WaitGroup
+	for i := range parallelActions {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			<-start
+			results[i], errs[i] = activeStorage.CreateAction(context.Background(), testusers.Alice.AuthID(), fixtures.DefaultValidCreateActionArgs())
+		}()
+	}
+	close(start)
+	wg.Wait()
+
+	// then:
+	allocated := map[string]int{}
+	for i := range parallelActions {
+		require.NoError(t, errs[i])
+		for _, input := range results[i].Inputs {
+			outpoint := fmt.Sprintf("%s.%d", input.SourceTxID, input.SourceVout)
+			_, alreadyAllocated := allocated[outpoint]
+			assert.Falsef(t, alreadyAllocated, "utxo %s allocated by more than one action", outpoint)
+			allocated[outpoint] = i
+		}
+	}
+	assert.Len(t, allocated, parallelActions)
+
+	// and:
+	stats, err := activeStorage.WalletStats(context.Background(), testusers.Alice.AuthID())
+	require.NoError(t, err)
+	assert.Equal(t, primitives.SatoshiValue(0), stats.Spendable)
+	assert.Equal(t, primitives.SatoshiValue(toppedUp.MustUInt64()), stats.Reserved)
+}
@@ -0,0 +1,88 @@
+package methodtests
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/4chain-ag/go-wallet-toolbox/pkg/defs"
+	"github.com/4chain-ag/go-wallet-toolbox/pkg/internal/fixtures"
+	"github.com/4chain-ag/go-wallet-toolbox/pkg/storage/internal/testabilities"
+	"github.com/4chain-ag/go-wallet-toolbox/pkg/storage/internal/testabilities/testusers"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type feeModelFetcherFunc func(ctx context.Context) (defs.FeeModel, error)
+
+func (f feeModelFetcherFunc) MiningFeeModel(ctx context.Context) (defs.FeeModel, error) {
+	return f(ctx)
+}
+
+func TestCreateActionWithDynamicFeeModel(t *testing.T) {
+	tests := map[string]struct {
+		fetched          defs.FeeModel
+		fetchErr         error
+		expectRefreshErr bool
+		expectedFee      int64
+		expectedFeeModel string
+	}{
+		"fee model fetched from broadcaster": {
+			fetched:          defs.FeeModel{Type: defs.SatPerKB, Value: 100},
+			expectedFee:      200,
+			expectedFeeModel: "100 sat/kb (arc-policy)",
+		},
+		"fallback to static fee model on fetch error": {
+			fetchErr:         errors.New("arc unavailable"),
+			expectRefreshErr: true,
+			expectedFee:      2,
+			expectedFeeModel: "1 sat/kb (static)",
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			given := testabilities.Given(t)
+
+			// given:
+			activeStorage := given.Provider().
+				WithFeeModelFetcher(feeModelFetcherFunc(func(context.Context) (defs.FeeModel, error) {
+					return test.fetched, test.fetchErr
+				})).
+				GORM()
+
+			// and:
+			given.Faucet(activeStorage, testusers.Alice).TopUp(100_000)
+
+			// and:
+			err := activeStorage.RefreshFeeModel(context.Background())
+			if test.expectRefreshErr {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+
+			// when:
+			result, err := activeStorage.CreateAction(context.Background(), testusers.Alice.AuthID(), fixtures.DefaultValidCreateActionArgs())
+
+			// then:
+			require.NoError(t, err)
+
+			// and:
+			var inputs, outputs uint64
+			for _, input := range result.Inputs {
+				inputs += uint64(input.SourceSatoshis)
+			}
+			for _, output := range result.Outputs {
+				outputs += uint64(output.Satoshis)
+			}
+			assert.Equal(t, uint64(test.expectedFee), inputs-outputs)
+
+			// and:
+			stored := given.StoredTransaction(result.Reference)
+			require.NotNil(t, stored.Fee)
+			assert.Equal(t, test.expectedFee, *stored.Fee)
+			require.NotNil(t, stored.FeeModel)
+			assert.Equal(t, test.expectedFeeModel, *stored.FeeModel)
+		})
+	}
+}
@@ -0,0 +1,121 @@
+package methodtests
+
+import (
+	"context"
+	"testing"
+
+	"github.com/4chain-ag/go-wallet-toolbox/pkg/internal/fixtures"
+	"github.com/4chain-ag/go-wallet-toolbox/pkg/storage/internal/testabilities"
+	"github.com/4chain-ag/go-wallet-toolbox/pkg/storage/internal/testabilities/testusers"
+	"github.com/4chain-ag/go-wallet-toolbox/pkg/storage/internal/testabilities/testutils"
+	"github.com/4chain-ag/go-wallet-toolbox/pkg/wdk"
+	"github.com/4chain-ag/go-wallet-toolbox/pkg/wdk/primitives"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEstimateCreateActionNilAuth(t *testing.T) {
+	given := testabilities.Given(t)
+
+	// given:
+	activeStorage := given.Provider().GORM()
+
+	// when:
+	_, err := activeStorage.EstimateCreateAction(context.Background(), wdk.AuthID{UserID: nil}, fixtures.DefaultValidCreateActionArgs())
+
+	// then:
+	require.Error(t, err)
+}
+
+func TestEstimateCreateActionHappyPath(t *testing.T) {
+	given := testabilities.Given(t)
+
+	// given:
+	activeStorage := given.Provider().GORM()
+
+	// and:
+	given.Faucet(activeStorage, testusers.Alice).TopUp(100_000)
+
+	// when:
+	estimate, err := activeStorage.EstimateCreateAction(context.Background(), testusers.Alice.AuthID(), fixtures.DefaultValidCreateActionArgs())
+
+	// then:
+	require.NoError(t, err)
+	assert.Equal(t, primitives.SatoshiValue(2), estimate.Fee)
+	assert.Equal(t, "1 sat/kb (static)", estimate.FeeModel)
+	assert.Equal(t, uint64(31), estimate.ChangeCount)
+	assert.Equal(t, primitives.SatoshiValue(57_998), estimate.ChangeSatoshis)
+	assert.Greater(t, estimate.Size, uint64(1_000))
+	assert.LessOrEqual(t, estimate.Size, uint64(2_000))
+
+	require.Len(t, estimate.Inputs, 1)
+	assert.Equal(t, int64(100_000), estimate.Inputs[0].SourceSatoshis)
+	assert.Equal(t, wdk.ProvidedByStorage, estimate.Inputs[0].ProvidedBy)
+
+	// and: nothing is reserved
+	stats, err := activeStorage.WalletStats(context.Background(), testusers.Alice.AuthID())
+	require.NoError(t, err)
+	assert.Equal(t, primitives.SatoshiValue(100_000), stats.Spendable)
+	assert.Equal(t, primitives.SatoshiValue(0), stats.Reserved)
+}
+
+func TestEstimateCreateActionMatchesCreateAction(t *testing.T) {
+	given := testabilities.Given(t)
+
+	// given:
+	activeStorage := given.Provider().GORM()
+
+	// and:
+	given.Faucet(activeStorage, testusers.Alice).TopUp(100_000)
+
+	// and:
+	args := fixtures.DefaultValidCreateActionArgs()
+
+	// when:
+	estimate, err := activeStorage.EstimateCreateAction(context.Background(), testusers.Alice.AuthID(), args)
+	require.NoError(t, err)
+
+	// and:
+	result, err := activeStorage.CreateAction(context.Background(), testusers.Alice.AuthID(), args)
+	require.NoError(t, err)
+
+	// then:
+	require.Len(t, result.Inputs, len(estimate.Inputs))
+	for i, input := range result.Inputs {
+		assert.Equal(t, estimate.Inputs[i].SourceTxID, input.SourceTxID)
+		assert.Equal(t, estimate.Inputs[i].SourceVout, input.SourceVout)
+	}
+
+	// and:
+	changeCount := testutils.CountOutputsWithCondition(t, result.Outputs, testutils.ProvidedByStorageCondition)
+	assert.Equal(t, estimate.ChangeCount, uint64(changeCount))
+
+	change := testutils.SumOutputsWithCondition(t, result.Outputs, testutils.SatoshiValue, testutils.ProvidedByStorageCondition)
+	assert.Equal(t, estimate.ChangeSatoshis, change)
+
+	// and:
+	var inputs, outputs uint64
+	for _, input := range result.Inputs {
+		inputs += uint64(input.SourceSatoshis)
+	}
+	for _, output := range result.Outputs {
+		outputs += uint64(output.Satoshis)
+	}
+	assert.Equal(t, uint64(estimate.Fee), inputs-outputs)
+}
+
+func TestEstimateCreateActionInsufficientFunds(t *testing.T) {
+	given := testabilities.Given(t)
+
+	// given:
+	activeStorage := given.Provider().GORM()
+
+	// and:
+	given.Faucet(activeStorage, testusers.Alice).TopUp(1)
+
+	// when:
+	_, err := activeStorage.EstimateCreateAction(context.Background(), testusers.Alice.AuthID(), fixtures.DefaultValidCreateActionArgs())
+
+	// then:
+	require.Error(t, err)
+}
@@ -0,0 +1,229 @@
+package methodtests
+
+import (
+	"context"
+	"testing"
+
+	"github.com/4chain-ag/go-wallet-toolbox/pkg/internal/fixtures"
+	"github.com/4chain-ag/go-wallet-toolbox/pkg/storage"
+	"github.com/4chain-ag/go-wallet-toolbox/pkg/storage/internal/testabilities"
+	"github.com/4chain-ag/go-wallet-toolbox/pkg/storage/internal/testabilities/testusers"
+	"github.com/4chain-ag/go-wallet-toolbox/pkg/wdk"
+	"github.com/4chain-ag/go-wallet-toolbox/pkg/wdk/primitives"
+	"github.com/go-softwarelab/common/pkg/to"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestListOutputsNilAuth(t *testing.T) {
+	given := testabilities.Given(t)
+
+	// given:
+	activeStorage := given.Provider().GORM()
+
+	// when:
+	_, err := activeStorage.ListOutputs(context.Background(), wdk.AuthID{UserID: nil}, *fixtures.DefaultValidListOutputsArgs())
+
+	// then:
+	require.Error(t, err)
+}
+
+func TestListOutputsByTags(t *testing.T) {
+	tests := map[string]struct {
+		tags          []primitives.StringUnder300
+		mode          wdk.QueryMode
+		expectedCount int
+	}{
+		"any of the tags": {
+			tags:          []primitives.StringUnder300{"tag1", "unknown"},
+			mode:          wdk.QueryModeAny,
+			expectedCount: 1,
+		},
+		"all of the tags": {
+			tags:          []primitives.StringUnder300{"tag1", "tag2"},
+			mode:          wdk.QueryModeAll,
+			expectedCount: 1,
+		},
+		"not all of the tags": {
+			tags:          []primitives.StringUnder300{"tag1", "unknown"},
+			mode:          wdk.QueryModeAll,
+			expectedCount: 0,
+		},
+		"unknown tag": {
+			tags:          []primitives.StringUnder300{"unknown"},
+			mode:          wdk.QueryModeAny,
+			expectedCount: 0,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			given := testabilities.Given(t)
+
+			// given:
+			activeStorage := given.Provider().GORM()
+			internalizeOutputToCustomBasket(t, activeStorage)
+
+			// and:
+			args := fixtures.DefaultValidListOutputsArgs()
+			args.Tags = test.tags
+			args.TagQueryMode = test.mode
+
+			// when:
+			result, err := activeStorage.ListOutputs(context.Background(), testusers.Alice.AuthID(), *args)
+
+			// then:
+			require.NoError(t, err)
+			assert.Equal(t, primitives.PositiveInteger(test.expectedCount), result.TotalOutputs)
+			assert.Len(t, result.Outputs, test.expectedCount)
+		})
+	}
+}
+
+func TestListOutputsIncludeTags(t *testing.T) {
+	given := testabilities.Given(t)
+
+	// given:
+	activeStorage := given.Provider().GORM()
+	internalizeOutputToCustomBasket(t, activeStorage)
+
+	// and:
+	args := fixtures.DefaultValidListOutputsArgs()
+	args.Tags = nil
+	args.IncludeTags = to.Ptr(primitives.BooleanDefaultFalse(true))
+
+	// when:
+	result, err := activeStorage.ListOutputs(context.Background(), testusers.Alice.AuthID(), *args)
+
+	// then:
+	require.NoError(t, err)
+	require.Len(t, result.Outputs, 1)
+
+	output := result.Outputs[0]
+	assert.Equal(t, primitives.OutpointString(fixtures.OutputOutpoint), output.Outpoint)
+	assert.Equal(t, primitives.SatoshiValue(fixtures.ExpectedValueToInternalize), output.Satoshis)
+	assert.ElementsMatch(t, []string{"tag1", "tag2"}, output.Tags)
+}
+
+func TestListOutputsOfOtherUser(t *testing.T) {
+	given := testabilities.Given(t)
+
+	// given:
+	activeStorage := given.Provider().GORM()
+	internalizeOutputToCustomBasket(t, activeStorage)
+
+	// and:
+	args := fixtures.DefaultValidListOutputsArgs()
+	args.Tags = nil
+
+	// when:
+	result, err := activeStorage.ListOutputs(context.Background(), testusers.Bob.AuthID(), *args)
+
+	// then:
+	require.NoError(t, err)
+	assert.Equal(t, primitives.PositiveInteger(0), result.TotalOutputs)
+	assert.Empty(t, result.Outputs)
+}
+
+func TestAddAndRemoveOutputTags(t *testing.T) {
+	given := testabilities.Given(t)
+
+	// given:
+	activeStorage := given.Provider().GORM()
+	internalizeOutputToCustomBasket(t, activeStorage)
+
+	// when:
+	err := activeStorage.AddOutputTags(context.Background(), testusers.Alice.AuthID(), *fixtures.DefaultValidOutputTagsArgs())
+
+	// then:
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{"tag1", "tag2", fixtures.OutputTag}, listedTagsOfInternalizedOutput(t, activeStorage))
+
+	// when:
+	err = activeStorage.RemoveOutputTags(context.Background(), testusers.Alice.AuthID(), wdk.OutputTagsArgs{
+		Output: fixtures.OutputOutpoint,
+		Tags:   []primitives.StringUnder300{"tag1", fixtures.OutputTag},
+	})
+
+	// then:
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{"tag2"}, listedTagsOfInternalizedOutput(t, activeStorage))
+
+	// when:
+	err = activeStorage.AddOutputTags(context.Background(), testusers.Alice.AuthID(), *fixtures.DefaultValidOutputTagsArgs())
+
+	// then:
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{"tag2", fixtures.OutputTag}, listedTagsOfInternalizedOutput(t, activeStorage))
+}
+
+func TestOutputTagsErrorCases(t *testing.T) {
+	tests := map[string]struct {
+		auth wdk.AuthID
+		args wdk.OutputTagsArgs
+	}{
+		"nil auth": {
+			auth: wdk.AuthID{UserID: nil},
+			args: *fixtures.DefaultValidOutputTagsArgs(),
+		},
+		"output of other user": {
+			auth: testusers.Bob.AuthID(),
+			args: *fixtures.DefaultValidOutputTagsArgs(),
+		},
+		"unknown output": {
+			auth: testusers.Alice.AuthID(),
+			args: wdk.OutputTagsArgs{
+				Output: "03895fb984362a4196bc9931629318fcbb2aeba7c6293638119ea653fa31d119.1",
+				Tags:   []primitives.StringUnder300{fixtures.OutputTag},
+			},
+		},
+		"invalid outpoint": {
+			auth: testusers.Alice.AuthID(),
+			args: wdk.OutputTagsArgs{
+				Output: "not-an-outpoint",
+				Tags:   []primitives.StringUnder300{fixtures.OutputTag},
+			},
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			given := testabilities.Given(t)
+
+			// given:
+			activeStorage := given.Provider().GORM()
+			internalizeOutputToCustomBasket(t, activeStorage)
+
+			// when:
+			addErr := activeStorage.AddOutputTags(context.Background(), test.auth, test.args)
+			removeErr := activeStorage.RemoveOutputTags(context.Background(), test.auth, test.args)
+
+			// then:
+			require.Error(t, addErr)
+			require.Error(t, removeErr)
+		})
+	}
+}
+
+func internalizeOutputToCustomBasket(t *testing.T, activeStorage *storage.Provider) {
+	t.Helper()
+
+	_, err := activeStorage.InternalizeAction(
+		context.Background(),
+		testusers.Alice.AuthID(),
+		fixtures.DefaultInternalizeActionArgs(t, wdk.BasketInsertionProtocol),
+	)
+	require.NoError(t, err)
+}
+
+func listedTagsOfInternalizedOutput(t *testing.T, activeStorage *storage.Provider) []string {
+	t.Helper()
+
+	args := fixtures.DefaultValidListOutputsArgs()
+	args.Tags = nil
+	args.IncludeTags = to.Ptr(primitives.BooleanDefaultFalse(true))
+
+	result, err := activeStorage.ListOutputs(context.Background(), testusers.Alice.AuthID(), *args)
+	require.NoError(t, err)
+	require.Len(t, result.Outputs, 1)
+
+	return result.Outputs[0].Tags
+}
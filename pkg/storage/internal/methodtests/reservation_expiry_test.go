@@ -0,0 +1,142 @@
+package methodtests
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/4chain-ag/go-wallet-toolbox/pkg/internal/fixtures"
+	"github.com/4chain-ag/go-wallet-toolbox/pkg/storage/internal/testabilities"
+	"github.com/4chain-ag/go-wallet-toolbox/pkg/storage/internal/testabilities/testusers"
+	"github.com/4chain-ag/go-wallet-toolbox/pkg/wdk/primitives"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSweepExpiredReservations(t *testing.T) {
+	given := testabilities.Given(t)
+
+	// given:
+	activeStorage := given.Provider().WithReservationTimeout(time.Nanosecond).GORM()
+
+	// and:
+	given.Faucet(activeStorage, testusers.Alice).TopUp(100_000)
+
+	// and:
+	_, err := activeStorage.CreateAction(context.Background(), testusers.Alice.AuthID(), fixtures.DefaultValidCreateActionArgs())
+	require.NoError(t, err)
+
+	// when:
+	aborted, err := activeStorage.SweepExpiredReservations(context.Background())
+
+	// then:
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), aborted)
+
+	stats, err := activeStorage.WalletStats(context.Background(), testusers.Alice.AuthID())
+	require.NoError(t, err)
+	assert.Equal(t, primitives.SatoshiValue(100_000), stats.Spendable)
+	assert.Equal(t, primitives.SatoshiValue(0), stats.Reserved)
+
+	// when:
+	aborted, err = activeStorage.SweepExpiredReservations(context.Background())
+
+	// then:
+	require.NoError(t, err)
+	assert.Equal(t, int64(0), aborted)
+}
+
+func TestSweepNotExpiredReservations(t *testing.T) {
+	tests := map[string]struct {
+		timeout time.Duration
+	}{
+		"reservations without expiration": {
+			timeout: 0,
+		},
+		"reservation not expired yet": {
+			timeout: time.Hour,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			given := testabilities.Given(t)
+
+			// given:
+			activeStorage := given.Provider().WithReservationTimeout(test.timeout).GORM()
+
+			// and:
+			given.Faucet(activeStorage, testusers.Alice).TopUp(100_000)
+
+			// and:
+			_, err := activeStorage.CreateAction(context.Background(), testusers.Alice.AuthID(), fixtures.DefaultValidCreateActionArgs())
+			require.NoError(t, err)
+
+			// when:
+			aborted, err := activeStorage.SweepExpiredReservations(context.Background())
+
+			// then:
+			require.NoError(t, err)
+			assert.Equal(t, int64(0), aborted)
+
+			stats, err := activeStorage.WalletStats(context.Background(), testusers.Alice.AuthID())
+			require.NoError(t, err)
+			assert.Equal(t, primitives.SatoshiValue(0), stats.Spendable)
+			assert.Equal(t, primitives.SatoshiValue(100_000), stats.Reserved)
+
+			// when:
+			_, err = activeStorage.CreateAction(context.Background(), testusers.Alice.AuthID(), fixtures.DefaultValidCreateActionArgs())
+
+			// then:
+			require.Error(t, err)
+		})
+	}
+}
+
+func TestWalletStatsWithExpiredReservation(t *testing.T) {
+	given := testabilities.Given(t)
+
+	// given:
+	activeStorage := given.Provider().WithReservationTimeout(time.Nanosecond).GORM()
+
+	// and:
+	given.Faucet(activeStorage, testusers.Alice).TopUp(100_000)
+
+	// and: the reservation expires, but it is not swept yet
+	_, err := activeStorage.CreateAction(context.Background(), testusers.Alice.AuthID(), fixtures.DefaultValidCreateActionArgs())
+	require.NoError(t, err)
+
+	// when:
+	stats, err := activeStorage.WalletStats(context.Background(), testusers.Alice.AuthID())
+
+	// then:
+	require.NoError(t, err)
+	assert.Equal(t, primitives.SatoshiValue(100_000), stats.Spendable)
+	assert.Equal(t, primitives.SatoshiValue(0), stats.Reserved)
+}
+
+func TestCreateActionReusesExpiredReservation(t *testing.T) {
+	given := testabilities.Given(t)
+
+	// given:
+	activeStorage := given.Provider().WithReservationTimeout(time.Nanosecond).GORM()
+
+	// and:
+	given.Faucet(activeStorage, testusers.Alice).TopUp(100_000)
+
+	// and:
+	_, err := activeStorage.CreateAction(context.Background(), testusers.Alice.AuthID(), fixtures.DefaultValidCreateActionArgs())
+	require.NoError(t, err)
+
+	// when:
+	result, err := activeStorage.CreateAction(context.Background(), testusers.Alice.AuthID(), fixtures.DefaultValidCreateActionArgs())
+
+	// then:
+	require.NoError(t, err)
+	require.Len(t, result.Inputs, 1)
+	assert.Equal(t, int64(100_000), result.Inputs[0].SourceSatoshis)
+
+	// and: only the latest transaction holds the reservation, the first one has been aborted
+	aborted, err := activeStorage.SweepExpiredReservations(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), aborted)
+}
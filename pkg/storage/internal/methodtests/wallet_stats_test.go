@@ -0,0 +1,106 @@
+package methodtests
+
+import (
+	"context"
+	"testing"
+
+	"github.com/4chain-ag/go-wallet-toolbox/pkg/internal/fixtures"
+	"github.com/4chain-ag/go-wallet-toolbox/pkg/storage/internal/testabilities"
+	"github.com/4chain-ag/go-wallet-toolbox/pkg/storage/internal/testabilities/testusers"
+	"github.com/4chain-ag/go-wallet-toolbox/pkg/wdk"
+	"github.com/4chain-ag/go-wallet-toolbox/pkg/wdk/primitives"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWalletStatsNilAuth(t *testing.T) {
+	given := testabilities.Given(t)
+
+	// given:
+	activeStorage := given.Provider().GORM()
+
+	// when:
+	_, err := activeStorage.WalletStats(context.Background(), wdk.AuthID{UserID: nil})
+
+	// then:
+	require.Error(t, err)
+}
+
+func TestWalletStats(t *testing.T) {
+	given := testabilities.Given(t)
+
+	// given:
+	activeStorage := given.Provider().GORM()
+
+	// and: UTXO reserved by not yet processed createAction
+	given.Faucet(activeStorage, testusers.Alice).TopUp(100_000)
+	_, err := activeStorage.CreateAction(context.Background(), testusers.Alice.AuthID(), fixtures.DefaultValidCreateActionArgs())
+	require.NoError(t, err)
+
+	// and: spendable UTXO from completed transaction
+	given.Faucet(activeStorage, testusers.Alice).TopUp(500)
+
+	// and: spendable UTXO from unproven incoming transaction
+	_, err = activeStorage.InternalizeAction(context.Background(), testusers.Alice.AuthID(), fixtures.DefaultInternalizeActionArgs(t, wdk.WalletPaymentProtocol))
+	require.NoError(t, err)
+
+	// when:
+	result, err := activeStorage.WalletStats(context.Background(), testusers.Alice.AuthID())
+
+	// then:
+	require.NoError(t, err)
+
+	assert.Equal(t, primitives.SatoshiValue(500+fixtures.ExpectedValueToInternalize), result.Spendable)
+	assert.Equal(t, primitives.SatoshiValue(100_000), result.Reserved)
+	assert.Equal(t, primitives.SatoshiValue(fixtures.ExpectedValueToInternalize), result.PendingIncoming)
+	assert.Equal(t, uint64(3), result.UTXOsCount)
+
+	assert.Equal(t, []*wdk.BasketBalance{{
+		Name:       wdk.BasketNameForChange,
+		Spendable:  primitives.SatoshiValue(500 + fixtures.ExpectedValueToInternalize),
+		Reserved:   100_000,
+		UTXOsCount: 3,
+	}}, result.Baskets)
+
+	// and:
+	require.Len(t, result.UTXOsSizeHistogram, len(wdk.UTXOsSizeHistogramBounds)+1)
+
+	assert.Equal(t, uint64(0), result.UTXOsSizeHistogram[0].MinSatoshis)
+	assert.Equal(t, uint64(1_000), *result.UTXOsSizeHistogram[0].MaxSatoshis)
+	assert.Equal(t, uint64(2), result.UTXOsSizeHistogram[0].UTXOsCount)
+	assert.Equal(t, primitives.SatoshiValue(500+fixtures.ExpectedValueToInternalize), result.UTXOsSizeHistogram[0].Satoshis)
+
+	assert.Equal(t, uint64(100_000), result.UTXOsSizeHistogram[3].MinSatoshis)
+	assert.Equal(t, uint64(1), result.UTXOsSizeHistogram[3].UTXOsCount)
+	assert.Equal(t, primitives.SatoshiValue(100_000), result.UTXOsSizeHistogram[3].Satoshis)
+
+	lastBucket := result.UTXOsSizeHistogram[len(result.UTXOsSizeHistogram)-1]
+	assert.Equal(t, uint64(10_000_000), lastBucket.MinSatoshis)
+	assert.Nil(t, lastBucket.MaxSatoshis)
+	assert.Equal(t, uint64(0), lastBucket.UTXOsCount)
+}
+
+func TestWalletStatsOfOtherUser(t *testing.T) {
+	given := testabilities.Given(t)
+
+	// given:
+	activeStorage := given.Provider().GORM()
+
+	// and:
+	given.Faucet(activeStorage, testusers.Alice).TopUp(100_000)
+
+	// when:
+	result, err := activeStorage.WalletStats(context.Background(), testusers.Bob.AuthID())
+
+	// then:
+	require.NoError(t, err)
+	assert.Equal(t, primitives.SatoshiValue(0), result.Spendable)
+	assert.Equal(t, primitives.SatoshiValue(0), result.Reserved)
+	assert.Equal(t, primitives.SatoshiValue(0), result.PendingIncoming)
+	assert.Equal(t, uint64(0), result.UTXOsCount)
+	assert.Empty(t, result.Baskets)
+
+	for _, bucket := range result.UTXOsSizeHistogram {
+		assert.Equal(t, uint64(0), bucket.UTXOsCount)
+	}
+}
@@ -28,6 +28,7 @@ func (c *cachedBasketMaker) findOrCreate(tx *gorm.DB, name string, numberOfDesir
 
 	var basket models.OutputBasket
 	err := tx.
+		Unscoped().
 		Where(models.OutputBasket{UserID: c.userID, Name: name}).
 		Attrs(models.OutputBasket{NumberOfDesiredUTXOs: numberOfDesiredUTXOs, MinimumDesiredUTXOValue: minimumDesiredUTXOValue}).
 		FirstOrCreate(&basket).
@@ -36,5 +37,14 @@ func (c *cachedBasketMaker) findOrCreate(tx *gorm.DB, name string, numberOfDesir
 		return nil, fmt.Errorf("failed to find or create output basket: %w", err)
 	}
 
+	if basket.DeletedAt.Valid {
+		// the basket was removed before, so we're restoring it instead of creating a duplicate
+		err = tx.Unscoped().Model(&basket).Update("deleted_at", nil).Error
+		if err != nil {
+			return nil, fmt.Errorf("failed to restore output basket: %w", err)
+		}
+	}
+
+	c.nameToID[name] = &basket.BasketID
 	return &basket.BasketID, nil
 }
@@ -0,0 +1,47 @@
+package repo
+
+import (
+	"fmt"
+
+	"github.com/4chain-ag/go-wallet-toolbox/pkg/storage/internal/database/models"
+	"gorm.io/gorm"
+)
+
+type cachedTagMaker struct {
+	userID  int
+	tagToID map[string]uint
+}
+
+func newCachedTagMaker(userID int) *cachedTagMaker {
+	return &cachedTagMaker{
+		userID:  userID,
+		tagToID: make(map[string]uint),
+	}
+}
+
+func (c *cachedTagMaker) findOrCreate(tx *gorm.DB, tag string) (uint, error) {
+	if cachedID, ok := c.tagToID[tag]; ok {
+		return cachedID, nil
+	}
+
+	var outputTag models.OutputTag
+	err := tx.
+		Unscoped().
+		Where(models.OutputTag{UserID: c.userID, Tag: tag}).
+		FirstOrCreate(&outputTag).
+		Error
+	if err != nil {
+		return 0, fmt.Errorf("failed to find or create output tag: %w", err)
+	}
+
+	if outputTag.DeletedAt.Valid {
+		// the tag was removed before, so we're restoring it instead of creating a duplicate
+		err = tx.Unscoped().Model(&outputTag).Update("deleted_at", nil).Error
+		if err != nil {
+			return 0, fmt.Errorf("failed to restore output tag: %w", err)
+		}
+	}
+
+	c.tagToID[tag] = outputTag.TagID
+	return outputTag.TagID, nil
+}
@@ -26,7 +26,10 @@ func (m *Migrator) Migrate(ctx context.Context) error {
 		models.UserUTXO{},
 		models.Transaction{},
 		models.Output{},
+		models.OutputTag{},
+		models.OutputTagMap{},
 		models.ProvenTxReq{},
+		models.ProvenTx{},
 	)
 	if err != nil {
 		return fmt.Errorf("failed to migrate settings: %w", err)
@@ -6,7 +6,9 @@ import (
 	"fmt"
 
 	"github.com/4chain-ag/go-wallet-toolbox/pkg/storage/internal/database/models"
+	"github.com/4chain-ag/go-wallet-toolbox/pkg/storage/internal/database/scopes"
 	"github.com/4chain-ag/go-wallet-toolbox/pkg/wdk"
+	"github.com/4chain-ag/go-wallet-toolbox/pkg/wdk/primitives"
 	"gorm.io/gorm"
 )
 
@@ -41,3 +43,129 @@ func (u *OutputBaskets) FindBasketByName(ctx context.Context, userID int, name s
 		},
 	}, nil
 }
+
+type basketUTXOsSummary struct {
+	BasketID   int
+	UTXOsCount uint64 `gorm:"column:utxos_count"`
+	Balance    uint64
+}
+
+func (u *OutputBaskets) ListBasketsWithSummary(ctx context.Context, userID int) ([]*wdk.OutputBasketSummary, error) {
+	db := u.db.WithContext(ctx)
+
+	var baskets []*models.OutputBasket
+	err := db.
+		Scopes(scopes.UserID(userID)).
+		Order("name").
+		Find(&baskets).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to find output baskets: %w", err)
+	}
+
+	var summaries []*basketUTXOsSummary
+	err = db.Model(&models.Output{}).
+		Select("basket_id, COUNT(*) AS utxos_count, COALESCE(SUM(satoshis), 0) AS balance").
+		Scopes(scopes.UserID(userID)).
+		Where("basket_id IS NOT NULL").
+		Where("spendable = ?", true).
+		Group("basket_id").
+		Scan(&summaries).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to summarize spendable outputs of output baskets: %w", err)
+	}
+
+	summaryByBasketID := make(map[int]*basketUTXOsSummary, len(summaries))
+	for _, summary := range summaries {
+		summaryByBasketID[summary.BasketID] = summary
+	}
+
+	result := make([]*wdk.OutputBasketSummary, 0, len(baskets))
+	for _, basket := range baskets {
+		basketSummary := &wdk.OutputBasketSummary{
+			BasketConfiguration: wdk.BasketConfiguration{
+				Name:                    basket.Name,
+				NumberOfDesiredUTXOs:    basket.NumberOfDesiredUTXOs,
+				MinimumDesiredUTXOValue: basket.MinimumDesiredUTXOValue,
+			},
+		}
+		if summary, ok := summaryByBasketID[basket.BasketID]; ok {
+			basketSummary.UTXOsCount = summary.UTXOsCount
+			basketSummary.Balance = primitives.SatoshiValue(summary.Balance)
+		}
+		result = append(result, basketSummary)
+	}
+
+	return result, nil
+}
+
+func (u *OutputBaskets) UpdateBasketConfiguration(ctx context.Context, userID int, name string, numberOfDesiredUTXOs *int64, minimumDesiredUTXOValue *uint64) error {
+	updates := make(map[string]any, 2)
+	if numberOfDesiredUTXOs != nil {
+		updates["number_of_desired_utxos"] = *numberOfDesiredUTXOs
+	}
+	if minimumDesiredUTXOValue != nil {
+		updates["minimum_desired_utxo_value"] = *minimumDesiredUTXOValue
+	}
+
+	res := u.db.WithContext(ctx).
+		Model(&models.OutputBasket{}).
+		Scopes(scopes.UserID(userID)).
+		Where("name = ?", name).
+		Updates(updates)
+	if res.Error != nil {
+		return fmt.Errorf("failed to update output basket: %w", res.Error)
+	}
+	if res.RowsAffected == 0 {
+		return fmt.Errorf("failed to update output basket: basket %q not found", name)
+	}
+
+	return nil
+}
+
+func (u *OutputBaskets) RemoveBasket(ctx context.Context, userID int, name string) error {
+	err := u.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var basket models.OutputBasket
+		err := tx.
+			Scopes(scopes.UserID(userID)).
+			Where("name = ?", name).
+			First(&basket).Error
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return fmt.Errorf("basket %q not found", name)
+			}
+			return fmt.Errorf("failed to find output basket: %w", err)
+		}
+
+		var utxosCount int64
+		err = tx.Model(&models.UserUTXO{}).
+			Scopes(scopes.UserID(userID), scopes.BasketID(basket.BasketID)).
+			Count(&utxosCount).Error
+		if err != nil {
+			return fmt.Errorf("failed to count utxos of output basket: %w", err)
+		}
+
+		var spendableOutputsCount int64
+		err = tx.Model(&models.Output{}).
+			Scopes(scopes.UserID(userID), scopes.BasketID(basket.BasketID)).
+			Where("spendable = ?", true).
+			Count(&spendableOutputsCount).Error
+		if err != nil {
+			return fmt.Errorf("failed to count spendable outputs of output basket: %w", err)
+		}
+
+		if utxosCount > 0 || spendableOutputsCount > 0 {
+			return fmt.Errorf("basket %q is not empty", name)
+		}
+
+		err = tx.Delete(&basket).Error
+		if err != nil {
+			return fmt.Errorf("failed to delete output basket: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to remove output basket: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,94 @@
+package repo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/4chain-ag/go-wallet-toolbox/pkg/storage/internal/database/models"
+	"github.com/4chain-ag/go-wallet-toolbox/pkg/storage/internal/database/scopes"
+	"github.com/4chain-ag/go-wallet-toolbox/pkg/wdk"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// AddOutputTags assigns the tags to the user's output identified by the outpoint
+func (o *Outputs) AddOutputTags(ctx context.Context, userID int, outpoint wdk.OutPoint, tags []string) error {
+	err := o.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		outputID, err := findOutputIDByOutpoint(tx, userID, outpoint)
+		if err != nil {
+			return err
+		}
+
+		tagMaker := newCachedTagMaker(userID)
+		for _, tag := range tags {
+			tagID, err := tagMaker.findOrCreate(tx, tag)
+			if err != nil {
+				return err
+			}
+
+			err = tx.
+				Clauses(clause.OnConflict{
+					Columns:   []clause.Column{{Name: "output_id"}, {Name: "output_tag_id"}},
+					DoUpdates: clause.Assignments(map[string]any{"deleted_at": nil}),
+				}).
+				Create(&models.OutputTagMap{OutputID: outputID, OutputTagID: tagID}).Error
+			if err != nil {
+				return fmt.Errorf("failed to assign tag %q to output: %w", tag, err)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to add output tags: %w", err)
+	}
+	return nil
+}
+
+// RemoveOutputTags unassigns the tags from the user's output identified by the outpoint
+func (o *Outputs) RemoveOutputTags(ctx context.Context, userID int, outpoint wdk.OutPoint, tags []string) error {
+	err := o.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		outputID, err := findOutputIDByOutpoint(tx, userID, outpoint)
+		if err != nil {
+			return err
+		}
+
+		err = tx.
+			Where("output_id = ?", outputID).
+			Where("output_tag_id IN (?)", tx.Model(&models.OutputTag{}).
+				Select("tag_id").
+				Where("user_id = ?", userID).
+				Where("tag IN ?", tags),
+			).
+			Delete(&models.OutputTagMap{}).Error
+		if err != nil {
+			return fmt.Errorf("failed to unassign tags from output: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to remove output tags: %w", err)
+	}
+	return nil
+}
+
+func findOutputIDByOutpoint(tx *gorm.DB, userID int, outpoint wdk.OutPoint) (uint, error) {
+	var output models.Output
+	err := tx.
+		Select("id").
+		Scopes(scopes.UserID(userID)).
+		Where("transaction_id IN (?)", tx.Model(&models.Transaction{}).
+			Select("id").
+			Where("user_id = ?", userID).
+			Where("tx_id = ?", outpoint.TxID),
+		).
+		Where("vout = ?", outpoint.Vout).
+		First(&output).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return 0, fmt.Errorf("output %s not found", outpoint)
+		}
+		return 0, fmt.Errorf("failed to find output %s: %w", outpoint, err)
+	}
+	return output.ID, nil
+}
@@ -6,9 +6,13 @@ import (
 	"iter"
 
 	"github.com/4chain-ag/go-wallet-toolbox/pkg/storage/internal/database/models"
+	"github.com/4chain-ag/go-wallet-toolbox/pkg/storage/internal/database/scopes"
+	"github.com/4chain-ag/go-wallet-toolbox/pkg/storage/internal/paging"
 	"github.com/4chain-ag/go-wallet-toolbox/pkg/wdk"
+	"github.com/4chain-ag/go-wallet-toolbox/pkg/wdk/primitives"
 	"github.com/go-softwarelab/common/pkg/seq"
 	"github.com/go-softwarelab/common/pkg/slices"
+	"github.com/go-softwarelab/common/pkg/to"
 	"gorm.io/gorm"
 )
 
@@ -16,6 +20,15 @@ type Outputs struct {
 	db *gorm.DB
 }
 
+type ListOutputsActionParams struct {
+	Basket       string
+	Tags         []string
+	TagQueryMode wdk.QueryMode
+	IncludeTags  bool
+	Limit        primitives.PositiveIntegerDefault10Max10000
+	Offset       primitives.PositiveInteger
+}
+
 func NewOutputs(db *gorm.DB) *Outputs {
 	return &Outputs{db: db}
 }
@@ -69,6 +82,95 @@ func (o *Outputs) FindInputsAndOutputsOfTransaction(ctx context.Context, transac
 	return
 }
 
+func (o *Outputs) ListAndCountOutputs(ctx context.Context, userID int, opts ListOutputsActionParams) (outputs []*models.Output, totalRows int64, err error) {
+	err = o.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		page := &paging.Page{}
+
+		// parse offset and limit
+		if opts.Limit > 0 {
+			limit, err := to.IntFromUnsigned(opts.Limit)
+			if err != nil {
+				return fmt.Errorf("error during parsing limit: %w", err)
+			}
+			page.Limit = limit
+		}
+
+		if opts.Offset > 0 {
+			ofs, err := to.IntFromUnsigned(opts.Offset)
+			if err != nil {
+				return fmt.Errorf("error during parsing offset: %w", err)
+			}
+			page.Offset = ofs
+		}
+
+		// prepare query
+		query := tx.Model(&models.Output{}).
+			Scopes(scopes.UserID(userID)).
+			Where("spendable = ?", true)
+
+		if opts.Basket != "" {
+			query = query.Where("basket_id IN (?)", tx.Model(&models.OutputBasket{}).
+				Select("basket_id").
+				Where("user_id = ?", userID).
+				Where("name = ?", opts.Basket),
+			)
+		}
+
+		if len(opts.Tags) > 0 {
+			query = query.Where("id IN (?)", outputIDsWithTags(tx, userID, opts.Tags, opts.TagQueryMode))
+		}
+
+		// first count all outputs
+		err := query.Count(&totalRows).Error
+		if err != nil {
+			return fmt.Errorf("error during counting outputs: %w", err)
+		}
+
+		// then find outputs with applied filters and paging
+		query = query.Scopes(
+			scopes.Paginate(page),
+		).Preload("Transaction", func(db *gorm.DB) *gorm.DB {
+			return db.Select("id, tx_id")
+		})
+
+		if opts.IncludeTags {
+			query = query.Preload("Tags.OutputTag")
+		}
+
+		err = query.Find(&outputs).Error
+		if err != nil {
+			return fmt.Errorf("error during finding outputs: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, -1, fmt.Errorf("failed to list outputs: %w", err)
+	}
+
+	return outputs, totalRows, nil
+}
+
+func outputIDsWithTags(tx *gorm.DB, userID int, tags []string, mode wdk.QueryMode) *gorm.DB {
+	tagIDs := tx.Model(&models.OutputTag{}).
+		Select("tag_id").
+		Where("user_id = ?", userID).
+		Where("tag IN ?", tags)
+
+	outputIDs := tx.Model(&models.OutputTagMap{}).
+		Select("output_id").
+		Where("output_tag_id IN (?)", tagIDs)
+
+	if mode == wdk.QueryModeAll {
+		uniqueTags := seq.Count(seq.Uniq(seq.FromSlice(tags)))
+		outputIDs = outputIDs.
+			Group("output_id").
+			Having("COUNT(DISTINCT output_tag_id) = ?", uniqueTags)
+	}
+
+	return outputIDs
+}
+
 func (o *Outputs) mapModelToTableOutput(model *models.Output) *wdk.TableOutput {
 	output := &wdk.TableOutput{
 		CreatedAt:          model.CreatedAt,
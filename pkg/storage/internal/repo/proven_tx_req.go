@@ -8,6 +8,7 @@ import (
 
 	"github.com/4chain-ag/go-wallet-toolbox/pkg/storage/internal/database/models"
 	"github.com/4chain-ag/go-wallet-toolbox/pkg/storage/internal/entity"
+	"github.com/4chain-ag/go-wallet-toolbox/pkg/wdk"
 	"gorm.io/gorm"
 )
 
@@ -58,3 +59,99 @@ func (p *ProvenTxReq) FindProvenTxRawTX(ctx context.Context, txID string) ([]byt
 	}
 	return model.RawTx, nil
 }
+
+// FindKnownTx returns the raw transaction with its proof (ProvenTx) if it is mined,
+// otherwise the raw transaction and its input BEEF (ProvenTxReq). Returns nil if the storage doesn't know the transaction.
+func (p *ProvenTxReq) FindKnownTx(ctx context.Context, txID string) (*entity.KnownTx, error) {
+	var provenTx models.ProvenTx
+	err := p.db.WithContext(ctx).First(&provenTx, "tx_id = ? ", txID).Error
+	if err == nil && len(provenTx.RawTx) > 0 {
+		return &entity.KnownTx{
+			TxID:       provenTx.TxID,
+			RawTx:      provenTx.RawTx,
+			MerklePath: provenTx.MerklePath,
+		}, nil
+	}
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, fmt.Errorf("failed to find proven tx: %w", err)
+	}
+
+	var req models.ProvenTxReq
+	err = p.db.WithContext(ctx).First(&req, "tx_id = ? ", txID).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to find proven tx req: %w", err)
+	}
+	if len(req.RawTx) == 0 {
+		return nil, nil
+	}
+
+	return &entity.KnownTx{
+		TxID:      req.TxID,
+		RawTx:     req.RawTx,
+		InputBeef: req.InputBeef,
+	}, nil
+}
+
+// UpdateProvenTxReqStatus changes the status of the existing ProvenTxReq and the transactions with its txID,
+// and stores the proof of the mined transaction. The status of ProvenTxReq in a terminal status (completed, invalid, doubleSpend)
+// is not changed anymore, only the history note is recorded, the same as for the update without status.
+// If the ProvenTxReq doesn't exist, entity.ErrNotFound is returned.
+func (p *ProvenTxReq) UpdateProvenTxReqStatus(ctx context.Context, update *entity.ProvenTxReqStatusUpdate, historyNote string, historyAttrs map[string]any) error {
+	err := p.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var model models.ProvenTxReq
+		err := tx.First(&model, "tx_id = ? ", update.TxID).Error
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return fmt.Errorf("proven tx req for tx %s: %w", update.TxID, entity.ErrNotFound)
+			}
+			return err
+		}
+
+		model.AddNote(time.Now(), historyNote, historyAttrs)
+		if update.Status == "" || isTerminalProvenTxReqStatus(model.Status) {
+			return tx.Save(&model).Error
+		}
+		model.Status = update.Status
+
+		if update.Proof != nil {
+			err = tx.Save(&models.ProvenTx{
+				TxID:       update.TxID,
+				Height:     update.Proof.Height,
+				BlockHash:  update.Proof.BlockHash,
+				MerkleRoot: update.Proof.MerkleRoot,
+				MerklePath: update.Proof.MerklePath,
+				RawTx:      model.RawTx,
+			}).Error
+			if err != nil {
+				return err
+			}
+		}
+
+		if update.TxStatus != nil {
+			err = tx.Model(&models.Transaction{}).
+				Where("tx_id = ?", update.TxID).
+				Update("status", *update.TxStatus).Error
+			if err != nil {
+				return err
+			}
+		}
+
+		return tx.Save(&model).Error
+	})
+	if err != nil {
+		return fmt.Errorf("failed to update proven tx req status: %w", err)
+	}
+	return nil
+}
+
+func isTerminalProvenTxReqStatus(status wdk.ProvenTxReqStatus) bool {
+	switch status {
+	case wdk.ProvenTxStatusCompleted, wdk.ProvenTxStatusInvalid, wdk.ProvenTxStatusDoubleSpend:
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,151 @@
+package repo
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/4chain-ag/go-wallet-toolbox/pkg/storage/internal/database/models"
+	"github.com/4chain-ag/go-wallet-toolbox/pkg/storage/internal/entity"
+	"github.com/4chain-ag/go-wallet-toolbox/pkg/wdk"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// AbortTransactionsWithExpiredReservations aborts all unsigned transactions which reservation of inputs expired before "now"
+// and releases all UTXOs reserved by them. It returns the number of aborted transactions.
+func (txs *Transactions) AbortTransactionsWithExpiredReservations(ctx context.Context, now time.Time) (int64, error) {
+	var aborted int64
+	err := txs.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		transactionIDs, err := findTransactionsWithExpiredReservations(tx, now, func(db *gorm.DB) *gorm.DB { return db })
+		if err != nil {
+			return err
+		}
+
+		aborted = int64(len(transactionIDs))
+		return abortTransactionsAndReleaseReservations(tx, transactionIDs)
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to abort transactions with expired reservations: %w", err)
+	}
+	return aborted, nil
+}
+
+// abortExpiredReservationHolders aborts the unsigned transactions which expired reservations are held on the given outputs,
+// so the outputs can be safely reserved by another transaction.
+func abortExpiredReservationHolders(tx *gorm.DB, userID int, outputIDs []uint, now time.Time) error {
+	if len(outputIDs) == 0 {
+		return nil
+	}
+
+	transactionIDs, err := findTransactionsWithExpiredReservations(tx, now, func(db *gorm.DB) *gorm.DB {
+		return db.Where("user_id = ?", userID).Where("output_id IN ?", outputIDs)
+	})
+	if err != nil {
+		return err
+	}
+
+	return abortTransactionsAndReleaseReservations(tx, transactionIDs)
+}
+
+func findTransactionsWithExpiredReservations(tx *gorm.DB, now time.Time, utxosFilter func(*gorm.DB) *gorm.DB) ([]uint, error) {
+	expiredReservations := tx.Model(&models.UserUTXO{}).
+		Select("reserved_by_id").
+		Scopes(utxosFilter).
+		Where("reserved_until < ?", now)
+
+	// the transactions are locked, so they cannot be processed concurrently while being aborted
+	var transactionIDs []uint
+	err := tx.Model(&models.Transaction{}).
+		Clauses(clause.Locking{Strength: clause.LockingStrengthUpdate}).
+		Where("status = ?", wdk.TxStatusUnsigned).
+		Where("id IN (?)", expiredReservations).
+		Pluck("id", &transactionIDs).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to find transactions with expired reservations: %w", err)
+	}
+	return transactionIDs, nil
+}
+
+func abortTransactionsAndReleaseReservations(tx *gorm.DB, transactionIDs []uint) error {
+	if len(transactionIDs) == 0 {
+		return nil
+	}
+
+	err := tx.Model(&models.Output{}).
+		Where("id IN (?)", tx.Model(&models.UserUTXO{}).Select("output_id").Where("reserved_by_id IN ?", transactionIDs)).
+		Update("spendable", true).Error
+	if err != nil {
+		return fmt.Errorf("failed to mark released outputs as spendable: %w", err)
+	}
+
+	err = tx.Model(&models.UserUTXO{}).
+		Where("reserved_by_id IN ?", transactionIDs).
+		Updates(map[string]any{
+			"reserved_by_id": nil,
+			"reserved_until": nil,
+		}).Error
+	if err != nil {
+		return fmt.Errorf("failed to release reserved utxos: %w", err)
+	}
+
+	err = tx.Model(&models.Transaction{}).
+		Where("id IN ?", transactionIDs).
+		Update("status", wdk.TxStatusFailed).Error
+	if err != nil {
+		return fmt.Errorf("failed to mark transactions as failed: %w", err)
+	}
+
+	return nil
+}
+
+// reserveUTXOs atomically reserves the outputs for the transaction.
+// The rows are locked (SELECT ... FOR UPDATE SKIP LOCKED), so concurrent transactions cannot reserve the same UTXOs;
+// SQLite ignores the locking clause, but it serializes the writers anyway.
+// If any of the outputs is already reserved (or locked) by another transaction, entity.ErrUTXOsAlreadyReserved is returned.
+func reserveUTXOs(tx *gorm.DB, userID int, transactionID uint, outputIDs []uint, reservedUntil *time.Time, now time.Time) error {
+	if len(outputIDs) == 0 {
+		return nil
+	}
+
+	var available []uint
+	err := tx.Model(&models.UserUTXO{}).
+		Clauses(clause.Locking{Strength: clause.LockingStrengthUpdate, Options: clause.LockingOptionsSkipLocked}).
+		Where("user_id = ?", userID).
+		Where("output_id IN ?", outputIDs).
+		Scopes(notReserved(now)).
+		Pluck("output_id", &available).Error
+	if err != nil {
+		return fmt.Errorf("failed to lock utxos for reservation: %w", err)
+	}
+	if len(available) != len(outputIDs) {
+		return entity.ErrUTXOsAlreadyReserved
+	}
+
+	res := tx.Model(&models.UserUTXO{}).
+		Where("user_id = ?", userID).
+		Where("output_id IN ?", outputIDs).
+		Scopes(notReserved(now)).
+		Updates(map[string]any{
+			"reserved_by_id": transactionID,
+			"reserved_until": reservedUntil,
+		})
+	if res.Error != nil {
+		return fmt.Errorf("failed to reserve utxos: %w", res.Error)
+	}
+	if res.RowsAffected != int64(len(outputIDs)) {
+		return entity.ErrUTXOsAlreadyReserved
+	}
+	return nil
+}
+
+// makeReservationsPermanent removes the expiry of reservations held by the transaction, because its inputs are already signed.
+func makeReservationsPermanent(tx *gorm.DB, transactionID uint) error {
+	err := tx.Model(&models.UserUTXO{}).
+		Where("reserved_by_id = ?", transactionID).
+		Update("reserved_until", nil).Error
+	if err != nil {
+		return fmt.Errorf("failed to remove reservation expiry: %w", err)
+	}
+	return nil
+}
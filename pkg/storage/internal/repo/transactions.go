@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"time"
 
 	"github.com/4chain-ag/go-wallet-toolbox/pkg/internal/txutils"
 	"github.com/4chain-ag/go-wallet-toolbox/pkg/storage/internal/database/models"
@@ -12,6 +13,7 @@ import (
 	"github.com/4chain-ag/go-wallet-toolbox/pkg/wdk"
 	"github.com/4chain-ag/go-wallet-toolbox/pkg/wdk/primitives"
 	"github.com/go-softwarelab/common/pkg/is"
+	"github.com/go-softwarelab/common/pkg/seq"
 	"github.com/go-softwarelab/common/pkg/slices"
 	"github.com/go-softwarelab/common/pkg/to"
 	"gorm.io/gorm"
@@ -37,11 +39,25 @@ func (txs *Transactions) CreateTransaction(ctx context.Context, newTx *entity.Ne
 			return err
 		}
 
-		if err = txs.markReservedOutputsAsNotSpendable(tx, newTx.UserID, newTx.ReservedOutputIDs); err != nil {
+		err = txs.connectOutputsWithTags(tx, newTx, model)
+		if err != nil {
+			return err
+		}
+
+		now := time.Now()
+		if err = abortExpiredReservationHolders(tx, newTx.UserID, newTx.ReservedOutputIDs, now); err != nil {
 			return err
 		}
 
-		return tx.Create(model).Error
+		if err = tx.Create(model).Error; err != nil {
+			return err
+		}
+
+		if err = reserveUTXOs(tx, newTx.UserID, model.ID, newTx.ReservedOutputIDs, newTx.ReservedUntil, now); err != nil {
+			return err
+		}
+
+		return txs.markReservedOutputsAsNotSpendable(tx, newTx.UserID, newTx.ReservedOutputIDs)
 	})
 	if err != nil {
 		return fmt.Errorf("failed to create transaction: %w", err)
@@ -67,18 +83,14 @@ func (txs *Transactions) toTransactionModel(newTx *entity.NewTx) (*models.Transa
 		LockTime:    newTx.LockTime,
 		InputBeef:   newTx.InputBeef,
 		TxID:        newTx.TxID,
+		Fee:         newTx.Fee,
+		FeeModel:    newTx.FeeModel,
 		Labels: slices.Map(newTx.Labels, func(label primitives.StringUnder300) *models.Label {
 			return &models.Label{
 				Name:   string(label),
 				UserID: newTx.UserID,
 			}
 		}),
-		ReservedUtxos: slices.Map(newTx.ReservedOutputIDs, func(reservedOutputID uint) *models.UserUTXO {
-			return &models.UserUTXO{
-				UserID:   newTx.UserID,
-				OutputID: reservedOutputID,
-			}
-		}),
 		Outputs: outputs,
 	}
 
@@ -105,6 +117,22 @@ func (txs *Transactions) connectOutputsWithBaskets(tx *gorm.DB, newTx *entity.Ne
 	return nil
 }
 
+func (txs *Transactions) connectOutputsWithTags(tx *gorm.DB, newTx *entity.NewTx, model *models.Transaction) error {
+	tagMaker := newCachedTagMaker(newTx.UserID)
+	for _, out := range model.Outputs {
+		for _, tagMap := range out.Tags {
+			tagID, err := tagMaker.findOrCreate(tx, tagMap.OutputTag.Tag)
+			if err != nil {
+				return err
+			}
+
+			tagMap.OutputTagID = tagID
+			tagMap.OutputTag = nil
+		}
+	}
+	return nil
+}
+
 func (txs *Transactions) makeNewOutput(userID int, output *entity.NewOutput) (*models.Output, error) {
 	out := models.Output{
 		Vout:               output.Vout,
@@ -130,6 +158,15 @@ func (txs *Transactions) makeNewOutput(userID int, output *entity.NewOutput) (*m
 		}
 	}
 
+	// Tags are not created here, the names are just passed for further processing (see connectOutputsWithTags())
+	out.Tags = seq.Collect(seq.Map(seq.Uniq(seq.FromSlice(output.Tags)), func(tag primitives.StringUnder300) *models.OutputTagMap {
+		return &models.OutputTagMap{
+			OutputTag: &models.OutputTag{
+				Tag: string(tag),
+			},
+		}
+	}))
+
 	if out.Spendable && out.Change {
 		if is.EmptyString(output.Basket) {
 			return nil, fmt.Errorf("basket not provided for change output")
@@ -203,16 +240,22 @@ func (txs *Transactions) UpdateTransaction(
 	historyAttrs map[string]any,
 ) error {
 	err := txs.db.WithContext(ctx).Transaction(func(tx *gorm.DB) (err error) {
-		err = tx.Model(models.Transaction{}).
+		// the status is checked again here, because the transaction could have been aborted (e.g. its reservation expired)
+		// after it was read by the caller
+		res := tx.Model(models.Transaction{}).
 			Scopes(scopes.UserID(updatedTx.UserID)).
 			Where("id = ?", updatedTx.TransactionID).
+			Where("status IN ?", []wdk.TxStatus{wdk.TxStatusUnsigned, wdk.TxStatusUnprocessed}).
 			Updates(map[string]any{
 				"tx_id":      updatedTx.TxID,
 				"input_beef": nil, // input_beef per user's transaction won't be needed anymore; it is moved to the ProvenTxReq (storage-wide)
 				"status":     updatedTx.TxStatus,
-			}).Error
-		if err != nil {
-			return err
+			})
+		if res.Error != nil {
+			return res.Error
+		}
+		if res.RowsAffected == 0 {
+			return fmt.Errorf("transaction %d is no longer in a valid status for processing", updatedTx.TransactionID)
 		}
 
 		err = tx.Model(models.Output{}).
@@ -223,6 +266,11 @@ func (txs *Transactions) UpdateTransaction(
 			return err
 		}
 
+		err = makeReservationsPermanent(tx, updatedTx.TransactionID)
+		if err != nil {
+			return err
+		}
+
 		return upsertProvenTxReq(tx, &entity.UpsertProvenTxReq{
 			TxID:      updatedTx.TxID,
 			Status:    updatedTx.ReqTxStatus,
@@ -2,13 +2,27 @@ package repo
 
 import (
 	"context"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/4chain-ag/go-wallet-toolbox/pkg/storage/internal/database/models"
 	"github.com/4chain-ag/go-wallet-toolbox/pkg/storage/internal/database/scopes"
 	"github.com/4chain-ag/go-wallet-toolbox/pkg/storage/internal/paging"
+	"github.com/4chain-ag/go-wallet-toolbox/pkg/wdk"
+	"github.com/4chain-ag/go-wallet-toolbox/pkg/wdk/primitives"
 	"gorm.io/gorm"
 )
 
+// pendingIncomingTxStatuses are the statuses of incoming transactions which are not proven yet
+var pendingIncomingTxStatuses = []wdk.TxStatus{
+	wdk.TxStatusUnprocessed,
+	wdk.TxStatusSending,
+	wdk.TxStatusUnproven,
+}
+
 type UTXOs struct {
 	db *gorm.DB
 }
@@ -22,7 +36,7 @@ func NewUTXOs(db *gorm.DB) *UTXOs {
 func (u *UTXOs) FindNotReservedUTXOs(ctx context.Context, userID int, basketID int, page *paging.Page) ([]*models.UserUTXO, error) {
 	var result []*models.UserUTXO
 	err := u.db.WithContext(ctx).
-		Scopes(scopes.UserID(userID), scopes.BasketID(basketID), scopes.Paginate(page), notReserved()).
+		Scopes(scopes.UserID(userID), scopes.BasketID(basketID), scopes.Paginate(page), notReserved(time.Now())).
 		Find(&result).Error
 	if err != nil {
 		return nil, err
@@ -35,14 +49,168 @@ func (u *UTXOs) CountUTXOs(ctx context.Context, userID int, basket int) (int64,
 
 	err := u.db.WithContext(ctx).
 		Model(&models.UserUTXO{}).
-		Scopes(scopes.UserID(userID), scopes.BasketID(basket), notReserved()).
+		Scopes(scopes.UserID(userID), scopes.BasketID(basket), notReserved(time.Now())).
 		Count(&count).Error
 
 	return count, err
 }
 
-func notReserved() func(*gorm.DB) *gorm.DB {
+type basketUTXOsBalance struct {
+	BasketID   int
+	UTXOsCount uint64 `gorm:"column:utxos_count"`
+	Spendable  uint64
+	Reserved   uint64
+}
+
+type utxosHistogramBucket struct {
+	Bucket     int
+	UTXOsCount uint64 `gorm:"column:utxos_count"`
+	Satoshis   uint64
+}
+
+// WalletStats summarizes the user's UTXOs: balances per basket, reserved and pending incoming amounts and the UTXOs size histogram.
+// Aggregations are done with plain SUM/COUNT/CASE expressions, so the results are the same for all supported databases.
+// UTXOs with an expired reservation are counted as spendable, the same as they are available for funding (see notReserved).
+func (u *UTXOs) WalletStats(ctx context.Context, userID int) (*wdk.WalletStatsResult, error) {
+	db := u.db.WithContext(ctx)
+	now := time.Now()
+
+	var balances []*basketUTXOsBalance
+	err := db.Model(&models.UserUTXO{}).
+		Select(`basket_id,
+			COUNT(*) AS utxos_count,
+			COALESCE(SUM(CASE WHEN reserved_by_id IS NULL OR reserved_until < ? THEN satoshis ELSE 0 END), 0) AS spendable,
+			COALESCE(SUM(CASE WHEN reserved_by_id IS NULL OR reserved_until < ? THEN 0 ELSE satoshis END), 0) AS reserved`, now, now).
+		Scopes(scopes.UserID(userID)).
+		Group("basket_id").
+		Scan(&balances).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to summarize utxos by basket: %w", err)
+	}
+
+	basketIDs := make([]int, 0, len(balances))
+	for _, balance := range balances {
+		basketIDs = append(basketIDs, balance.BasketID)
+	}
+
+	var baskets []*models.OutputBasket
+	if len(basketIDs) > 0 {
+		err = db.
+			Scopes(scopes.UserID(userID)).
+			Where("basket_id IN ?", basketIDs).
+			Find(&baskets).Error
+		if err != nil {
+			return nil, fmt.Errorf("failed to find output baskets: %w", err)
+		}
+	}
+
+	basketNameByID := make(map[int]string, len(baskets))
+	for _, basket := range baskets {
+		basketNameByID[basket.BasketID] = basket.Name
+	}
+
+	result := &wdk.WalletStatsResult{
+		Baskets: make([]*wdk.BasketBalance, 0, len(balances)),
+	}
+	for _, balance := range balances {
+		result.Spendable += primitives.SatoshiValue(balance.Spendable)
+		result.Reserved += primitives.SatoshiValue(balance.Reserved)
+		result.UTXOsCount += balance.UTXOsCount
+		result.Baskets = append(result.Baskets, &wdk.BasketBalance{
+			Name:       basketNameByID[balance.BasketID],
+			Spendable:  primitives.SatoshiValue(balance.Spendable),
+			Reserved:   primitives.SatoshiValue(balance.Reserved),
+			UTXOsCount: balance.UTXOsCount,
+		})
+	}
+	slices.SortFunc(result.Baskets, func(a, b *wdk.BasketBalance) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+
+	pendingTxIDs := db.Model(&models.Transaction{}).
+		Select("id").
+		Scopes(scopes.UserID(userID)).
+		Where("is_outgoing = ?", false).
+		Where("status IN ?", pendingIncomingTxStatuses)
+	pendingOutputIDs := db.Model(&models.Output{}).
+		Select("id").
+		Where("transaction_id IN (?)", pendingTxIDs)
+
+	var pendingIncoming uint64
+	err = db.Model(&models.UserUTXO{}).
+		Select("COALESCE(SUM(satoshis), 0)").
+		Scopes(scopes.UserID(userID)).
+		Where("output_id IN (?)", pendingOutputIDs).
+		Scan(&pendingIncoming).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to sum pending incoming utxos: %w", err)
+	}
+	result.PendingIncoming = primitives.SatoshiValue(pendingIncoming)
+
+	result.UTXOsSizeHistogram, err = u.utxosSizeHistogram(db, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+func (u *UTXOs) utxosSizeHistogram(db *gorm.DB, userID int) ([]*wdk.UTXOsSizeHistogramBucket, error) {
+	bucketExpr := histogramBucketExpr(wdk.UTXOsSizeHistogramBounds)
+
+	var buckets []*utxosHistogramBucket
+	err := db.Model(&models.UserUTXO{}).
+		Select(bucketExpr + " AS bucket, COUNT(*) AS utxos_count, COALESCE(SUM(satoshis), 0) AS satoshis").
+		Scopes(scopes.UserID(userID)).
+		Group(bucketExpr).
+		Scan(&buckets).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to calculate utxos size histogram: %w", err)
+	}
+
+	histogram := make([]*wdk.UTXOsSizeHistogramBucket, len(wdk.UTXOsSizeHistogramBounds)+1)
+	lowerBound := uint64(0)
+	for i := range histogram {
+		histogram[i] = &wdk.UTXOsSizeHistogramBucket{
+			MinSatoshis: lowerBound,
+		}
+		if i < len(wdk.UTXOsSizeHistogramBounds) {
+			upperBound := wdk.UTXOsSizeHistogramBounds[i]
+			histogram[i].MaxSatoshis = &upperBound
+			lowerBound = upperBound
+		}
+	}
+
+	for _, bucket := range buckets {
+		if bucket.Bucket < 0 || bucket.Bucket >= len(histogram) {
+			return nil, fmt.Errorf("unexpected utxos size histogram bucket %d", bucket.Bucket)
+		}
+		histogram[bucket.Bucket].UTXOsCount = bucket.UTXOsCount
+		histogram[bucket.Bucket].Satoshis = primitives.SatoshiValue(bucket.Satoshis)
+	}
+
+	return histogram, nil
+}
+
+// histogramBucketExpr builds a CASE expression which evaluates to the index of the histogram bucket the satoshis value belongs to
+func histogramBucketExpr(bounds []uint64) string {
+	var expr strings.Builder
+	expr.WriteString("CASE")
+	for i, bound := range bounds {
+		expr.WriteString(" WHEN satoshis < ")
+		expr.WriteString(strconv.FormatUint(bound, 10))
+		expr.WriteString(" THEN ")
+		expr.WriteString(strconv.Itoa(i))
+	}
+	expr.WriteString(" ELSE ")
+	expr.WriteString(strconv.Itoa(len(bounds)))
+	expr.WriteString(" END")
+	return expr.String()
+}
+
+// notReserved filters out UTXOs reserved by other transactions, unless their reservation has already expired
+func notReserved(now time.Time) func(*gorm.DB) *gorm.DB {
 	return func(db *gorm.DB) *gorm.DB {
-		return db.Where("reserved_by_id IS NULL")
+		return db.Where("reserved_by_id IS NULL OR reserved_until < ?", now)
 	}
 }
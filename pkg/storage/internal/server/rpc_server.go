@@ -1,10 +1,10 @@
 package server
 
 import (
-	"fmt"
 	"log/slog"
 	"net/http"
 
+	"github.com/4chain-ag/go-wallet-toolbox/pkg/internal/brc104"
 	"github.com/4chain-ag/go-wallet-toolbox/pkg/internal/logging"
 	"github.com/filecoin-project/go-jsonrpc"
 )
@@ -28,15 +28,8 @@ func NewRPCHandler(parentLogger *slog.Logger, name string, handler any) *RPCServ
 	}
 }
 
-func (s *RPCServer) Register(mux *http.ServeMux) {
-	mux.HandleFunc("POST /{$}", s.Handler.ServeHTTP)
-	mux.HandleFunc("POST /.well-known/auth", s.handleAuth) //fixme: this is a workaround to pass the client to the next step, it will be handled by the auth middleware
-}
-
-func (s *RPCServer) handleAuth(w http.ResponseWriter, r *http.Request) {
-	fmt.Println("handleAuth")
-	_ = r.Body.Close()
-
-	// from-kt: this is a workaround to pass the client to the next step
-	w.WriteHeader(http.StatusInternalServerError)
+// Register mounts the RPC handler accepting only the calls authenticated by BRC-103 over BRC-104 transport
+func (s *RPCServer) Register(mux *http.ServeMux, auth *brc104.Middleware) {
+	mux.Handle("POST /{$}", auth.Handler(s.Handler))
+	mux.HandleFunc("POST "+brc104.HandshakePath, auth.HandleHandshake)
 }
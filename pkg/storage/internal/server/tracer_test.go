@@ -7,8 +7,11 @@ import (
 	"testing"
 
 	"github.com/4chain-ag/go-wallet-toolbox/pkg/defs"
+	"github.com/4chain-ag/go-wallet-toolbox/pkg/internal/brc104"
+	"github.com/4chain-ag/go-wallet-toolbox/pkg/internal/fixtures"
 	"github.com/4chain-ag/go-wallet-toolbox/pkg/internal/logging"
 	"github.com/4chain-ag/go-wallet-toolbox/pkg/storage/internal/server"
+	primitives "github.com/bsv-blockchain/go-sdk/primitives/ec"
 	"github.com/filecoin-project/go-jsonrpc"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -23,13 +26,19 @@ func TestTracer(t *testing.T) {
 	handler := &mockHandler{}
 	rpcServer := server.NewRPCHandler(logger, "MockHandler", handler)
 
+	serverKey, err := primitives.PrivateKeyFromHex(fixtures.StorageServerPrivKey)
+	require.NoError(t, err)
+
 	mux := http.NewServeMux()
-	rpcServer.Register(mux)
+	rpcServer.Register(mux, brc104.NewMiddleware(logger, serverKey))
 
 	testSrv := httptest.NewServer(mux)
 	defer testSrv.Close()
 
 	// and client:
+	clientKey, err := primitives.NewPrivateKey()
+	require.NoError(t, err)
+
 	var client mockClient
 	closer, err := jsonrpc.NewMergeClient(
 		context.Background(),
@@ -38,6 +47,7 @@ func TestTracer(t *testing.T) {
 		[]any{&client},
 		nil,
 		jsonrpc.WithMethodNameFormatter(jsonrpc.NewMethodNameFormatter(false, jsonrpc.LowerFirstCharCase)),
+		jsonrpc.WithHTTPClient(&http.Client{Transport: brc104.NewTransport(clientKey, testSrv.Client().Transport)}),
 	)
 	require.NoError(t, err)
 	defer closer()
@@ -4,6 +4,7 @@ import (
 	"context"
 	"log/slog"
 	"testing"
+	"time"
 
 	"github.com/4chain-ag/go-wallet-toolbox/pkg/defs"
 	"github.com/4chain-ag/go-wallet-toolbox/pkg/internal/fixtures"
@@ -11,6 +12,7 @@ import (
 	"github.com/4chain-ag/go-wallet-toolbox/pkg/storage/internal/database"
 	"github.com/4chain-ag/go-wallet-toolbox/pkg/storage/internal/testabilities/testusers"
 	"github.com/4chain-ag/go-wallet-toolbox/pkg/wdk"
+	"github.com/bsv-blockchain/go-sdk/transaction/chaintracker"
 	"github.com/stretchr/testify/require"
 )
 
@@ -19,16 +21,24 @@ type ProviderFixture interface {
 	WithCommission(commission defs.Commission) ProviderFixture
 	WithFeeModel(feeModel defs.FeeModel) ProviderFixture
 	WithRandomizer(randomizer wdk.Randomizer) ProviderFixture
+	WithReservationTimeout(timeout time.Duration) ProviderFixture
+	WithFeeModelFetcher(fetcher storage.FeeModelFetcher) ProviderFixture
+	WithBeefServices(beefServices storage.BeefServices) ProviderFixture
+	WithChainTracker(chainTracker chaintracker.ChainTracker) ProviderFixture
 
 	GORM() *storage.Provider
 	GORMWithCleanDatabase() *storage.Provider
 }
 
 type providerFixture struct {
-	network    defs.BSVNetwork
-	commission defs.Commission
-	feeModel   defs.FeeModel
-	randomizer wdk.Randomizer
+	network            defs.BSVNetwork
+	commission         defs.Commission
+	feeModel           defs.FeeModel
+	randomizer         wdk.Randomizer
+	reservationTimeout time.Duration
+	feeModelFetcher    storage.FeeModelFetcher
+	beefServices       storage.BeefServices
+	chainTracker       chaintracker.ChainTracker
 
 	t       testing.TB
 	require *require.Assertions
@@ -56,6 +66,26 @@ func (p *providerFixture) WithRandomizer(randomizer wdk.Randomizer) ProviderFixt
 	return p
 }
 
+func (p *providerFixture) WithReservationTimeout(timeout time.Duration) ProviderFixture {
+	p.reservationTimeout = timeout
+	return p
+}
+
+func (p *providerFixture) WithFeeModelFetcher(fetcher storage.FeeModelFetcher) ProviderFixture {
+	p.feeModelFetcher = fetcher
+	return p
+}
+
+func (p *providerFixture) WithBeefServices(beefServices storage.BeefServices) ProviderFixture {
+	p.beefServices = beefServices
+	return p
+}
+
+func (p *providerFixture) WithChainTracker(chainTracker chaintracker.ChainTracker) ProviderFixture {
+	p.chainTracker = chainTracker
+	return p
+}
+
 func (p *providerFixture) GORM() *storage.Provider {
 	p.t.Helper()
 	provider := p.GORMWithCleanDatabase()
@@ -74,12 +104,16 @@ func (p *providerFixture) GORMWithCleanDatabase() *storage.Provider {
 	activeStorage, err := storage.NewGORMProvider(
 		p.logger,
 		storage.GORMProviderConfig{
-			Chain:      p.network,
-			FeeModel:   p.feeModel,
-			Commission: p.commission,
+			Chain:              p.network,
+			FeeModel:           p.feeModel,
+			Commission:         p.commission,
+			ReservationTimeout: p.reservationTimeout,
 		},
 		storage.WithGORM(p.db.DB),
 		storage.WithRandomizer(p.randomizer),
+		storage.WithFeeModelFetcher(p.feeModelFetcher),
+		storage.WithBeefServices(p.beefServices),
+		storage.WithChainTracker(p.chainTracker),
 	)
 	p.require.NoError(err)
 
@@ -3,7 +3,6 @@ package testabilities
 import (
 	"context"
 	"log/slog"
-	"net/http"
 	"net/http/httptest"
 	"testing"
 
@@ -16,10 +15,10 @@ import (
 	"github.com/4chain-ag/go-wallet-toolbox/pkg/storage"
 	"github.com/4chain-ag/go-wallet-toolbox/pkg/storage/internal/database"
 	"github.com/4chain-ag/go-wallet-toolbox/pkg/storage/internal/database/models"
-	"github.com/4chain-ag/go-wallet-toolbox/pkg/storage/internal/server"
 	"github.com/4chain-ag/go-wallet-toolbox/pkg/storage/internal/testabilities/dbfixtures"
 	"github.com/4chain-ag/go-wallet-toolbox/pkg/storage/internal/testabilities/testusers"
 	"github.com/4chain-ag/go-wallet-toolbox/pkg/wdk"
+	primitives "github.com/bsv-blockchain/go-sdk/primitives/ec"
 	txtestabilities "github.com/bsv-blockchain/universal-test-vectors/pkg/testabilities"
 	"github.com/stretchr/testify/require"
 	"go.uber.org/mock/gomock"
@@ -29,11 +28,17 @@ type StorageFixture interface {
 	Provider() ProviderFixture
 
 	StartedRPCServerFor(provider wdk.WalletStorageWriter) (cleanup func())
-	RPCClient() (*storage.WalletStorageWriterClient, func())
+	RPCClient(user testusers.User) (*storage.WalletStorageWriterClient, func())
+	RPCAdminClient() (*storage.WalletStorageWriterClient, func())
+	RPCServerURL() string
 
 	MockProvider() *mocks.MockWalletStorageWriter
 
 	Faucet(activeStorage *storage.Provider, user testusers.User) FaucetFixture
+
+	StoredTransaction(reference string) *models.Transaction
+	StoredProvenTxReq(txID string) *models.ProvenTxReq
+	StoredProvenTx(txID string) *models.ProvenTx
 }
 
 type FaucetFixture interface {
@@ -50,22 +55,39 @@ type storageFixture struct {
 
 func (s *storageFixture) StartedRPCServerFor(provider wdk.WalletStorageWriter) (cleanup func()) {
 	s.t.Helper()
-	rpcServer := server.NewRPCHandler(s.logger, fixtures.StorageHandlerName, provider)
+	serverKey, err := primitives.PrivateKeyFromHex(fixtures.StorageServerPrivKey)
+	s.require.NoError(err)
 
-	mux := http.NewServeMux()
-	rpcServer.Register(mux)
+	handler, err := storage.NewServer(s.logger, provider, storage.ServerOptions{PrivateKey: serverKey}).Handler()
+	s.require.NoError(err)
 
-	s.testServer = httptest.NewServer(mux)
+	s.testServer = httptest.NewServer(handler)
 	return s.testServer.Close
 }
 
-func (s *storageFixture) RPCClient() (client *storage.WalletStorageWriterClient, cleanup func()) {
+// RPCClient returns the client of the started RPC server authenticated as the given user
+func (s *storageFixture) RPCClient(user testusers.User) (client *storage.WalletStorageWriterClient, cleanup func()) {
+	s.t.Helper()
+	client, cleanup, err := storage.NewClient(s.testServer.URL, user.PrivateKey(s.t), storage.WithHttpClient(s.testServer.Client()))
+	s.require.NoError(err)
+	return client, cleanup
+}
+
+// RPCAdminClient returns the client of the started RPC server authenticated with the server's own private key
+func (s *storageFixture) RPCAdminClient() (client *storage.WalletStorageWriterClient, cleanup func()) {
 	s.t.Helper()
-	client, cleanup, err := storage.NewClient(s.testServer.URL, storage.WithHttpClient(s.testServer.Client()))
+	serverKey, err := primitives.PrivateKeyFromHex(fixtures.StorageServerPrivKey)
+	s.require.NoError(err)
+
+	client, cleanup, err = storage.NewClient(s.testServer.URL, serverKey, storage.WithHttpClient(s.testServer.Client()))
 	s.require.NoError(err)
 	return client, cleanup
 }
 
+func (s *storageFixture) RPCServerURL() string {
+	return s.testServer.URL
+}
+
 func (s *storageFixture) MockProvider() *mocks.MockWalletStorageWriter {
 	s.t.Helper()
 	ctrl := gomock.NewController(s.t)
@@ -107,6 +129,16 @@ func (s *storageFixture) Faucet(activeStorage *storage.Provider, user testusers.
 	}
 }
 
+func (s *storageFixture) StoredTransaction(reference string) *models.Transaction {
+	s.t.Helper()
+
+	var transaction models.Transaction
+	err := s.db.DB.Where("reference = ?", reference).First(&transaction).Error
+	s.require.NoError(err)
+
+	return &transaction
+}
+
 func Given(t testing.TB) StorageFixture {
 	db, _ := dbfixtures.TestDatabase(t)
 	return &storageFixture{
@@ -116,3 +148,27 @@ func Given(t testing.TB) StorageFixture {
 		db:      db,
 	}
 }
+
+func (s *storageFixture) StoredProvenTxReq(txID string) *models.ProvenTxReq {
+	s.t.Helper()
+
+	var provenTxReq models.ProvenTxReq
+	err := s.db.DB.Where("tx_id = ?", txID).First(&provenTxReq).Error
+	s.require.NoError(err)
+
+	return &provenTxReq
+}
+
+// StoredProvenTx returns the stored proof of the transaction or nil if there is none
+func (s *storageFixture) StoredProvenTx(txID string) *models.ProvenTx {
+	s.t.Helper()
+
+	var provenTxs []models.ProvenTx
+	err := s.db.DB.Where("tx_id = ?", txID).Find(&provenTxs).Error
+	s.require.NoError(err)
+
+	if len(provenTxs) == 0 {
+		return nil
+	}
+	return &provenTxs[0]
+}
@@ -11,6 +11,6 @@ import (
 type MockFunder struct {
 }
 
-func (m *MockFunder) Fund(ctx context.Context, targetSat satoshi.Value, currentTxSize uint64, basket *wdk.TableOutputBasket, userID int) (*actions.FundingResult, error) {
+func (m *MockFunder) Fund(ctx context.Context, targetSat satoshi.Value, currentTxSize uint64, dataSize uint64, basket *wdk.TableOutputBasket, userID int) (*actions.FundingResult, error) {
 	return &actions.FundingResult{}, nil
 }
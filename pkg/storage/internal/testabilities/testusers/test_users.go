@@ -1,6 +1,7 @@
 package testusers
 
 import (
+	"fmt"
 	"testing"
 
 	"github.com/4chain-ag/go-wallet-toolbox/pkg/wdk"
@@ -31,10 +32,29 @@ var Bob = User{
 
 func (u User) AuthID() wdk.AuthID {
 	return wdk.AuthID{
-		UserID: &u.ID,
+		IdentityKey: u.IdentityKey(),
+		UserID:      &u.ID,
 	}
 }
 
+// IdentityKey returns the public key of the user, which is authenticated by the storage server
+func (u User) IdentityKey() string {
+	identityKey, err := wdk.IdentityKey(u.PrivKey)
+	if err != nil {
+		panic(fmt.Sprintf("invalid private key of the test user %s: %v", u.Name, err))
+	}
+	return identityKey
+}
+
+func (u User) PrivateKey(t testing.TB) *primitives.PrivateKey {
+	t.Helper()
+
+	priv, err := primitives.PrivateKeyFromHex(u.PrivKey)
+	require.NoError(t, err)
+
+	return priv
+}
+
 func (u User) PubKey(t *testing.T) string {
 	t.Helper()
 
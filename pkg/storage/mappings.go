@@ -5,6 +5,8 @@ import (
 	"github.com/4chain-ag/go-wallet-toolbox/pkg/storage/internal/repo"
 	"github.com/4chain-ag/go-wallet-toolbox/pkg/wdk"
 	"github.com/4chain-ag/go-wallet-toolbox/pkg/wdk/primitives"
+	"github.com/go-softwarelab/common/pkg/must"
+	"github.com/go-softwarelab/common/pkg/slices"
 )
 
 func tableCertificateXFieldsToModelFields(userID int) func(*wdk.TableCertificateField) *models.CertificateField {
@@ -80,3 +82,46 @@ func certificateModelFieldsToFieldsResult(fields []*models.CertificateField) map
 
 	return result
 }
+
+func listOutputsArgsToActionParams(args wdk.ListOutputsArgs) repo.ListOutputsActionParams {
+	return repo.ListOutputsActionParams{
+		Basket:       string(args.Basket),
+		Tags:         slices.Map(args.Tags, primitivesToString),
+		TagQueryMode: args.TagQueryMode,
+		IncludeTags:  args.IncludeTags.Value(),
+		Limit:        args.Limit,
+		Offset:       args.Offset,
+	}
+}
+
+func outputModelToResult(model *models.Output) *wdk.WalletOutput {
+	outpoint := wdk.OutPoint{Vout: model.Vout}
+	if model.Transaction != nil && model.Transaction.TxID != nil {
+		outpoint.TxID = *model.Transaction.TxID
+	}
+
+	return &wdk.WalletOutput{
+		Satoshis:           primitives.SatoshiValue(must.ConvertToUInt64(model.Satoshis)),
+		LockingScript:      (*primitives.HexString)(model.LockingScript),
+		Spendable:          model.Spendable,
+		CustomInstructions: model.CustomInstructions,
+		Tags:               outputTagMapsToTags(model.Tags),
+		Outpoint:           primitives.OutpointString(outpoint.String()),
+	}
+}
+
+func outputTagMapsToTags(tagMaps []*models.OutputTagMap) []string {
+	tags := make([]string, 0, len(tagMaps))
+	for _, tagMap := range tagMaps {
+		if tagMap.OutputTag == nil {
+			continue
+		}
+		tags = append(tags, tagMap.OutputTag.Tag)
+	}
+
+	return tags
+}
+
+func primitivesToString(value primitives.StringUnder300) string {
+	return string(value)
+}
@@ -4,16 +4,21 @@ import (
 	"context"
 	"fmt"
 	"log/slog"
+	"time"
 
 	"github.com/4chain-ag/go-wallet-toolbox/pkg/defs"
+	"github.com/4chain-ag/go-wallet-toolbox/pkg/internal/logging"
 	"github.com/4chain-ag/go-wallet-toolbox/pkg/internal/validate"
 	"github.com/4chain-ag/go-wallet-toolbox/pkg/randomizer"
 	"github.com/4chain-ag/go-wallet-toolbox/pkg/storage/internal/actions"
 	"github.com/4chain-ag/go-wallet-toolbox/pkg/storage/internal/database"
 	"github.com/4chain-ag/go-wallet-toolbox/pkg/storage/internal/database/models"
+	"github.com/4chain-ag/go-wallet-toolbox/pkg/storage/internal/entity"
+	"github.com/4chain-ag/go-wallet-toolbox/pkg/storage/internal/feemodel"
 	"github.com/4chain-ag/go-wallet-toolbox/pkg/storage/internal/repo"
 	"github.com/4chain-ag/go-wallet-toolbox/pkg/wdk"
 	"github.com/4chain-ag/go-wallet-toolbox/pkg/wdk/primitives"
+	"github.com/bsv-blockchain/go-sdk/transaction/chaintracker"
 	"github.com/go-softwarelab/common/pkg/slices"
 	"github.com/go-softwarelab/common/pkg/to"
 )
@@ -31,15 +36,33 @@ type Repository interface {
 	CreateCertificate(ctx context.Context, certificate *models.Certificate) (uint, error)
 	DeleteCertificate(ctx context.Context, userID int, args wdk.RelinquishCertificateArgs) error
 	ListAndCountCertificates(ctx context.Context, userID int, opts repo.ListCertificatesActionParams) ([]*models.Certificate, int64, error)
+
+	ListAndCountOutputs(ctx context.Context, userID int, opts repo.ListOutputsActionParams) ([]*models.Output, int64, error)
+	AddOutputTags(ctx context.Context, userID int, outpoint wdk.OutPoint, tags []string) error
+	RemoveOutputTags(ctx context.Context, userID int, outpoint wdk.OutPoint, tags []string) error
+
+	ListBasketsWithSummary(ctx context.Context, userID int) ([]*wdk.OutputBasketSummary, error)
+	UpdateBasketConfiguration(ctx context.Context, userID int, name string, numberOfDesiredUTXOs *int64, minimumDesiredUTXOValue *uint64) error
+	RemoveBasket(ctx context.Context, userID int, name string) error
+	WalletStats(ctx context.Context, userID int) (*wdk.WalletStatsResult, error)
+	AbortTransactionsWithExpiredReservations(ctx context.Context, now time.Time) (int64, error)
+
+	UpdateProvenTxReqStatus(ctx context.Context, update *entity.ProvenTxReqStatusUpdate, historyNote string, historyAttrs map[string]any) error
+	FindKnownTx(ctx context.Context, txID string) (*entity.KnownTx, error)
 }
 
 // Provider is a storage provider.
 type Provider struct {
 	Chain defs.BSVNetwork
 
-	settings *wdk.TableSettings
-	repo     Repository
-	actions  *actions.Actions
+	settings  *wdk.TableSettings
+	repo      Repository
+	actions   *actions.Actions
+	feeModels *feemodel.Provider
+
+	beefServices BeefServices
+	chainTracker chaintracker.ChainTracker
+	logger       *slog.Logger
 }
 
 // GORMProviderConfig is a configuration for GORM storage provider.
@@ -48,6 +71,11 @@ type GORMProviderConfig struct {
 	Chain      defs.BSVNetwork
 	FeeModel   defs.FeeModel
 	Commission defs.Commission
+	// ReservationTimeout is the time after which UTXOs reserved by not processed (unsigned) transactions are released.
+	// Zero value means that reservations never expire.
+	ReservationTimeout time.Duration
+	// CoinSelection is the strategy of choosing user's UTXOs to fund transactions, empty value means the default strategy.
+	CoinSelection defs.CoinSelectionStrategy
 }
 
 // NewGORMProvider creates a new storage provider with GORM repository.
@@ -65,13 +93,6 @@ func NewGORMProvider(logger *slog.Logger, config GORMProviderConfig, opts ...Pro
 
 	repos := db.CreateRepositories()
 
-	var funder actions.Funder
-	if options.funder != nil {
-		funder = options.funder
-	} else {
-		funder = db.CreateFunder(config.FeeModel)
-	}
-
 	var random wdk.Randomizer
 	if options.randomizer != nil {
 		random = options.randomizer
@@ -79,10 +100,27 @@ func NewGORMProvider(logger *slog.Logger, config GORMProviderConfig, opts ...Pro
 		random = randomizer.New()
 	}
 
+	feeModels := feemodel.NewProvider(logger, config.FeeModel, options.feeModelFetcher)
+
+	var funder actions.Funder
+	if options.funder != nil {
+		funder = options.funder
+	} else {
+		funder, err = db.CreateFunder(feeModels, config.CoinSelection, random)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create funder: %w", err)
+		}
+	}
+
 	return &Provider{
-		Chain:   config.Chain,
-		repo:    repos,
-		actions: actions.New(logger, funder, config.Commission, repos, random),
+		Chain:     config.Chain,
+		repo:      repos,
+		actions:   actions.New(logger, funder, config.Chain, config.Commission, repos, random, config.ReservationTimeout, config.FeeModel),
+		feeModels: feeModels,
+
+		beefServices: options.beefServices,
+		chainTracker: options.chainTracker,
+		logger:       logging.Child(logger, "storage"),
 	}, nil
 }
 
@@ -221,6 +259,170 @@ func (p *Provider) ListCertificates(ctx context.Context, auth wdk.AuthID, args w
 	return result, nil
 }
 
+// ListOutputs will list spendable outputs filtered by basket and tags
+func (p *Provider) ListOutputs(ctx context.Context, auth wdk.AuthID, args wdk.ListOutputsArgs) (*wdk.ListOutputsResult, error) {
+	if auth.UserID == nil {
+		return nil, fmt.Errorf("access is denied due to an authorization error")
+	}
+
+	err := validate.ListOutputsArgs(&args)
+	if err != nil {
+		return nil, fmt.Errorf("invalid listOutputs args: %w", err)
+	}
+
+	outputModels, totalCount, err := p.repo.ListAndCountOutputs(ctx, *auth.UserID, listOutputsArgsToActionParams(args))
+	if err != nil {
+		return nil, fmt.Errorf("error during listing outputs action: %w", err)
+	}
+
+	tc, err := to.UInt64(totalCount)
+	if err != nil {
+		return nil, fmt.Errorf("error during parsing total count of outputs: %w", err)
+	}
+
+	return &wdk.ListOutputsResult{
+		TotalOutputs: primitives.PositiveInteger(tc),
+		Outputs:      slices.Map(outputModels, outputModelToResult),
+	}, nil
+}
+
+// AddOutputTags will assign provided tags to the existing output
+func (p *Provider) AddOutputTags(ctx context.Context, auth wdk.AuthID, args wdk.OutputTagsArgs) error {
+	if auth.UserID == nil {
+		return fmt.Errorf("access is denied due to an authorization error")
+	}
+
+	err := validate.OutputTagsArgs(&args)
+	if err != nil {
+		return fmt.Errorf("invalid addOutputTags args: %w", err)
+	}
+
+	outpoint, err := wdk.OutPointFromString(args.Output)
+	if err != nil {
+		return fmt.Errorf("invalid addOutputTags args: %w", err)
+	}
+
+	err = p.repo.AddOutputTags(ctx, *auth.UserID, *outpoint, slices.Map(args.Tags, primitivesToString))
+	if err != nil {
+		return fmt.Errorf("failed to add output tags: %w", err)
+	}
+
+	return nil
+}
+
+// RemoveOutputTags will unassign provided tags from the existing output
+func (p *Provider) RemoveOutputTags(ctx context.Context, auth wdk.AuthID, args wdk.OutputTagsArgs) error {
+	if auth.UserID == nil {
+		return fmt.Errorf("access is denied due to an authorization error")
+	}
+
+	err := validate.OutputTagsArgs(&args)
+	if err != nil {
+		return fmt.Errorf("invalid removeOutputTags args: %w", err)
+	}
+
+	outpoint, err := wdk.OutPointFromString(args.Output)
+	if err != nil {
+		return fmt.Errorf("invalid removeOutputTags args: %w", err)
+	}
+
+	err = p.repo.RemoveOutputTags(ctx, *auth.UserID, *outpoint, slices.Map(args.Tags, primitivesToString))
+	if err != nil {
+		return fmt.Errorf("failed to remove output tags: %w", err)
+	}
+
+	return nil
+}
+
+// ListBaskets will list user's output baskets with their UTXOs count and balance
+func (p *Provider) ListBaskets(ctx context.Context, auth wdk.AuthID) (*wdk.ListBasketsResult, error) {
+	if auth.UserID == nil {
+		return nil, fmt.Errorf("access is denied due to an authorization error")
+	}
+
+	baskets, err := p.repo.ListBasketsWithSummary(ctx, *auth.UserID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list baskets: %w", err)
+	}
+
+	return &wdk.ListBasketsResult{
+		Baskets: baskets,
+	}, nil
+}
+
+// UpdateBasketConfiguration will change the desired UTXOs parameters of the existing basket
+func (p *Provider) UpdateBasketConfiguration(ctx context.Context, auth wdk.AuthID, args wdk.UpdateBasketConfigurationArgs) error {
+	if auth.UserID == nil {
+		return fmt.Errorf("access is denied due to an authorization error")
+	}
+
+	err := validate.UpdateBasketConfigurationArgs(&args)
+	if err != nil {
+		return fmt.Errorf("invalid updateBasketConfiguration args: %w", err)
+	}
+
+	err = p.repo.UpdateBasketConfiguration(ctx, *auth.UserID, string(args.Name), args.NumberOfDesiredUTXOs, args.MinimumDesiredUTXOValue)
+	if err != nil {
+		return fmt.Errorf("failed to update basket configuration: %w", err)
+	}
+
+	return nil
+}
+
+// RemoveBasket will remove the basket if it doesn't hold any outputs
+func (p *Provider) RemoveBasket(ctx context.Context, auth wdk.AuthID, args wdk.RemoveBasketArgs) error {
+	if auth.UserID == nil {
+		return fmt.Errorf("access is denied due to an authorization error")
+	}
+
+	err := validate.RemoveBasketArgs(&args)
+	if err != nil {
+		return fmt.Errorf("invalid removeBasket args: %w", err)
+	}
+
+	err = p.repo.RemoveBasket(ctx, *auth.UserID, string(args.Name))
+	if err != nil {
+		return fmt.Errorf("failed to remove basket: %w", err)
+	}
+
+	return nil
+}
+
+// WalletStats will summarize user's balance: spendable and reserved amounts per basket, pending incoming amount and UTXOs size histogram
+func (p *Provider) WalletStats(ctx context.Context, auth wdk.AuthID) (*wdk.WalletStatsResult, error) {
+	if auth.UserID == nil {
+		return nil, fmt.Errorf("access is denied due to an authorization error")
+	}
+
+	stats, err := p.repo.WalletStats(ctx, *auth.UserID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get wallet stats: %w", err)
+	}
+
+	return stats, nil
+}
+
+// SweepExpiredReservations will abort unsigned transactions with expired inputs reservations and release their reserved UTXOs.
+// It returns the number of aborted transactions.
+func (p *Provider) SweepExpiredReservations(ctx context.Context) (int64, error) {
+	aborted, err := p.repo.AbortTransactionsWithExpiredReservations(ctx, time.Now())
+	if err != nil {
+		return 0, fmt.Errorf("failed to sweep expired reservations: %w", err)
+	}
+
+	return aborted, nil
+}
+
+// RefreshFeeModel fetches the fee model applied to newly created transactions (see WithFeeModelFetcher).
+// On failure, the static fee model from the configuration is applied until the next successful refresh.
+func (p *Provider) RefreshFeeModel(ctx context.Context) error {
+	err := p.feeModels.Refresh(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to refresh fee model: %w", err)
+	}
+	return nil
+}
+
 // FindOrInsertUser will find user by their identityKey or inserts a new one if not found
 func (p *Provider) FindOrInsertUser(ctx context.Context, identityKey string) (*wdk.FindOrInsertUserResponse, error) {
 	user, err := p.repo.FindUser(ctx, identityKey)
@@ -271,6 +473,23 @@ func (p *Provider) CreateAction(ctx context.Context, auth wdk.AuthID, args wdk.V
 	return res, nil
 }
 
+// EstimateCreateAction is a dry-run of createAction, it returns the fee, allocated inputs, change and the size of the transaction
+// without reserving the inputs and storing the transaction.
+func (p *Provider) EstimateCreateAction(ctx context.Context, auth wdk.AuthID, args wdk.ValidCreateActionArgs) (*wdk.StorageEstimateCreateActionResult, error) {
+	if auth.UserID == nil {
+		return nil, fmt.Errorf("missing user ID")
+	}
+	if err := validate.ValidCreateActionArgs(&args); err != nil {
+		return nil, fmt.Errorf("invalid createAction args: %w", err)
+	}
+
+	res, err := p.actions.Estimate(ctx, *auth.UserID, actions.FromValidCreateActionArgs(&args))
+	if err != nil {
+		return nil, fmt.Errorf("failed to estimate createAction: %w", err)
+	}
+	return res, nil
+}
+
 // InternalizeAction Storage level processing for wallet `internalizeAction`.
 func (p *Provider) InternalizeAction(ctx context.Context, auth wdk.AuthID, args wdk.InternalizeActionArgs) (*wdk.InternalizeActionResult, error) {
 	if auth.UserID == nil {
@@ -1,8 +1,13 @@
 package storage
 
 import (
+	"context"
+
+	"github.com/4chain-ag/go-wallet-toolbox/pkg/defs"
 	"github.com/4chain-ag/go-wallet-toolbox/pkg/storage/internal/actions"
 	"github.com/4chain-ag/go-wallet-toolbox/pkg/wdk"
+	"github.com/bsv-blockchain/go-sdk/transaction"
+	"github.com/bsv-blockchain/go-sdk/transaction/chaintracker"
 	"gorm.io/gorm"
 )
 
@@ -10,9 +15,24 @@ import (
 type ProviderOption func(*providerOptions)
 
 type providerOptions struct {
-	gormDB     *gorm.DB
-	funder     actions.Funder
-	randomizer wdk.Randomizer
+	gormDB          *gorm.DB
+	funder          actions.Funder
+	randomizer      wdk.Randomizer
+	feeModelFetcher FeeModelFetcher
+	beefServices    BeefServices
+	chainTracker    chaintracker.ChainTracker
+}
+
+// FeeModelFetcher fetches the current mining fee model, e.g. from the broadcaster's (ARC) policy.
+type FeeModelFetcher interface {
+	MiningFeeModel(ctx context.Context) (defs.FeeModel, error)
+}
+
+// BeefServices provides the transactions and proofs not known to the storage, used to build BEEF (see Provider.GetBeefForTransaction).
+type BeefServices interface {
+	RawTx(txID string) (wdk.RawTxResult, error)
+	// MinedMerklePath returns the merkle path of the mined transaction, or nil if the transaction is not mined yet.
+	MinedMerklePath(ctx context.Context, txID string) (*transaction.MerklePath, error)
 }
 
 // WithGORM sets the GORM database for the provider.
@@ -29,6 +49,30 @@ func WithRandomizer(randomizer wdk.Randomizer) ProviderOption {
 	}
 }
 
+// WithFeeModelFetcher sets the source of the dynamic fee model, see Provider.RefreshFeeModel.
+// The fee model from the configuration is used until the first refresh and when fetching fails.
+func WithFeeModelFetcher(fetcher FeeModelFetcher) ProviderOption {
+	return func(o *providerOptions) {
+		o.feeModelFetcher = fetcher
+	}
+}
+
+// WithBeefServices sets the services used by GetBeefForTransaction for the transactions and proofs which are not stored.
+func WithBeefServices(beefServices BeefServices) ProviderOption {
+	return func(o *providerOptions) {
+		o.beefServices = beefServices
+	}
+}
+
+// WithChainTracker sets the chain tracker used by GetBeefForTransaction to verify the merkle roots of the BEEF,
+// and by ProcessArcCallback to verify the merkle paths of the mined transactions.
+// Without it, the merkle paths of the BEEF are only checked to prove the transactions, and the MINED callbacks are rejected.
+func WithChainTracker(chainTracker chaintracker.ChainTracker) ProviderOption {
+	return func(o *providerOptions) {
+		o.chainTracker = chainTracker
+	}
+}
+
 func toOptions(opts []ProviderOption) *providerOptions {
 	options := &providerOptions{}
 	for _, opt := range opts {
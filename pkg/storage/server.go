@@ -6,6 +6,7 @@ import (
 	"net/http"
 	"time"
 
+	"github.com/4chain-ag/go-wallet-toolbox/pkg/internal/brc104"
 	"github.com/4chain-ag/go-wallet-toolbox/pkg/internal/logging"
 	"github.com/4chain-ag/go-wallet-toolbox/pkg/storage/internal/server"
 	"github.com/4chain-ag/go-wallet-toolbox/pkg/wdk"
@@ -27,18 +28,38 @@ func NewServer(logger *slog.Logger, storage wdk.WalletStorageWriter, opts Server
 	}
 }
 
+// Handler returns the HTTP handler of the server,
+// the JSON-RPC calls are accepted only from the clients authenticated by BRC-103 over BRC-104 transport
+// and the storage methods are allowed only for the users matching the authenticated identity key.
+// The administrative methods are allowed only for the clients authenticated with the server's own private key.
+func (s *Server) Handler() (http.Handler, error) {
+	if s.options.PrivateKey == nil {
+		return nil, fmt.Errorf("server private key is required")
+	}
+
+	adminIdentityKey := s.options.PrivateKey.PubKey().ToDERHex()
+	rpcServer := server.NewRPCHandler(s.logger, "remote_storage", newAuthorizedStorage(s.provider, adminIdentityKey))
+
+	mux := http.NewServeMux()
+	rpcServer.Register(mux, brc104.NewMiddleware(s.logger, s.options.PrivateKey))
+	for pattern, handler := range s.options.Handlers {
+		mux.Handle(pattern, handler)
+	}
+	return mux, nil
+}
+
 // Start starts the server
 // NOTE: This method is blocking
 func (s *Server) Start() error {
-	rpcServer := server.NewRPCHandler(s.logger, "remote_storage", s.provider)
-
-	mux := http.NewServeMux()
-	rpcServer.Register(mux)
+	handler, err := s.Handler()
+	if err != nil {
+		return err
+	}
 
 	port := s.options.Port
 	httpServer := &http.Server{
 		Addr:              fmt.Sprintf(":%d", port),
-		Handler:           mux,
+		Handler:           handler,
 		ReadHeaderTimeout: 3 * time.Second,
 		ReadTimeout:       10 * time.Second,
 		WriteTimeout:      10 * time.Second,
@@ -46,7 +67,7 @@ func (s *Server) Start() error {
 	}
 
 	s.logger.Info("Listening...", slog.Any("port", port))
-	err := httpServer.ListenAndServe()
+	err = httpServer.ListenAndServe()
 	if err != nil {
 		return fmt.Errorf("failed to start server: %w", err)
 	}
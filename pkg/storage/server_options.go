@@ -1,6 +1,16 @@
 package storage
 
+import (
+	"net/http"
+
+	primitives "github.com/bsv-blockchain/go-sdk/primitives/ec"
+)
+
 // ServerOptions represents configurable options for the storage server
 type ServerOptions struct {
 	Port uint
+	// PrivateKey is the server's key used to authenticate the clients (BRC-103) and sign the responses, it's required
+	PrivateKey *primitives.PrivateKey
+	// Handlers are additional HTTP handlers mounted on the server by their patterns, e.g. the ARC callback receiver
+	Handlers map[string]http.Handler
 }
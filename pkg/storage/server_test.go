@@ -7,7 +7,10 @@ import (
 	"github.com/4chain-ag/go-wallet-toolbox/pkg/defs"
 	"github.com/4chain-ag/go-wallet-toolbox/pkg/internal/fixtures"
 	"github.com/4chain-ag/go-wallet-toolbox/pkg/storage/internal/testabilities"
+	"github.com/4chain-ag/go-wallet-toolbox/pkg/storage/internal/testabilities/testusers"
 	"github.com/4chain-ag/go-wallet-toolbox/pkg/wdk"
+	"github.com/filecoin-project/go-jsonrpc"
+	"github.com/go-softwarelab/common/pkg/to"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"go.uber.org/mock/gomock"
@@ -24,9 +27,25 @@ func TestRPCCommunication(t *testing.T) {
 	defer cleanupSrv()
 
 	// and client:
-	client, cleanupCli := given.RPCClient()
+	client, cleanupCli := given.RPCClient(testusers.Alice)
 	defer cleanupCli()
 
+	// and admin client:
+	adminClient, cleanupAdmin := given.RPCAdminClient()
+	defer cleanupAdmin()
+
+	// and:
+	givenAuthenticatedAlice := func() {
+		mockStorage.EXPECT().
+			FindOrInsertUser(gomock.Any(), testusers.Alice.IdentityKey()).
+			Return(&wdk.FindOrInsertUserResponse{
+				User: wdk.TableUser{
+					UserID:      testusers.Alice.ID,
+					IdentityKey: testusers.Alice.IdentityKey(),
+				},
+			}, nil)
+	}
+
 	t.Run("Migrate", func(t *testing.T) {
 		// given:
 		mockStorage.EXPECT().
@@ -34,7 +53,7 @@ func TestRPCCommunication(t *testing.T) {
 			Return("current-migration-version", nil)
 
 		// when:
-		migrationVersion, err := client.Migrate(context.Background(), fixtures.StorageName, fixtures.StorageIdentityKey)
+		migrationVersion, err := adminClient.Migrate(context.Background(), fixtures.StorageName, fixtures.StorageIdentityKey)
 
 		// then:
 		require.NoError(t, err)
@@ -55,7 +74,7 @@ func TestRPCCommunication(t *testing.T) {
 			Return(storageResult, nil)
 
 		// when:
-		response, err := client.MakeAvailable(context.Background())
+		response, err := adminClient.MakeAvailable(context.Background())
 
 		// then:
 		require.NoError(t, err)
@@ -64,7 +83,7 @@ func TestRPCCommunication(t *testing.T) {
 
 	t.Run("FindOrInsertUser", func(t *testing.T) {
 		// given:
-		userIdentityKey := "03f17660f611ce531402a2ce1e070380b6fde57aca211d707bfab27bce42d86beb"
+		userIdentityKey := testusers.Alice.IdentityKey()
 
 		storageResult := &wdk.FindOrInsertUserResponse{
 			User: wdk.TableUser{
@@ -75,11 +94,11 @@ func TestRPCCommunication(t *testing.T) {
 
 		// and:
 		mockStorage.EXPECT().
-			FindOrInsertUser(gomock.Any(), fixtures.StorageIdentityKey).
+			FindOrInsertUser(gomock.Any(), userIdentityKey).
 			Return(storageResult, nil)
 
 		// when:
-		response, err := client.FindOrInsertUser(context.Background(), fixtures.StorageIdentityKey)
+		response, err := client.FindOrInsertUser(context.Background(), userIdentityKey)
 
 		// then:
 		require.NoError(t, err)
@@ -87,7 +106,275 @@ func TestRPCCommunication(t *testing.T) {
 		assert.EqualValues(t, storageResult, response)
 	})
 
+	t.Run("ListOutputs", func(t *testing.T) {
+		// given:
+		givenAuthenticatedAlice()
+
+		args := *fixtures.DefaultValidListOutputsArgs()
+
+		storageResult := &wdk.ListOutputsResult{
+			TotalOutputs: 1,
+			Outputs: []*wdk.WalletOutput{{
+				Satoshis:  fixtures.ExpectedValueToInternalize,
+				Spendable: true,
+				Tags:      []string{fixtures.OutputTag},
+				Outpoint:  fixtures.OutputOutpoint,
+			}},
+		}
+
+		// and:
+		mockStorage.EXPECT().
+			ListOutputs(gomock.Any(), testusers.Alice.AuthID(), args).
+			Return(storageResult, nil)
+
+		// when:
+		response, err := client.ListOutputs(context.Background(), testusers.Alice.AuthID(), args)
+
+		// then:
+		require.NoError(t, err)
+		assert.EqualValues(t, storageResult, response)
+	})
+
+	t.Run("AddOutputTags", func(t *testing.T) {
+		// given:
+		givenAuthenticatedAlice()
+
+		args := *fixtures.DefaultValidOutputTagsArgs()
+
+		mockStorage.EXPECT().
+			AddOutputTags(gomock.Any(), testusers.Alice.AuthID(), args).
+			Return(nil)
+
+		// when:
+		err := client.AddOutputTags(context.Background(), testusers.Alice.AuthID(), args)
+
+		// then:
+		require.NoError(t, err)
+	})
+
+	t.Run("RemoveOutputTags", func(t *testing.T) {
+		// given:
+		givenAuthenticatedAlice()
+
+		args := *fixtures.DefaultValidOutputTagsArgs()
+
+		mockStorage.EXPECT().
+			RemoveOutputTags(gomock.Any(), testusers.Alice.AuthID(), args).
+			Return(nil)
+
+		// when:
+		err := client.RemoveOutputTags(context.Background(), testusers.Alice.AuthID(), args)
+
+		// then:
+		require.NoError(t, err)
+	})
+
+	t.Run("ListBaskets", func(t *testing.T) {
+		// given:
+		givenAuthenticatedAlice()
+
+		storageResult := &wdk.ListBasketsResult{
+			Baskets: []*wdk.OutputBasketSummary{{
+				BasketConfiguration: wdk.DefaultBasketConfiguration(),
+				UTXOsCount:          1,
+				Balance:             100_000,
+			}},
+		}
+
+		mockStorage.EXPECT().
+			ListBaskets(gomock.Any(), testusers.Alice.AuthID()).
+			Return(storageResult, nil)
+
+		// when:
+		response, err := client.ListBaskets(context.Background(), testusers.Alice.AuthID())
+
+		// then:
+		require.NoError(t, err)
+		assert.EqualValues(t, storageResult, response)
+	})
+
+	t.Run("UpdateBasketConfiguration", func(t *testing.T) {
+		// given:
+		givenAuthenticatedAlice()
+
+		args := *fixtures.DefaultValidUpdateBasketConfigurationArgs()
+
+		mockStorage.EXPECT().
+			UpdateBasketConfiguration(gomock.Any(), testusers.Alice.AuthID(), args).
+			Return(nil)
+
+		// when:
+		err := client.UpdateBasketConfiguration(context.Background(), testusers.Alice.AuthID(), args)
+
+		// then:
+		require.NoError(t, err)
+	})
+
+	t.Run("RemoveBasket", func(t *testing.T) {
+		// given:
+		givenAuthenticatedAlice()
+
+		args := *fixtures.DefaultValidRemoveBasketArgs()
+
+		mockStorage.EXPECT().
+			RemoveBasket(gomock.Any(), testusers.Alice.AuthID(), args).
+			Return(nil)
+
+		// when:
+		err := client.RemoveBasket(context.Background(), testusers.Alice.AuthID(), args)
+
+		// then:
+		require.NoError(t, err)
+	})
+
+	t.Run("WalletStats", func(t *testing.T) {
+		// given:
+		givenAuthenticatedAlice()
+
+		storageResult := &wdk.WalletStatsResult{
+			Spendable:       100_000,
+			Reserved:        1_000,
+			PendingIncoming: 999,
+			UTXOsCount:      2,
+			Baskets: []*wdk.BasketBalance{{
+				Name:       wdk.BasketNameForChange,
+				Spendable:  100_000,
+				Reserved:   1_000,
+				UTXOsCount: 2,
+			}},
+			UTXOsSizeHistogram: []*wdk.UTXOsSizeHistogramBucket{{
+				MinSatoshis: 0,
+				MaxSatoshis: to.Ptr(uint64(1_000)),
+				UTXOsCount:  1,
+				Satoshis:    999,
+			}},
+		}
+
+		mockStorage.EXPECT().
+			WalletStats(gomock.Any(), testusers.Alice.AuthID()).
+			Return(storageResult, nil)
+
+		// when:
+		response, err := client.WalletStats(context.Background(), testusers.Alice.AuthID())
+
+		// then:
+		require.NoError(t, err)
+		assert.EqualValues(t, storageResult, response)
+	})
+
 	t.Run("CreateAction", func(t *testing.T) {
 		t.Skip("Not implemented yet")
 	})
+
+	t.Run("EstimateCreateAction", func(t *testing.T) {
+		// given:
+		givenAuthenticatedAlice()
+
+		args := fixtures.DefaultValidCreateActionArgs()
+
+		storageResult := &wdk.StorageEstimateCreateActionResult{
+			Fee:      2,
+			FeeModel: "1 sat/kb (static)",
+			Inputs: []wdk.StorageCreateTransactionSdkInput{{
+				Vin:                   0,
+				SourceTxID:            "756754d5ad8f00e05c36d89a852971c0a1dc0c10f20cd7840ead347aff475ef6",
+				SourceVout:            0,
+				SourceSatoshis:        100_000,
+				SourceLockingScript:   "76a914f7238871139f4926cbd592a03a737981e558245d88ac",
+				UnlockingScriptLength: 107,
+				ProvidedBy:            wdk.ProvidedByStorage,
+				Type:                  "P2PKH",
+			}},
+			ChangeCount:    1,
+			ChangeSatoshis: 57_998,
+			Size:           1_278,
+		}
+
+		mockStorage.EXPECT().
+			EstimateCreateAction(gomock.Any(), testusers.Alice.AuthID(), gomock.Any()).
+			Return(storageResult, nil)
+
+		// when:
+		response, err := client.EstimateCreateAction(context.Background(), testusers.Alice.AuthID(), args)
+
+		// then:
+		require.NoError(t, err)
+		assert.EqualValues(t, storageResult, response)
+	})
+	t.Run("rejects AuthID of other user than the authenticated one", func(t *testing.T) {
+		// when:
+		_, err := client.ListBaskets(context.Background(), testusers.Bob.AuthID())
+
+		// then:
+		require.Error(t, err)
+	})
+
+	t.Run("rejects user ID not matching the authenticated identity key", func(t *testing.T) {
+		// given:
+		givenAuthenticatedAlice()
+
+		// and:
+		auth := testusers.Alice.AuthID()
+		auth.UserID = to.Ptr(testusers.Bob.ID)
+
+		// when:
+		_, err := client.ListBaskets(context.Background(), auth)
+
+		// then:
+		require.Error(t, err)
+	})
+
+	t.Run("rejects FindOrInsertUser of other identity key than the authenticated one", func(t *testing.T) {
+		// when:
+		_, err := client.FindOrInsertUser(context.Background(), testusers.Bob.IdentityKey())
+
+		// then:
+		require.Error(t, err)
+	})
+
+	t.Run("rejects Migrate of other identity key than the server's one", func(t *testing.T) {
+		// when:
+		_, err := client.Migrate(context.Background(), fixtures.StorageName, fixtures.StorageIdentityKey)
+
+		// then:
+		require.Error(t, err)
+	})
+
+	t.Run("rejects MakeAvailable of other identity key than the server's one", func(t *testing.T) {
+		// when:
+		_, err := client.MakeAvailable(context.Background())
+
+		// then:
+		require.Error(t, err)
+	})
+}
+
+func TestRPCAuthentication(t *testing.T) {
+	t.Run("rejects not authenticated call", func(t *testing.T) {
+		// given:
+		given := testabilities.Given(t)
+		cleanupSrv := given.StartedRPCServerFor(given.MockProvider())
+		defer cleanupSrv()
+
+		// and client without authentication:
+		var client struct {
+			MakeAvailable func(context.Context) (*wdk.TableSettings, error)
+		}
+		closer, err := jsonrpc.NewMergeClient(
+			context.Background(),
+			given.RPCServerURL(),
+			"remote_storage",
+			[]any{&client},
+			nil,
+			jsonrpc.WithMethodNameFormatter(jsonrpc.NewMethodNameFormatter(false, jsonrpc.LowerFirstCharCase)),
+		)
+		require.NoError(t, err)
+		defer closer()
+
+		// when:
+		_, err = client.MakeAvailable(context.Background())
+
+		// then:
+		require.Error(t, err)
+	})
 }
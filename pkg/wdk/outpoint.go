@@ -1,5 +1,13 @@
 package wdk
 
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/4chain-ag/go-wallet-toolbox/pkg/wdk/primitives"
+)
+
 // OutPoint identifies a unique transaction output by its txid and index vout
 type OutPoint struct {
 	// TxID Transaction double sha256 hash as big endian hex string
@@ -7,3 +15,26 @@ type OutPoint struct {
 	// Vout Zero based output index within the transaction
 	Vout uint32
 }
+
+// OutPointFromString parses the outpoint string in format "<txid>.<vout>"
+func OutPointFromString(outpoint primitives.OutpointString) (*OutPoint, error) {
+	txID, index, found := strings.Cut(string(outpoint), ".")
+	if !found {
+		return nil, fmt.Errorf("outpoint must be txid and output index joined with '.'")
+	}
+
+	vout, err := strconv.ParseUint(index, 10, 32)
+	if err != nil {
+		return nil, fmt.Errorf("invalid output index of outpoint: %w", err)
+	}
+
+	return &OutPoint{
+		TxID: txID,
+		Vout: uint32(vout), //nolint:gosec // parsed with 32 bit size, so it cannot overflow
+	}, nil
+}
+
+// String returns the outpoint in format "<txid>.<vout>"
+func (o OutPoint) String() string {
+	return fmt.Sprintf("%s.%d", o.TxID, o.Vout)
+}
@@ -13,8 +13,19 @@ type WalletStorageWriter interface {
 	MakeAvailable(ctx context.Context) (*TableSettings, error)
 	FindOrInsertUser(ctx context.Context, identityKey string) (*FindOrInsertUserResponse, error)
 	CreateAction(ctx context.Context, auth AuthID, args ValidCreateActionArgs) (*StorageCreateActionResult, error)
+	EstimateCreateAction(ctx context.Context, auth AuthID, args ValidCreateActionArgs) (*StorageEstimateCreateActionResult, error)
 
 	InsertCertificateAuth(ctx context.Context, auth AuthID, certificate *TableCertificateX) (uint, error)
 	RelinquishCertificate(ctx context.Context, auth AuthID, args RelinquishCertificateArgs) error
 	ListCertificates(ctx context.Context, auth AuthID, args ListCertificatesArgs) (*ListCertificatesResult, error)
+
+	ListOutputs(ctx context.Context, auth AuthID, args ListOutputsArgs) (*ListOutputsResult, error)
+	AddOutputTags(ctx context.Context, auth AuthID, args OutputTagsArgs) error
+	RemoveOutputTags(ctx context.Context, auth AuthID, args OutputTagsArgs) error
+
+	ListBaskets(ctx context.Context, auth AuthID) (*ListBasketsResult, error)
+	UpdateBasketConfiguration(ctx context.Context, auth AuthID, args UpdateBasketConfigurationArgs) error
+	RemoveBasket(ctx context.Context, auth AuthID, args RemoveBasketArgs) error
+
+	WalletStats(ctx context.Context, auth AuthID) (*WalletStatsResult, error)
 }
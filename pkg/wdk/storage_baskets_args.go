@@ -0,0 +1,28 @@
+package wdk
+
+import "github.com/4chain-ag/go-wallet-toolbox/pkg/wdk/primitives"
+
+// OutputBasketSummary represents the output basket configuration together with the count and balance of its spendable outputs
+type OutputBasketSummary struct {
+	BasketConfiguration
+	UTXOsCount uint64                  `json:"utxosCount"`
+	Balance    primitives.SatoshiValue `json:"balance"`
+}
+
+// ListBasketsResult is a result of listBaskets
+type ListBasketsResult struct {
+	Baskets []*OutputBasketSummary `json:"baskets"`
+}
+
+// UpdateBasketConfigurationArgs represents the arguments for changing the configuration of an existing basket.
+// Only provided (non-nil) parameters are updated.
+type UpdateBasketConfigurationArgs struct {
+	Name                    primitives.StringUnder300 `json:"name"`
+	NumberOfDesiredUTXOs    *int64                    `json:"numberOfDesiredUTXOs,omitempty"`
+	MinimumDesiredUTXOValue *uint64                   `json:"minimumDesiredUTXOValue,omitempty"`
+}
+
+// RemoveBasketArgs represents the arguments for removing an empty basket
+type RemoveBasketArgs struct {
+	Name primitives.StringUnder300 `json:"name"`
+}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 
 	"github.com/4chain-ag/go-wallet-toolbox/pkg/wdk/primitives"
+	"github.com/bsv-blockchain/go-sdk/script"
 	"github.com/go-softwarelab/common/pkg/to"
 )
 
@@ -50,6 +51,15 @@ func (o *ValidCreateActionOutput) ScriptLength() (uint64, error) {
 	return lengthInBytes, nil
 }
 
+// IsData returns true if the locking script is a data (OP_RETURN) script.
+func (o *ValidCreateActionOutput) IsData() bool {
+	lockingScript, err := script.NewFromHex(string(o.LockingScript))
+	if err != nil {
+		return false
+	}
+	return lockingScript.IsData()
+}
+
 // ValidCreateActionOptions represents options for createAction
 type ValidCreateActionOptions struct {
 	AcceptDelayedBroadcast *primitives.BooleanDefaultTrue  `json:"acceptDelayedBroadcast,omitempty"`
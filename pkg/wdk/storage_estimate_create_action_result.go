@@ -0,0 +1,20 @@
+package wdk
+
+import "github.com/4chain-ag/go-wallet-toolbox/pkg/wdk/primitives"
+
+// StorageEstimateCreateActionResult represents the result of a dry-run of createAction.
+// Nothing is reserved or stored, so the estimated inputs may be allocated differently by the following createAction.
+type StorageEstimateCreateActionResult struct {
+	// Fee is the fee which would be paid by the transaction
+	Fee primitives.SatoshiValue `json:"fee"`
+	// FeeModel is the fee model applied to calculate the fee, e.g. "1 sat/kb (static)"
+	FeeModel string `json:"feeModel"`
+	// Inputs are the UTXOs which would be allocated to fund the transaction
+	Inputs []StorageCreateTransactionSdkInput `json:"inputs"`
+	// ChangeCount is the number of change outputs
+	ChangeCount uint64 `json:"changeCount"`
+	// ChangeSatoshis is the total value of change outputs
+	ChangeSatoshis primitives.SatoshiValue `json:"changeSatoshis"`
+	// Size is the estimated size of the final (signed) transaction in bytes
+	Size uint64 `json:"size"`
+}
@@ -0,0 +1,44 @@
+package wdk
+
+import "github.com/4chain-ag/go-wallet-toolbox/pkg/wdk/primitives"
+
+// QueryMode defines how multiple filter values are combined
+type QueryMode string
+
+// Possible values for QueryMode
+const (
+	QueryModeAny QueryMode = "any"
+	QueryModeAll QueryMode = "all"
+)
+
+// ListOutputsArgs represents the arguments for listing outputs
+type ListOutputsArgs struct {
+	Basket       primitives.StringUnder300                   `json:"basket"`
+	Tags         []primitives.StringUnder300                 `json:"tags"`
+	TagQueryMode QueryMode                                   `json:"tagQueryMode"`
+	IncludeTags  *primitives.BooleanDefaultFalse             `json:"includeTags"`
+	Limit        primitives.PositiveIntegerDefault10Max10000 `json:"limit"`
+	Offset       primitives.PositiveInteger                  `json:"offset"`
+}
+
+// WalletOutput represents a single output returned by listOutputs
+type WalletOutput struct {
+	Satoshis           primitives.SatoshiValue   `json:"satoshis"`
+	LockingScript      *primitives.HexString     `json:"lockingScript,omitempty"`
+	Spendable          bool                      `json:"spendable"`
+	CustomInstructions *string                   `json:"customInstructions,omitempty"`
+	Tags               []string                  `json:"tags,omitempty"`
+	Outpoint           primitives.OutpointString `json:"outpoint"`
+}
+
+// ListOutputsResult is a result of listOutputs
+type ListOutputsResult struct {
+	TotalOutputs primitives.PositiveInteger `json:"totalOutputs"`
+	Outputs      []*WalletOutput            `json:"outputs"`
+}
+
+// OutputTagsArgs represents the arguments for adding or removing tags of an existing output
+type OutputTagsArgs struct {
+	Output primitives.OutpointString   `json:"output"`
+	Tags   []primitives.StringUnder300 `json:"tags"`
+}
@@ -0,0 +1,39 @@
+package wdk
+
+import "github.com/4chain-ag/go-wallet-toolbox/pkg/wdk/primitives"
+
+// UTXOsSizeHistogramBounds are the upper bounds (exclusive) in satoshis of the UTXOs size histogram buckets.
+// The last bucket collects all UTXOs with value greater or equal to the last bound.
+var UTXOsSizeHistogramBounds = []uint64{1_000, 10_000, 100_000, 1_000_000, 10_000_000}
+
+// BasketBalance represents the balance of the user's UTXOs stored in one basket
+type BasketBalance struct {
+	Name       string                  `json:"name"`
+	Spendable  primitives.SatoshiValue `json:"spendable"`
+	Reserved   primitives.SatoshiValue `json:"reserved"`
+	UTXOsCount uint64                  `json:"utxosCount"`
+}
+
+// UTXOsSizeHistogramBucket represents a count and total value of the UTXOs with value in range [MinSatoshis, MaxSatoshis).
+// MaxSatoshis is nil for the last (unbounded) bucket.
+type UTXOsSizeHistogramBucket struct {
+	MinSatoshis uint64                  `json:"minSatoshis"`
+	MaxSatoshis *uint64                 `json:"maxSatoshis,omitempty"`
+	UTXOsCount  uint64                  `json:"utxosCount"`
+	Satoshis    primitives.SatoshiValue `json:"satoshis"`
+}
+
+// WalletStatsResult is a result of walletStats
+type WalletStatsResult struct {
+	// Spendable is the total value of UTXOs not reserved by any transaction
+	Spendable primitives.SatoshiValue `json:"spendable"`
+	// Reserved is the total value of UTXOs reserved as inputs of not yet processed transactions
+	Reserved primitives.SatoshiValue `json:"reserved"`
+	// PendingIncoming is the total value of UTXOs received in incoming transactions that are not proven yet
+	PendingIncoming primitives.SatoshiValue `json:"pendingIncoming"`
+	// UTXOsCount is the number of all user's UTXOs (both spendable and reserved)
+	UTXOsCount uint64 `json:"utxosCount"`
+
+	Baskets            []*BasketBalance            `json:"baskets"`
+	UTXOsSizeHistogram []*UTXOsSizeHistogramBucket `json:"utxosSizeHistogram"`
+}